@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/analytics"
+)
+
+type Analytics struct {
+	// Tracker is only available in exporter mode
+	Tracker *analytics.Tracker
+}
+
+type epochRangeJSON struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// trackedRangeJSON is the range of epochs the tracker has statistics of. They're kept in memory only,
+// so the range starts over when the node restarts, and a partial first epoch is incomplete.
+type trackedRangeJSON struct {
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+	Partial bool   `json:"partial"`
+}
+
+type committeeAnalyticsJSON struct {
+	CommitteeID string                     `json:"committee_id"`
+	Operators   []spectypes.OperatorID     `json:"operators"`
+	Duties      uint64                     `json:"duties"`
+	Instances   uint64                     `json:"instances"`
+	Stats       []*analytics.OperatorStats `json:"stats"`
+}
+
+// Operators ranks operators by their consensus participation in the requested epochs.
+func (h *Analytics) Operators(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From   uint64 `form:"from"`
+		To     uint64 `form:"to"`
+		SortBy string `form:"sort_by"`
+		Limit  int    `form:"limit"`
+	}
+	var response struct {
+		Epochs  epochRangeJSON             `json:"epochs"`
+		Tracked *trackedRangeJSON          `json:"tracked"`
+		Data    []*analytics.OperatorStats `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	epochs, tracked, err := h.epochRange(request.From, request.To)
+	if err != nil {
+		return err
+	}
+	if request.Limit < 0 {
+		return api.BadRequestError(fmt.Errorf("'limit' must not be negative"))
+	}
+	sortBy := analytics.SortByParticipation
+	if request.SortBy != "" {
+		sortBy = analytics.SortBy(request.SortBy)
+		if !sortBy.Valid() {
+			return api.BadRequestError(fmt.Errorf("unknown 'sort_by' %q", request.SortBy))
+		}
+	}
+
+	response.Epochs = epochs
+	response.Tracked = tracked
+	response.Data = h.Tracker.Operators(analytics.EpochRange{From: phase0.Epoch(epochs.From), To: phase0.Epoch(epochs.To)}, sortBy)
+	if request.Limit > 0 && len(response.Data) > request.Limit {
+		response.Data = response.Data[:request.Limit]
+	}
+	return api.Render(w, r, response)
+}
+
+// Committees breaks down the consensus participation of committees by operator in the requested epochs.
+func (h *Analytics) Committees(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From       uint64       `form:"from"`
+		To         uint64       `form:"to"`
+		Committees api.HexSlice `form:"committees"`
+	}
+	var response struct {
+		Epochs  epochRangeJSON            `json:"epochs"`
+		Tracked *trackedRangeJSON         `json:"tracked"`
+		Data    []*committeeAnalyticsJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	epochs, tracked, err := h.epochRange(request.From, request.To)
+	if err != nil {
+		return err
+	}
+	ids := make([]spectypes.CommitteeID, len(request.Committees))
+	for i, id := range request.Committees {
+		if len(id) != len(spectypes.CommitteeID{}) {
+			return api.BadRequestError(fmt.Errorf("invalid committee ID %x", id))
+		}
+		ids[i] = spectypes.CommitteeID(id)
+	}
+
+	response.Epochs = epochs
+	response.Tracked = tracked
+	response.Data = []*committeeAnalyticsJSON{}
+	for _, committee := range h.Tracker.Committees(analytics.EpochRange{From: phase0.Epoch(epochs.From), To: phase0.Epoch(epochs.To)}, ids...) {
+		response.Data = append(response.Data, &committeeAnalyticsJSON{
+			CommitteeID: hex.EncodeToString(committee.CommitteeID[:]),
+			Operators:   committee.Operators,
+			Duties:      committee.Duties,
+			Instances:   committee.Instances,
+			Stats:       committee.Stats,
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+// epochRange resolves the requested epochs within the tracked ones, zero values default to all the tracked epochs.
+// The tracked range is nil until there are statistics.
+func (h *Analytics) epochRange(from, to uint64) (epochRangeJSON, *trackedRangeJSON, error) {
+	if h.Tracker == nil {
+		return epochRangeJSON{}, nil, api.Error(fmt.Errorf("analytics are only available in exporter mode"))
+	}
+	if to != 0 && from > to {
+		return epochRangeJSON{}, nil, api.BadRequestError(fmt.Errorf("'from' must be less than or equal to 'to'"))
+	}
+
+	tracked, ok := h.Tracker.Tracked()
+	if !ok {
+		return epochRangeJSON{From: from, To: to}, nil, nil
+	}
+	from = max(from, uint64(tracked.From))
+	if to == 0 || to > uint64(tracked.To) {
+		to = uint64(tracked.To)
+	}
+	return epochRangeJSON{From: from, To: to}, &trackedRangeJSON{
+		From:    uint64(tracked.From),
+		To:      uint64(tracked.To),
+		Partial: tracked.Partial,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+)
+
+type Logging struct {
+	Levels *logging.LevelRegistry
+}
+
+func (h *Logging) LogLevels(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, h.Levels.Snapshot())
+}
+
+// SetLogLevels sets the level of a component, or the default level if no component is given,
+// and enables or disables the debug logs of a validator.
+func (h *Logging) SetLogLevels(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Component string `json:"component" form:"component"`
+		Level     string `json:"level" form:"level"`
+		Validator string `json:"validator" form:"validator"`
+		Debug     bool   `json:"debug" form:"debug"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	if request.Validator != "" {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(request.Validator, "0x"))
+		if err != nil || len(pubKey) != phase0.PublicKeyLength {
+			return api.BadRequestError(fmt.Errorf("invalid validator public key %q", request.Validator))
+		}
+		h.Levels.SetValidatorDebug(request.Validator, request.Debug)
+	}
+
+	switch {
+	case request.Component == "" && request.Level != "":
+		level, err := zapcore.ParseLevel(request.Level)
+		if err != nil {
+			return api.BadRequestError(err)
+		}
+		h.Levels.SetDefaultLevel(level)
+	case request.Component != "" && request.Level == "":
+		h.Levels.UnsetLevel(request.Component)
+	case request.Component != "":
+		if err := h.Levels.SetLevels(map[string]string{request.Component: request.Level}); err != nil {
+			return api.BadRequestError(err)
+		}
+	}
+
+	return api.Render(w, r, h.Levels.Snapshot())
+}
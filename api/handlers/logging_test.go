@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestSetLogLevels(t *testing.T) {
+	h := &Logging{Levels: logging.NewLevelRegistry(zapcore.InfoLevel)}
+	pubKey := strings.Repeat("ab", 48)
+
+	setLogLevels := func(body string) (int, logging.LevelsSnapshot) {
+		r := httptest.NewRequest(http.MethodPut, "/v1/node/log-levels", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		api.Handler(h.SetLogLevels)(w, r)
+
+		var snapshot logging.LevelsSnapshot
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &snapshot))
+		}
+		return w.Code, snapshot
+	}
+
+	code, snapshot := setLogLevels(`{"component": "P2PNetwork", "level": "debug"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"P2PNetwork": "debug"}, snapshot.Components)
+
+	code, snapshot = setLogLevels(`{"level": "warn", "validator": "0x` + pubKey + `", "debug": true}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "warn", snapshot.Default)
+	require.Equal(t, []string{pubKey}, snapshot.DebugValidators)
+
+	code, snapshot = setLogLevels(`{"component": "P2PNetwork", "validator": "` + pubKey + `", "debug": false}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, snapshot.Components)
+	require.Empty(t, snapshot.DebugValidators)
+
+	code, _ = setLogLevels(`{"component": "P2PNetwork", "level": "loud"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = setLogLevels(`{"validator": "0x1234", "debug": true}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -5,13 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/message/validation"
+	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
 	networkpeers "github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/nodeprobe"
 )
 
@@ -40,19 +47,21 @@ type connectionJSON struct {
 }
 
 type peerJSON struct {
-	ID            peer.ID          `json:"id"`
-	Addresses     []string         `json:"addresses"`
-	Connections   []connectionJSON `json:"connections"`
-	Connectedness string           `json:"connectedness"`
-	Subnets       string           `json:"subnets"`
-	Version       string           `json:"version"`
+	ID            peer.ID               `json:"id"`
+	Addresses     []string              `json:"addresses"`
+	Connections   []connectionJSON      `json:"connections"`
+	Connectedness string                `json:"connectedness"`
+	Subnets       string                `json:"subnets"`
+	Version       string                `json:"version"`
+	Capabilities  *records.Capabilities `json:"capabilities,omitempty"`
 }
 
 type identityJSON struct {
-	PeerID    peer.ID  `json:"peer_id"`
-	Addresses []string `json:"addresses"`
-	Subnets   string   `json:"subnets"`
-	Version   string   `json:"version"`
+	PeerID       peer.ID               `json:"peer_id"`
+	Addresses    []string              `json:"addresses"`
+	Subnets      string                `json:"subnets"`
+	Version      string                `json:"version"`
+	Capabilities *records.Capabilities `json:"capabilities,omitempty"`
 }
 
 type healthStatus struct {
@@ -93,14 +102,19 @@ type Node struct {
 	TopicIndex      TopicIndex
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	HealthRegistry  *nodeprobe.Registry
+	ResourceManager p2pv1.ResourceUsageProvider
+	FailureAudit    *validation.FailureAudit
+	IntentLedger    *ekm.IntentLedger
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
 	nodeInfo := h.PeersIndex.Self()
 	resp := identityJSON{
-		PeerID:  h.Network.LocalPeer(),
-		Subnets: nodeInfo.Metadata.Subnets,
-		Version: nodeInfo.Metadata.NodeVersion,
+		PeerID:       h.Network.LocalPeer(),
+		Subnets:      nodeInfo.Metadata.Subnets,
+		Version:      nodeInfo.Metadata.NodeVersion,
+		Capabilities: nodeInfo.Metadata.Capabilities,
 	}
 	for _, addr := range h.Network.ListenAddresses() {
 		resp.Addresses = append(resp.Addresses, addr.String())
@@ -127,6 +141,75 @@ func (h *Node) Topics(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+// Resources returns the resource usage of the libp2p resource manager next to its limits
+func (h *Node) Resources(w http.ResponseWriter, r *http.Request) error {
+	usage, err := h.ResourceManager.ResourceUsage()
+	if err != nil {
+		return api.Error(fmt.Errorf("error getting resource usage: %w", err))
+	}
+	return api.Render(w, r, usage)
+}
+
+// ValidationFailures returns the latest messages that failed validation, newest first
+func (h *Node) ValidationFailures(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Signer    uint64  `form:"signer"`
+		Validator api.Hex `form:"validator"`
+		Error     string  `form:"error"`
+		Limit     int     `form:"limit"`
+	}
+	var response struct {
+		Data []validation.ValidationFailure `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if request.Limit < 0 {
+		return api.BadRequestError(fmt.Errorf("'limit' must not be negative"))
+	}
+
+	response.Data = h.FailureAudit.Query(validation.FailureFilter{
+		Signer:    request.Signer,
+		Validator: request.Validator,
+		Error:     request.Error,
+		Limit:     request.Limit,
+	})
+	return api.Render(w, r, response)
+}
+
+func (h *Node) SigningIntents(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKey   api.Hex `form:"pubkey"`
+		FromSlot uint64  `form:"from_slot"`
+		ToSlot   uint64  `form:"to_slot"`
+		Outcome  string  `form:"outcome"`
+		Limit    int     `form:"limit"`
+	}
+	var response struct {
+		Data []ekm.SigningIntent `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	if request.Limit < 0 {
+		return api.BadRequestError(fmt.Errorf("'limit' must not be negative"))
+	}
+	if request.ToSlot != 0 && request.FromSlot > request.ToSlot {
+		return api.BadRequestError(fmt.Errorf("'from_slot' must be less than or equal to 'to_slot'"))
+	}
+
+	response.Data = h.IntentLedger.Query(ekm.IntentFilter{
+		PubKey:   request.PubKey,
+		FromSlot: phase0.Slot(request.FromSlot),
+		ToSlot:   phase0.Slot(request.ToSlot),
+		Outcome:  request.Outcome,
+		Limit:    request.Limit,
+	})
+	return api.Render(w, r, response)
+}
+
 func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	ctx := context.Background()
 	var resp healthCheckJSON
@@ -166,6 +249,48 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+// Livez responds with 503 if a liveness component failed, in which case the node should be restarted.
+// The status of the liveness components is listed on failure or with the verbose query parameter.
+func (h *Node) Livez(w http.ResponseWriter, r *http.Request) error {
+	return h.probe(w, r, true)
+}
+
+// Readyz responds with 503 if any component failed, in which case the node can't perform its duties.
+// The status of the components is listed on failure or with the verbose query parameter.
+func (h *Node) Readyz(w http.ResponseWriter, r *http.Request) error {
+	return h.probe(w, r, false)
+}
+
+// HealthReport returns the detailed health of the components of the node.
+func (h *Node) HealthReport(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, h.HealthRegistry.Report(r.Context()))
+}
+
+func (h *Node) probe(w http.ResponseWriter, r *http.Request, liveness bool) error {
+	report := h.HealthRegistry.Report(r.Context())
+	ok := report.Ready
+	if liveness {
+		ok = report.Live
+	}
+
+	var body strings.Builder
+	if _, verbose := r.URL.Query()["verbose"]; verbose || !ok {
+		body.WriteString(report.Summary(liveness))
+	}
+	status := http.StatusOK
+	if ok {
+		body.WriteString("ok\n")
+	} else {
+		status = http.StatusServiceUnavailable
+		body.WriteString("failed\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, err := io.WriteString(w, body.String())
+	return err
+}
+
 func (h *Node) peers(peers []peer.ID) []peerJSON {
 	resp := make([]peerJSON, len(peers))
 	for i, id := range peers {
@@ -188,10 +313,11 @@ func (h *Node) peers(peers []peer.ID) []peerJSON {
 		}
 
 		nodeInfo := h.PeersIndex.NodeInfo(id)
-		if nodeInfo == nil {
+		if nodeInfo == nil || nodeInfo.Metadata == nil {
 			continue
 		}
 		resp[i].Version = nodeInfo.Metadata.NodeVersion
+		resp[i].Capabilities = nodeInfo.Metadata.Capabilities
 	}
 	return resp
 }
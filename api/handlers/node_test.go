@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/nodeprobe"
+)
+
+func TestProbes(t *testing.T) {
+	status := func(status nodeprobe.Status, reasons ...string) nodeprobe.Component {
+		return nodeprobe.ComponentFunc(func(ctx context.Context) nodeprobe.Health {
+			return nodeprobe.Health{Status: status, Reasons: reasons}
+		})
+	}
+
+	registry := nodeprobe.NewRegistry()
+	registry.Register("db", status(nodeprobe.StatusOK), nodeprobe.WithLiveness())
+	registry.Register("p2p", status(nodeprobe.StatusDegraded, "not enough connected peers"))
+	h := &Node{HealthRegistry: registry}
+
+	get := func(handler api.HandlerFunc, target string) (int, string) {
+		w := httptest.NewRecorder()
+		api.Handler(handler)(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w.Code, w.Body.String()
+	}
+
+	code, body := get(h.Livez, "/livez")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok\n", body)
+
+	code, body = get(h.Readyz, "/readyz?verbose")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "[ok] db\n[degraded] p2p: not enough connected peers\nok\n", body)
+
+	// Failed components fail readiness, and liveness only if they're liveness components.
+	registry.Register("p2p", status(nodeprobe.StatusFailed, "no peers are connected"))
+	code, body = get(h.Readyz, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[ok] db\n[failed] p2p: no peers are connected\nfailed\n", body)
+
+	code, _ = get(h.Livez, "/livez")
+	require.Equal(t, http.StatusOK, code)
+
+	registry.Register("db", status(nodeprobe.StatusFailed, "closed"), nodeprobe.WithLiveness())
+	code, body = get(h.Livez, "/livez")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[failed] db: closed\nfailed\n", body)
+
+	code, body = get(h.HealthReport, "/v1/node/health/report")
+	require.Equal(t, http.StatusOK, code)
+	var report nodeprobe.Report
+	require.NoError(t, json.Unmarshal([]byte(body), &report))
+	require.Equal(t, nodeprobe.StatusFailed, report.Status)
+	require.False(t, report.Live)
+	require.False(t, report.Ready)
+	require.Equal(t, []string{"no peers are connected"}, report.Components["p2p"].Reasons)
+}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -21,6 +23,11 @@ type Server struct {
 	node       *handlers.Node
 	validators *handlers.Validators
 	exporter   *handlers.Exporter
+	analytics  *handlers.Analytics
+	logging    *handlers.Logging
+
+	// adminToken authorizes the admin endpoints, which are disabled if it's empty.
+	adminToken string
 }
 
 func New(
@@ -29,6 +36,9 @@ func New(
 	node *handlers.Node,
 	validators *handlers.Validators,
 	exporter *handlers.Exporter,
+	analytics *handlers.Analytics,
+	logging *handlers.Logging,
+	adminToken string,
 ) *Server {
 	return &Server{
 		logger:     logger,
@@ -36,6 +46,9 @@ func New(
 		node:       node,
 		validators: validators,
 		exporter:   exporter,
+		analytics:  analytics,
+		logging:    logging,
+		adminToken: adminToken,
 	}
 }
 
@@ -47,14 +60,30 @@ func (s *Server) Run() error {
 	router.Use(middlewareLogger(s.logger))
 	router.Use(middlewareNodeVersion)
 
+	router.Get("/livez", api.Handler(s.node.Livez))
+	router.Get("/readyz", api.Handler(s.node.Readyz))
 	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
+	router.Get("/v1/node/health/report", api.Handler(s.node.HealthReport))
+	router.Get("/v1/node/resources", api.Handler(s.node.Resources))
+	router.Get("/v1/node/validation-failures", api.Handler(s.node.ValidationFailures))
+	router.Get("/v1/node/signing-intents", api.Handler(s.node.SigningIntents))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
 	// We kept both GET and POST methods to ensure compatibility and avoid breaking changes for clients that may rely on either method
 	router.Get("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
 	router.Post("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
+	router.Get("/v1/exporter/analytics/operators", api.Handler(s.analytics.Operators))
+	router.Get("/v1/exporter/analytics/committees", api.Handler(s.analytics.Committees))
+
+	if s.adminToken != "" {
+		router.Group(func(router chi.Router) {
+			router.Use(middlewareBearerAuth(s.adminToken))
+			router.Get("/v1/node/log-levels", api.Handler(s.logging.LogLevels))
+			router.Put("/v1/node/log-levels", api.Handler(s.logging.SetLogLevels))
+		})
+	}
 
 	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
 
@@ -90,6 +119,20 @@ func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
 	}
 }
 
+func middlewareBearerAuth(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func middlewareNodeVersion(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-SSV-Node-Version", commons.GetNodeVersion())
@@ -16,7 +16,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog"
 	"go.uber.org/zap"
 	"tailscale.com/util/singleflight"
 
@@ -156,14 +155,7 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
-	httpClient, err := eth2clienthttp.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		eth2clienthttp.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
-		eth2clienthttp.WithTimeout(commonTimeout),
-		eth2clienthttp.WithReducedMemoryUsage(true),
-	)
+	httpClient, err := newHTTPClient(opt.Context, opt.BeaconNodeAddr, commonTimeout)
 	if err != nil {
 		logger.Error("Consensus client initialization failed",
 			zap.String("address", opt.BeaconNodeAddr),
@@ -279,6 +271,11 @@ func (gc *GoClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// Network returns the beacon network of the client, which is derived from the beacon node in dynamic network mode.
+func (gc *GoClient) Network() beaconprotocol.Network {
+	return gc.network
+}
+
 // GetBeaconNetwork returns the beacon network the node is on
 func (gc *GoClient) GetBeaconNetwork() spectypes.BeaconNetwork {
 	return gc.network.BeaconNetwork
@@ -0,0 +1,153 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2clienthttp "github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// NetworkParams returns the parameters of the beacon network the node is on,
+// according to its spec and genesis.
+func (gc *GoClient) NetworkParams(ctx context.Context) (beaconprotocol.Params, error) {
+	start := time.Now()
+	specResponse, err := gc.client.Spec(ctx, &api.SpecOpts{})
+	recordRequestDuration(gc.ctx, "Spec", gc.client.Address(), http.MethodGet, time.Since(start), err)
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "Spec"),
+			zap.Error(err),
+		)
+		return beaconprotocol.Params{}, fmt.Errorf("failed to obtain spec response: %w", err)
+	}
+	if specResponse == nil || specResponse.Data == nil {
+		gc.log.Error(clNilResponseDataErrMsg,
+			zap.String("api", "Spec"),
+		)
+		return beaconprotocol.Params{}, fmt.Errorf("spec response data is nil")
+	}
+
+	start = time.Now()
+	genesisResponse, err := gc.client.Genesis(ctx, &api.GenesisOpts{})
+	recordRequestDuration(gc.ctx, "Genesis", gc.client.Address(), http.MethodGet, time.Since(start), err)
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
+			zap.String("api", "Genesis"),
+			zap.Error(err),
+		)
+		return beaconprotocol.Params{}, fmt.Errorf("failed to obtain genesis response: %w", err)
+	}
+	if genesisResponse == nil || genesisResponse.Data == nil {
+		gc.log.Error(clNilResponseDataErrMsg,
+			zap.String("api", "Genesis"),
+		)
+		return beaconprotocol.Params{}, fmt.Errorf("genesis response data is nil")
+	}
+
+	return networkParams(specResponse.Data, genesisResponse.Data.GenesisTime)
+}
+
+// DynamicNetwork connects to the beacon node of opt and builds the beacon network from its parameters,
+// returning an error if they don't match those explicitly configured in opt.Network.
+// It's meant to be called at startup, before anything depends on the network.
+func DynamicNetwork(logger *zap.Logger, opt beaconprotocol.Options) (beaconprotocol.Network, error) {
+	commonTimeout := opt.CommonTimeout
+	if commonTimeout == 0 {
+		commonTimeout = DefaultCommonTimeout
+	}
+	httpClient, err := newHTTPClient(opt.Context, opt.BeaconNodeAddr, commonTimeout)
+	if err != nil {
+		return beaconprotocol.Network{}, fmt.Errorf("failed to create http client: %w", err)
+	}
+
+	gc := &GoClient{
+		log:     logger,
+		ctx:     opt.Context,
+		network: opt.Network,
+		client:  httpClient.(*eth2clienthttp.Service),
+	}
+	return gc.dynamicNetwork(opt.Context)
+}
+
+// dynamicNetwork builds the beacon network from the parameters of the beacon node,
+// returning an error if they don't match those explicitly configured.
+func (gc *GoClient) dynamicNetwork(ctx context.Context) (beaconprotocol.Network, error) {
+	params, err := gc.NetworkParams(ctx)
+	if err != nil {
+		return beaconprotocol.Network{}, err
+	}
+	return networkFromParams(gc.network, params)
+}
+
+// networkFromParams builds the beacon network from the parameters of the beacon node.
+// The parameters set explicitly in the configured network are checked against them, which are all of them
+// for spec and custom networks. The parameters a network config left unset are taken from the beacon node.
+func networkFromParams(configured beaconprotocol.Network, params beaconprotocol.Params) (beaconprotocol.Network, error) {
+	explicit := configured.ExplicitParams()
+	if diff := explicit.Fill(params).Diff(params); len(diff) != 0 {
+		return beaconprotocol.Network{}, fmt.Errorf("beacon network params of %s mismatch (configured != consensus client): %s",
+			configured.BeaconNetwork, strings.Join(diff, ", "))
+	}
+	return beaconprotocol.NewCustomNetwork(configured.BeaconNetwork, params), nil
+}
+
+func newHTTPClient(ctx context.Context, addr string, timeout time.Duration) (eth2client.Service, error) {
+	return eth2clienthttp.New(ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		eth2clienthttp.WithAddress(addr),
+		// LogLevel supplies the level of logging to carry out.
+		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
+		eth2clienthttp.WithTimeout(timeout),
+		eth2clienthttp.WithReducedMemoryUsage(true),
+	)
+}
+
+func networkParams(spec map[string]any, genesisTime time.Time) (beaconprotocol.Params, error) {
+	genesisForkVersion, err := specValue[phase0.Version](spec, "GENESIS_FORK_VERSION")
+	if err != nil {
+		return beaconprotocol.Params{}, err
+	}
+	slotDuration, err := specValue[time.Duration](spec, "SECONDS_PER_SLOT")
+	if err != nil {
+		return beaconprotocol.Params{}, err
+	}
+	slotsPerEpoch, err := specValue[uint64](spec, "SLOTS_PER_EPOCH")
+	if err != nil {
+		return beaconprotocol.Params{}, err
+	}
+	epochsPerSyncCommitteePeriod, err := specValue[uint64](spec, "EPOCHS_PER_SYNC_COMMITTEE_PERIOD")
+	if err != nil {
+		return beaconprotocol.Params{}, err
+	}
+
+	return beaconprotocol.Params{
+		GenesisForkVersion:           genesisForkVersion,
+		GenesisTime:                  genesisTime.Unix(),
+		SlotDuration:                 slotDuration,
+		SlotsPerEpoch:                slotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: epochsPerSyncCommitteePeriod,
+	}, nil
+}
+
+func specValue[T any](spec map[string]any, key string) (T, error) {
+	var zero T
+	raw, ok := spec[key]
+	if !ok {
+		return zero, fmt.Errorf("%s is missing in the spec", key)
+	}
+	value, ok := raw.(T)
+	if !ok {
+		return zero, fmt.Errorf("%s has unexpected type %T in the spec", key, raw)
+	}
+	return value, nil
+}
@@ -0,0 +1,75 @@
+package goclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestNetworkParams(t *testing.T) {
+	spec := map[string]any{
+		"GENESIS_FORK_VERSION":             phase0.Version{0x01, 0x01, 0x70, 0x00},
+		"SECONDS_PER_SLOT":                 12 * time.Second,
+		"SLOTS_PER_EPOCH":                  uint64(32),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(256),
+	}
+	genesisTime := time.Unix(1695902400, 0)
+
+	params, err := networkParams(spec, genesisTime)
+	require.NoError(t, err)
+	require.Equal(t, beaconprotocol.Params{
+		GenesisForkVersion:           [4]byte{0x01, 0x01, 0x70, 0x00},
+		GenesisTime:                  1695902400,
+		SlotDuration:                 12 * time.Second,
+		SlotsPerEpoch:                32,
+		EpochsPerSyncCommitteePeriod: 256,
+	}, params)
+
+	delete(spec, "SLOTS_PER_EPOCH")
+	_, err = networkParams(spec, genesisTime)
+	require.ErrorContains(t, err, "SLOTS_PER_EPOCH is missing")
+
+	spec["SLOTS_PER_EPOCH"] = "32"
+	_, err = networkParams(spec, genesisTime)
+	require.ErrorContains(t, err, "SLOTS_PER_EPOCH has unexpected type")
+}
+
+func TestNetworkFromParams(t *testing.T) {
+	holesky := beaconprotocol.NewNetwork(spectypes.HoleskyNetwork)
+	params := holesky.Params()
+	params.SlotDuration = 6 * time.Second
+
+	// all the params of a spec network are explicit
+	network, err := networkFromParams(holesky, holesky.Params())
+	require.NoError(t, err)
+	require.Equal(t, holesky.Params(), network.Params())
+	require.Equal(t, spectypes.HoleskyNetwork, network.GetBeaconNetwork())
+
+	_, err = networkFromParams(holesky, params)
+	require.ErrorContains(t, err, "slot duration 12s != 6s")
+
+	// a node configured for mainnet can't follow a holesky consensus client
+	_, err = networkFromParams(beaconprotocol.NewNetwork(spectypes.MainNetwork), holesky.Params())
+	require.ErrorContains(t, err, "genesis time")
+
+	// explicit params must match, the rest are taken from the consensus client
+	explicit := beaconprotocol.Params{SlotsPerEpoch: 32}
+	configured := beaconprotocol.NewConfiguredNetwork(spectypes.HoleskyNetwork, explicit.Fill(holesky.Params()), explicit)
+	network, err = networkFromParams(configured, params)
+	require.NoError(t, err)
+	require.Equal(t, params, network.Params())
+
+	// all the params of a custom network are explicit
+	_, err = networkFromParams(beaconprotocol.NewCustomNetwork("devnet", holesky.Params()), params)
+	require.ErrorContains(t, err, "slot duration 12s != 6s")
+
+	explicit = beaconprotocol.Params{SlotDuration: 12 * time.Second}
+	configured = beaconprotocol.NewConfiguredNetwork(spectypes.HoleskyNetwork, explicit.Fill(holesky.Params()), explicit)
+	_, err = networkFromParams(configured, params)
+	require.ErrorContains(t, err, "slot duration 12s != 6s")
+}
@@ -19,6 +19,11 @@ type GlobalConfig struct {
 	LogFilePath    string `yaml:"LogFilePath" env:"LOG_FILE_PATH" env-default:"./data/debug.log" env-description:"Defines a file path to write logs into"`
 	LogFileSize    int    `yaml:"LogFileSize" env:"LOG_FILE_SIZE" env-default:"500" env-description:"Defines a file size in megabytes to rotate logs"`
 	LogFileBackups int    `yaml:"LogFileBackups" env:"LOG_FILE_BACKUPS" env-default:"3" env-description:"Defines a number of backups to keep when rotating logs"`
+
+	LogLevels                  map[string]string `yaml:"LogLevels" env:"LOG_LEVELS" env-description:"Defines log levels of components overriding LogLevel, such as 'P2PNetwork:debug,EventHandler:warn'"`
+	LogDebugValidators         []string          `yaml:"LogDebugValidators" env:"LOG_DEBUG_VALIDATORS" env-description:"Defines public keys of validators whose debug logs are enabled regardless of log levels"`
+	LogDebugSamplingInitial    uint64            `yaml:"LogDebugSamplingInitial" env:"LOG_DEBUG_SAMPLING_INITIAL" env-description:"Defines a number of debug logs of each message logged per second before sampling them, 0 disables sampling"`
+	LogDebugSamplingThereafter uint64            `yaml:"LogDebugSamplingThereafter" env:"LOG_DEBUG_SAMPLING_THEREAFTER" env-default:"100" env-description:"Defines that every nth debug log of each message is logged once sampled"`
 }
 
 // ProcessArgs processes and handles CLI arguments
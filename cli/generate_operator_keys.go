@@ -12,6 +12,7 @@ import (
 
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keys/pkcs11"
 )
 
 var generateOperatorKeysCmd = &cobra.Command{
@@ -26,6 +27,11 @@ var generateOperatorKeysCmd = &cobra.Command{
 		passwordFilePath, _ := cmd.Flags().GetString("password-file")
 		privateKeyFilePath, _ := cmd.Flags().GetString("operator-key-file")
 
+		if usePKCS11, _ := cmd.Flags().GetBool("pkcs11"); usePKCS11 {
+			generatePKCS11OperatorKey(cmd, logger)
+			return
+		}
+
 		privKey, err := keys.GeneratePrivateKey()
 		if err != nil {
 			logger.Fatal("Failed to generate keys", zap.Error(err))
@@ -72,6 +78,37 @@ var generateOperatorKeysCmd = &cobra.Command{
 	},
 }
 
+// generatePKCS11OperatorKey generates the operator key on a PKCS#11 token, which never reveals the private key.
+func generatePKCS11OperatorKey(cmd *cobra.Command, logger *zap.Logger) {
+	var cfg pkcs11.Config
+	cfg.Module, _ = cmd.Flags().GetString("pkcs11-module")
+	cfg.TokenLabel, _ = cmd.Flags().GetString("pkcs11-token-label")
+	cfg.PINFile, _ = cmd.Flags().GetString("pkcs11-pin-file")
+	cfg.KeyLabel, _ = cmd.Flags().GetString("pkcs11-key-label")
+	if !cfg.Enabled() {
+		logger.Fatal("PKCS#11 module is required")
+	}
+
+	privKey, err := pkcs11.Generate(cfg)
+	if err != nil {
+		logger.Fatal("Failed to generate keys on PKCS#11 token", zap.Error(err))
+	}
+	defer func() {
+		if err := privKey.Close(); err != nil {
+			logger.Error("Failed to close PKCS#11 session", zap.Error(err))
+		}
+	}()
+
+	pubKeyBase64, err := privKey.Public().Base64()
+	if err != nil {
+		logger.Fatal("Failed to get public key PEM", zap.Error(err))
+	}
+	logger.Info("generated public key (base64)", zap.String("pk", string(pubKeyBase64)))
+	logger.Info("generated private key on PKCS#11 token",
+		zap.String("token_label", cfg.TokenLabel),
+		zap.String("key_label", privKey.Label()))
+}
+
 func writeFile(fileName string, data []byte) error {
 	return os.WriteFile(fileName, data, 0600)
 }
@@ -89,5 +126,10 @@ func readFile(filePath string) ([]byte, error) {
 func init() {
 	generateOperatorKeysCmd.Flags().StringP("password-file", "p", "", "File path to the password used to encrypt the private key")
 	generateOperatorKeysCmd.Flags().StringP("operator-key-file", "o", "", "File path to the operator private key")
+	generateOperatorKeysCmd.Flags().Bool("pkcs11", false, "Generate the operator private key on a PKCS#11 token")
+	generateOperatorKeysCmd.Flags().String("pkcs11-module", "", "Path to the PKCS#11 module library of the token")
+	generateOperatorKeysCmd.Flags().String("pkcs11-token-label", "", "Label of the PKCS#11 token")
+	generateOperatorKeysCmd.Flags().String("pkcs11-pin-file", "", "File path to the user PIN of the PKCS#11 token")
+	generateOperatorKeysCmd.Flags().String("pkcs11-key-label", pkcs11.DefaultKeyLabel, "Label of the operator private key on the PKCS#11 token")
 	RootCmd.AddCommand(generateOperatorKeysCmd)
 }
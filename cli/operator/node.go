@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/binary"
@@ -25,11 +26,13 @@ import (
 	"github.com/ssvlabs/ssv/beacon/goclient"
 	global_config "github.com/ssvlabs/ssv/cli/config"
 	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/ekm/kek"
 	"github.com/ssvlabs/ssv/eth/eventhandler"
 	"github.com/ssvlabs/ssv/eth/eventparser"
 	"github.com/ssvlabs/ssv/eth/eventsyncer"
 	"github.com/ssvlabs/ssv/eth/executionclient"
 	"github.com/ssvlabs/ssv/eth/localevents"
+	"github.com/ssvlabs/ssv/exporter/analytics"
 	exporterapi "github.com/ssvlabs/ssv/exporter/api"
 	"github.com/ssvlabs/ssv/exporter/api/decided"
 	"github.com/ssvlabs/ssv/exporter/convert"
@@ -49,15 +52,19 @@ import (
 	"github.com/ssvlabs/ssv/nodeprobe"
 	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/operator"
+	"github.com/ssvlabs/ssv/operator/clockdrift"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/keyrotation"
 	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keys/pkcs11"
 	"github.com/ssvlabs/ssv/operator/keystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validator"
 	"github.com/ssvlabs/ssv/operator/validators"
 	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/valuecheck"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 	"github.com/ssvlabs/ssv/storage/basedb"
@@ -72,6 +79,14 @@ type KeyStore struct {
 	PasswordFile   string `yaml:"PasswordFile" env:"PASSWORD_FILE" env-description:"Password for operator private key file decryption"`
 }
 
+// KeyRotation configures the new operator key when rotating the operator key.
+// The node runs as both operators once its public key is registered, and switches to it once the old operator is removed.
+type KeyRotation struct {
+	NewOperatorPrivateKey string `yaml:"NewOperatorPrivateKey" env:"NEW_OPERATOR_KEY" env-description:"New operator private key to rotate to"`
+	NewPrivateKeyFile     string `yaml:"NewPrivateKeyFile" env:"NEW_PRIVATE_KEY_FILE" env-description:"New operator private key file to rotate to"`
+	NewPasswordFile       string `yaml:"NewPasswordFile" env:"NEW_PASSWORD_FILE" env-description:"Password for new operator private key file decryption"`
+}
+
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
 	DBOptions                  basedb.Options                   `yaml:"db"`
@@ -80,21 +95,33 @@ type config struct {
 	ConsensusClient            beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
 	P2pNetworkConfig           p2pv1.Config                     `yaml:"p2p"`
 	KeyStore                   KeyStore                         `yaml:"KeyStore"`
+	PKCS11                     pkcs11.Config                    `yaml:"PKCS11"`
 	Graffiti                   string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"ssv.network" `
 	OperatorPrivateKey         string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	MetricsAPIPort             int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
 	EnableProfile              bool                             `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
+	TracesEndpoint             string                           `yaml:"TracesEndpoint" env:"TRACES_ENDPOINT" env-description:"OTLP/HTTP endpoint to export traces to, such as http://collector:4318. Tracing is disabled without it."`
 	NetworkPrivateKey          string                           `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAPIAdminTokenFile       string                           `yaml:"SSVAPIAdminTokenFile" env:"SSV_API_ADMIN_TOKEN_FILE" env-description:"File with the bearer token authorizing the admin endpoints of the SSV API, which are disabled without it"`
 	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	SigningIntentsSize         int                              `yaml:"SigningIntentsSize" env:"SIGNING_INTENTS_SIZE" env-default:"10000" env-description:"Number of signing intents kept for forensics"`
+	ValueChecks                valuecheck.Config                `yaml:"ValueChecks"`
+	KeyRotation                KeyRotation                      `yaml:"KeyRotation"`
+	KEK                        kek.Config                       `yaml:"KEK"`
+	PreviousKEK                kek.Config                       `yaml:"PreviousKEK" env-prefix:"PREVIOUS_"`
+	ClockDrift                 clockdrift.Config                `yaml:"ClockDrift"`
 }
 
 var cfg config
 
 var globalArgs global_config.Args
 
+// networkConfigPath is the path of the file of a custom network config, which is used instead of the configured network
+var networkConfigPath string
+
 // StartNodeCmd is the command to start SSV node
 var StartNodeCmd = &cobra.Command{
 	Use:   "start-node",
@@ -111,10 +138,14 @@ var StartNodeCmd = &cobra.Command{
 
 		logger.Info(fmt.Sprintf("starting %v", commons.GetBuildData()))
 
+		observabilityOptions := []observability.Option{observability.WithMetrics()}
+		if cfg.TracesEndpoint != "" {
+			observabilityOptions = append(observabilityOptions, observability.WithTraces(cfg.TracesEndpoint))
+		}
 		observabilityShutdown, err := observability.Initialize(
 			cmd.Parent().Short,
 			cmd.Parent().Version,
-			observability.WithMetrics())
+			observabilityOptions...)
 		if err != nil {
 			logger.Fatal("could not initialize observability configuration", zap.Error(err))
 		}
@@ -129,52 +160,75 @@ var StartNodeCmd = &cobra.Command{
 		if err != nil {
 			logger.Fatal("could not setup network", zap.Error(err))
 		}
-		cfg.DBOptions.Ctx = cmd.Context()
-		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
-		if err != nil {
-			logger.Fatal("could not setup db", zap.Error(err))
+		if cfg.ConsensusClient.DynamicNetwork {
+			// everything below depends on the beacon network, so it's derived before anything else
+			networkConfig.Beacon, err = setupDynamicNetwork(cmd.Context(), logger, networkConfig.Beacon.GetNetwork())
+			if err != nil {
+				logger.Fatal("could not derive beacon network from consensus client", zap.Error(err))
+			}
 		}
-
 		var operatorPrivKey keys.OperatorPrivateKey
 		var operatorPrivKeyText string
-		if cfg.KeyStore.PrivateKeyFile != "" {
-			// nolint: gosec
-			encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
+		if cfg.PKCS11.Enabled() {
+			pkcs11PrivKey, err := pkcs11.Open(cfg.PKCS11)
 			if err != nil {
-				logger.Fatal("could not read PEM file", zap.Error(err))
+				logger.Fatal("could not open operator private key on PKCS#11 token", zap.Error(err))
 			}
-
-			// nolint: gosec
-			keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
+			defer func() {
+				if err := pkcs11PrivKey.Close(); err != nil {
+					logger.Error("could not close PKCS#11 session", zap.Error(err))
+				}
+			}()
+			operatorPrivKey = pkcs11PrivKey
+		} else {
+			operatorPrivKey, operatorPrivKeyText, err = loadOperatorKey(cfg.KeyStore, cfg.OperatorPrivateKey)
 			if err != nil {
-				logger.Fatal("could not read password file", zap.Error(err))
+				logger.Fatal("could not load operator private key", zap.Error(err))
 			}
+		}
 
-			decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
+		var rotatingKey *keys.RotatingKey
+		if cfg.KeyRotation.NewPrivateKeyFile != "" || cfg.KeyRotation.NewOperatorPrivateKey != "" {
+			newOperatorPrivKey, _, err := loadOperatorKey(KeyStore{
+				PrivateKeyFile: cfg.KeyRotation.NewPrivateKeyFile,
+				PasswordFile:   cfg.KeyRotation.NewPasswordFile,
+			}, cfg.KeyRotation.NewOperatorPrivateKey)
 			if err != nil {
-				logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
-			}
-			operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
-			if err != nil {
-				logger.Fatal("could not extract operator private key from file", zap.Error(err))
+				logger.Fatal("could not load new operator private key", zap.Error(err))
 			}
+			rotatingKey = keys.NewRotatingKey(operatorPrivKey, newOperatorPrivKey)
+			operatorPrivKey = rotatingKey
+		}
+		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
 
-			operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
-		} else {
-			operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
+		var kekProvider kek.Provider
+		if cfg.KEK.Enabled() {
+			kekProvider, err = kek.New(cfg.KEK)
 			if err != nil {
-				logger.Fatal("could not decode operator private key", zap.Error(err))
+				logger.Fatal("could not create key-encryption key provider", zap.Error(err))
 			}
-			operatorPrivKeyText = cfg.OperatorPrivateKey
 		}
-		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork(), operatorPrivKey, kekProvider)
+		if err != nil {
+			logger.Fatal("could not setup db", zap.Error(err))
+		}
 
 		nodeStorage, operatorData := setupOperatorStorage(logger, db, operatorPrivKey, operatorPrivKeyText)
 		operatorDataStore := operatordatastore.New(operatorData)
 
 		usingLocalEvents := len(cfg.LocalEventsPath) != 0
 
-		if err := validateConfig(nodeStorage, networkConfig.NetworkName(), usingLocalEvents); err != nil {
+		var networkConfigHash string
+		if networkConfigPath != "" {
+			networkConfigHash, err = networkConfig.Hash()
+			if err != nil {
+				logger.Fatal("could not hash network config", zap.Error(err))
+			}
+		}
+
+		if err := validateConfig(nodeStorage, networkConfig.NetworkName(), networkConfigHash, usingLocalEvents); err != nil {
 			logger.Fatal("failed to validate config", zap.Error(err))
 		}
 
@@ -183,17 +237,33 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("could not get operator private key hash", zap.Error(err))
 		}
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey)
+		intentLedger := ekm.NewIntentLedger(cfg.SigningIntentsSize)
+		kekOption, err := setupKEK(kekProvider, ekmHashedKey, rotatingKey)
+		if err != nil {
+			logger.Fatal("could not set up key-encryption keys", zap.Error(err))
+		}
+		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey, ekm.WithIntentLedger(intentLedger), kekOption)
 		if err != nil {
 			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
 		}
 
+		var keyRotator *keyrotation.Rotator
+		if rotatingKey != nil {
+			keyRotator, err = setupKeyRotation(logger, rotatingKey, keyManager, nodeStorage, operatorDataStore)
+			if err != nil {
+				logger.Fatal("could not set up operator key rotation", zap.Error(err))
+			}
+		}
+
 		cfg.P2pNetworkConfig.Ctx = cmd.Context()
 
+		clockDrift := clockdrift.New(logger, networkConfig.Beacon, cfg.ClockDrift)
+
 		slotTickerProvider := func() slotticker.SlotTicker {
 			return slotticker.New(logger, slotticker.Config{
 				SlotDuration: networkConfig.SlotDurationSec(),
 				GenesisTime:  networkConfig.GetGenesisTime(),
+				Offset:       clockDrift.Offset,
 			})
 		}
 
@@ -203,6 +273,12 @@ var StartNodeCmd = &cobra.Command{
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
+		if !cfg.ConsensusClient.DynamicNetwork && networkConfigPath != "" {
+			if err := validateNetworkParams(cmd.Context(), networkConfig, consensusClient); err != nil {
+				logger.Fatal("network config doesn't match the consensus client", zap.Error(err))
+			}
+		}
+
 		executionClient, err := executionclient.New(
 			cmd.Context(),
 			cfg.ExecutionClient.Addr,
@@ -222,25 +298,40 @@ var StartNodeCmd = &cobra.Command{
 		cfg.P2pNetworkConfig.OperatorPubKeyHash = format.OperatorID(operatorData.PublicKey)
 		cfg.P2pNetworkConfig.OperatorDataStore = operatorDataStore
 		cfg.P2pNetworkConfig.FullNode = cfg.SSVOptions.ValidatorOptions.FullNode
+		cfg.P2pNetworkConfig.Exporter = cfg.SSVOptions.ValidatorOptions.Exporter
 		cfg.P2pNetworkConfig.Network = networkConfig
 
 		validatorsMap := validators.New(cmd.Context())
 
 		dutyStore := dutystore.New()
 		cfg.SSVOptions.DutyStore = dutyStore
+		cfg.SSVOptions.ClockDrift = clockDrift
 
 		signatureVerifier := signatureverifier.NewSignatureVerifier(nodeStorage)
 
+		peerReputation := validation.NewPeerReputation(cfg.P2pNetworkConfig.PeerReputationConfig)
+
+		failureAudit, err := validation.NewFailureAudit(cfg.P2pNetworkConfig.ValidationAuditConfig, db)
+		if err != nil {
+			logger.Fatal("failed to create validation failure audit", zap.Error(err))
+		}
+		go failureAudit.Run(cmd.Context(), logger)
+
 		messageValidator := validation.New(
 			networkConfig,
 			nodeStorage.ValidatorStore(),
 			dutyStore,
 			signatureVerifier,
-			validation.WithLogger(logger),
+			validation.WithLogger(logger.Named(logging.NameMessageValidation)),
+			validation.WithPeerReputation(peerReputation),
+			validation.WithFailureAudit(failureAudit),
+			validation.WithClockDrift(clockDrift),
 		)
 
 		cfg.P2pNetworkConfig.MessageValidator = messageValidator
+		cfg.P2pNetworkConfig.PeerReputation = peerReputation
 		cfg.SSVOptions.ValidatorOptions.MessageValidator = messageValidator
+		cfg.SSVOptions.ValidatorOptions.SignatureVerifier = signatureVerifier
 
 		p2pNetwork := setupP2P(logger, db)
 
@@ -264,6 +355,12 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.RecipientsStorage = nodeStorage
 		cfg.SSVOptions.ValidatorOptions.GasLimit = cfg.ConsensusClient.GasLimit
 
+		valueCheckPolicy, err := valuecheck.New(cmd.Context(), logger, cfg.ValueChecks, consensusClient)
+		if err != nil {
+			logger.Fatal("could not create value check policy", zap.Error(err))
+		}
+		cfg.SSVOptions.ValidatorOptions.ValueCheckPolicy = valueCheckPolicy
+
 		if cfg.WsAPIPort != 0 {
 			ws := exporterapi.NewWsServer(cmd.Context(), nil, http.NewServeMux(), cfg.WithPing)
 			cfg.SSVOptions.WS = ws
@@ -284,6 +381,12 @@ var StartNodeCmd = &cobra.Command{
 			convert.RoleVoluntaryExit,
 		}
 
+		var participationTracker *analytics.Tracker
+		if cfg.SSVOptions.ValidatorOptions.Exporter {
+			participationTracker = analytics.NewTracker(networkConfig.Beacon, nodeStorage.ValidatorStore())
+			cfg.SSVOptions.ValidatorOptions.ParticipationTracker = participationTracker
+		}
+
 		storageMap := ibftstorage.NewStores()
 
 		for _, storageRole := range storageRoles {
@@ -291,9 +394,19 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
+		cfg.P2pNetworkConfig.DecidedStores = storageMap
 		cfg.SSVOptions.ValidatorOptions.Graffiti = []byte(cfg.Graffiti)
 		cfg.SSVOptions.ValidatorOptions.ValidatorStore = nodeStorage.ValidatorStore()
 		cfg.SSVOptions.ValidatorOptions.OperatorSigner = types.NewSsvOperatorSigner(operatorPrivKey, operatorDataStore.GetOperatorID)
+		if rotatingKey != nil {
+			cfg.SSVOptions.ValidatorOptions.NewOperatorSigner = types.NewSsvOperatorSigner(rotatingKey.New(), func() spectypes.OperatorID {
+				if newOperatorData := operatorDataStore.GetNewOperatorData(); newOperatorData != nil {
+					return newOperatorData.ID
+				}
+				// once rotated, the new operator is the node's own
+				return operatorDataStore.GetOperatorID()
+			})
+		}
 
 		validatorCtrl := validator.NewController(logger, cfg.SSVOptions.ValidatorOptions)
 		cfg.SSVOptions.ValidatorController = validatorCtrl
@@ -324,6 +437,19 @@ var StartNodeCmd = &cobra.Command{
 		nodeProber.Wait()
 		logger.Info("ethereum node(s) are healthy")
 
+		healthRegistry := nodeprobe.NewRegistry()
+		healthRegistry.Register("consensus_client", nodeProber.Component("consensus client"))
+		healthRegistry.Register("execution_client", nodeProber.Component("execution client"))
+		healthRegistry.Register("db", nodeprobe.DBComponent(db), nodeprobe.WithLiveness())
+		healthRegistry.Register("signer", nodeprobe.SignerComponent(operatorPrivKey))
+		healthRegistry.Register("queues", nodeprobe.QueueComponent(validatorCtrl))
+		healthRegistry.Register("slot_ticker", nodeprobe.SlotTickerComponent(func() (time.Time, time.Duration, bool) {
+			tick, ok := operatorNode.LastSlotTick()
+			return tick.Time, tick.Delay, ok
+		}, networkConfig.SlotDurationSec()), nodeprobe.WithLiveness())
+		healthRegistry.Register("p2p", nodeprobe.P2PComponent(p2pNetwork.(nodeprobe.TopicIndex)))
+		healthRegistry.Register("clock", nodeprobe.ClockDriftComponent(clockDrift))
+
 		eventSyncer := setupEventHandling(
 			cmd.Context(),
 			logger,
@@ -334,9 +460,11 @@ var StartNodeCmd = &cobra.Command{
 			operatorDataStore,
 			operatorPrivKey,
 			keyManager,
+			keyRotator,
 		)
 		if len(cfg.LocalEventsPath) == 0 {
 			nodeProber.AddNode("event syncer", eventSyncer)
+			healthRegistry.Register("event_syncer", nodeprobe.EventSyncerComponent(eventSyncer, executionClient))
 		}
 
 		// Increase MaxPeers if the operator is subscribed to many subnets.
@@ -381,6 +509,10 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		if cfg.SSVAPIPort > 0 {
+			apiAdminToken, err := readAPIAdminToken()
+			if err != nil {
+				logger.Fatal("could not read SSV API admin token", zap.Error(err))
+			}
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
@@ -391,6 +523,10 @@ var StartNodeCmd = &cobra.Command{
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
 					NodeProber:      nodeProber,
+					HealthRegistry:  healthRegistry,
+					ResourceManager: p2pNetwork.(p2pv1.ResourceUsageProvider),
+					FailureAudit:    failureAudit,
+					IntentLedger:    intentLedger,
 				},
 				&handlers.Validators{
 					Shares: nodeStorage.Shares(),
@@ -399,6 +535,13 @@ var StartNodeCmd = &cobra.Command{
 					DomainType: networkConfig.DomainType,
 					QBFTStores: storageMap,
 				},
+				&handlers.Analytics{
+					Tracker: participationTracker,
+				},
+				&handlers.Logging{
+					Levels: logging.Levels(),
+				},
+				apiAdminToken,
 			)
 			go func() {
 				err := apiServer.Run()
@@ -413,15 +556,16 @@ var StartNodeCmd = &cobra.Command{
 	},
 }
 
-func validateConfig(nodeStorage operatorstorage.Storage, networkName string, usingLocalEvents bool) error {
+func validateConfig(nodeStorage operatorstorage.Storage, networkName, networkConfigHash string, usingLocalEvents bool) error {
 	storedConfig, foundConfig, err := nodeStorage.GetConfig(nil)
 	if err != nil {
 		return fmt.Errorf("failed to get stored config: %w", err)
 	}
 
 	currentConfig := &operatorstorage.ConfigLock{
-		NetworkName:      networkName,
-		UsingLocalEvents: usingLocalEvents,
+		NetworkName:       networkName,
+		UsingLocalEvents:  usingLocalEvents,
+		NetworkConfigHash: networkConfigHash,
 	}
 
 	if foundConfig {
@@ -438,8 +582,21 @@ func validateConfig(nodeStorage operatorstorage.Storage, networkName string, usi
 	return nil
 }
 
+// validateNetworkParams checks that the beacon network params of the network config match those of the consensus client.
+func validateNetworkParams(ctx context.Context, networkConfig networkconfig.NetworkConfig, consensusClient *goclient.GoClient) error {
+	params, err := consensusClient.NetworkParams(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get network params of consensus client: %w", err)
+	}
+	if diff := networkConfig.Beacon.GetNetwork().Params().Diff(params); len(diff) != 0 {
+		return fmt.Errorf("beacon network params mismatch (config != consensus client): %s", strings.Join(diff, ", "))
+	}
+	return nil
+}
+
 func init() {
 	global_config.ProcessArgs(&cfg, &globalArgs, StartNodeCmd)
+	StartNodeCmd.PersistentFlags().StringVar(&networkConfigPath, "network-config", "", "Path to a YAML or JSON file of a custom network config, which is used instead of the configured network")
 }
 
 func setupGlobal() (*zap.Logger, error) {
@@ -467,11 +624,34 @@ func setupGlobal() (*zap.Logger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("logging.SetGlobalLogger: %w", err)
 	}
+	if err := logging.Levels().SetLevels(cfg.LogLevels); err != nil {
+		return nil, fmt.Errorf("could not set log levels: %w", err)
+	}
+	for _, pubKey := range cfg.LogDebugValidators {
+		logging.Levels().SetValidatorDebug(pubKey, true)
+	}
+	logging.Levels().SetDebugSampling(cfg.LogDebugSamplingInitial, cfg.LogDebugSamplingThereafter)
 
 	return zap.L(), nil
 }
 
-func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.BadgerDB, error) {
+// readAPIAdminToken reads the token authorizing the admin endpoints of the SSV API, if configured.
+func readAPIAdminToken() (string, error) {
+	if cfg.SSVAPIAdminTokenFile == "" {
+		return "", nil
+	}
+	// #nosec G304
+	token, err := os.ReadFile(cfg.SSVAPIAdminTokenFile)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(token)) == 0 {
+		return "", errors.New("token file is empty")
+	}
+	return string(bytes.TrimSpace(token)), nil
+}
+
+func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network, operatorPrivKey keys.OperatorPrivateKey, kekProvider kek.Provider) (*kv.BadgerDB, error) {
 	db, err := kv.New(logger, cfg.DBOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open db")
@@ -485,9 +665,11 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	}
 
 	migrationOpts := migrations.Options{
-		Db:      db,
-		DbPath:  cfg.DBOptions.Path,
-		Network: eth2Network,
+		Db:          db,
+		DbPath:      cfg.DBOptions.Path,
+		Network:     eth2Network,
+		OperatorKey: operatorPrivKey,
+		KEK:         kekProvider,
 	}
 	applied, err := migrations.Run(cfg.DBOptions.Ctx, logger, migrationOpts)
 	if err != nil {
@@ -533,6 +715,17 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 		logger.Fatal("could not get hashed private key", zap.Error(err))
 	}
 
+	// The storage is pinned to the new key if it was rotated to before the node restarted.
+	if rotatingKey, ok := configPrivKey.(*keys.RotatingKey); ok && found {
+		newStoragePrivKeyHash, err := rotatingKey.New().StorageHash()
+		if err != nil {
+			logger.Fatal("could not hash new private key", zap.Error(err))
+		}
+		if newStoragePrivKeyHash == storedPrivKeyHash {
+			rotatingKey.Rotate()
+		}
+	}
+
 	configStoragePrivKeyHash, err := configPrivKey.StorageHash()
 	if err != nil {
 		logger.Fatal("could not hash private key", zap.Error(err))
@@ -541,17 +734,21 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 	// Backwards compatibility for the old hashing method,
 	// which was hashing the text from the configuration directly,
 	// whereas StorageHash re-encodes with PEM format.
-	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
-	if err != nil {
-		logger.Fatal("could not decode private key", zap.Error(err))
-	}
-	configStoragePrivKeyLegacyHash, err := rsaencryption.HashRsaKey(cliPrivKeyDecoded)
-	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
+	// Keys held by a PKCS#11 token have no text and no legacy hash.
+	var configStoragePrivKeyLegacyHash string
+	if configPrivKeyText != "" {
+		cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
+		if err != nil {
+			logger.Fatal("could not decode private key", zap.Error(err))
+		}
+		configStoragePrivKeyLegacyHash, err = rsaencryption.HashRsaKey(cliPrivKeyDecoded)
+		if err != nil {
+			logger.Fatal("could not hash private key", zap.Error(err))
+		}
 	}
 
 	if !found {
-		if err := nodeStorage.SavePrivateKeyHash(configStoragePrivKeyHash); err != nil {
+		if err := nodeStorage.SavePrivateKeyHash(nil, configStoragePrivKeyHash); err != nil {
 			logger.Fatal("could not save hashed private key", zap.Error(err))
 		}
 	} else if configStoragePrivKeyHash != storedPrivKeyHash &&
@@ -582,10 +779,121 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 	return nodeStorage, operatorData
 }
 
-func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
-	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+func loadOperatorKey(keyStore KeyStore, operatorPrivateKey string) (keys.OperatorPrivateKey, string, error) {
+	if keyStore.PrivateKeyFile == "" {
+		privKey, err := keys.PrivateKeyFromString(operatorPrivateKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not decode operator private key: %w", err)
+		}
+		return privKey, operatorPrivateKey, nil
+	}
+
+	// nolint: gosec
+	encryptedJSON, err := os.ReadFile(keyStore.PrivateKeyFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read PEM file: %w", err)
+	}
+
+	// nolint: gosec
+	keyStorePassword, err := os.ReadFile(keyStore.PasswordFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read password file: %w", err)
+	}
+
+	decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
+	if err != nil {
+		return nil, "", fmt.Errorf("could not decrypt operator private key keystore: %w", err)
+	}
+	privKey, err := keys.PrivateKeyFromBytes(decryptedKeystore)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not extract operator private key from file: %w", err)
+	}
+
+	return privKey, base64.StdEncoding.EncodeToString(decryptedKeystore), nil
+}
+
+// setupKEK returns the option wrapping the data key of the signer storage by kekProvider,
+// or by the operator key if kekProvider is nil. A data key wrapped by the previous KEK
+// or by the other key of a key being rotated is re-wrapped on startup.
+func setupKEK(kekProvider kek.Provider, ekmHash string, rotatingKey *keys.RotatingKey) (ekm.Option, error) {
+	var fallbacks []kek.Provider
+	if kekProvider == nil {
+		operatorKEK, err := kek.Operator(ekmHash)
+		if err != nil {
+			return nil, err
+		}
+		kekProvider = operatorKEK
+	}
+	if cfg.PreviousKEK.Enabled() {
+		previousKEK, err := kek.New(cfg.PreviousKEK)
+		if err != nil {
+			return nil, fmt.Errorf("could not create previous key-encryption key provider: %w", err)
+		}
+		fallbacks = append(fallbacks, previousKEK)
+	}
+	if rotatingKey != nil {
+		for _, key := range []keys.OperatorPrivateKey{rotatingKey.Old(), rotatingKey.New()} {
+			keyEKMHash, err := key.EKMHash()
+			if err != nil {
+				return nil, err
+			}
+			operatorKEK, err := kek.Operator(keyEKMHash)
+			if err != nil {
+				return nil, err
+			}
+			fallbacks = append(fallbacks, operatorKEK)
+		}
+	}
+	return ekm.WithKEK(kekProvider, fallbacks...), nil
+}
+
+// setupKeyRotation creates the rotator of the operator key. If the new public key is already registered,
+// the node runs as both operators right away, until the old operator is removed.
+func setupKeyRotation(
+	logger *zap.Logger,
+	rotatingKey *keys.RotatingKey,
+	keyManager ekm.KeyManager,
+	nodeStorage operatorstorage.Storage,
+	operatorDataStore operatordatastore.OperatorDataStore,
+) (*keyrotation.Rotator, error) {
+	keyRotator, err := keyrotation.New(logger, rotatingKey, keyManager, nodeStorage)
 	if err != nil {
-		return networkconfig.NetworkConfig{}, err
+		return nil, err
+	}
+	if rotatingKey.Rotated() {
+		return keyRotator, nil
+	}
+
+	operatorData, found, err := nodeStorage.GetOperatorDataByPubKey(nil, keyRotator.NewPublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("could not get operator data by new public key: %w", err)
+	}
+	if !found {
+		logger.Info("waiting for new operator public key to be registered to rotate operator key",
+			zap.String("new_pubkey", string(keyRotator.NewPublicKey())))
+		return keyRotator, nil
+	}
+
+	logger.Info("running as both the old and the new operator until the old operator is removed",
+		fields.OperatorID(operatorData.ID))
+	operatorDataStore.SetNewOperatorData(operatorData)
+	return keyRotator, nil
+}
+
+func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
+	var networkConfig networkconfig.NetworkConfig
+	var err error
+	if networkConfigPath != "" {
+		networkConfig, err = networkconfig.LoadFromFile(networkConfigPath)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, err
+		}
+		logger.Info("loaded custom network config", zap.String("path", networkConfigPath))
+	} else {
+		networkConfig, err = networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, err
+		}
 	}
 
 	if cfg.SSVOptions.CustomDomainType != "" {
@@ -641,6 +949,24 @@ func setupP2P(logger *zap.Logger, db basedb.Database) network.P2PNetwork {
 	return n
 }
 
+// setupDynamicNetwork builds the beacon network from the parameters of the consensus client,
+// checking them against those explicitly set in the configured network.
+func setupDynamicNetwork(ctx context.Context, logger *zap.Logger, configured beaconprotocol.Network) (beaconprotocol.Network, error) {
+	opt := cfg.ConsensusClient
+	opt.Context = ctx
+	opt.Network = configured
+	network, err := goclient.DynamicNetwork(logger, opt)
+	if err != nil {
+		return beaconprotocol.Network{}, err
+	}
+	logger.Info("derived beacon network from consensus client",
+		zap.Duration("slot_duration", network.SlotDurationSec()),
+		zap.Uint64("slots_per_epoch", network.SlotsPerEpoch()),
+		zap.Int64("genesis_time", network.MinGenesisTime()),
+	)
+	return network, nil
+}
+
 func setupConsensusClient(
 	logger *zap.Logger,
 	operatorDataStore operatordatastore.OperatorDataStore,
@@ -665,6 +991,7 @@ func setupEventHandling(
 	operatorDataStore operatordatastore.OperatorDataStore,
 	operatorDecrypter keys.OperatorDecrypter,
 	keyManager ekm.KeyManager,
+	keyRotator *keyrotation.Rotator,
 ) *eventsyncer.EventSyncer {
 	eventFilterer, err := executionClient.Filterer()
 	if err != nil {
@@ -673,6 +1000,14 @@ func setupEventHandling(
 
 	eventParser := eventparser.New(eventFilterer)
 
+	eventHandlerOptions := []eventhandler.Option{
+		eventhandler.WithFullNode(),
+		eventhandler.WithLogger(logger),
+	}
+	if keyRotator != nil {
+		eventHandlerOptions = append(eventHandlerOptions, eventhandler.WithKeyRotator(keyRotator))
+	}
+
 	eventHandler, err := eventhandler.New(
 		nodeStorage,
 		eventParser,
@@ -682,8 +1017,7 @@ func setupEventHandling(
 		operatorDecrypter,
 		keyManager,
 		cfg.SSVOptions.ValidatorOptions.Beacon,
-		eventhandler.WithFullNode(),
-		eventhandler.WithLogger(logger),
+		eventHandlerOptions...,
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
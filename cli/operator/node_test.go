@@ -29,7 +29,7 @@ func Test_verifyConfig(t *testing.T) {
 			NetworkName:      testNetworkName,
 			UsingLocalEvents: true,
 		}
-		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, c.UsingLocalEvents))
+		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, "", c.UsingLocalEvents))
 
 		storedConfig, found, err := nodeStorage.GetConfig(nil)
 		require.NoError(t, err)
@@ -45,7 +45,7 @@ func Test_verifyConfig(t *testing.T) {
 			UsingLocalEvents: true,
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
-		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, c.UsingLocalEvents))
+		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, "", c.UsingLocalEvents))
 
 		storedConfig, found, err := nodeStorage.GetConfig(nil)
 		require.NoError(t, err)
@@ -62,7 +62,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, testNetworkName, true),
+			validateConfig(nodeStorage, testNetworkName, "", true),
 			"incompatible config change: network mismatch. Stored network testnet:alan1 does not match current network testnet:alan. The database must be removed or reinitialized",
 		)
 
@@ -81,7 +81,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, testNetworkName, c.UsingLocalEvents),
+			validateConfig(nodeStorage, testNetworkName, "", c.UsingLocalEvents),
 			"incompatible config change: network mismatch. Stored network testnet:alan1 does not match current network testnet:alan. The database must be removed or reinitialized",
 		)
 
@@ -100,7 +100,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, c.NetworkName, true),
+			validateConfig(nodeStorage, c.NetworkName, "", true),
 			"incompatible config change: enabling local events is not allowed. The database must be removed or reinitialized",
 		)
 
@@ -119,7 +119,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, c.NetworkName, false),
+			validateConfig(nodeStorage, c.NetworkName, "", false),
 			"incompatible config change: disabling local events is not allowed. The database must be removed or reinitialized",
 		)
 
@@ -130,4 +130,19 @@ func Test_verifyConfig(t *testing.T) {
 
 		require.NoError(t, nodeStorage.DeleteConfig(nil))
 	})
+
+	t.Run("has different network config hash in DB", func(t *testing.T) {
+		c := &operatorstorage.ConfigLock{
+			NetworkName:       testNetworkName,
+			NetworkConfigHash: "aa",
+		}
+		require.NoError(t, nodeStorage.SaveConfig(nil, c))
+		require.ErrorContains(t,
+			validateConfig(nodeStorage, c.NetworkName, "bb", c.UsingLocalEvents),
+			`incompatible config change: network config mismatch. Stored network config hash "aa" does not match current network config hash "bb"`,
+		)
+		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, c.NetworkConfigHash, c.UsingLocalEvents))
+
+		require.NoError(t, nodeStorage.DeleteConfig(nil))
+	})
 }
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/eth/localevents"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/operator/keystore"
+	"github.com/ssvlabs/ssv/operator/recovery"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+// decryptShareCmd decrypts the share private key of an operator from the shares of a ValidatorAdded event
+var decryptShareCmd = &cobra.Command{
+	Use:   "decrypt-share",
+	Short: "Decrypts the share private key of an operator from the shares of a ValidatorAdded event, for offline disaster recovery",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameRecovery)
+		threshold.Init()
+
+		shares, err := loadShares(cmd)
+		if err != nil {
+			logger.Fatal("failed to load validator shares", zap.Error(err))
+		}
+		operatorID, _ := cmd.Flags().GetUint64("operator-id")
+		operatorKey, err := loadRecoveryOperatorKey(cmd)
+		if err != nil {
+			logger.Fatal("failed to load operator private key", zap.Error(err))
+		}
+
+		share, err := shares.Decrypt(operatorID, operatorKey)
+		if err != nil {
+			logger.Fatal("failed to decrypt share", zap.Error(err))
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		data, err := json.MarshalIndent(shares.Export(operatorID, share), "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal decrypted share", zap.Error(err))
+		}
+		if err := writeNewFile(output, data); err != nil {
+			logger.Fatal("failed to write decrypted share", zap.Error(err))
+		}
+		if err := writeAuditRecord(cmd, shares.NewAuditRecord(cmd.Use, []uint64{operatorID}, output)); err != nil {
+			logger.Fatal("failed to write audit record", zap.Error(err))
+		}
+		logger.Info("decrypted share",
+			zap.Uint64("operator_id", operatorID),
+			zap.String("share_public_key", share.GetPublicKey().SerializeToHexStr()),
+			zap.String("output", output))
+	},
+}
+
+// reconstructValidatorKeyCmd reconstructs a validator private key from a quorum of decrypted shares
+var reconstructValidatorKeyCmd = &cobra.Command{
+	Use:   "reconstruct-validator-key",
+	Short: "Reconstructs a validator private key from a quorum of decrypted shares into an EIP-2335 keystore, for offline disaster recovery",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameRecovery)
+		threshold.Init()
+
+		shares, err := loadShares(cmd)
+		if err != nil {
+			logger.Fatal("failed to load validator shares", zap.Error(err))
+		}
+
+		shareKeys := make(map[uint64]*bls.SecretKey)
+		shareFiles, _ := cmd.Flags().GetStringSlice("share-file")
+		for _, shareFile := range shareFiles {
+			data, err := readFile(shareFile)
+			if err != nil {
+				logger.Fatal("failed to read decrypted share", zap.String("file", shareFile), zap.Error(err))
+			}
+			var decryptedShare recovery.DecryptedShare
+			if err := json.Unmarshal(data, &decryptedShare); err != nil {
+				logger.Fatal("failed to parse decrypted share", zap.String("file", shareFile), zap.Error(err))
+			}
+			if _, ok := shareKeys[decryptedShare.OperatorID]; ok {
+				logger.Fatal("duplicate share", zap.Uint64("operator_id", decryptedShare.OperatorID))
+			}
+			shareKeys[decryptedShare.OperatorID], err = shares.Import(decryptedShare)
+			if err != nil {
+				logger.Fatal("invalid decrypted share", zap.String("file", shareFile), zap.Error(err))
+			}
+		}
+
+		// The share of our operator can be decrypted right away rather than supplied as a file.
+		if operatorID, _ := cmd.Flags().GetUint64("operator-id"); operatorID != 0 {
+			operatorKey, err := loadRecoveryOperatorKey(cmd)
+			if err != nil {
+				logger.Fatal("failed to load operator private key", zap.Error(err))
+			}
+			if _, ok := shareKeys[operatorID]; ok {
+				logger.Fatal("duplicate share", zap.Uint64("operator_id", operatorID))
+			}
+			shareKeys[operatorID], err = shares.Decrypt(operatorID, operatorKey)
+			if err != nil {
+				logger.Fatal("failed to decrypt share", zap.Error(err))
+			}
+		}
+
+		validatorKey, err := shares.Reconstruct(shareKeys)
+		if err != nil {
+			logger.Fatal("failed to reconstruct validator key", zap.Error(err))
+		}
+
+		keystorePasswordFile, _ := cmd.Flags().GetString("keystore-password-file")
+		keystorePassword, err := readFile(keystorePasswordFile)
+		if err != nil {
+			logger.Fatal("failed to read keystore password file", zap.Error(err))
+		}
+		encryptedJSON, err := keystore.EncryptValidatorKeystore(validatorKey, strings.TrimSpace(string(keystorePassword)), "reconstructed from SSV shares")
+		if err != nil {
+			logger.Fatal("failed to encrypt validator keystore", zap.Error(err))
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if err := writeNewFile(output, encryptedJSON); err != nil {
+			logger.Fatal("failed to write validator keystore", zap.Error(err))
+		}
+		if err := writeAuditRecord(cmd, shares.NewAuditRecord(cmd.Use, maps.Keys(shareKeys), output)); err != nil {
+			logger.Fatal("failed to write audit record", zap.Error(err))
+		}
+		logger.Info("reconstructed validator key",
+			zap.String("validator_public_key", hex.EncodeToString(shares.ValidatorPublicKey)),
+			zap.Int("shares", len(shareKeys)),
+			zap.String("output", output))
+	},
+}
+
+// loadShares loads the shares of the validator from a ValidatorAdded event,
+// given either as a local events file, as an export of a database of the events or as the raw event fields.
+func loadShares(cmd *cobra.Command) (*recovery.Shares, error) {
+	validatorPublicKeyHex, _ := cmd.Flags().GetString("validator-public-key")
+	validatorPublicKey, err := hex.DecodeString(strings.TrimPrefix(validatorPublicKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode validator public key: %w", err)
+	}
+
+	if eventsFile, _ := cmd.Flags().GetString("events-file"); eventsFile != "" {
+		events, err := localevents.Load(eventsFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load events: %w", err)
+		}
+		for _, event := range events {
+			validatorAdded, ok := event.Data.(contract.ContractValidatorAdded)
+			if ok && hex.EncodeToString(validatorAdded.PublicKey) == hex.EncodeToString(validatorPublicKey) {
+				return recovery.SharesFromEvent(&validatorAdded)
+			}
+		}
+		return nil, fmt.Errorf("no ValidatorAdded event of validator %x", validatorPublicKey)
+	}
+
+	if exportFile, _ := cmd.Flags().GetString("export-file"); exportFile != "" {
+		// #nosec G304
+		f, err := os.Open(exportFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not open export file: %w", err)
+		}
+		defer f.Close()
+		return recovery.SharesFromExport(f, validatorPublicKey)
+	}
+
+	operatorIDFlags, _ := cmd.Flags().GetUintSlice("operator-ids")
+	operatorIDs := make([]uint64, 0, len(operatorIDFlags))
+	for _, operatorID := range operatorIDFlags {
+		operatorIDs = append(operatorIDs, uint64(operatorID))
+	}
+	sharesHex, _ := cmd.Flags().GetString("shares")
+	sharesData, err := hex.DecodeString(strings.TrimPrefix(sharesHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode shares: %w", err)
+	}
+	return recovery.ParseShares(validatorPublicKey, operatorIDs, sharesData)
+}
+
+// loadRecoveryOperatorKey loads the operator private key from a keystore or from its base64 encoding.
+func loadRecoveryOperatorKey(cmd *cobra.Command) (keys.OperatorPrivateKey, error) {
+	operatorKeyFile, _ := cmd.Flags().GetString("operator-key-file")
+	if operatorKeyFile == "" {
+		operatorPrivateKey, _ := cmd.Flags().GetString("operator-private-key")
+		return keys.PrivateKeyFromString(operatorPrivateKey)
+	}
+
+	encryptedJSON, err := readFile(operatorKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read operator key file: %w", err)
+	}
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	password, err := readFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read password file: %w", err)
+	}
+	decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(password))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt operator key file: %w", err)
+	}
+	return keys.PrivateKeyFromBytes(decryptedKeystore)
+}
+
+// writeAuditRecord appends record to the audit log, which records every recovery of key material.
+func writeAuditRecord(cmd *cobra.Command, record recovery.AuditRecord) error {
+	auditLog, _ := cmd.Flags().GetString("audit-log")
+	return recovery.WriteAuditRecord(auditLog, record)
+}
+
+// writeNewFile writes key material to a new file, refusing to overwrite an existing one.
+func writeNewFile(fileName string, data []byte) error {
+	// #nosec G304
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func addRecoveryFlags(c *cobra.Command) {
+	c.Flags().String("validator-public-key", "", "Hex encoded public key of the validator")
+	c.Flags().String("events-file", "", "File path to local events holding the ValidatorAdded event of the validator")
+	c.Flags().String("export-file", "", "File path to a JSON export of ValidatorAdded events holding the event of the validator, if no events file is given")
+	c.Flags().UintSlice("operator-ids", nil, "Operator IDs of the ValidatorAdded event, if no events or export file is given")
+	c.Flags().String("shares", "", "Hex encoded shares of the ValidatorAdded event, if no events or export file is given")
+	c.Flags().Uint64("operator-id", 0, "ID of the operator whose share to decrypt")
+	c.Flags().StringP("operator-key-file", "o", "", "File path to the operator private key keystore")
+	c.Flags().StringP("password-file", "p", "", "File path to the password of the operator private key keystore")
+	c.Flags().String("operator-private-key", "", "Base64 encoded operator private key, if no operator key file is given")
+	c.Flags().String("audit-log", "recovery_audit.jsonl", "File path to the audit log to append a record of the recovery to")
+	_ = c.MarkFlagRequired("validator-public-key")
+}
+
+func init() {
+	addRecoveryFlags(decryptShareCmd)
+	decryptShareCmd.Flags().String("output", "decrypted_share.json", "File path to write the decrypted share to")
+	_ = decryptShareCmd.MarkFlagRequired("operator-id")
+
+	addRecoveryFlags(reconstructValidatorKeyCmd)
+	reconstructValidatorKeyCmd.Flags().StringSlice("share-file", nil, "File paths to decrypted shares of other operators")
+	reconstructValidatorKeyCmd.Flags().String("keystore-password-file", "", "File path to the password used to encrypt the validator keystore")
+	reconstructValidatorKeyCmd.Flags().String("output", "keystore.json", "File path to write the validator keystore to")
+	_ = reconstructValidatorKeyCmd.MarkFlagRequired("keystore-password-file")
+
+	RootCmd.AddCommand(decryptShareCmd)
+	RootCmd.AddCommand(reconstructValidatorKeyCmd)
+}
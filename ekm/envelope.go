@@ -0,0 +1,168 @@
+package ekm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ssvlabs/ssv/ekm/kek"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	envelopePrefix = prefix + "envelope-"
+	envelopePath   = "envelope"
+
+	dataKeySize = 32
+)
+
+// ErrNoEnvelope is returned when opening the envelope of a signer storage that isn't envelope encrypted.
+var ErrNoEnvelope = errors.New("signer storage isn't envelope encrypted")
+
+// envelope is the data key encrypting the accounts, wrapped by a key-encryption key.
+type envelope struct {
+	KEK        string `json:"kek"`
+	WrappedKey []byte `json:"wrapped_key"`
+}
+
+// EnableEnvelope re-encrypts the stored accounts under a new data key, which is stored wrapped by kekProvider.
+func (s *storage) EnableEnvelope(ctx context.Context, kekProvider kek.Provider) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrappedKey, err := kekProvider.Wrap(ctx, dataKey)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Update(func(txn basedb.Txn) error {
+		if err := s.reEncryptTxn(txn, dataKey); err != nil {
+			return err
+		}
+		return s.saveEnvelope(txn, &envelope{KEK: kekProvider.ID(), WrappedKey: wrappedKey})
+	}); err != nil {
+		return err
+	}
+
+	s.encryptionKey = dataKey
+	s.kekID = kekProvider.ID()
+	return nil
+}
+
+// OpenEnvelope unwraps the data key of the stored accounts and uses it from then on.
+// The data key is unwrapped by the first of kekProvider and fallbacks that wrapped it,
+// and is re-wrapped by kekProvider if it was wrapped by a fallback, which rotates the key-encryption key.
+func (s *storage) OpenEnvelope(ctx context.Context, kekProvider kek.Provider, fallbacks ...kek.Provider) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	env, found, err := s.getEnvelope()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNoEnvelope
+	}
+
+	var dataKey []byte
+	var unwrapErrs []error
+	for i, provider := range append([]kek.Provider{kekProvider}, fallbacks...) {
+		if provider.ID() != env.KEK {
+			continue
+		}
+		dataKey, err = provider.Unwrap(ctx, env.WrappedKey)
+		if err != nil {
+			unwrapErrs = append(unwrapErrs, err)
+			continue
+		}
+		if i > 0 {
+			if err := s.rewrap(ctx, nil, dataKey, kekProvider); err != nil {
+				return err
+			}
+		}
+		break
+	}
+	if dataKey == nil {
+		if len(unwrapErrs) == 0 {
+			return fmt.Errorf("data key is wrapped by key-encryption key %q, which isn't configured", env.KEK)
+		}
+		return fmt.Errorf("could not unwrap data key: %w", errors.Join(unwrapErrs...))
+	}
+
+	s.encryptionKey = dataKey
+	if s.kekID == "" {
+		s.kekID = env.KEK
+	}
+	return nil
+}
+
+// RewrapEnvelope wraps the data key of the stored accounts by kekProvider, saving it in rw if it isn't nil.
+func (s *storage) RewrapEnvelope(ctx context.Context, rw basedb.ReadWriter, kekProvider kek.Provider) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.kekID == "" {
+		return ErrNoEnvelope
+	}
+	return s.rewrap(ctx, rw, s.encryptionKey, kekProvider)
+}
+
+// HasEnvelope returns whether the stored accounts are envelope encrypted.
+func (s *storage) HasEnvelope() (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, found, err := s.getEnvelope()
+	return found, err
+}
+
+// EnvelopeKEK returns the ID of the key-encryption key wrapping the data key, or an empty string
+// if the storage isn't envelope encrypted.
+func (s *storage) EnvelopeKEK() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.kekID
+}
+
+func (s *storage) rewrap(ctx context.Context, rw basedb.ReadWriter, dataKey []byte, kekProvider kek.Provider) error {
+	wrappedKey, err := kekProvider.Wrap(ctx, dataKey)
+	if err != nil {
+		return err
+	}
+	if err := s.saveEnvelope(rw, &envelope{KEK: kekProvider.ID(), WrappedKey: wrappedKey}); err != nil {
+		return err
+	}
+	s.kekID = kekProvider.ID()
+	return nil
+}
+
+func (s *storage) getEnvelope() (*envelope, bool, error) {
+	obj, found, err := s.db.Get(s.objPrefix(envelopePrefix), []byte(envelopePath))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get envelope: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	var env envelope
+	if err := json.Unmarshal(obj.Value, &env); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	return &env, true, nil
+}
+
+func (s *storage) saveEnvelope(rw basedb.ReadWriter, env *envelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+	return s.db.Using(rw).Set(s.objPrefix(envelopePrefix), []byte(envelopePath), data)
+}
@@ -0,0 +1,135 @@
+package ekm
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/ekm/kek"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+func testFileKEK(t *testing.T, key string) kek.Provider {
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat(key, 32)), 0600))
+	provider, err := kek.NewFile(path)
+	require.NoError(t, err)
+	return provider
+}
+
+func TestEnvelope(t *testing.T) {
+	threshold.Init()
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	operatorKey := hex.EncodeToString(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+	network := networkconfig.TestNetwork.Beacon.GetNetwork()
+	kek1 := testFileKEK(t, "01")
+	kek2 := testFileKEK(t, "02")
+
+	signerStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, signerStorage.SetEncryptionKey(operatorKey))
+	wallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(wallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := 1
+	account, err := wallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), &index)
+	require.NoError(t, err)
+
+	enveloped, err := signerStorage.HasEnvelope()
+	require.NoError(t, err)
+	require.False(t, enveloped)
+	require.ErrorIs(t, signerStorage.OpenEnvelope(ctx, kek1), ErrNoEnvelope)
+	require.ErrorIs(t, signerStorage.RewrapEnvelope(ctx, nil, kek1), ErrNoEnvelope)
+
+	require.NoError(t, signerStorage.EnableEnvelope(ctx, kek1))
+	require.Equal(t, kek1.ID(), signerStorage.EnvelopeKEK())
+
+	// The accounts are no longer encrypted with the operator key.
+	operatorKeyStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, operatorKeyStorage.SetEncryptionKey(operatorKey))
+	_, err = operatorKeyStorage.ListAccounts()
+	require.Error(t, err)
+
+	// The data key can't be unwrapped without the key-encryption key wrapping it.
+	require.ErrorContains(t, NewSignerStorage(db, network, logger).OpenEnvelope(ctx, kek2), "isn't configured")
+
+	openedStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, openedStorage.OpenEnvelope(ctx, kek1))
+	accounts, err := openedStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ValidatorPublicKey(), accounts[0].ValidatorPublicKey())
+
+	// Opening with a new key-encryption key and the previous one as a fallback rotates it.
+	rotatedStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, rotatedStorage.OpenEnvelope(ctx, kek2, kek1))
+	require.Equal(t, kek2.ID(), rotatedStorage.EnvelopeKEK())
+	require.ErrorContains(t, NewSignerStorage(db, network, logger).OpenEnvelope(ctx, kek1), "isn't configured")
+
+	reopenedStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, reopenedStorage.OpenEnvelope(ctx, kek2))
+	accounts, err = reopenedStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
+
+func TestKeyManagerEnvelope(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldKey := hex.EncodeToString(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+	newKey := hex.EncodeToString(_byteArray("2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40"))
+	network := networkconfig.TestNetwork
+
+	oldKEK, err := kek.Operator(oldKey)
+	require.NoError(t, err)
+	km, err := NewETHKeyManagerSigner(logger, db, network, oldKey, WithKEK(oldKEK))
+	require.NoError(t, err)
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	require.NoError(t, km.AddShare(sk))
+
+	// Rotating the operator key re-wraps the data key rather than re-encrypting the accounts.
+	require.NoError(t, km.RotateEncryptionKey(nil, newKey))
+
+	newKEK, err := kek.Operator(newKey)
+	require.NoError(t, err)
+	km, err = NewETHKeyManagerSigner(logger, db, network, newKey, WithKEK(newKEK))
+	require.NoError(t, err)
+	accounts, err := km.(*ethKeyManagerSigner).ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+
+	// Switching to another key-encryption key re-wraps the data key wrapped by the operator key.
+	fileKEK := testFileKEK(t, "03")
+	km, err = NewETHKeyManagerSigner(logger, db, network, newKey, WithKEK(fileKEK))
+	require.NoError(t, err)
+	require.Equal(t, fileKEK.ID(), km.(*ethKeyManagerSigner).storage.EnvelopeKEK())
+
+	// The data key wrapped by an external key-encryption key is independent of the operator key.
+	require.NoError(t, km.RotateEncryptionKey(nil, oldKey))
+	km, err = NewETHKeyManagerSigner(logger, db, network, "", WithKEK(fileKEK))
+	require.NoError(t, err)
+	accounts, err = km.(*ethKeyManagerSigner).ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
@@ -1,6 +1,7 @@
 package ekm
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"sync"
@@ -26,7 +27,9 @@ import (
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	"github.com/ssvlabs/ssv/ekm/kek"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
@@ -45,9 +48,34 @@ type ethKeyManagerSigner struct {
 	wallet            core.Wallet
 	walletLock        *sync.RWMutex
 	signer            signer.ValidatorSigner
+	signLocks         signLocks
 	storage           Storage
+	network           beacon.BeaconNetwork
 	domain            spectypes.DomainType
 	slashingProtector core.SlashingProtector
+	intents           *IntentLedger
+	kek               kek.Provider
+	kekFallbacks      []kek.Provider
+}
+
+type Option func(*ethKeyManagerSigner)
+
+// WithIntentLedger records the intents of signing beacon objects in the ledger before signing them,
+// rejecting the ones conflicting with earlier intents. The ledger is loaded from and saved to the signer storage.
+func WithIntentLedger(ledger *IntentLedger) Option {
+	return func(km *ethKeyManagerSigner) {
+		km.intents = ledger
+	}
+}
+
+// WithKEK envelope encrypts the signer storage with a data key wrapped by primary.
+// A data key wrapped by one of fallbacks, or by the operator key, is re-wrapped by primary,
+// which rotates the key-encryption key.
+func WithKEK(primary kek.Provider, fallbacks ...kek.Provider) Option {
+	return func(km *ethKeyManagerSigner) {
+		km.kek = primary
+		km.kekFallbacks = fallbacks
+	}
 }
 
 // StorageProvider provides the underlying KeyManager storage.
@@ -64,10 +92,13 @@ type KeyManager interface {
 	AddShare(shareKey *bls.SecretKey) error
 	// RemoveShare removes a share key
 	RemoveShare(pubKey string) error
+	// RotateEncryptionKey re-encrypts the stored share keys under a new encryption key in rw,
+	// or in a transaction of its own if rw is nil
+	RotateEncryptionKey(rw basedb.ReadWriter, newKey string) error
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string) (KeyManager, error) {
+func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string, opts ...Option) (KeyManager, error) {
 	signerStore := NewSignerStorage(db, network.Beacon, logger)
 	if encryptionKey != "" {
 		err := signerStore.SetEncryptionKey(encryptionKey)
@@ -75,6 +106,28 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 			return nil, err
 		}
 	}
+	km := &ethKeyManagerSigner{
+		walletLock: &sync.RWMutex{},
+		storage:    signerStore,
+		network:    network.Beacon,
+		domain:     network.DomainType,
+	}
+	for _, opt := range opts {
+		opt(km)
+	}
+
+	if km.kek != nil {
+		if err := km.openEnvelope(encryptionKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if km.intents != nil {
+		if err := km.intents.load(logger, signerStore, network.Beacon); err != nil {
+			return nil, err
+		}
+	}
+
 	options := &eth2keymanager.KeyVaultOptions{}
 	options.SetStorage(signerStore)
 	options.SetWalletType(core.NDWallet)
@@ -94,17 +147,12 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 		}
 	}
 
-	slashingProtector := slashingprotection.NewNormalProtection(signerStore)
-	beaconSigner := signer.NewSimpleSigner(wallet, slashingProtector, core.Network(network.Beacon.GetBeaconNetwork()))
-
-	return &ethKeyManagerSigner{
-		wallet:            wallet,
-		walletLock:        &sync.RWMutex{},
-		signer:            beaconSigner,
-		storage:           signerStore,
-		domain:            network.DomainType,
-		slashingProtector: slashingProtector,
-	}, nil
+	km.wallet = wallet
+	km.slashingProtector = slashingprotection.NewNormalProtection(signerStore)
+	// the network of the key manager's signer is only named, as attestations and blocks,
+	// whose signing depends on the network parameters, are signed by signAttestation and signBlock
+	km.signer = signer.NewSimpleSigner(wallet, km.slashingProtector, core.Network(network.Beacon.GetBeaconNetwork()))
+	return km, nil
 }
 
 func (km *ethKeyManagerSigner) ListAccounts() ([]core.ValidatorAccount, error) {
@@ -120,6 +168,30 @@ func (km *ethKeyManagerSigner) RetrieveHighestProposal(pubKey []byte) (phase0.Sl
 }
 
 func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	return km.SignBeaconObjectForDuty(obj, domain, pk, domainType, nil)
+}
+
+// SignBeaconObjectForDuty signs the object like SignBeaconObject, attributing the signing intent to the duty.
+func (km *ethKeyManagerSigner) SignBeaconObjectForDuty(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType, duty *SigningDuty) (spectypes.Signature, [32]byte, error) {
+	if km.intents == nil {
+		return km.sign(obj, domain, pk, domainType)
+	}
+
+	intent, key, err := newSigningIntent(obj, pk, domainType, duty)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	seq, err := km.intents.begin(intent, key)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	sig, root, err := km.sign(obj, domain, pk, domainType)
+	km.intents.complete(seq, key, err)
+	return sig, root, err
+}
+
+func (km *ethKeyManagerSigner) sign(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
 	sig, rootSlice, err := km.signBeaconObject(obj, domain, pk, domainType)
 	if err != nil {
 		return nil, [32]byte{}, err
@@ -139,33 +211,17 @@ func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.
 		if !ok {
 			return nil, nil, errors.New("could not cast obj to AttestationData")
 		}
-		return km.signer.SignBeaconAttestation(data, domain, pk)
+		return km.signAttestation(data, domain, pk)
 	case spectypes.DomainProposer:
 		switch v := obj.(type) {
 		case *capella.BeaconBlock:
-			vBlock := &spec.VersionedBeaconBlock{
-				Version: spec.DataVersionCapella,
-				Capella: v,
-			}
-			return km.signer.SignBeaconBlock(vBlock, domain, pk)
+			return km.signBlock(v, v.Slot, domain, pk)
 		case *deneb.BeaconBlock:
-			vBlock := &spec.VersionedBeaconBlock{
-				Version: spec.DataVersionDeneb,
-				Deneb:   v,
-			}
-			return km.signer.SignBeaconBlock(vBlock, domain, pk)
+			return km.signBlock(v, v.Slot, domain, pk)
 		case *apiv1capella.BlindedBeaconBlock:
-			vBlindedBlock := &api.VersionedBlindedBeaconBlock{
-				Version: spec.DataVersionCapella,
-				Capella: v,
-			}
-			return km.signer.SignBlindedBeaconBlock(vBlindedBlock, domain, pk)
+			return km.signBlock(v, v.Slot, domain, pk)
 		case *apiv1deneb.BlindedBeaconBlock:
-			vBlindedBlock := &api.VersionedBlindedBeaconBlock{
-				Version: spec.DataVersionDeneb,
-				Deneb:   v,
-			}
-			return km.signer.SignBlindedBeaconBlock(vBlindedBlock, domain, pk)
+			return km.signBlock(v, v.Slot, domain, pk)
 		default:
 			return nil, nil, fmt.Errorf("obj type is unknown: %T", obj)
 		}
@@ -273,6 +329,55 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (km *ethKeyManagerSigner) RotateEncryptionKey(rw basedb.ReadWriter, newKey string) error {
+	km.walletLock.Lock()
+	defer km.walletLock.Unlock()
+
+	if km.storage.EnvelopeKEK() != "" {
+		// The data key is independent of the operator key, so only a data key
+		// wrapped by the operator key has to be re-wrapped.
+		if km.storage.EnvelopeKEK() != kek.ProviderOperator {
+			return nil
+		}
+		operatorKEK, err := kek.Operator(newKey)
+		if err != nil {
+			return err
+		}
+		if err := km.storage.RewrapEnvelope(context.Background(), rw, operatorKEK); err != nil {
+			return errors.Wrap(err, "could not re-wrap data key")
+		}
+		return nil
+	}
+
+	if err := km.storage.ReEncrypt(rw, newKey); err != nil {
+		return errors.Wrap(err, "could not re-encrypt signer storage")
+	}
+	return nil
+}
+
+// openEnvelope unwraps the data key of the signer storage, enabling envelope encryption
+// if the storage isn't envelope encrypted yet.
+func (km *ethKeyManagerSigner) openEnvelope(encryptionKey string) error {
+	ctx := context.Background()
+	fallbacks := km.kekFallbacks
+	if encryptionKey != "" {
+		operatorKEK, err := kek.Operator(encryptionKey)
+		if err != nil {
+			return err
+		}
+		fallbacks = append(fallbacks, operatorKEK)
+	}
+
+	err := km.storage.OpenEnvelope(ctx, km.kek, fallbacks...)
+	if errors.Is(err, ErrNoEnvelope) {
+		err = km.storage.EnableEnvelope(ctx, km.kek)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not open signer storage envelope")
+	}
+	return nil
+}
+
 func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 	km.walletLock.Lock()
 	defer km.walletLock.Unlock()
@@ -0,0 +1,115 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+)
+
+// The signer of the key manager only knows the parameters of the networks it's built with, and exits the process
+// when it estimates the slots of any other network, which it does to refuse signing attestations and blocks
+// far into the future. So attestations and blocks are signed here instead, estimating the slots of the configured
+// beacon network, while the rest of the objects, whose signing doesn't depend on the network, are signed by the key manager.
+
+// signLocks serialize the slashing protection of an account's attestations or blocks,
+// so that the check and the update of its highest attestation or proposal are atomic.
+type signLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (l *signLocks) lock(accountPubKey []byte, operation string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	key := hex.EncodeToString(accountPubKey) + "_" + operation
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[key] = lock
+	}
+	return lock
+}
+
+// maxValidSlot returns the latest slot objects can be signed for, later ones are too far into the future.
+func (km *ethKeyManagerSigner) maxValidSlot() phase0.Slot {
+	return km.network.EstimatedSlotAtTime(time.Now().Unix() + signer.FarFutureMaxValidEpoch)
+}
+
+func (km *ethKeyManagerSigner) signAttestation(data *phase0.AttestationData, domain phase0.Domain, pk []byte) ([]byte, []byte, error) {
+	account, err := km.account(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lock := km.signLocks.lock(pk, "attestation")
+	lock.Lock()
+	defer lock.Unlock()
+
+	maxValidEpoch := km.network.EstimatedEpochAtSlot(km.maxValidSlot())
+	if data.Target.Epoch > maxValidEpoch {
+		return nil, nil, errors.New("target epoch too far into the future")
+	}
+	if data.Source.Epoch > maxValidEpoch {
+		return nil, nil, errors.New("source epoch too far into the future")
+	}
+
+	if err := km.IsAttestationSlashable(pk, data); err != nil {
+		return nil, nil, err
+	}
+	if err := km.slashingProtector.UpdateHighestAttestation(pk, data); err != nil {
+		return nil, nil, err
+	}
+	return signRoot(account, data, domain)
+}
+
+func (km *ethKeyManagerSigner) signBlock(block ssz.HashRoot, slot phase0.Slot, domain phase0.Domain, pk []byte) ([]byte, []byte, error) {
+	account, err := km.account(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lock := km.signLocks.lock(pk, "proposal")
+	lock.Lock()
+	defer lock.Unlock()
+
+	if slot > km.maxValidSlot() {
+		return nil, nil, errors.New("proposed block slot too far into the future")
+	}
+
+	if err := km.IsBeaconBlockSlashable(pk, slot); err != nil {
+		return nil, nil, err
+	}
+	if err := km.slashingProtector.UpdateHighestProposal(pk, slot); err != nil {
+		return nil, nil, err
+	}
+	return signRoot(account, block, domain)
+}
+
+func (km *ethKeyManagerSigner) account(pk []byte) (core.ValidatorAccount, error) {
+	if pk == nil {
+		return nil, errors.New("account was not supplied")
+	}
+	return km.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+}
+
+func signRoot(account core.ValidatorAccount, obj ssz.HashRoot, domain phase0.Domain) ([]byte, []byte, error) {
+	root, err := signer.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing root")
+	}
+	sig, err := account.ValidationKeySign(root[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, root[:], nil
+}
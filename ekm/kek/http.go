@@ -0,0 +1,118 @@
+package kek
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxResponseSize bounds the responses of key-encryption key services, which only return keys.
+const maxResponseSize = 1 << 20
+
+// httpKMS wraps data keys with a generic KMS, which serves
+//
+//	POST /wrap   {"plaintext": "<base64>"}  -> {"ciphertext": "<base64>"}
+//	POST /unwrap {"ciphertext": "<base64>"} -> {"plaintext": "<base64>"}
+type httpKMS struct {
+	url    string
+	keyID  string
+	token  string
+	client *http.Client
+}
+
+// NewHTTP returns the provider of the key-encryption key keyID held by a generic HTTP KMS.
+// The key is identified by keyID rather than the URL of the KMS, which may change.
+func NewHTTP(baseURL, keyID, token string) (Provider, error) {
+	if _, err := url.Parse(baseURL); err != nil || baseURL == "" {
+		return nil, fmt.Errorf("invalid KMS URL %q", baseURL)
+	}
+	if keyID == "" {
+		return nil, fmt.Errorf("KMS key ID is required")
+	}
+	return &httpKMS{
+		url:    strings.TrimSuffix(baseURL, "/"),
+		keyID:  keyID,
+		token:  token,
+		client: newHTTPClient(),
+	}, nil
+}
+
+func (h *httpKMS) ID() string {
+	return ProviderHTTP + ":" + h.keyID
+}
+
+func (h *httpKMS) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var response struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	request := struct {
+		Plaintext []byte `json:"plaintext"`
+	}{Plaintext: dataKey}
+	if err := postJSON(ctx, h.client, h.url+"/wrap", h.headers(), request, &response); err != nil {
+		return nil, fmt.Errorf("could not wrap data key with KMS: %w", err)
+	}
+	if len(response.Ciphertext) == 0 {
+		return nil, fmt.Errorf("no ciphertext returned by KMS")
+	}
+	return response.Ciphertext, nil
+}
+
+func (h *httpKMS) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var response struct {
+		Plaintext []byte `json:"plaintext"`
+	}
+	request := struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}{Ciphertext: wrapped}
+	if err := postJSON(ctx, h.client, h.url+"/unwrap", h.headers(), request, &response); err != nil {
+		return nil, fmt.Errorf("could not unwrap data key with KMS: %w", err)
+	}
+	return response.Plaintext, nil
+}
+
+func (h *httpKMS) headers() map[string]string {
+	if h.token == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + h.token}
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, request, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Package kek provides the key-encryption keys (KEKs) wrapping the data key that encrypts the signer storage.
+//
+// The signer storage is encrypted with a random data key, which is stored wrapped by a KEK.
+// Rotating the KEK only re-wraps the data key, so it's independent of the operator key.
+package kek
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ProviderOperator = "operator"
+	ProviderFile     = "file"
+	ProviderVault    = "vault"
+	ProviderHTTP     = "http"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Provider wraps and unwraps data keys with a key-encryption key.
+type Provider interface {
+	// ID identifies the key-encryption key, so that a data key is unwrapped with the key it was wrapped with.
+	ID() string
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// Config configures a key-encryption key provider.
+type Config struct {
+	Provider string      `yaml:"Provider" env:"KEK_PROVIDER" env-description:"Key-encryption key provider of the signer storage: operator (default), file, vault or http"`
+	File     string      `yaml:"File" env:"KEK_FILE" env-description:"File with the hex encoded 32-byte key-encryption key of the file provider"`
+	Vault    VaultConfig `yaml:"Vault"`
+	HTTP     HTTPConfig  `yaml:"HTTP"`
+}
+
+type VaultConfig struct {
+	Address   string `yaml:"Address" env:"KEK_VAULT_ADDRESS" env-description:"Address of the Vault server"`
+	TokenFile string `yaml:"TokenFile" env:"KEK_VAULT_TOKEN_FILE" env-description:"File with the Vault token"`
+	Mount     string `yaml:"Mount" env:"KEK_VAULT_MOUNT" env-default:"transit" env-description:"Mount path of the Vault transit secrets engine"`
+	Key       string `yaml:"Key" env:"KEK_VAULT_KEY" env-description:"Name of the Vault transit key"`
+}
+
+type HTTPConfig struct {
+	URL       string `yaml:"URL" env:"KEK_HTTP_URL" env-description:"Base URL of the KMS, which serves the /wrap and /unwrap endpoints"`
+	KeyID     string `yaml:"KeyID" env:"KEK_HTTP_KEY_ID" env-description:"ID of the key-encryption key in the KMS, which identifies it independently of the KMS URL"`
+	TokenFile string `yaml:"TokenFile" env:"KEK_HTTP_TOKEN_FILE" env-description:"File with the bearer token of the KMS"`
+}
+
+// Enabled returns whether a provider other than the default operator key provider is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != "" && c.Provider != ProviderOperator
+}
+
+// New creates the configured provider. The operator provider is created with Operator instead,
+// as it's derived from the operator key.
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case ProviderFile:
+		return NewFile(cfg.File)
+	case ProviderVault:
+		token, err := readToken(cfg.Vault.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewVaultTransit(cfg.Vault.Address, token, cfg.Vault.Mount, cfg.Vault.Key)
+	case ProviderHTTP:
+		var token string
+		if cfg.HTTP.TokenFile != "" {
+			var err error
+			token, err = readToken(cfg.HTTP.TokenFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return NewHTTP(cfg.HTTP.URL, cfg.HTTP.KeyID, token)
+	default:
+		return nil, fmt.Errorf("unknown key-encryption key provider %q", cfg.Provider)
+	}
+}
+
+func readToken(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("token file is required")
+	}
+	// #nosec G304
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read token file: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// aesKEK wraps data keys with AES-256-GCM.
+type aesKEK struct {
+	id  string
+	key []byte
+}
+
+// Operator returns the provider of the key-encryption key derived from the operator key,
+// which is the hex encoded EKM hash of the operator key.
+func Operator(ekmHash string) (Provider, error) {
+	key, err := hex.DecodeString(ekmHash)
+	if err != nil {
+		return nil, errors.New("the key must be a valid hexadecimal string")
+	}
+	return &aesKEK{id: ProviderOperator, key: key}, nil
+}
+
+// NewFile returns the provider of a key-encryption key read from a file, which holds the key hex encoded.
+func NewFile(path string) (Provider, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key-encryption key file: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode key-encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key-encryption key must be 32 bytes long, got %d", len(key))
+	}
+	// The key is identified by its fingerprint rather than its path, which may change.
+	fingerprint := sha256.Sum256(key)
+	return &aesKEK{id: ProviderFile + ":" + hex.EncodeToString(fingerprint[:8]), key: key}, nil
+}
+
+func (k *aesKEK) ID() string {
+	return k.id
+}
+
+func (k *aesKEK) Wrap(_ context.Context, dataKey []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+func (k *aesKEK) Unwrap(_ context.Context, wrapped []byte) ([]byte, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < gcm.NonceSize() {
+		return nil, errors.New("malformed wrapped key")
+	}
+	nonce, ciphertext := wrapped[:gcm.NonceSize()], wrapped[gcm.NonceSize():]
+	// #nosec G407 false positive: https://github.com/securego/gosec/issues/1211
+	dataKey, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+func (k *aesKEK) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
@@ -0,0 +1,168 @@
+package kek
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testDataKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testRoundTrip(t *testing.T, provider Provider) {
+	ctx := context.Background()
+	wrapped, err := provider.Wrap(ctx, testDataKey)
+	require.NoError(t, err)
+	require.NotEqual(t, testDataKey, wrapped)
+
+	unwrapped, err := provider.Unwrap(ctx, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, testDataKey, unwrapped)
+}
+
+func TestOperator(t *testing.T) {
+	provider, err := Operator(strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	require.Equal(t, ProviderOperator, provider.ID())
+	testRoundTrip(t, provider)
+
+	otherProvider, err := Operator(strings.Repeat("cd", 32))
+	require.NoError(t, err)
+	wrapped, err := provider.Wrap(context.Background(), testDataKey)
+	require.NoError(t, err)
+	_, err = otherProvider.Unwrap(context.Background(), wrapped)
+	require.Error(t, err)
+
+	_, err = Operator("not hex")
+	require.Error(t, err)
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kek")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("01", 32)+"\n"), 0600))
+
+	provider, err := New(Config{Provider: ProviderFile, File: path})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(provider.ID(), ProviderFile+":"))
+	testRoundTrip(t, provider)
+
+	// The key is identified by its fingerprint rather than its path.
+	otherPath := filepath.Join(dir, "other")
+	require.NoError(t, os.WriteFile(otherPath, []byte("0x"+strings.Repeat("01", 32)), 0600))
+	otherProvider, err := NewFile(otherPath)
+	require.NoError(t, err)
+	require.Equal(t, provider.ID(), otherProvider.ID())
+
+	require.NoError(t, os.WriteFile(path, []byte("0102"), 0600))
+	_, err = NewFile(path)
+	require.ErrorContains(t, err, "must be 32 bytes long")
+
+	_, err = NewFile(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestVaultTransit(t *testing.T) {
+	const token = "vault-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var request map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/ssv":
+			writeJSON(t, w, map[string]any{"data": map[string]string{"ciphertext": "vault:v1:" + request["plaintext"]}})
+		case "/v1/transit/decrypt/ssv":
+			writeJSON(t, w, map[string]any{"data": map[string]string{"plaintext": strings.TrimPrefix(request["ciphertext"], "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(token), 0600))
+
+	provider, err := New(Config{Provider: ProviderVault, Vault: VaultConfig{
+		Address:   server.URL,
+		TokenFile: tokenFile,
+		Mount:     "transit",
+		Key:       "ssv",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "vault:transit/ssv", provider.ID())
+	testRoundTrip(t, provider)
+
+	unauthorized, err := NewVaultTransit(server.URL, "wrong", "transit", "ssv")
+	require.NoError(t, err)
+	_, err = unauthorized.Wrap(context.Background(), testDataKey)
+	require.Error(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	const token = "kms-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var request map[string][]byte
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		switch r.URL.Path {
+		case "/wrap":
+			writeJSON(t, w, map[string][]byte{"ciphertext": []byte(hex.EncodeToString(request["plaintext"]))})
+		case "/unwrap":
+			plaintext, err := hex.DecodeString(string(request["ciphertext"]))
+			require.NoError(t, err)
+			writeJSON(t, w, map[string][]byte{"plaintext": plaintext})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(token+"\n"), 0600))
+
+	provider, err := New(Config{Provider: ProviderHTTP, HTTP: HTTPConfig{URL: server.URL + "/", KeyID: "ssv", TokenFile: tokenFile}})
+	require.NoError(t, err)
+	require.Equal(t, ProviderHTTP+":ssv", provider.ID())
+	testRoundTrip(t, provider)
+
+	// The key is identified by its ID rather than the URL of the KMS, which may change.
+	moved, err := NewHTTP("https://kms.example", "ssv", token)
+	require.NoError(t, err)
+	require.Equal(t, provider.ID(), moved.ID())
+
+	_, err = NewHTTP(server.URL, "", token)
+	require.ErrorContains(t, err, "key ID is required")
+
+	unauthorized, err := NewHTTP(server.URL, "ssv", "")
+	require.NoError(t, err)
+	_, err = unauthorized.Wrap(context.Background(), testDataKey)
+	require.Error(t, err)
+}
+
+func TestNew_Unknown(t *testing.T) {
+	require.False(t, Config{}.Enabled())
+	require.False(t, Config{Provider: ProviderOperator}.Enabled())
+
+	_, err := New(Config{Provider: "unknown"})
+	require.ErrorContains(t, err, "unknown key-encryption key provider")
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
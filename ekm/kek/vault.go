@@ -0,0 +1,85 @@
+package kek
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// vaultTransit wraps data keys with a key of the transit secrets engine of HashiCorp Vault.
+type vaultTransit struct {
+	address string
+	token   string
+	mount   string
+	key     string
+	client  *http.Client
+}
+
+// NewVaultTransit returns the provider of a key-encryption key held by the Vault transit secrets engine.
+// Rotating the transit key in Vault doesn't require re-wrapping, as Vault keeps the previous versions.
+func NewVaultTransit(address, token, mount, key string) (Provider, error) {
+	if _, err := url.Parse(address); err != nil || address == "" {
+		return nil, fmt.Errorf("invalid Vault address %q", address)
+	}
+	if key == "" {
+		return nil, fmt.Errorf("transit key is required")
+	}
+	if mount == "" {
+		mount = "transit"
+	}
+	return &vaultTransit{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		key:     key,
+		client:  newHTTPClient(),
+	}, nil
+}
+
+func (v *vaultTransit) ID() string {
+	return fmt.Sprintf("%s:%s/%s", ProviderVault, v.mount, v.key)
+}
+
+func (v *vaultTransit) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	request := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}
+	if err := postJSON(ctx, v.client, v.url("encrypt"), v.headers(), request, &response); err != nil {
+		return nil, fmt.Errorf("could not wrap data key with Vault: %w", err)
+	}
+	if response.Data.Ciphertext == "" {
+		return nil, fmt.Errorf("no ciphertext returned by Vault")
+	}
+	return []byte(response.Data.Ciphertext), nil
+}
+
+func (v *vaultTransit) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	request := map[string]string{"ciphertext": string(wrapped)}
+	if err := postJSON(ctx, v.client, v.url("decrypt"), v.headers(), request, &response); err != nil {
+		return nil, fmt.Errorf("could not unwrap data key with Vault: %w", err)
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(response.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode data key from Vault: %w", err)
+	}
+	return dataKey, nil
+}
+
+func (v *vaultTransit) url(operation string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s", v.address, v.mount, operation, url.PathEscape(v.key))
+}
+
+func (v *vaultTransit) headers() map[string]string {
+	return map[string]string{"X-Vault-Token": v.token}
+}
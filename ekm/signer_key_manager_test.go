@@ -1034,3 +1034,38 @@ func buildAttestationData(slot phase0.Slot, highestSource, highestTarget phase0.
 		},
 	}
 }
+
+func TestSignAttestation_CustomNetwork(t *testing.T) {
+	network, err := networkconfig.LoadFromFile("../networkconfig/custom-network.example.yaml")
+	require.NoError(t, err)
+	require.Empty(t, core.NetworkFromString(string(network.Beacon.GetBeaconNetwork())), "the key manager must not know the example network")
+
+	km := testKeyManager(t, &network)
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	pk := sk.GetPublicKey().Serialize()
+
+	currentSlot := network.Beacon.EstimatedCurrentSlot()
+	currentEpoch := network.Beacon.EstimatedEpochAtSlot(currentSlot)
+	attestation := func(targetEpoch phase0.Epoch) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:            network.Beacon.FirstSlotAtEpoch(targetEpoch),
+			BeaconBlockRoot: phase0.Root{1},
+			Source:          &phase0.Checkpoint{Epoch: targetEpoch - 1},
+			Target:          &phase0.Checkpoint{Epoch: targetEpoch},
+		}
+	}
+
+	sig, root, err := km.SignBeaconObject(attestation(currentEpoch+minSPAttestationEpochGap+1), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.NoError(t, err)
+	require.NotEqual(t, [32]byte{}, root)
+
+	signature := &bls.Sign{}
+	require.NoError(t, signature.Deserialize(sig))
+	require.True(t, signature.VerifyByte(sk.GetPublicKey(), root[:]))
+
+	// the far future protection estimates the epochs of the custom network
+	_, _, err = km.SignBeaconObject(attestation(currentEpoch+10), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.EqualError(t, err, "target epoch too far into the future")
+}
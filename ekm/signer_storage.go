@@ -1,9 +1,11 @@
 package ekm
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -20,6 +22,7 @@ import (
 	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/ekm/kek"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	registry "github.com/ssvlabs/ssv/protocol/v2/blockchain/eth1"
@@ -34,6 +37,7 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	signingIntentsPrefix  = prefix + "signing_intents-"
 )
 
 // Storage represents the interface for ssv node storage
@@ -45,8 +49,18 @@ type Storage interface {
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
 	SetEncryptionKey(newKey string) error
+	ReEncrypt(rw basedb.ReadWriter, newKey string) error
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
+	EnableEnvelope(ctx context.Context, kekProvider kek.Provider) error
+	OpenEnvelope(ctx context.Context, kekProvider kek.Provider, fallbacks ...kek.Provider) error
+	RewrapEnvelope(ctx context.Context, rw basedb.ReadWriter, kekProvider kek.Provider) error
+	HasEnvelope() (bool, error)
+	EnvelopeKEK() string
+
+	SaveSigningIntent(record SigningIntentRecord) error
+	ListSigningIntents(fromSlot phase0.Slot) ([]SigningIntentRecord, error)
+	PruneSigningIntents(beforeEpoch phase0.Epoch) error
 
 	BeaconNetwork() beacon.BeaconNetwork
 }
@@ -55,6 +69,9 @@ type storage struct {
 	db            basedb.Database
 	network       beacon.BeaconNetwork
 	encryptionKey []byte
+	// previousKey decrypts the accounts re-encrypted in a transaction that isn't committed yet
+	previousKey []byte
+	kekID       string
 	logger        *zap.Logger // struct logger is used because core.Storage does not support passing a logger
 	lock          sync.RWMutex
 }
@@ -84,6 +101,67 @@ func (s *storage) SetEncryptionKey(newKey string) error {
 	return nil
 }
 
+// ReEncrypt re-encrypts the stored accounts under newKey and uses it from then on.
+// If rw is nil, the accounts are re-encrypted in a transaction of their own. Otherwise they're re-encrypted in rw,
+// and the accounts are decrypted under either key, so that they can be read whether rw is committed or not.
+// The stored accounts are left untouched if re-encryption fails, and accounts already
+// encrypted under newKey are skipped, so an interrupted re-encryption can be resumed.
+func (s *storage) ReEncrypt(rw basedb.ReadWriter, newKey string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keyBytes, err := hex.DecodeString(newKey)
+	if err != nil {
+		return errors.New("the key must be a valid hexadecimal string")
+	}
+
+	if rw != nil {
+		err = s.reEncryptTxn(rw, keyBytes)
+	} else {
+		err = s.db.Update(func(txn basedb.Txn) error {
+			return s.reEncryptTxn(txn, keyBytes)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if rw != nil {
+		s.previousKey = s.encryptionKey
+	}
+	s.encryptionKey = keyBytes
+	return nil
+}
+
+// reEncryptTxn re-encrypts the stored accounts under newKey, skipping the ones already encrypted under it.
+func (s *storage) reEncryptTxn(rw basedb.ReadWriter, newKey []byte) error {
+	var accounts []basedb.Obj
+	err := rw.GetAll(s.objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+		value, err := s.decryptData(obj.Value)
+		if err != nil {
+			if _, newKeyErr := decryptWithKey(newKey, obj.Value); newKeyErr == nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to decrypt account")
+		}
+		accounts = append(accounts, basedb.Obj{Key: obj.Key, Value: value})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		encryptedValue, err := encryptWithKey(newKey, account.Value)
+		if err != nil {
+			return err
+		}
+		if err := rw.Set(s.objPrefix(accountsPrefix), account.Key, encryptedValue); err != nil {
+			return errors.Wrap(err, "failed to save account")
+		}
+	}
+	return nil
+}
+
 func (s *storage) DropRegistryData() error {
 	return s.db.DropPrefix(s.objPrefix(accountsPrefix))
 }
@@ -97,7 +175,8 @@ func (s *storage) Name() string {
 	return "SSV Storage"
 }
 
-// Network returns the network storage is related to.
+// Network returns the network storage is related to. The key manager derives the paths of accounts from it,
+// which don't depend on the network, so it may be a network the key manager doesn't know.
 func (s *storage) Network() core.Network {
 	return core.Network(s.network.GetBeaconNetwork())
 }
@@ -349,12 +428,84 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
 
+// SaveSigningIntent saves the signing intent, replacing the saved intent of the same sequence number.
+func (s *storage) SaveSigningIntent(record SigningIntentRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signing intent")
+	}
+	return s.db.Set(s.objPrefix(signingIntentsPrefix), signingIntentKey(record.Slot, record.Intent.Seq), data)
+}
+
+// ListSigningIntents returns the saved signing intents from the given slot onwards, ordered by slot.
+func (s *storage) ListSigningIntents(fromSlot phase0.Slot) ([]SigningIntentRecord, error) {
+	var records []SigningIntentRecord
+	err := s.db.GetAll(s.objPrefix(signingIntentsPrefix), func(i int, obj basedb.Obj) error {
+		if signingIntentKeySlot(obj.Key) < fromSlot {
+			return nil
+		}
+		var record SigningIntentRecord
+		if err := json.Unmarshal(obj.Value, &record); err != nil {
+			return errors.Wrap(err, "failed to unmarshal signing intent")
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// PruneSigningIntents deletes the saved signing intents of the epochs before the given one.
+func (s *storage) PruneSigningIntents(beforeEpoch phase0.Epoch) error {
+	beforeSlot := s.network.FirstSlotAtEpoch(beforeEpoch)
+
+	var keys [][]byte
+	err := s.db.GetAll(s.objPrefix(signingIntentsPrefix), func(i int, obj basedb.Obj) error {
+		if signingIntentKeySlot(obj.Key) < beforeSlot {
+			keys = append(keys, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return s.db.Update(func(txn basedb.Txn) error {
+		for _, key := range keys {
+			if err := txn.Delete(s.objPrefix(signingIntentsPrefix), key); err != nil {
+				return errors.Wrap(err, "failed to delete signing intent")
+			}
+		}
+		return nil
+	})
+}
+
+// signingIntentKey orders the saved signing intents by slot, so that they're pruned by epoch
+func signingIntentKey(slot phase0.Slot, seq uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, uint64(slot))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+func signingIntentKeySlot(key []byte) phase0.Slot {
+	if len(key) < 8 {
+		return 0
+	}
+	return phase0.Slot(binary.BigEndian.Uint64(key))
+}
+
 func (s *storage) decryptData(objectValue []byte) ([]byte, error) {
 	if len(s.encryptionKey) == 0 {
 		return objectValue, nil
 	}
 
 	decryptedData, err := s.decrypt(objectValue)
+	if err != nil && len(s.previousKey) != 0 {
+		decryptedData, err = decryptWithKey(s.previousKey, objectValue)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decrypt wallet")
 	}
@@ -376,7 +527,11 @@ func (s *storage) encryptData(objectValue []byte) ([]byte, error) {
 }
 
 func (s *storage) encrypt(data []byte) ([]byte, error) {
-	block, err := aes.NewCipher(s.encryptionKey)
+	return encryptWithKey(s.encryptionKey, data)
+}
+
+func encryptWithKey(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -392,7 +547,11 @@ func (s *storage) encrypt(data []byte) ([]byte, error) {
 }
 
 func (s *storage) decrypt(data []byte) ([]byte, error) {
-	block, err := aes.NewCipher(s.encryptionKey)
+	return decryptWithKey(s.encryptionKey, data)
+}
+
+func decryptWithKey(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -546,3 +546,95 @@ func TestDropRegistryData(t *testing.T) {
 	err := signerStorage.DropRegistryData()
 	require.NoError(t, err)
 }
+
+func TestReEncrypt(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldKey := hex.EncodeToString(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+	newKey := hex.EncodeToString(_byteArray("2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40"))
+	network := networkconfig.TestNetwork.Beacon.GetNetwork()
+
+	signerStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, signerStorage.SetEncryptionKey(oldKey))
+	wallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(wallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := 1
+	account, err := wallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), &index)
+	require.NoError(t, err)
+
+	require.Error(t, signerStorage.ReEncrypt(nil, "not hex"))
+	require.NoError(t, signerStorage.ReEncrypt(nil, newKey))
+
+	// re-encrypting with a storage that still uses the old key resumes an interrupted re-encryption
+	oldKeyStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, oldKeyStorage.SetEncryptionKey(oldKey))
+	_, err = oldKeyStorage.ListAccounts()
+	require.Error(t, err)
+	require.NoError(t, oldKeyStorage.ReEncrypt(nil, newKey))
+
+	accounts, err := signerStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ValidatorPublicKey(), accounts[0].ValidatorPublicKey())
+
+	newKeyStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, newKeyStorage.SetEncryptionKey(newKey))
+	accounts, err = newKeyStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
+
+func TestReEncrypt_Txn(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldKey := hex.EncodeToString(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+	newKey := hex.EncodeToString(_byteArray("2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40"))
+	network := networkconfig.TestNetwork.Beacon.GetNetwork()
+
+	signerStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, signerStorage.SetEncryptionKey(oldKey))
+	wallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(wallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := 1
+	_, err = wallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), &index)
+	require.NoError(t, err)
+
+	// a discarded re-encryption leaves the accounts encrypted under the old key, which still decrypts them
+	txn := db.Begin()
+	require.NoError(t, signerStorage.ReEncrypt(txn, newKey))
+	txn.Discard()
+	accounts, err := signerStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+
+	oldKeyStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, oldKeyStorage.SetEncryptionKey(oldKey))
+	_, err = oldKeyStorage.ListAccounts()
+	require.NoError(t, err)
+
+	// a committed one re-encrypts them under the new key
+	txn = db.Begin()
+	require.NoError(t, signerStorage.ReEncrypt(txn, newKey))
+	require.NoError(t, txn.Commit())
+	accounts, err = signerStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+
+	newKeyStorage := NewSignerStorage(db, network, logger)
+	require.NoError(t, newKeyStorage.SetEncryptionKey(newKey))
+	accounts, err = newKeyStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
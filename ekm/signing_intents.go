@@ -0,0 +1,449 @@
+package ekm
+
+import (
+	"cmp"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+const (
+	defaultSigningIntentsSize = 10_000
+	// intentRetentionSlots is the number of slots conflicting intents are detected in
+	intentRetentionSlots = 64
+)
+
+const (
+	IntentOutcomePending  = "pending"
+	IntentOutcomeSigned   = "signed"
+	IntentOutcomeFailed   = "failed"
+	IntentOutcomeRejected = "rejected"
+)
+
+// ErrConflictingIntent is returned when an object conflicting with an earlier intent is requested to be signed.
+var ErrConflictingIntent = errors.New("conflicting signing intent")
+
+// SigningDuty describes the duty a signature is requested for.
+type SigningDuty struct {
+	Runner spectypes.RunnerRole
+	Duty   *spectypes.ValidatorDuty
+}
+
+// SigningIntent is a record of a requested signature, made before the object is signed.
+type SigningIntent struct {
+	Seq             uint64                `json:"seq"`
+	Time            time.Time             `json:"time"`
+	Runner          string                `json:"runner,omitempty"`
+	Duty            string                `json:"duty,omitempty"`
+	Reason          string                `json:"reason"`
+	SharePubKey     string                `json:"share_pubkey"`
+	ValidatorPubKey string                `json:"validator_pubkey,omitempty"`
+	ValidatorIndex  phase0.ValidatorIndex `json:"validator_index,omitempty"`
+	Slot            phase0.Slot           `json:"slot,omitempty"`
+	Root            string                `json:"root"`
+	Outcome         string                `json:"outcome"`
+	Error           string                `json:"error,omitempty"`
+	// ConflictsWith is the sequence number of the intent a rejected intent conflicts with
+	ConflictsWith *uint64 `json:"conflicts_with,omitempty"`
+}
+
+// SigningIntentRecord is a signing intent as saved in the signer storage.
+type SigningIntentRecord struct {
+	Intent SigningIntent `json:"intent"`
+	// Slot is the slot the intent is pruned by, which is the slot of the object it conflicts on if any
+	Slot phase0.Slot `json:"slot"`
+	// Domain and Index identify the object the intent conflicts on along with Slot,
+	// Domain is nil if the intent can't conflict
+	Domain *phase0.DomainType `json:"domain,omitempty"`
+	Index  uint64             `json:"index,omitempty"`
+}
+
+func newSigningIntentRecord(intent SigningIntent, key *intentKey) SigningIntentRecord {
+	record := SigningIntentRecord{Intent: intent, Slot: intent.Slot}
+	if key != nil {
+		domainType := key.domainType
+		record.Slot = key.slot
+		record.Domain = &domainType
+		record.Index = key.index
+	}
+	return record
+}
+
+func (r SigningIntentRecord) key() *intentKey {
+	if r.Domain == nil {
+		return nil
+	}
+	return &intentKey{sharePubKey: r.Intent.SharePubKey, domainType: *r.Domain, slot: r.Slot, index: r.Index}
+}
+
+// intentStore persists the signing intents, it's implemented by the signer storage.
+type intentStore interface {
+	SaveSigningIntent(record SigningIntentRecord) error
+	ListSigningIntents(fromSlot phase0.Slot) ([]SigningIntentRecord, error)
+	PruneSigningIntents(beforeEpoch phase0.Epoch) error
+}
+
+// IntentFilter selects signing intents, zero fields match any intent.
+type IntentFilter struct {
+	// PubKey is the public key of the share or the validator
+	PubKey   []byte
+	FromSlot phase0.Slot
+	ToSlot   phase0.Slot
+	Outcome  string
+	Limit    int
+}
+
+func (f IntentFilter) matches(intent SigningIntent) bool {
+	if len(f.PubKey) != 0 {
+		pubKey := hex.EncodeToString(f.PubKey)
+		if intent.SharePubKey != pubKey && intent.ValidatorPubKey != pubKey {
+			return false
+		}
+	}
+	if intent.Slot < f.FromSlot || (f.ToSlot != 0 && intent.Slot > f.ToSlot) {
+		return false
+	}
+	if f.Outcome != "" && !strings.EqualFold(intent.Outcome, f.Outcome) {
+		return false
+	}
+	return true
+}
+
+// intentKey identifies the objects of which a share may only sign one per slot.
+type intentKey struct {
+	sharePubKey string
+	domainType  phase0.DomainType
+	slot        phase0.Slot
+	// index distinguishes objects of the same slot, such as contributions of different subcommittees
+	index uint64
+}
+
+type registeredIntent struct {
+	seq  uint64
+	root string
+}
+
+// IntentLedger records the intents of signing beacon objects before they're signed,
+// and rejects intents conflicting with earlier ones of any runner, such as two different attestations
+// of a share in the same slot. The latest intents are kept in a ring buffer for forensics.
+//
+// Once loaded from the signer storage, intents are saved there before the objects are signed,
+// so that conflicts are detected across restarts. The saved intents are pruned by epoch,
+// keeping only the epochs conflicts are detected in.
+type IntentLedger struct {
+	size int
+
+	logger  *zap.Logger
+	store   intentStore
+	network beacon.BeaconNetwork
+	// prunedEpoch is the epoch the saved intents of earlier epochs were pruned up to
+	prunedEpoch phase0.Epoch
+
+	mu sync.Mutex
+	// entries is the ring buffer, the intent of sequence number seq is at seq % size
+	entries []SigningIntent
+	count   int
+	seq     uint64
+	// registered are the intents conflicting intents are detected against
+	registered  map[intentKey]registeredIntent
+	highestSlot phase0.Slot
+}
+
+// NewIntentLedger creates a new IntentLedger keeping the given number of intents.
+func NewIntentLedger(size int) *IntentLedger {
+	if size <= 0 {
+		size = defaultSigningIntentsSize
+	}
+	return &IntentLedger{
+		size:       size,
+		entries:    make([]SigningIntent, size),
+		registered: make(map[intentKey]registeredIntent),
+	}
+}
+
+// load restores the intents saved in the store and saves the following intents there.
+// Intents which were pending when the node stopped may have been signed, so they conflict like signed ones.
+func (l *IntentLedger) load(logger *zap.Logger, store intentStore, network beacon.BeaconNetwork) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	records, err := store.ListSigningIntents(0)
+	if err != nil {
+		return fmt.Errorf("could not load signing intents: %w", err)
+	}
+	slices.SortFunc(records, func(a, b SigningIntentRecord) int {
+		return cmp.Compare(a.Intent.Seq, b.Intent.Seq)
+	})
+
+	for _, record := range records {
+		l.seq = max(l.seq, record.Intent.Seq)
+		key := record.key()
+		if key == nil {
+			continue
+		}
+		l.highestSlot = max(l.highestSlot, key.slot)
+		if record.Intent.Outcome == IntentOutcomePending || record.Intent.Outcome == IntentOutcomeSigned {
+			if _, ok := l.registered[*key]; !ok {
+				l.registered[*key] = registeredIntent{seq: record.Intent.Seq, root: record.Intent.Root}
+			}
+		}
+	}
+	if len(records) > 0 {
+		l.seq++
+	}
+	l.prune()
+
+	// the ring buffer holds the latest sequence numbers, the ones of pruned intents are left empty
+	l.count = int(min(l.seq, uint64(l.size)))
+	for _, record := range records {
+		if record.Intent.Seq >= l.seq-uint64(l.count) {
+			l.entries[record.Intent.Seq%uint64(l.size)] = record.Intent
+		}
+	}
+
+	l.logger = logger
+	l.store = store
+	l.network = network
+	l.prunedEpoch = 0
+	l.pruneStore()
+	return nil
+}
+
+// begin records an intent of signing the object. It returns ErrConflictingIntent if a different object
+// was already requested to be signed by the share for the same slot. The intent is saved in the store,
+// if there's one, before returning.
+func (l *IntentLedger) begin(intent SigningIntent, key *intentKey) (uint64, error) {
+	intent, err := l.register(intent, key)
+	if l.store != nil {
+		if saveErr := l.store.SaveSigningIntent(newSigningIntentRecord(intent, key)); saveErr != nil {
+			l.complete(intent.Seq, key, saveErr)
+			return intent.Seq, fmt.Errorf("could not save signing intent: %w", saveErr)
+		}
+	}
+	return intent.Seq, err
+}
+
+func (l *IntentLedger) register(intent SigningIntent, key *intentKey) (SigningIntent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	intent.Seq = l.seq
+	intent.Time = time.Now()
+	intent.Outcome = IntentOutcomePending
+
+	var err error
+	if key != nil {
+		if registered, ok := l.registered[*key]; ok && registered.root != intent.Root {
+			conflictsWith := registered.seq
+			err = fmt.Errorf("%w: %s of slot %d conflicts with intent %d", ErrConflictingIntent, intent.Reason, key.slot, registered.seq)
+			intent.Outcome = IntentOutcomeRejected
+			intent.Error = err.Error()
+			intent.ConflictsWith = &conflictsWith
+		} else if !ok {
+			l.registered[*key] = registeredIntent{seq: intent.Seq, root: intent.Root}
+			if key.slot > l.highestSlot {
+				l.highestSlot = key.slot
+				l.prune()
+				l.pruneStore()
+			}
+		}
+	}
+
+	l.record(intent)
+	return intent, err
+}
+
+// complete records the outcome of signing the object of an intent. Intents failed to be signed
+// don't conflict with later ones.
+func (l *IntentLedger) complete(seq uint64, key *intentKey, signErr error) {
+	intent, ok := l.completeIntent(seq, key, signErr)
+	if !ok || l.store == nil {
+		return
+	}
+	if err := l.store.SaveSigningIntent(newSigningIntentRecord(intent, key)); err != nil {
+		l.logger.Warn("could not save signing intent outcome", zap.Uint64("seq", seq), zap.Error(err))
+	}
+}
+
+func (l *IntentLedger) completeIntent(seq uint64, key *intentKey, signErr error) (SigningIntent, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if signErr != nil && key != nil {
+		if registered, ok := l.registered[*key]; ok && registered.seq == seq {
+			delete(l.registered, *key)
+		}
+	}
+
+	if seq < l.seq-uint64(l.count) {
+		// the intent was already overwritten
+		return SigningIntent{}, false
+	}
+	intent := &l.entries[seq%uint64(l.size)]
+	if signErr != nil {
+		intent.Outcome = IntentOutcomeFailed
+		intent.Error = signErr.Error()
+	} else {
+		intent.Outcome = IntentOutcomeSigned
+	}
+	return *intent, true
+}
+
+func (l *IntentLedger) record(intent SigningIntent) {
+	l.entries[intent.Seq%uint64(l.size)] = intent
+	l.seq++
+	l.count = min(l.count+1, l.size)
+}
+
+func (l *IntentLedger) prune() {
+	for key := range l.registered {
+		if key.slot+intentRetentionSlots < l.highestSlot {
+			delete(l.registered, key)
+		}
+	}
+}
+
+// pruneStore deletes the saved intents of the epochs which are entirely out of the retained slots,
+// whenever such an epoch passes.
+func (l *IntentLedger) pruneStore() {
+	if l.store == nil || l.highestSlot < intentRetentionSlots {
+		return
+	}
+	epoch := l.network.EstimatedEpochAtSlot(l.highestSlot - intentRetentionSlots)
+	if epoch <= l.prunedEpoch {
+		return
+	}
+	if err := l.store.PruneSigningIntents(epoch); err != nil {
+		l.logger.Warn("could not prune signing intents", zap.Uint64("epoch", uint64(epoch)), zap.Error(err))
+		return
+	}
+	l.prunedEpoch = epoch
+}
+
+// Query returns the intents matching the filter, newest first.
+func (l *IntentLedger) Query(filter IntentFilter) []SigningIntent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make([]SigningIntent, 0)
+	for seq := l.seq; seq > l.seq-uint64(l.count); seq-- {
+		intent := l.entries[(seq-1)%uint64(l.size)]
+		if intent.Seq != seq-1 || intent.Time.IsZero() {
+			// the intent was pruned before the ledger was loaded
+			continue
+		}
+		if !filter.matches(intent) {
+			continue
+		}
+		result = append(result, intent)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// newSigningIntent describes the intent of signing the object, returning the key it conflicts on
+// if the share may sign only one such object per slot.
+func newSigningIntent(obj ssz.HashRoot, pk []byte, domainType phase0.DomainType, duty *SigningDuty) (SigningIntent, *intentKey, error) {
+	root, err := obj.HashTreeRoot()
+	if err != nil {
+		return SigningIntent{}, nil, fmt.Errorf("could not compute object root: %w", err)
+	}
+
+	intent := SigningIntent{
+		Reason:      signingReason(domainType),
+		SharePubKey: hex.EncodeToString(pk),
+		Root:        hex.EncodeToString(root[:]),
+	}
+	if duty != nil {
+		intent.Runner = duty.Runner.String()
+		if duty.Duty != nil {
+			intent.Duty = duty.Duty.Type.String()
+			intent.ValidatorPubKey = hex.EncodeToString(duty.Duty.PubKey[:])
+			intent.ValidatorIndex = duty.Duty.ValidatorIndex
+			intent.Slot = duty.Duty.Slot
+		}
+	}
+
+	key := &intentKey{sharePubKey: intent.SharePubKey, domainType: domainType}
+	switch v := obj.(type) {
+	case *phase0.AttestationData:
+		key.slot = v.Slot
+	case *capella.BeaconBlock:
+		key.slot = v.Slot
+	case *deneb.BeaconBlock:
+		key.slot = v.Slot
+	case *apiv1capella.BlindedBeaconBlock:
+		key.slot = v.Slot
+	case *apiv1deneb.BlindedBeaconBlock:
+		key.slot = v.Slot
+	case *phase0.AggregateAndProof:
+		if v.Aggregate == nil || v.Aggregate.Data == nil {
+			return intent, nil, nil
+		}
+		key.slot = v.Aggregate.Data.Slot
+	case *altair.ContributionAndProof:
+		if v.Contribution == nil {
+			return intent, nil, nil
+		}
+		key.slot = v.Contribution.Slot
+		key.index = v.Contribution.SubcommitteeIndex
+	case spectypes.SSZBytes:
+		// sync committee messages sign only the block root, so the slot is known only from the duty
+		if domainType != spectypes.DomainSyncCommittee || duty == nil || duty.Duty == nil {
+			return intent, nil, nil
+		}
+		key.slot = duty.Duty.Slot
+	default:
+		// other objects, such as selection proofs and randao reveals, are deterministic and can't conflict
+		return intent, nil, nil
+	}
+	if intent.Slot == 0 {
+		intent.Slot = key.slot
+	}
+	return intent, key, nil
+}
+
+func signingReason(domainType phase0.DomainType) string {
+	switch domainType {
+	case spectypes.DomainAttester:
+		return "attestation"
+	case spectypes.DomainProposer:
+		return "block_proposal"
+	case spectypes.DomainVoluntaryExit:
+		return "voluntary_exit"
+	case spectypes.DomainAggregateAndProof:
+		return "aggregate_and_proof"
+	case spectypes.DomainSelectionProof:
+		return "selection_proof"
+	case spectypes.DomainRandao:
+		return "randao_reveal"
+	case spectypes.DomainSyncCommittee:
+		return "sync_committee_message"
+	case spectypes.DomainSyncCommitteeSelectionProof:
+		return "sync_committee_selection_proof"
+	case spectypes.DomainContributionAndProof:
+		return "contribution_and_proof"
+	case spectypes.DomainApplicationBuilder:
+		return "validator_registration"
+	default:
+		return fmt.Sprintf("unknown_domain_%x", domainType)
+	}
+}
@@ -0,0 +1,160 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestIntentLedger_Conflicts(t *testing.T) {
+	ledger := NewIntentLedger(0)
+	pk := []byte{1, 2, 3}
+
+	attestation := func(slot phase0.Slot, root byte) *phase0.AttestationData {
+		data := *spectestingutils.TestingAttestationData
+		data.Slot = slot
+		data.BeaconBlockRoot = phase0.Root{root}
+		return &data
+	}
+	begin := func(obj *phase0.AttestationData, runner spectypes.RunnerRole) error {
+		intent, key, err := newSigningIntent(obj, pk, spectypes.DomainAttester, &SigningDuty{Runner: runner})
+		require.NoError(t, err)
+		seq, err := ledger.begin(intent, key)
+		if err == nil {
+			ledger.complete(seq, key, nil)
+		}
+		return err
+	}
+
+	require.NoError(t, begin(attestation(10, 1), spectypes.RoleCommittee))
+	// signing the same object again is allowed
+	require.NoError(t, begin(attestation(10, 1), spectypes.RoleCommittee))
+	require.ErrorIs(t, begin(attestation(10, 2), spectypes.RoleAggregator), ErrConflictingIntent)
+	require.NoError(t, begin(attestation(11, 2), spectypes.RoleCommittee))
+
+	rejected := ledger.Query(IntentFilter{Outcome: IntentOutcomeRejected})
+	require.Len(t, rejected, 1)
+	require.Equal(t, "attestation", rejected[0].Reason)
+	require.Equal(t, spectypes.RoleAggregator.String(), rejected[0].Runner)
+	require.EqualValues(t, 10, rejected[0].Slot)
+	require.EqualValues(t, 0, *rejected[0].ConflictsWith)
+
+	require.Len(t, ledger.Query(IntentFilter{Outcome: IntentOutcomeSigned}), 3)
+	require.Len(t, ledger.Query(IntentFilter{PubKey: pk, FromSlot: 11}), 1)
+	require.Empty(t, ledger.Query(IntentFilter{PubKey: []byte{4}}))
+
+	// failed intents don't conflict with later ones
+	intent, key, err := newSigningIntent(attestation(12, 1), pk, spectypes.DomainAttester, nil)
+	require.NoError(t, err)
+	seq, err := ledger.begin(intent, key)
+	require.NoError(t, err)
+	ledger.complete(seq, key, errSigning)
+	require.NoError(t, begin(attestation(12, 2), spectypes.RoleCommittee))
+	require.Equal(t, IntentOutcomeFailed, ledger.Query(IntentFilter{FromSlot: 12})[1].Outcome)
+
+	// conflicts aren't detected for slots that aren't retained anymore
+	require.NoError(t, begin(attestation(100, 1), spectypes.RoleCommittee))
+	require.NoError(t, begin(attestation(10, 3), spectypes.RoleCommittee))
+}
+
+var errSigning = errors.New("signing failed")
+
+func TestIntentLedger_Persisted(t *testing.T) {
+	store, done := newStorageForTest(t)
+	defer done()
+	logger := logging.TestLogger(t)
+	pk := []byte{1, 2, 3}
+
+	attestation := func(slot phase0.Slot, root byte) *phase0.AttestationData {
+		data := *spectestingutils.TestingAttestationData
+		data.Slot = slot
+		data.BeaconBlockRoot = phase0.Root{root}
+		return &data
+	}
+	begin := func(ledger *IntentLedger, obj *phase0.AttestationData, signErr error) error {
+		intent, key, err := newSigningIntent(obj, pk, spectypes.DomainAttester, nil)
+		require.NoError(t, err)
+		seq, err := ledger.begin(intent, key)
+		if err == nil {
+			ledger.complete(seq, key, signErr)
+		}
+		return err
+	}
+	loadLedger := func() *IntentLedger {
+		ledger := NewIntentLedger(10)
+		require.NoError(t, ledger.load(logger, store, store.BeaconNetwork()))
+		return ledger
+	}
+
+	ledger := loadLedger()
+	require.NoError(t, begin(ledger, attestation(10, 1), nil))
+	require.NoError(t, begin(ledger, attestation(11, 1), errSigning))
+
+	// after a restart the saved intents are conflicted with, except the ones that failed to be signed
+	ledger = loadLedger()
+	require.ErrorIs(t, begin(ledger, attestation(10, 2), nil), ErrConflictingIntent)
+	require.NoError(t, begin(ledger, attestation(11, 2), nil))
+	intents := ledger.Query(IntentFilter{})
+	require.Len(t, intents, 4)
+	require.EqualValues(t, 3, intents[0].Seq)
+	require.Equal(t, IntentOutcomeRejected, intents[1].Outcome)
+	require.Equal(t, IntentOutcomeFailed, intents[2].Outcome)
+	require.Equal(t, IntentOutcomeSigned, intents[3].Outcome)
+
+	// the saved intents of epochs out of the retained slots are pruned
+	slotsPerEpoch := phase0.Slot(store.BeaconNetwork().SlotsPerEpoch())
+	require.NoError(t, begin(ledger, attestation(intentRetentionSlots+2*slotsPerEpoch, 1), nil))
+	records, err := store.ListSigningIntents(0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	ledger = loadLedger()
+	require.NoError(t, begin(ledger, attestation(10, 3), nil))
+	require.Len(t, ledger.Query(IntentFilter{}), 2)
+}
+
+func TestSignBeaconObjectForDuty(t *testing.T) {
+	km := testKeyManager(t, nil)
+	ledger := NewIntentLedger(10)
+	km.(*ethKeyManagerSigner).intents = ledger
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	pk := sk.GetPublicKey().Serialize()
+
+	currentSlot := km.(*ethKeyManagerSigner).storage.Network().EstimatedCurrentSlot()
+	currentEpoch := km.(*ethKeyManagerSigner).storage.Network().EstimatedEpochAtSlot(currentSlot)
+	data := &phase0.AttestationData{
+		Slot:            currentSlot,
+		BeaconBlockRoot: phase0.Root{1},
+		Source:          &phase0.Checkpoint{Epoch: currentEpoch + minSPAttestationEpochGap},
+		Target:          &phase0.Checkpoint{Epoch: currentEpoch + minSPAttestationEpochGap + 1},
+	}
+	duty := &SigningDuty{
+		Runner: spectypes.RoleCommittee,
+		Duty:   &spectypes.ValidatorDuty{Type: spectypes.BNRoleAttester, Slot: currentSlot, ValidatorIndex: 1},
+	}
+
+	_, _, err := km.(*ethKeyManagerSigner).SignBeaconObjectForDuty(data, phase0.Domain{}, pk, spectypes.DomainAttester, duty)
+	require.NoError(t, err)
+
+	conflicting := *data
+	conflicting.BeaconBlockRoot = phase0.Root{2}
+	_, _, err = km.SignBeaconObject(&conflicting, phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.ErrorIs(t, err, ErrConflictingIntent)
+
+	intents := ledger.Query(IntentFilter{})
+	require.Len(t, intents, 2)
+	require.Equal(t, IntentOutcomeRejected, intents[0].Outcome)
+	require.Equal(t, IntentOutcomeSigned, intents[1].Outcome)
+	require.Equal(t, spectypes.BNRoleAttester.String(), intents[1].Duty)
+	require.Equal(t, hex.EncodeToString(pk), intents[1].SharePubKey)
+}
@@ -59,6 +59,14 @@ type taskExecutor interface {
 	ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64, validatorIndex phase0.ValidatorIndex, ownValidator bool) error
 }
 
+// KeyRotator switches the node to a new operator key.
+type KeyRotator interface {
+	// NewPublicKey returns the base64 encoded public key being rotated to.
+	NewPublicKey() []byte
+	// Rotate persists the switch to the new key in txn and makes it the active one.
+	Rotate(txn basedb.Txn) error
+}
+
 type EventHandler struct {
 	nodeStorage       nodestorage.Storage
 	taskExecutor      taskExecutor
@@ -68,6 +76,7 @@ type EventHandler struct {
 	operatorDecrypter keys.OperatorDecrypter
 	keyManager        ekm.KeyManager
 	beacon            beaconprotocol.BeaconNode
+	keyRotator        KeyRotator
 
 	fullNode bool
 	logger   *zap.Logger
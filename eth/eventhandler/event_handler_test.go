@@ -21,6 +21,7 @@ import (
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	ekmcore "github.com/ssvlabs/eth2-key-manager/core"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
@@ -1458,7 +1459,7 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, operator *test
 		logger.Fatal("failed to encode operator public key", zap.Error(err))
 	}
 
-	if err := nodeStorage.SavePrivateKeyHash(encodedPrivKey); err != nil {
+	if err := nodeStorage.SavePrivateKeyHash(nil, encodedPrivKey); err != nil {
 		logger.Fatal("couldn't setup operator private key", zap.Error(err))
 	}
 
@@ -1696,3 +1697,69 @@ func requireKeyManagerDataToNotExist(t *testing.T, eh *EventHandler, expectedAcc
 	require.NoError(t, err)
 	require.False(t, found)
 }
+
+type testKeyRotator struct {
+	newPublicKey []byte
+	rotations    int
+}
+
+func (r *testKeyRotator) NewPublicKey() []byte {
+	return r.newPublicKey
+}
+
+func (r *testKeyRotator) Rotate(txn basedb.Txn) error {
+	if txn == nil {
+		return fmt.Errorf("rotated outside of the event transaction")
+	}
+	r.rotations++
+	return nil
+}
+
+func TestHandleOperatorEventsRotateKey(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ops, err := createOperators(3, 0)
+	require.NoError(t, err)
+
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	newPublicKey, err := ops[1].privateKey.Public().Base64()
+	require.NoError(t, err)
+	keyRotator := &testKeyRotator{newPublicKey: newPublicKey}
+	WithKeyRotator(keyRotator)(eh)
+
+	oldOperatorID := eh.operatorDataStore.GetOperatorID()
+	for i, op := range ops {
+		publicKey, err := op.privateKey.Public().Base64()
+		require.NoError(t, err)
+		require.NoError(t, eh.handleOperatorAdded(nil, &contract.ContractOperatorAdded{
+			OperatorId: oldOperatorID + spectypes.OperatorID(i),
+			Owner:      testAddr,
+			PublicKey:  publicKey,
+		}))
+	}
+
+	// once the new public key is registered, the node runs as both operators
+	require.Zero(t, keyRotator.rotations)
+	require.Equal(t, oldOperatorID, eh.operatorDataStore.GetOperatorID())
+	require.Equal(t, newPublicKey, eh.operatorDataStore.GetNewOperatorData().PublicKey)
+	require.Equal(t, []spectypes.OperatorID{oldOperatorID, oldOperatorID + 1}, eh.operatorDataStore.GetOperatorIDs())
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	require.NoError(t, eh.handleOperatorRemoved(txn, &contract.ContractOperatorRemoved{OperatorId: oldOperatorID + 2}))
+	require.Zero(t, keyRotator.rotations)
+
+	// and as the new operator only once the old one is removed
+	require.NoError(t, eh.handleOperatorRemoved(txn, &contract.ContractOperatorRemoved{OperatorId: oldOperatorID}))
+	require.Equal(t, 1, keyRotator.rotations)
+	require.Equal(t, oldOperatorID+1, eh.operatorDataStore.GetOperatorID())
+	require.Equal(t, newPublicKey, eh.operatorDataStore.GetOperatorData().PublicKey)
+	require.Nil(t, eh.operatorDataStore.GetNewOperatorData())
+	require.Equal(t, []spectypes.OperatorID{oldOperatorID + 1}, eh.operatorDataStore.GetOperatorIDs())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -88,7 +89,11 @@ func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, event *contract.Cont
 		return nil
 	}
 
-	if bytes.Equal(event.PublicKey, eh.operatorDataStore.GetOperatorData().PublicKey) {
+	if eh.keyRotator != nil && bytes.Equal(event.PublicKey, eh.keyRotator.NewPublicKey()) {
+		// The node runs as both operators until the clusters migrated and the old operator is removed.
+		eh.operatorDataStore.SetNewOperatorData(od)
+		logger = logger.With(zap.Bool("own_operator", true), zap.Bool("new_key", true))
+	} else if bytes.Equal(event.PublicKey, eh.operatorDataStore.GetOperatorData().PublicKey) {
 		eh.operatorDataStore.SetOperatorData(od)
 		logger = logger.With(zap.Bool("own_operator", true))
 	}
@@ -120,7 +125,16 @@ func (eh *EventHandler) handleOperatorRemoved(txn basedb.Txn, event *contract.Co
 		fields.Owner(od.OwnerAddress),
 	)
 
-	// This function is currently no-op and it will do nothing. Operator removed event is not used in the current implementation.
+	// Operator removed event is only used to complete the rotation of the operator key.
+	newOperatorData := eh.operatorDataStore.GetNewOperatorData()
+	if eh.keyRotator != nil && newOperatorData != nil && od.ID == eh.operatorDataStore.GetOperatorID() {
+		if err := eh.keyRotator.Rotate(txn); err != nil {
+			return fmt.Errorf("could not rotate operator key: %w", err)
+		}
+		eh.operatorDataStore.SetOperatorData(newOperatorData)
+		eh.operatorDataStore.SetNewOperatorData(nil)
+		logger = logger.With(zap.Bool("own_operator", true), zap.Bool("rotated_key", true))
+	}
 
 	logger.Debug("processed event")
 	return nil
@@ -209,7 +223,7 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 		return nil, &MalformedEventError{Err: ErrShareBelongsToDifferentOwner}
 	}
 
-	if validatorShare.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
+	if _, ok := eh.operatorDataStore.ShareOperatorID(validatorShare); ok {
 		ownShare = validatorShare
 		logger = logger.With(zap.Bool("own_validator", true))
 	}
@@ -235,7 +249,7 @@ func (eh *EventHandler) handleShareCreation(
 		return nil, fmt.Errorf("could not extract validator share from event: %w", err)
 	}
 
-	if share.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
+	if _, ok := eh.operatorDataStore.ShareOperatorID(share); ok {
 		if shareSecret == nil {
 			return nil, errors.New("could not decode shareSecret")
 		}
@@ -281,7 +295,7 @@ func (eh *EventHandler) validatorAddedEventToShare(
 	validatorShare.ValidatorPubKey = validatorPK
 	validatorShare.OwnerAddress = event.Owner
 
-	selfOperatorID := eh.operatorDataStore.GetOperatorID()
+	selfOperatorIDs := eh.operatorDataStore.GetOperatorIDs()
 	var shareSecret *bls.SecretKey
 
 	shareMembers := make([]*spectypes.ShareMember, 0)
@@ -303,7 +317,7 @@ func (eh *EventHandler) validatorAddedEventToShare(
 			SharePubKey: sharePublicKeys[i],
 		})
 
-		if operatorID != selfOperatorID {
+		if !slices.Contains(selfOperatorIDs, operatorID) {
 			continue
 		}
 
@@ -371,7 +385,7 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 		return emptyPK, fmt.Errorf("could not remove validator share: %w", err)
 	}
 
-	isOperatorShare := share.BelongsToOperator(eh.operatorDataStore.GetOperatorID())
+	_, isOperatorShare := eh.operatorDataStore.ShareOperatorID(share)
 	if isOperatorShare || eh.fullNode {
 		logger = logger.With(zap.String("validator_pubkey", hex.EncodeToString(share.ValidatorPubKey[:])))
 	}
@@ -514,7 +528,7 @@ func (eh *EventHandler) handleValidatorExited(txn basedb.Txn, event *contract.Co
 		ValidatorIndex: share.BeaconMetadata.Index,
 		BlockNumber:    event.Raw.BlockNumber,
 	}
-	if share.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
+	if _, ok := eh.operatorDataStore.ShareOperatorID(share); ok {
 		ed.OwnValidator = true
 	}
 
@@ -547,7 +561,7 @@ func (eh *EventHandler) processClusterEvent(
 	updatedPubKeys := make([]string, 0)
 
 	for _, share := range shares {
-		isOperatorShare := share.BelongsToOperator(eh.operatorDataStore.GetOperatorID())
+		_, isOperatorShare := eh.operatorDataStore.ShareOperatorID(share)
 		if isOperatorShare || eh.fullNode {
 			updatedPubKeys = append(updatedPubKeys, hex.EncodeToString(share.ValidatorPubKey[:]))
 		}
@@ -21,3 +21,10 @@ func WithFullNode() Option {
 		eh.fullNode = true
 	}
 }
+
+// WithKeyRotator rotates the operator key once its new public key is registered.
+func WithKeyRotator(keyRotator KeyRotator) Option {
+	return func(eh *EventHandler) {
+		eh.keyRotator = keyRotator
+	}
+}
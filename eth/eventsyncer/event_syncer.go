@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -42,6 +43,7 @@ type EventSyncer struct {
 	logger             *zap.Logger
 	stalenessThreshold time.Duration
 
+	healthMu                 sync.Mutex
 	lastProcessedBlock       uint64
 	lastProcessedBlockChange time.Time
 }
@@ -65,6 +67,9 @@ func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, event
 
 // Healthy returns nil if the syncer is syncing ongoing events.
 func (es *EventSyncer) Healthy(ctx context.Context) error {
+	es.healthMu.Lock()
+	defer es.healthMu.Unlock()
+
 	lastProcessedBlock, found, err := es.nodeStorage.GetLastProcessedBlock(nil)
 	if err != nil {
 		return fmt.Errorf("failed to read last processed block: %w", err)
@@ -83,6 +88,18 @@ func (es *EventSyncer) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// LastProcessedBlock returns the last block whose events were processed, or 0 if none was.
+func (es *EventSyncer) LastProcessedBlock() (uint64, error) {
+	lastProcessedBlock, found, err := es.nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock == nil {
+		return 0, nil
+	}
+	return lastProcessedBlock.Uint64(), nil
+}
+
 // SyncHistory reads and processes historical events since the given fromBlock.
 func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
 	fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
@@ -219,7 +219,7 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, privKey keys.O
 		logger.Fatal("failed to encode operator public key", zap.Error(err))
 	}
 
-	if err := nodeStorage.SavePrivateKeyHash(privKeyHash); err != nil {
+	if err := nodeStorage.SavePrivateKeyHash(nil, privKeyHash); err != nil {
 		logger.Fatal("could not setup operator private key", zap.Error(err))
 	}
 
@@ -280,6 +280,26 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// HeadBlock returns the number of the latest block of the execution client.
+func (ec *ExecutionClient) HeadBlock(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
+	defer cancel()
+
+	head, err := ec.client.BlockNumber(ctx)
+	if err != nil {
+		ec.logger.Error(elResponseErrMsg,
+			zap.String("method", "eth_blockNumber"),
+			zap.Error(err))
+		return 0, err
+	}
+	return head, nil
+}
+
+// FollowDistance returns the number of blocks behind the head block from which logs are fetched.
+func (ec *ExecutionClient) FollowDistance() uint64 {
+	return ec.followDistance
+}
+
 func (ec *ExecutionClient) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error) {
 	b, err := ec.client.BlockByNumber(ctx, blockNumber)
 	if err != nil {
@@ -0,0 +1,221 @@
+package analytics
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// SortBy is the metric operators are ranked by.
+type SortBy string
+
+const (
+	// SortByParticipation ranks by participation rate, highest first
+	SortByParticipation SortBy = "participation"
+	// SortByFirstSigner ranks by first-signer frequency, highest first
+	SortByFirstSigner SortBy = "first_signer"
+	// SortByRound ranks by average commit round, lowest first
+	SortByRound SortBy = "round"
+	// SortByLateCommits ranks by late-commit ratio, lowest first
+	SortByLateCommits SortBy = "late_commits"
+)
+
+// Valid reports whether operators can be ranked by s.
+func (s SortBy) Valid() bool {
+	switch s {
+	case SortByParticipation, SortByFirstSigner, SortByRound, SortByLateCommits:
+		return true
+	default:
+		return false
+	}
+}
+
+// OperatorStats are the consensus participation statistics of an operator.
+type OperatorStats struct {
+	OperatorID spectypes.OperatorID `json:"operator_id"`
+	// Duties is the number of duties of the operator's committees in which post-consensus messages were seen
+	Duties uint64 `json:"duties"`
+	// Signed is the number of those duties in which the operator's post-consensus signature was seen
+	Signed uint64 `json:"signed"`
+	// Instances is the number of consensus instances of the operator's committees in which commits were seen
+	Instances uint64 `json:"instances"`
+	// FirstCommits is the number of those instances in which the operator's commit was seen first
+	FirstCommits uint64 `json:"first_commits"`
+	Commits      uint64 `json:"commits"`
+	// LateCommits is the number of commits seen after the instance was already decided
+	LateCommits uint64 `json:"late_commits"`
+
+	ParticipationRate    float64 `json:"participation_rate"`
+	FirstSignerFrequency float64 `json:"first_signer_frequency"`
+	AverageRound         float64 `json:"average_round"`
+	LateCommitRatio      float64 `json:"late_commit_ratio"`
+
+	roundSum uint64
+}
+
+func (s *OperatorStats) add(other *OperatorStats) {
+	s.Duties += other.Duties
+	s.Signed += other.Signed
+	s.Instances += other.Instances
+	s.FirstCommits += other.FirstCommits
+	s.Commits += other.Commits
+	s.LateCommits += other.LateCommits
+	s.roundSum += other.roundSum
+}
+
+func (s *OperatorStats) computeRates() {
+	s.ParticipationRate = ratio(s.Signed, s.Duties)
+	s.FirstSignerFrequency = ratio(s.FirstCommits, s.Instances)
+	s.AverageRound = ratio(s.roundSum, s.Commits)
+	s.LateCommitRatio = ratio(s.LateCommits, s.Commits)
+}
+
+func ratio(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// CommitteeStats are the participation statistics of a committee and its operators.
+type CommitteeStats struct {
+	CommitteeID spectypes.CommitteeID
+	Operators   []spectypes.OperatorID
+	Duties      uint64
+	Instances   uint64
+	Stats       []*OperatorStats
+}
+
+// EpochRange selects the epochs to aggregate, a zero To selects up to the latest epoch.
+type EpochRange struct {
+	From phase0.Epoch
+	To   phase0.Epoch
+}
+
+func (r EpochRange) contains(epoch phase0.Epoch) bool {
+	return epoch >= r.From && (r.To == 0 || epoch <= r.To)
+}
+
+// TrackedRange is the range of epochs that have statistics.
+type TrackedRange struct {
+	From phase0.Epoch
+	To   phase0.Epoch
+	// Partial is true if From is the epoch in which tracking started, whose statistics are incomplete
+	Partial bool
+}
+
+// Tracked returns the range of epochs that have statistics, ok is false if there are none yet.
+// Statistics are kept in memory only, so the range starts over when the node restarts.
+func (t *Tracker) Tracked() (tracked TrackedRange, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for epoch := range t.epochs {
+		if !ok || epoch < tracked.From {
+			tracked.From = epoch
+		}
+		if !ok || epoch > tracked.To {
+			tracked.To = epoch
+		}
+		ok = true
+	}
+	tracked.Partial = ok && tracked.From == t.startEpoch
+	return tracked, ok
+}
+
+// Operators returns the statistics of operators aggregated over all their committees in the epoch range,
+// ranked by the given metric. Operators with equal metrics are ordered by ID.
+func (t *Tracker) Operators(epochs EpochRange, sortBy SortBy) []*OperatorStats {
+	t.mu.Lock()
+	operators := make(map[spectypes.OperatorID]*OperatorStats)
+	for epoch, committees := range t.epochs {
+		if !epochs.contains(epoch) {
+			continue
+		}
+		for _, committee := range committees {
+			for operatorID, stats := range committee.stats {
+				aggregated, ok := operators[operatorID]
+				if !ok {
+					aggregated = &OperatorStats{OperatorID: operatorID}
+					operators[operatorID] = aggregated
+				}
+				aggregated.add(stats)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	result := make([]*OperatorStats, 0, len(operators))
+	for _, stats := range operators {
+		stats.computeRates()
+		result = append(result, stats)
+	}
+	slices.SortFunc(result, func(a, b *OperatorStats) int {
+		if c := compareBy(a, b, sortBy); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.OperatorID, b.OperatorID)
+	})
+	return result
+}
+
+func compareBy(a, b *OperatorStats, sortBy SortBy) int {
+	switch sortBy {
+	case SortByFirstSigner:
+		return cmp.Compare(b.FirstSignerFrequency, a.FirstSignerFrequency)
+	case SortByRound:
+		return cmp.Compare(a.AverageRound, b.AverageRound)
+	case SortByLateCommits:
+		return cmp.Compare(a.LateCommitRatio, b.LateCommitRatio)
+	default:
+		return cmp.Compare(b.ParticipationRate, a.ParticipationRate)
+	}
+}
+
+// Committees returns the statistics of the given committees, or of all committees when none are given,
+// aggregated over the epoch range.
+func (t *Tracker) Committees(epochs EpochRange, ids ...spectypes.CommitteeID) []*CommitteeStats {
+	t.mu.Lock()
+	committees := make(map[spectypes.CommitteeID]*CommitteeStats)
+	for epoch, epochCommittees := range t.epochs {
+		if !epochs.contains(epoch) {
+			continue
+		}
+		for id, stats := range epochCommittees {
+			if len(ids) > 0 && !slices.Contains(ids, id) {
+				continue
+			}
+			aggregated, ok := committees[id]
+			if !ok {
+				aggregated = &CommitteeStats{CommitteeID: id, Operators: stats.operators}
+				for _, operatorID := range stats.operators {
+					aggregated.Stats = append(aggregated.Stats, &OperatorStats{OperatorID: operatorID})
+				}
+				committees[id] = aggregated
+			}
+			aggregated.Duties += stats.duties
+			aggregated.Instances += stats.instances
+			for _, operatorStats := range aggregated.Stats {
+				if epochStats, ok := stats.stats[operatorStats.OperatorID]; ok {
+					operatorStats.add(epochStats)
+				}
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	result := make([]*CommitteeStats, 0, len(committees))
+	for _, committee := range committees {
+		for _, stats := range committee.Stats {
+			stats.computeRates()
+		}
+		result = append(result, committee)
+	}
+	slices.SortFunc(result, func(a, b *CommitteeStats) int {
+		return bytes.Compare(a.CommitteeID[:], b.CommitteeID[:])
+	})
+	return result
+}
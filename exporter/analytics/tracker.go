@@ -0,0 +1,271 @@
+// Package analytics derives the consensus participation of operators from the messages
+// an exporter observes on the network.
+package analytics
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+const (
+	// defaultRetainedEpochs is the number of epochs whose statistics are kept, roughly a day
+	defaultRetainedEpochs = 225
+	// instanceRetentionEpochs is the number of epochs in which messages of an instance are still tracked
+	instanceRetentionEpochs = 2
+)
+
+// CommitteeStore resolves the committee that performs the duties of a message identifier.
+type CommitteeStore interface {
+	Validator(pubKey []byte) (*types.SSVShare, bool)
+	Committee(id spectypes.CommitteeID) (*registrystorage.Committee, bool)
+}
+
+type Option func(*Tracker)
+
+// WithRetainedEpochs sets the number of epochs whose statistics are kept.
+func WithRetainedEpochs(epochs uint64) Option {
+	return func(t *Tracker) {
+		if epochs > 0 {
+			t.retainedEpochs = epochs
+		}
+	}
+}
+
+// Tracker aggregates per-epoch participation statistics of operators from the commit and
+// post-consensus messages of their committees. The statistics depend on the order and timing
+// in which messages are observed, so they can't be derived from storage and are kept in memory only:
+// they cover the epochs since the node started, see Tracked.
+type Tracker struct {
+	beaconNetwork  beacon.BeaconNetwork
+	committees     CommitteeStore
+	retainedEpochs uint64
+
+	mu        sync.Mutex
+	instances map[instanceKey]*instance
+	epochs    map[phase0.Epoch]map[spectypes.CommitteeID]*committeeStats
+	// lastEpoch is the latest epoch a message was seen for
+	lastEpoch phase0.Epoch
+	// startEpoch is the epoch of the first tracked message, which is only partially tracked
+	startEpoch phase0.Epoch
+	started    bool
+}
+
+type instanceKey struct {
+	msgID spectypes.MessageID
+	slot  phase0.Slot
+}
+
+// instance tracks the messages of a single duty of a committee.
+type instance struct {
+	epoch       phase0.Epoch
+	stats       *committeeStats
+	quorum      uint64
+	decided     bool
+	firstCommit spectypes.OperatorID
+	committed   map[spectypes.OperatorID]struct{}
+	commits     map[specqbft.Round]uint64
+	postSigners map[spectypes.OperatorID]struct{}
+}
+
+type committeeStats struct {
+	operators []spectypes.OperatorID
+	// duties is the number of duties in which post-consensus messages were seen
+	duties uint64
+	// instances is the number of consensus instances in which commit messages were seen
+	instances uint64
+	stats     map[spectypes.OperatorID]*OperatorStats
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker(beaconNetwork beacon.BeaconNetwork, committees CommitteeStore, opts ...Option) *Tracker {
+	t := &Tracker{
+		beaconNetwork:  beaconNetwork,
+		committees:     committees,
+		retainedEpochs: defaultRetainedEpochs,
+		instances:      make(map[instanceKey]*instance),
+		epochs:         make(map[phase0.Epoch]map[spectypes.CommitteeID]*committeeStats),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// OnConsensusMessage tracks a consensus message of a committee, only commit messages are taken into account.
+// An operator's commit is late if it's seen after a quorum of commits already decided the instance,
+// aggregated commits only complete the quorum.
+func (t *Tracker) OnConsensusMessage(msgID spectypes.MessageID, msg *specqbft.Message, signers []spectypes.OperatorID) {
+	if msg.MsgType != specqbft.CommitMsgType || len(signers) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	inst := t.instance(msgID, phase0.Slot(msg.Height))
+	if inst == nil {
+		return
+	}
+
+	if len(signers) > 1 {
+		if uint64(len(signers)) >= inst.quorum {
+			inst.decided = true
+		}
+		return
+	}
+
+	signer := signers[0]
+	stats, ok := inst.stats.stats[signer]
+	if !ok {
+		return
+	}
+	if _, ok := inst.committed[signer]; ok {
+		return
+	}
+	inst.committed[signer] = struct{}{}
+
+	if inst.firstCommit == 0 {
+		inst.firstCommit = signer
+		stats.FirstCommits++
+		inst.stats.instances++
+		for _, operatorStats := range inst.stats.stats {
+			operatorStats.Instances++
+		}
+	}
+
+	stats.Commits++
+	stats.roundSum += uint64(msg.Round)
+	if inst.decided {
+		stats.LateCommits++
+		return
+	}
+
+	inst.commits[msg.Round]++
+	if inst.commits[msg.Round] >= inst.quorum {
+		inst.decided = true
+	}
+}
+
+// OnPostConsensusMessage tracks the post-consensus partial signatures of an operator for a duty.
+func (t *Tracker) OnPostConsensusMessage(msgID spectypes.MessageID, slot phase0.Slot, signer spectypes.OperatorID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	inst := t.instance(msgID, slot)
+	if inst == nil {
+		return
+	}
+
+	stats, ok := inst.stats.stats[signer]
+	if !ok {
+		return
+	}
+	if _, ok := inst.postSigners[signer]; ok {
+		return
+	}
+
+	if len(inst.postSigners) == 0 {
+		inst.stats.duties++
+		for _, operatorStats := range inst.stats.stats {
+			operatorStats.Duties++
+		}
+	}
+	inst.postSigners[signer] = struct{}{}
+	stats.Signed++
+}
+
+// instance returns the tracked instance of the duty, creating it if needed.
+// It returns nil if the committee is unknown or the slot is too old to be tracked.
+func (t *Tracker) instance(msgID spectypes.MessageID, slot phase0.Slot) *instance {
+	key := instanceKey{msgID: msgID, slot: slot}
+	if inst, ok := t.instances[key]; ok {
+		return inst
+	}
+
+	epoch := t.beaconNetwork.EstimatedEpochAtSlot(slot)
+	if epoch+instanceRetentionEpochs <= t.lastEpoch {
+		return nil
+	}
+
+	committeeID, operators, ok := t.committee(msgID)
+	if !ok {
+		return nil
+	}
+
+	if !t.started {
+		t.startEpoch = epoch
+		t.started = true
+	}
+	if epoch > t.lastEpoch {
+		t.lastEpoch = epoch
+		t.prune()
+	}
+
+	committees, ok := t.epochs[epoch]
+	if !ok {
+		committees = make(map[spectypes.CommitteeID]*committeeStats)
+		t.epochs[epoch] = committees
+	}
+	stats, ok := committees[committeeID]
+	if !ok {
+		stats = &committeeStats{
+			operators: operators,
+			stats:     make(map[spectypes.OperatorID]*OperatorStats, len(operators)),
+		}
+		for _, operatorID := range operators {
+			stats.stats[operatorID] = &OperatorStats{OperatorID: operatorID}
+		}
+		committees[committeeID] = stats
+	}
+
+	quorum, _ := types.ComputeQuorumAndPartialQuorum(uint64(len(operators)))
+	inst := &instance{
+		epoch:       epoch,
+		stats:       stats,
+		quorum:      quorum,
+		committed:   make(map[spectypes.OperatorID]struct{}),
+		commits:     make(map[specqbft.Round]uint64),
+		postSigners: make(map[spectypes.OperatorID]struct{}),
+	}
+	t.instances[key] = inst
+	return inst
+}
+
+func (t *Tracker) committee(msgID spectypes.MessageID) (spectypes.CommitteeID, []spectypes.OperatorID, bool) {
+	dutyExecutorID := msgID.GetDutyExecutorID()
+	if msgID.GetRoleType() == spectypes.RoleCommittee {
+		committeeID := spectypes.CommitteeID(dutyExecutorID[16:])
+		committee, ok := t.committees.Committee(committeeID)
+		if !ok {
+			return spectypes.CommitteeID{}, nil, false
+		}
+		return committeeID, committee.Operators, true
+	}
+
+	share, ok := t.committees.Validator(dutyExecutorID)
+	if !ok {
+		return spectypes.CommitteeID{}, nil, false
+	}
+	return share.CommitteeID(), share.OperatorIDs(), true
+}
+
+// prune drops the instances and statistics that are no longer retained.
+func (t *Tracker) prune() {
+	for key, inst := range t.instances {
+		if inst.epoch+instanceRetentionEpochs <= t.lastEpoch {
+			delete(t.instances, key)
+		}
+	}
+	for epoch := range t.epochs {
+		if uint64(epoch)+t.retainedEpochs <= uint64(t.lastEpoch) {
+			delete(t.epochs, epoch)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type testCommitteeStore struct {
+	committees map[spectypes.CommitteeID]*registrystorage.Committee
+}
+
+func (s *testCommitteeStore) Validator([]byte) (*types.SSVShare, bool) {
+	return nil, false
+}
+
+func (s *testCommitteeStore) Committee(id spectypes.CommitteeID) (*registrystorage.Committee, bool) {
+	committee, ok := s.committees[id]
+	return committee, ok
+}
+
+func commit(slot phase0.Slot, round specqbft.Round) *specqbft.Message {
+	return &specqbft.Message{MsgType: specqbft.CommitMsgType, Height: specqbft.Height(slot), Round: round}
+}
+
+func TestTracker(t *testing.T) {
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	committeeID := spectypes.CommitteeID{1}
+	store := &testCommitteeStore{committees: map[spectypes.CommitteeID]*registrystorage.Committee{
+		committeeID: {ID: committeeID, Operators: []spectypes.OperatorID{1, 2, 3, 4}},
+	}}
+	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType, append(make([]byte, 16), committeeID[:]...), spectypes.RoleCommittee)
+	unknownMsgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType, make([]byte, 48), spectypes.RoleCommittee)
+
+	tracker := NewTracker(beaconNetwork, store, WithRetainedEpochs(2))
+	_, ok := tracker.Tracked()
+	require.False(t, ok)
+
+	// slot 1 decides in round 1, operator 4 commits after the quorum
+	tracker.OnConsensusMessage(msgID, commit(1, 1), []spectypes.OperatorID{2})
+	tracker.OnConsensusMessage(msgID, commit(1, 1), []spectypes.OperatorID{1})
+	tracker.OnConsensusMessage(msgID, commit(1, 1), []spectypes.OperatorID{1})
+	tracker.OnConsensusMessage(msgID, commit(1, 1), []spectypes.OperatorID{3})
+	tracker.OnConsensusMessage(msgID, commit(1, 1), []spectypes.OperatorID{4})
+	for _, signer := range []spectypes.OperatorID{1, 2, 3} {
+		tracker.OnPostConsensusMessage(msgID, 1, signer)
+	}
+
+	// slot 2 decides in round 2 by an aggregated commit, operator 4 doesn't participate
+	tracker.OnConsensusMessage(msgID, commit(2, 2), []spectypes.OperatorID{1})
+	tracker.OnConsensusMessage(msgID, commit(2, 2), []spectypes.OperatorID{1, 2, 3})
+	tracker.OnConsensusMessage(msgID, commit(2, 2), []spectypes.OperatorID{3})
+	for _, signer := range []spectypes.OperatorID{1, 3} {
+		tracker.OnPostConsensusMessage(msgID, 2, signer)
+	}
+
+	// messages of unknown committees are ignored
+	tracker.OnPostConsensusMessage(unknownMsgID, 2, 1)
+
+	operators := tracker.Operators(EpochRange{}, SortByParticipation)
+	require.Len(t, operators, 4)
+	require.Equal(t, []spectypes.OperatorID{1, 3, 2, 4}, operatorIDs(operators))
+
+	op1 := operators[0]
+	require.EqualValues(t, 2, op1.Duties)
+	require.Equal(t, 1.0, op1.ParticipationRate)
+	require.Equal(t, 0.5, op1.FirstSignerFrequency)
+	require.Equal(t, 1.5, op1.AverageRound)
+	require.Equal(t, 0.0, op1.LateCommitRatio)
+
+	op3 := operators[1]
+	require.Equal(t, 0.5, op3.LateCommitRatio)
+	require.Equal(t, 0.5, operators[2].ParticipationRate)
+	require.Equal(t, 1.0, operators[3].LateCommitRatio)
+
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, operatorIDs(tracker.Operators(EpochRange{}, SortByFirstSigner)))
+	require.Equal(t, []spectypes.OperatorID{2, 4, 1, 3}, operatorIDs(tracker.Operators(EpochRange{}, SortByRound)))
+
+	committees := tracker.Committees(EpochRange{})
+	require.Len(t, committees, 1)
+	require.Equal(t, committeeID, committees[0].CommitteeID)
+	require.EqualValues(t, 2, committees[0].Duties)
+	require.EqualValues(t, 2, committees[0].Instances)
+	require.Empty(t, tracker.Committees(EpochRange{}, spectypes.CommitteeID{2}))
+
+	// the epoch in which tracking started is partial
+	tracked, ok := tracker.Tracked()
+	require.True(t, ok)
+	require.Equal(t, TrackedRange{From: 0, To: 0, Partial: true}, tracked)
+
+	// statistics of epochs that aren't retained anymore are dropped
+	slotsPerEpoch := phase0.Slot(beaconNetwork.SlotsPerEpoch())
+	tracker.OnPostConsensusMessage(msgID, 2*slotsPerEpoch, 1)
+	tracked, ok = tracker.Tracked()
+	require.True(t, ok)
+	require.Equal(t, TrackedRange{From: 2, To: 2}, tracked)
+	require.Empty(t, tracker.Operators(EpochRange{}, SortByParticipation)[0].Commits)
+
+	// messages of instances that aren't tracked anymore are ignored
+	tracker.OnPostConsensusMessage(msgID, 3, 4)
+	require.EqualValues(t, 1, tracker.Operators(EpochRange{}, SortByParticipation)[0].Duties)
+}
+
+func operatorIDs(stats []*OperatorStats) []spectypes.OperatorID {
+	ids := make([]spectypes.OperatorID, len(stats))
+	for i, s := range stats {
+		ids[i] = s.OperatorID
+	}
+	return ids
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging/fields"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+const peersWaitInterval = 5 * time.Second
+
+// ParticipantsVerifier verifies decided participants that were synced from peers
+type ParticipantsVerifier func(identifier convert.MessageID, entry protocolp2p.DecidedHistoryEntry) error
+
+// ShareProvider returns the share of the given validator
+type ShareProvider func(pubKey []byte) (*ssvtypes.SSVShare, bool)
+
+// DomainProvider returns the signature domain of the given type at the given epoch
+type DomainProvider func(epoch phase0.Epoch, domainType phase0.DomainType) (phase0.Domain, error)
+
+// EpochEstimator estimates the epoch of a slot
+type EpochEstimator interface {
+	EstimatedEpochAtSlot(slot phase0.Slot) phase0.Epoch
+}
+
+// NewParticipantsVerifier returns a ParticipantsVerifier that recomputes the signing root of each entry
+// from its slot, role and domain and verifies the partial signatures in its proof against the committee of the validator.
+// Only attester and sync committee participants can be verified, as their proofs carry the decided beacon vote.
+func NewParticipantsVerifier(shares ShareProvider, domains DomainProvider, epochs EpochEstimator) ParticipantsVerifier {
+	return func(identifier convert.MessageID, entry protocolp2p.DecidedHistoryEntry) error {
+		share, ok := shares(identifier.GetDutyExecutorID())
+		if !ok {
+			return fmt.Errorf("unknown validator")
+		}
+		root, err := expectedSigningRoot(identifier.GetRoleType(), entry, domains, epochs)
+		if err != nil {
+			return err
+		}
+		return verifyParticipants(share, root, entry)
+	}
+}
+
+// expectedSigningRoot computes the signing root of the duty of the given role at the slot of the entry,
+// so that signatures of other duties can't be replayed as a proof.
+func expectedSigningRoot(role convert.RunnerRole, entry protocolp2p.DecidedHistoryEntry, domains DomainProvider, epochs EpochEstimator) (phase0.Root, error) {
+	proof := entry.Proof
+	if proof == nil {
+		return phase0.Root{}, fmt.Errorf("missing proof")
+	}
+	if proof.BeaconVote == nil {
+		return phase0.Root{}, fmt.Errorf("missing beacon vote")
+	}
+	epoch := epochs.EstimatedEpochAtSlot(entry.Slot)
+
+	var (
+		root       phase0.Root
+		domainType phase0.DomainType
+		object     ssz.HashRoot
+	)
+	switch role {
+	case convert.RoleAttester:
+		if proof.BeaconVote.Target.Epoch != epoch {
+			return root, fmt.Errorf("target epoch %d doesn't match slot", proof.BeaconVote.Target.Epoch)
+		}
+		domainType = spectypes.DomainAttester
+		object = &phase0.AttestationData{
+			Slot:            entry.Slot,
+			Index:           proof.CommitteeIndex,
+			BeaconBlockRoot: proof.BeaconVote.BlockRoot,
+			Source:          proof.BeaconVote.Source,
+			Target:          proof.BeaconVote.Target,
+		}
+	case convert.RoleSyncCommittee:
+		domainType = spectypes.DomainSyncCommittee
+		object = spectypes.SSZBytes(proof.BeaconVote.BlockRoot[:])
+	default:
+		return root, fmt.Errorf("participants of role %s can't be verified", role.String())
+	}
+
+	domain, err := domains(epoch, domainType)
+	if err != nil {
+		return root, fmt.Errorf("get domain: %w", err)
+	}
+	root, err = spectypes.ComputeETHSigningRoot(object, domain)
+	if err != nil {
+		return root, fmt.Errorf("compute signing root: %w", err)
+	}
+	if root != proof.SigningRoot {
+		return root, fmt.Errorf("signing root doesn't match the duty")
+	}
+	return root, nil
+}
+
+func verifyParticipants(share *ssvtypes.SSVShare, root phase0.Root, entry protocolp2p.DecidedHistoryEntry) error {
+	proof := entry.Proof
+	if proof == nil {
+		return fmt.Errorf("missing proof")
+	}
+	if share.HasBeaconMetadata() && share.BeaconMetadata.Index != proof.ValidatorIndex {
+		return fmt.Errorf("wrong validator index %d", proof.ValidatorIndex)
+	}
+	if !slices.IsSorted(entry.Signers) || len(slices.Compact(slices.Clone(entry.Signers))) != len(entry.Signers) {
+		return fmt.Errorf("signers are not sorted or not unique")
+	}
+	if !share.HasQuorum(uint64(len(entry.Signers))) {
+		return fmt.Errorf("no quorum of signers (%d)", len(entry.Signers))
+	}
+	if len(proof.Signatures) != len(entry.Signers) {
+		return fmt.Errorf("proof doesn't match signers")
+	}
+
+	for _, signer := range entry.Signers {
+		signature, ok := proof.Signatures[signer]
+		if !ok {
+			return fmt.Errorf("missing signature of signer %d", signer)
+		}
+		if err := verifyPartialSignature(share, signer, signature, root); err != nil {
+			return fmt.Errorf("signer %d: %w", signer, err)
+		}
+	}
+	return nil
+}
+
+func verifyPartialSignature(share *ssvtypes.SSVShare, signer spectypes.OperatorID, signature spectypes.Signature, root phase0.Root) error {
+	for _, member := range share.Committee {
+		if member.Signer != signer {
+			continue
+		}
+		pk, err := ssvtypes.DeserializeBLSPublicKey(member.SharePubKey)
+		if err != nil {
+			return fmt.Errorf("could not deserialize share public key: %w", err)
+		}
+		sig := &bls.Sign{}
+		if err := sig.Deserialize(signature); err != nil {
+			return fmt.Errorf("could not deserialize signature: %w", err)
+		}
+		if !sig.VerifyByte(&pk, root[:]) {
+			return fmt.Errorf("wrong signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown signer")
+}
+
+// HistorySyncer fills gaps in the stored decided participants with decided history synced from peers.
+type HistorySyncer struct {
+	logger   *zap.Logger
+	stores   *QBFTStores
+	network  protocolp2p.DecidedHistorySyncer
+	verify   ParticipantsVerifier
+	minPeers int
+}
+
+// NewHistorySyncer creates a new HistorySyncer
+func NewHistorySyncer(logger *zap.Logger, stores *QBFTStores, network protocolp2p.DecidedHistorySyncer, verify ParticipantsVerifier, minPeers int) *HistorySyncer {
+	return &HistorySyncer{
+		logger:   logger,
+		stores:   stores,
+		network:  network,
+		verify:   verify,
+		minPeers: max(minPeers, 1),
+	}
+}
+
+// FillGaps syncs the decided participants of the given identifiers that were missed while the node was down,
+// i.e. from the highest slot saved for each identifier up to the given slot, looking back at most maxSlots.
+func (hs *HistorySyncer) FillGaps(ctx context.Context, identifiers []convert.MessageID, currentSlot, maxSlots phase0.Slot) error {
+	// Gaps are determined before syncing, as live decided messages keep bumping the highest slot.
+	gapStarts := make(map[convert.MessageID]phase0.Slot, len(identifiers))
+	earliest := phase0.Slot(0)
+	if currentSlot > maxSlots {
+		earliest = currentSlot - maxSlots
+	}
+	for _, identifier := range identifiers {
+		store := hs.stores.Get(identifier.GetRoleType())
+		if store == nil {
+			continue
+		}
+		highest, found, err := store.GetHighestSlot(identifier)
+		if err != nil {
+			return fmt.Errorf("get highest slot of %s: %w", identifier.GetRoleType(), err)
+		}
+		from := earliest
+		if found && highest+1 > from {
+			from = highest + 1
+		}
+		gapStarts[identifier] = from
+	}
+
+	if err := hs.waitForPeers(ctx); err != nil {
+		return err
+	}
+
+	var synced, rejected int
+	for _, identifier := range identifiers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		from, ok := gapStarts[identifier]
+		if !ok || from > currentSlot {
+			continue
+		}
+		store := hs.stores.Get(identifier.GetRoleType())
+
+		entries, err := hs.network.SyncDecidedByRange(hs.logger, identifier, from, currentSlot)
+		if err != nil {
+			hs.logger.Debug("could not sync decided history",
+				fields.MessageID(spectypes.MessageID(identifier)),
+				zap.Uint64("from", uint64(from)),
+				zap.Uint64("to", uint64(currentSlot)),
+				zap.Error(err))
+		}
+
+		for _, entry := range entries {
+			if err := hs.verify(identifier, entry); err != nil {
+				rejected++
+				hs.logger.Debug("rejected synced decided participants",
+					fields.MessageID(spectypes.MessageID(identifier)),
+					fields.Slot(entry.Slot),
+					zap.Error(err))
+				continue
+			}
+			updated, err := store.UpdateParticipants(identifier, entry.Slot, entry.Signers)
+			if err != nil {
+				return fmt.Errorf("update participants: %w", err)
+			}
+			if !updated {
+				continue
+			}
+			if err := store.SaveParticipantsProof(identifier, entry.Slot, entry.Proof); err != nil {
+				return fmt.Errorf("save participants proof: %w", err)
+			}
+			synced++
+		}
+	}
+
+	hs.logger.Info("finished syncing decided history",
+		zap.Int("identifiers", len(identifiers)),
+		zap.Int("synced", synced),
+		zap.Int("rejected", rejected))
+	return nil
+}
+
+func (hs *HistorySyncer) waitForPeers(ctx context.Context) error {
+	ticker := time.NewTicker(peersWaitInterval)
+	defer ticker.Stop()
+	for {
+		peers := hs.network.DecidedHistoryPeers()
+		if peers >= hs.minPeers {
+			return nil
+		}
+		hs.logger.Debug("waiting for decided history peers", zap.Int("peers", peers), zap.Int("min_peers", hs.minPeers))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
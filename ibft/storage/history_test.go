@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+type mockHistorySyncer struct {
+	entries  []protocolp2p.DecidedHistoryEntry
+	requests [][2]phase0.Slot
+}
+
+func (m *mockHistorySyncer) DecidedHistoryPeers() int {
+	return 1
+}
+
+func (m *mockHistorySyncer) SyncDecidedByRange(_ *zap.Logger, _ convert.MessageID, from, to phase0.Slot) ([]protocolp2p.DecidedHistoryEntry, error) {
+	m.requests = append(m.requests, [2]phase0.Slot{from, to})
+	return m.entries, nil
+}
+
+var testingBeaconNetwork = beacon.NewNetwork(spectypes.MainNetwork)
+
+func testingDomain(epoch phase0.Epoch, domainType phase0.DomainType) (phase0.Domain, error) {
+	return phase0.Domain{domainType[0], byte(epoch)}, nil
+}
+
+func testingHistoryEntry(t *testing.T, ks *spectestingutils.TestKeySet, slot phase0.Slot, signers ...spectypes.OperatorID) protocolp2p.DecidedHistoryEntry {
+	epoch := testingBeaconNetwork.EstimatedEpochAtSlot(slot)
+	vote := &spectypes.BeaconVote{
+		BlockRoot: phase0.Root{byte(slot)},
+		Source:    &phase0.Checkpoint{Epoch: epoch - 1},
+		Target:    &phase0.Checkpoint{Epoch: epoch},
+	}
+	domain, err := testingDomain(epoch, spectypes.DomainAttester)
+	require.NoError(t, err)
+	root, err := spectypes.ComputeETHSigningRoot(&phase0.AttestationData{
+		Slot:            slot,
+		Index:           3,
+		BeaconBlockRoot: vote.BlockRoot,
+		Source:          vote.Source,
+		Target:          vote.Target,
+	}, domain)
+	require.NoError(t, err)
+
+	proof := &qbftstorage.ParticipantsProof{
+		ValidatorIndex: 1,
+		SigningRoot:    root,
+		Signatures:     make(map[spectypes.OperatorID]spectypes.Signature),
+		BeaconVote:     vote,
+		CommitteeIndex: 3,
+	}
+	for _, signer := range signers {
+		proof.Signatures[signer] = ks.Shares[signer].SignByte(root[:]).Serialize()
+	}
+	return protocolp2p.DecidedHistoryEntry{Slot: slot, Signers: signers, Proof: proof}
+}
+
+func TestHistorySyncer_FillGaps(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ks := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{
+		Share: *spectestingutils.TestingShare(ks, 1),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beacon.ValidatorMetadata{Index: 1},
+		},
+	}
+	shares := func(pubKey []byte) (*ssvtypes.SSVShare, bool) {
+		return share, true
+	}
+
+	stores := NewStoresFromRoles(db, convert.RoleAttester)
+	store := stores.Get(convert.RoleAttester)
+	identifier := convert.NewMsgID(spectypes.DomainType{}, share.ValidatorPubKey[:], convert.RoleAttester)
+
+	// The node saw slot 10 before going down.
+	_, err = store.UpdateParticipants(identifier, 10, []spectypes.OperatorID{1, 2, 3})
+	require.NoError(t, err)
+
+	// Another validator's watermark doesn't affect the gap of this one.
+	other := convert.NewMsgID(spectypes.DomainType{}, []byte{1, 2, 3}, convert.RoleAttester)
+	_, err = store.UpdateParticipants(other, 19, []spectypes.OperatorID{1, 2, 3})
+	require.NoError(t, err)
+
+	forged := testingHistoryEntry(t, ks, 14, 1, 2, 3)
+	forged.Proof.Signatures[3] = forged.Proof.Signatures[2]
+
+	// Valid signatures of the duty at slot 11 replayed as the participants of slot 17.
+	replayed := testingHistoryEntry(t, ks, 11, 1, 2, 3)
+	replayed.Slot = 17
+
+	network := &mockHistorySyncer{
+		entries: []protocolp2p.DecidedHistoryEntry{
+			testingHistoryEntry(t, ks, 12, 1, 2, 3),
+			testingHistoryEntry(t, ks, 13, 1, 2),   // no quorum
+			forged,                                 // invalid signature
+			{Slot: 15, Signers: []uint64{1, 2, 3}}, // no proof
+			testingHistoryEntry(t, ks, 16, 2, 3, 4),
+			replayed, // signing root of another slot
+		},
+	}
+
+	syncer := NewHistorySyncer(logger, stores, network, NewParticipantsVerifier(shares, testingDomain, testingBeaconNetwork), 1)
+	require.NoError(t, syncer.FillGaps(context.Background(), []convert.MessageID{identifier}, 20, 100))
+	require.Equal(t, [][2]phase0.Slot{{11, 20}}, network.requests)
+
+	entries, err := store.GetParticipantsInRange(identifier, 11, 20)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, phase0.Slot(12), entries[0].Slot)
+	require.Equal(t, phase0.Slot(16), entries[1].Slot)
+	require.Equal(t, []spectypes.OperatorID{2, 3, 4}, entries[1].Signers)
+
+	proof, err := store.GetParticipantsProof(identifier, 16)
+	require.NoError(t, err)
+	require.Equal(t, network.entries[4].Proof, proof)
+
+	highest, found, err := store.GetHighestSlot(identifier)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Slot(16), highest)
+}
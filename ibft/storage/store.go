@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"sync"
@@ -12,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/exporter/convert"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
@@ -22,6 +24,10 @@ const (
 	highestInstanceKey = "highest_instance"
 	instanceKey        = "instance"
 	participantsKey    = "participants"
+	proofKey           = "participants_proof"
+	highestSlotKey     = "highest_slot"
+	// instanceStateKey is prefixed by instanceKey, so that CleanAllInstances removes the instance state too
+	instanceStateKey = "instance_state"
 )
 
 var (
@@ -91,6 +97,10 @@ func (i *ibftStorage) UpdateParticipants(identifier convert.MessageID, slot phas
 		return false, fmt.Errorf("save participants: %w", err)
 	}
 
+	if err := i.bumpHighestSlot(txn, identifier, slot); err != nil {
+		return false, fmt.Errorf("save highest slot: %w", err)
+	}
+
 	if err := txn.Commit(); err != nil {
 		return false, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -150,6 +160,86 @@ func (i *ibftStorage) saveParticipants(txn basedb.ReadWriter, identifier convert
 	return nil
 }
 
+func (i *ibftStorage) SaveParticipantsProof(identifier convert.MessageID, slot phase0.Slot, proof *qbftstorage.ParticipantsProof) error {
+	bytes, err := proof.Encode()
+	if err != nil {
+		return fmt.Errorf("encode proof: %w", err)
+	}
+	if err := i.save(nil, bytes, proofKey, identifier[:], uInt64ToByteSlice(uint64(slot))); err != nil {
+		return fmt.Errorf("save to DB: %w", err)
+	}
+	return nil
+}
+
+func (i *ibftStorage) GetParticipantsProof(identifier convert.MessageID, slot phase0.Slot) (*qbftstorage.ParticipantsProof, error) {
+	val, found, err := i.get(nil, proofKey, identifier[:], uInt64ToByteSlice(uint64(slot)))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	proof := &qbftstorage.ParticipantsProof{}
+	if err := proof.Decode(val); err != nil {
+		return nil, fmt.Errorf("decode proof: %w", err)
+	}
+	return proof, nil
+}
+
+func (i *ibftStorage) GetHighestSlot(identifier convert.MessageID) (phase0.Slot, bool, error) {
+	return i.getHighestSlot(nil, identifier)
+}
+
+func (i *ibftStorage) getHighestSlot(txn basedb.ReadWriter, identifier convert.MessageID) (phase0.Slot, bool, error) {
+	val, found, err := i.get(txn, highestSlotKey, identifier[:])
+	if err != nil || !found {
+		return 0, found, err
+	}
+	return phase0.Slot(binary.LittleEndian.Uint64(val)), true, nil
+}
+
+// bumpHighestSlot saves the given slot if it's higher than the highest slot saved so far for the identifier
+func (i *ibftStorage) bumpHighestSlot(txn basedb.ReadWriter, identifier convert.MessageID, slot phase0.Slot) error {
+	highest, found, err := i.getHighestSlot(txn, identifier)
+	if err != nil {
+		return err
+	}
+	if found && highest >= slot {
+		return nil
+	}
+	return i.save(txn, uInt64ToByteSlice(uint64(slot)), highestSlotKey, identifier[:])
+}
+
+// SaveInstanceState replaces the saved state of the running instance of the state's identifier.
+func (i *ibftStorage) SaveInstanceState(state *specqbft.State) error {
+	bytes, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode instance state: %w", err)
+	}
+	if err := i.save(nil, bytes, instanceStateKey, state.ID); err != nil {
+		return fmt.Errorf("save to DB: %w", err)
+	}
+	return nil
+}
+
+// GetInstanceState returns the saved state of the running instance of the given identifier, or nil if not found.
+func (i *ibftStorage) GetInstanceState(identifier []byte) (*specqbft.State, error) {
+	val, found, err := i.get(nil, instanceStateKey, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	state := &specqbft.State{}
+	if err := json.Unmarshal(val, state); err != nil {
+		return nil, fmt.Errorf("decode instance state: %w", err)
+	}
+	return state, nil
+}
+
 func mergeParticipants(existingParticipants, newParticipants []spectypes.OperatorID) []spectypes.OperatorID {
 	allParticipants := slices.Concat(existingParticipants, newParticipants)
 	slices.Sort(allParticipants)
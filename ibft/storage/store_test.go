@@ -6,7 +6,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
 )
 
 func TestEncodeDecodeOperators(t *testing.T) {
@@ -101,3 +105,34 @@ func Test_mergeQuorums(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceState(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := New(db, "test")
+	identifier := []byte{1, 2, 3, 4}
+
+	state, err := store.GetInstanceState(identifier)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	saved := &specqbft.State{
+		ID:                identifier,
+		Height:            10,
+		Round:             3,
+		LastPreparedRound: 2,
+		LastPreparedValue: []byte{5, 6, 7},
+	}
+	require.NoError(t, store.SaveInstanceState(saved))
+
+	state, err = store.GetInstanceState(identifier)
+	require.NoError(t, err)
+	require.Equal(t, saved, state)
+
+	require.NoError(t, store.CleanAllInstances(identifier))
+	state, err = store.GetInstanceState(identifier)
+	require.NoError(t, err)
+	require.Nil(t, state)
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/integration/qbftsimulation"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+)
+
+func runBeaconFaults(t *testing.T, slots phase0.Slot, faults map[spectypes.OperatorID]map[fakebeacon.Endpoint]fakebeacon.Fault) *qbftsimulation.Report {
+	sim, err := qbftsimulation.New(zap.NewNop(), qbftsimulation.Config{
+		Committee: 4,
+		Slots:     slots,
+		Seed:      1,
+		Faults: qbftsimulation.Faults{
+			Link:   qbftsimulation.Link{Latency: 50 * time.Millisecond},
+			Beacon: faults,
+		},
+	})
+	require.NoError(t, err)
+	report, err := sim.Run(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, report.CheckSafety())
+	return report
+}
+
+func TestBeaconFaults_FailedSubmission(t *testing.T) {
+	// the beacon node of operator 2 rejects every attestation
+	report := runBeaconFaults(t, 64, map[spectypes.OperatorID]map[fakebeacon.Endpoint]fakebeacon.Fault{
+		2: {fakebeacon.EndpointSubmitAttestations: {Err: fakebeacon.ErrInjected}},
+	})
+
+	attested := 0
+	for slot := phase0.Slot(1); slot <= 64; slot++ {
+		// the rest of the committee isn't affected
+		attesterSlot := false
+		for _, operator := range []spectypes.OperatorID{1, 3, 4} {
+			_, ok := report.Submitted(slot, operator, spectypes.BNRoleSyncCommittee)
+			require.True(t, ok, "slot %d, operator %d", slot, operator)
+			if _, ok := report.Submitted(slot, operator, spectypes.BNRoleAttester); ok {
+				attesterSlot = true
+				attested++
+			}
+		}
+
+		_, ok := report.Submitted(slot, 2, spectypes.BNRoleAttester)
+		require.False(t, ok)
+		// the failed submission aborts the sync committee submission of the same slot
+		_, ok = report.Submitted(slot, 2, spectypes.BNRoleSyncCommittee)
+		require.Equal(t, !attesterSlot, ok, "slot %d", slot)
+	}
+	// one attester duty per epoch
+	require.Equal(t, 2*3, attested)
+}
+
+func TestBeaconFaults_FailedDutyFetch(t *testing.T) {
+	// operator 3 can't fetch its duties in the first slots, so it misses them and catches up afterwards
+	report := runBeaconFaults(t, 40, map[spectypes.OperatorID]map[fakebeacon.Endpoint]fakebeacon.Fault{
+		3: {fakebeacon.EndpointAttesterDuties: {Err: fakebeacon.ErrInjected, Calls: 5}},
+	})
+
+	for slot := phase0.Slot(1); slot <= 5; slot++ {
+		require.False(t, report.Live(slot), "slot %d", slot)
+		_, decided := report.Decisions[slot][3]
+		require.False(t, decided, "slot %d", slot)
+		// a quorum is still reached without operator 3
+		_, decided = report.Decisions[slot][1]
+		require.True(t, decided, "slot %d", slot)
+	}
+	require.Equal(t, 1.0, report.Liveness(6, 40))
+}
@@ -50,6 +50,9 @@ func (bn *TestingBeaconNodeWrapped) SubmitAggregateSelectionProof(slot phase0.Sl
 func (bn *TestingBeaconNodeWrapped) GetBeaconNetwork() spectypes.BeaconNetwork {
 	return bn.Bn.GetBeaconNetwork()
 }
+func (bn *TestingBeaconNodeWrapped) Network() beacon.Network {
+	return beacon.NewNetwork(bn.Bn.GetBeaconNetwork())
+}
 func (bn *TestingBeaconNodeWrapped) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
 	return bn.Bn.GetBeaconBlock(slot, graffiti, randao)
 }
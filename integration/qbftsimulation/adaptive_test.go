@@ -0,0 +1,153 @@
+package qbftsimulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
+)
+
+const (
+	adaptiveSlotsPerEpoch = 8
+	adaptiveForkEpoch     = 2
+)
+
+func runAdaptiveSimulation(t *testing.T, cfg Config, adaptive bool) (*Simulation, *Report) {
+	cfg.SlotsPerEpoch = adaptiveSlotsPerEpoch
+	if adaptive {
+		// epochs are shorter than on real networks, so more of them are learned from
+		config := roundtimer.DefaultAdaptiveTimeoutConfig
+		config.Window = 6
+		cfg.AdaptiveTimeouts = &AdaptiveTimeouts{
+			Config:    config,
+			ForkEpoch: adaptiveForkEpoch,
+		}
+	}
+	sim, err := New(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	report, err := sim.Run(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, report.CheckSafety())
+	return sim, report
+}
+
+// requireTimeoutsWithinSlot checks that the quick rounds which must fit in the slot end before the slot does
+func requireTimeoutsWithinSlot(t *testing.T, sim *Simulation, slot phase0.Slot) {
+	rounds := specqbft.Round(roundtimer.DefaultAdaptiveTimeoutConfig.RoundsInSlot)
+	for _, n := range sim.nodes {
+		require.LessOrEqual(t, n.roundTimeout(slot, rounds), sim.cfg.SlotDuration)
+	}
+}
+
+// requireSameTimeouts checks that the operators which observed the same decisions learned the same timeouts
+// and returns them
+func requireSameTimeouts(t *testing.T, sim *Simulation, slot phase0.Slot, operators ...spectypes.OperatorID) []time.Duration {
+	var timeouts []time.Duration
+	for i, operator := range operators {
+		var quick []time.Duration
+		for round := specqbft.FirstRound; round <= roundtimer.QuickTimeoutThreshold; round++ {
+			quick = append(quick, sim.node(operator).quickTimeout(slot, round))
+		}
+		if i == 0 {
+			timeouts = quick
+			continue
+		}
+		require.Equal(t, timeouts, quick, "operator %d", operator)
+	}
+	return timeouts
+}
+
+func TestSimulation_AdaptiveTimeouts_CrashedLeader(t *testing.T) {
+	cfg := Config{
+		Committee: 4,
+		Slots:     160,
+		Seed:      9,
+		Faults: Faults{
+			Link:    Link{Latency: 50 * time.Millisecond, Jitter: 100 * time.Millisecond},
+			Crashes: []Crash{{Operator: 4, From: 1}},
+		},
+	}
+	_, fixed := runAdaptiveSimulation(t, cfg, false)
+	sim, adaptive := runAdaptiveSimulation(t, cfg, true)
+
+	require.Equal(t, 1.0, fixed.Liveness(1, 160))
+	require.Equal(t, 1.0, adaptive.Liveness(1, 160))
+
+	// timeouts are the same until the fork
+	forkSlot := phase0.Slot(adaptiveForkEpoch * adaptiveSlotsPerEpoch)
+	require.Equal(t, fixed.MeanDecisionTime(1, forkSlot-1), adaptive.MeanDecisionTime(1, forkSlot-1))
+
+	// the first rounds of a small committee time out as with fixed timeouts, so a crashed leader isn't waited for longer
+	require.LessOrEqual(t, adaptive.MeanDecisionTime(forkSlot, 160), fixed.MeanDecisionTime(forkSlot, 160))
+	timeouts := requireSameTimeouts(t, sim, 160, 1, 2, 3)
+	require.Equal(t, roundtimer.QuickTimeout, timeouts[0])
+	requireTimeoutsWithinSlot(t, sim, 160)
+}
+
+func TestSimulation_AdaptiveTimeouts_SlowCommittee(t *testing.T) {
+	cfg := Config{
+		Committee: 4,
+		Slots:     160,
+		Seed:      10,
+		Faults: Faults{
+			Link: Link{Latency: 500 * time.Millisecond, Jitter: 200 * time.Millisecond},
+			OperatorLinks: map[spectypes.OperatorID]Link{
+				2: {Latency: 800 * time.Millisecond, Jitter: 100 * time.Millisecond},
+			},
+		},
+	}
+	_, fixed := runAdaptiveSimulation(t, cfg, false)
+	sim, adaptive := runAdaptiveSimulation(t, cfg, true)
+
+	forkSlot := phase0.Slot(adaptiveForkEpoch * adaptiveSlotsPerEpoch)
+	require.GreaterOrEqual(t, adaptive.Liveness(forkSlot, 160), fixed.Liveness(forkSlot, 160))
+
+	// the committee mostly decides in the first round, so there's nothing to learn,
+	// and the timeouts stay within their bounds
+	timeouts := requireSameTimeouts(t, sim, 160, 1, 2, 3, 4)
+	require.Equal(t, roundtimer.QuickTimeout, timeouts[0])
+	for _, quick := range timeouts {
+		require.GreaterOrEqual(t, quick, roundtimer.DefaultAdaptiveTimeoutConfig.Min)
+		require.LessOrEqual(t, quick, roundtimer.DefaultAdaptiveTimeoutConfig.Max)
+	}
+	requireTimeoutsWithinSlot(t, sim, 160)
+}
+
+func TestSimulation_AdaptiveTimeouts_VerySlowCommittee(t *testing.T) {
+	cfg := Config{
+		Committee: 4,
+		Slots:     64,
+		Seed:      10,
+		Faults: Faults{
+			Link: Link{Latency: 700 * time.Millisecond, Jitter: 200 * time.Millisecond},
+			OperatorLinks: map[spectypes.OperatorID]Link{
+				2: {Latency: 800 * time.Millisecond, Jitter: 100 * time.Millisecond},
+			},
+		},
+	}
+	_, fixed := runAdaptiveSimulation(t, cfg, false)
+	sim, adaptive := runAdaptiveSimulation(t, cfg, true)
+
+	// the first round of a committee whose first rounds keep failing waits longer once it learned from a window of instances,
+	// every operator learned the same from the decided rounds
+	timeouts := requireSameTimeouts(t, sim, 64, 1, 2, 3, 4)
+	require.Greater(t, timeouts[0], roundtimer.QuickTimeout)
+	requireTimeoutsWithinSlot(t, sim, 64)
+
+	// rounds that keep failing wait longer, so the committee decides in the quick rounds
+	// instead of falling back to the slow ones
+	forkSlot := phase0.Slot(adaptiveForkEpoch * adaptiveSlotsPerEpoch)
+	for slot := forkSlot; slot <= 64; slot++ {
+		for operator, d := range adaptive.Decisions[slot] {
+			require.LessOrEqual(t, d.Round, roundtimer.QuickTimeoutThreshold, "slot %d operator %d", slot, operator)
+		}
+	}
+	require.Less(t, adaptive.MeanDecisionTime(forkSlot, 64), fixed.MeanDecisionTime(forkSlot, 64)-5*time.Second)
+}
@@ -0,0 +1,120 @@
+package qbftsimulation
+
+import (
+	"crypto/sha256"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+)
+
+const (
+	// validatorIndex is the index of the committee's validator
+	validatorIndex = phase0.ValidatorIndex(spectestingutils.TestingValidatorIndex)
+	// syncCommitteePeriodEpochs is longer than any simulation, so the validator is in the sync committee throughout it
+	syncCommitteePeriodEpochs = 256
+)
+
+// share returns the share of the committee's validator that belongs to the given operator
+func (sim *Simulation) share(operator spectypes.OperatorID) *spectypes.Share {
+	share := spectestingutils.TestingShare(sim.keySet, validatorIndex)
+	share.SharePubKey = sim.keySet.Shares[operator].GetPublicKey().Serialize()
+	return share
+}
+
+func (sim *Simulation) validatorPubKey() phase0.BLSPubKey {
+	return phase0.BLSPubKey(sim.share(1).ValidatorPubKey)
+}
+
+// attesterSlot returns the slot of the validator's attester duty in the given epoch, the middle of the epoch
+func (sim *Simulation) attesterSlot(epoch phase0.Epoch) phase0.Slot {
+	return sim.netCfg.Beacon.FirstSlotAtEpoch(epoch) + phase0.Slot(sim.cfg.SlotsPerEpoch/2) // #nosec G115
+}
+
+// roles returns the duties of the validator in the given slot, it's in the sync committee so it has a duty in every slot
+func (sim *Simulation) roles(slot phase0.Slot) []spectypes.BeaconRole {
+	roles := []spectypes.BeaconRole{spectypes.BNRoleSyncCommittee}
+	if slot == sim.attesterSlot(sim.netCfg.Beacon.EstimatedEpochAtSlot(slot)) {
+		roles = append(roles, spectypes.BNRoleAttester)
+	}
+	return roles
+}
+
+func (sim *Simulation) syncCommitteeDuty() *eth2apiv1.SyncCommitteeDuty {
+	return &eth2apiv1.SyncCommitteeDuty{
+		PubKey:                        sim.validatorPubKey(),
+		ValidatorIndex:                validatorIndex,
+		ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{7},
+	}
+}
+
+// newBeaconNode returns the beacon node of the given operator, which serves the validator's duties of every simulated epoch
+func (sim *Simulation) newBeaconNode(n *node) *beaconNode {
+	bn := fakebeacon.New(fakebeacon.WithNetwork(sim.netCfg.Beacon))
+	for epoch := phase0.Epoch(0); epoch <= sim.netCfg.Beacon.EstimatedEpochAtSlot(sim.cfg.Slots); epoch++ {
+		bn.SetAttesterDuties(epoch, &eth2apiv1.AttesterDuty{
+			PubKey:                  sim.validatorPubKey(),
+			Slot:                    sim.attesterSlot(epoch),
+			ValidatorIndex:          validatorIndex,
+			CommitteeIndex:          3,
+			CommitteeLength:         128,
+			CommitteesAtSlot:        36,
+			ValidatorCommitteeIndex: 11,
+		})
+		bn.SetSyncCommitteeDuties(epoch, sim.syncCommitteeDuty())
+	}
+	for endpoint, fault := range sim.cfg.Faults.Beacon[n.id] {
+		bn.InjectFault(endpoint, fault)
+	}
+	return &beaconNode{Node: bn, n: n}
+}
+
+// beaconNode is the beacon node of an operator, it records the attestations and sync committee messages
+// that the operator submits in the report of the simulation.
+type beaconNode struct {
+	*fakebeacon.Node
+	n *node
+}
+
+func (bn *beaconNode) SubmitAttestations(attestations []*phase0.Attestation) error {
+	if err := bn.Node.SubmitAttestations(attestations); err != nil {
+		return err
+	}
+	for _, attestation := range attestations {
+		bn.n.sim.report.submitted(attestation.Data.Slot, bn.n.id, Submission{
+			Role: spectypes.BNRoleAttester,
+			Root: attestation.Data.BeaconBlockRoot,
+			Data: attestation.Data,
+			At:   bn.n.sim.sched.Now(),
+		})
+	}
+	return nil
+}
+
+func (bn *beaconNode) SubmitSyncMessages(messages []*altair.SyncCommitteeMessage) error {
+	if err := bn.Node.SubmitSyncMessages(messages); err != nil {
+		return err
+	}
+	for _, message := range messages {
+		bn.n.sim.report.submitted(message.Slot, bn.n.id, Submission{
+			Role: spectypes.BNRoleSyncCommittee,
+			Root: message.BeaconBlockRoot,
+			At:   bn.n.sim.sched.Now(),
+		})
+	}
+	return nil
+}
+
+// conflictingValue returns a valid vote that conflicts with the given one, voting for a different block
+func conflictingValue(value []byte) ([]byte, error) {
+	vote := &spectypes.BeaconVote{}
+	if err := vote.Decode(value); err != nil {
+		return nil, err
+	}
+	vote.BlockRoot = sha256.Sum256(vote.BlockRoot[:])
+	return vote.Encode()
+}
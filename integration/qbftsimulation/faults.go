@@ -0,0 +1,133 @@
+package qbftsimulation
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+)
+
+// Link describes the conditions of a link between two operators
+type Link struct {
+	// Latency is the base delay of every message
+	Latency time.Duration
+	// Jitter is a random delay in [0, Jitter) that is added to Latency
+	Jitter time.Duration
+	// Loss is the probability [0, 1] that a message is dropped
+	Loss float64
+}
+
+// Partition splits the operators into groups that can't reach each other in slots [From, To).
+// operators that are not listed in any group are isolated from everyone else.
+type Partition struct {
+	From   phase0.Slot
+	To     phase0.Slot
+	Groups [][]spectypes.OperatorID
+}
+
+// Crash takes an operator down in slots [From, To), the operator restarts with an empty state at To.
+// a zero To means that the operator never recovers.
+type Crash struct {
+	Operator spectypes.OperatorID
+	From     phase0.Slot
+	To       phase0.Slot
+}
+
+// ByzantineBehavior is the way a Byzantine operator deviates from the protocol
+type ByzantineBehavior int
+
+const (
+	// Equivocate sends conflicting proposals, prepares and commits to the operators with an even ID
+	Equivocate ByzantineBehavior = iota
+	// Mute receives messages as usual but never sends any
+	Mute
+)
+
+func (b ByzantineBehavior) String() string {
+	switch b {
+	case Equivocate:
+		return "equivocate"
+	case Mute:
+		return "mute"
+	default:
+		return "unknown"
+	}
+}
+
+// Faults are the faults that are injected into a simulation
+type Faults struct {
+	// Link applies to all the messages in the network
+	Link Link
+	// OperatorLinks override Link for the messages sent by specific operators
+	OperatorLinks map[spectypes.OperatorID]Link
+	Partitions    []Partition
+	Crashes       []Crash
+	// ClockSkew is added to the local clock of specific operators, a positive skew means the clock is ahead
+	ClockSkew map[spectypes.OperatorID]time.Duration
+	Byzantine map[spectypes.OperatorID]ByzantineBehavior
+	// Beacon are injected into the beacon nodes of specific operators, for example inconsistent attestation data
+	// makes an operator propose a different vote than the rest of the committee.
+	// faults with latency aren't supported since fakebeacon.Node delays calls on the real clock.
+	Beacon map[spectypes.OperatorID]map[fakebeacon.Endpoint]fakebeacon.Fault
+}
+
+func (f *Faults) validate() error {
+	for operator, faults := range f.Beacon {
+		for endpoint, fault := range faults {
+			if fault.Latency != 0 {
+				return fmt.Errorf("operator %d: latency of %s isn't supported", operator, endpoint)
+			}
+		}
+	}
+	return nil
+}
+
+// link returns the link of messages sent by the given operator
+func (f *Faults) link(from spectypes.OperatorID) Link {
+	if l, ok := f.OperatorLinks[from]; ok {
+		return l
+	}
+	return f.Link
+}
+
+// partitioned returns true if the given operators can't reach each other in the given slot
+func (f *Faults) partitioned(a, b spectypes.OperatorID, slot phase0.Slot) bool {
+	for _, p := range f.Partitions {
+		if slot < p.From || slot >= p.To {
+			continue
+		}
+		if group(p.Groups, a) == -1 || group(p.Groups, a) != group(p.Groups, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// crashed returns true if the given operator is down in the given slot
+func (f *Faults) crashed(operator spectypes.OperatorID, slot phase0.Slot) bool {
+	for _, c := range f.Crashes {
+		if c.Operator == operator && slot >= c.From && (c.To == 0 || slot < c.To) {
+			return true
+		}
+	}
+	return false
+}
+
+// honest returns true if the given operator follows the protocol
+func (f *Faults) honest(operator spectypes.OperatorID) bool {
+	_, byzantine := f.Byzantine[operator]
+	return !byzantine
+}
+
+func group(groups [][]spectypes.OperatorID, operator spectypes.OperatorID) int {
+	for i, g := range groups {
+		if slices.Contains(g, operator) {
+			return i
+		}
+	}
+	return -1
+}
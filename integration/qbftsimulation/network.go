@@ -0,0 +1,164 @@
+package qbftsimulation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// NetworkStats counts the messages that went through the virtual network
+type NetworkStats struct {
+	Sent      uint64
+	Delivered uint64
+	// Lost are the messages that were dropped due to link loss
+	Lost uint64
+	// Blocked are the messages that were dropped due to partitions
+	Blocked uint64
+	// Equivocated are the messages that were replaced by a Byzantine operator
+	Equivocated uint64
+	// Invalid are the delivered messages that message validation didn't accept
+	Invalid uint64
+}
+
+// virtualNetwork delivers broadcasted messages as pubsub messages to all the operators of the committee (including the sender,
+// like pubsub does for local subscribers), applying the link conditions and partitions of the simulation.
+type virtualNetwork struct {
+	sim   *Simulation
+	rand  *rand.Rand
+	stats NetworkStats
+	// equivocatedRoots maps roots that were proposed by Byzantine operators to their conflicting roots,
+	// so that their prepares and commits follow the conflicting proposal.
+	equivocatedRoots map[[32]byte][32]byte
+}
+
+func newVirtualNetwork(sim *Simulation, seed int64) *virtualNetwork {
+	return &virtualNetwork{
+		sim: sim,
+		// #nosec G404
+		rand:             rand.New(rand.NewSource(seed)),
+		equivocatedRoots: make(map[[32]byte][32]byte),
+	}
+}
+
+// broadcast sends the given message from the given operator to the committee
+func (vn *virtualNetwork) broadcast(from *node, msg *spectypes.SignedSSVMessage) {
+	behavior, byzantine := vn.sim.cfg.Faults.Byzantine[from.id]
+	slot := vn.sim.currentSlot()
+
+	data, err := msg.Encode()
+	if err != nil {
+		from.logger.Debug("could not encode message", zap.Error(err))
+		return
+	}
+
+	var conflicting []byte
+	for _, to := range vn.sim.nodes {
+		if to.id == from.id {
+			vn.deliver(from, to, data, 0)
+			continue
+		}
+		vn.stats.Sent++
+		if byzantine && behavior == Mute {
+			continue
+		}
+		if vn.sim.cfg.Faults.partitioned(from.id, to.id, slot) {
+			vn.stats.Blocked++
+			continue
+		}
+		link := vn.sim.cfg.Faults.link(from.id)
+		if link.Loss > 0 && vn.rand.Float64() < link.Loss {
+			vn.stats.Lost++
+			continue
+		}
+		delay := link.Latency
+		if link.Jitter > 0 {
+			delay += time.Duration(vn.rand.Int63n(int64(link.Jitter)))
+		}
+
+		out := data
+		if byzantine && behavior == Equivocate && to.id%2 == 0 {
+			if conflicting == nil {
+				conflicting = vn.conflicting(from, msg, data)
+			}
+			if !bytes.Equal(conflicting, data) {
+				out = conflicting
+				vn.stats.Equivocated++
+			}
+		}
+		vn.deliver(from, to, out, delay)
+	}
+}
+
+func (vn *virtualNetwork) deliver(from, to *node, data []byte, delay time.Duration) {
+	vn.sim.sched.After(delay, func() {
+		if to.receive(from, data) {
+			vn.stats.Delivered++
+		}
+	})
+}
+
+// conflicting returns the encoding of a message that conflicts with the given one, or its own encoding if there's none
+func (vn *virtualNetwork) conflicting(from *node, msg *spectypes.SignedSSVMessage, data []byte) []byte {
+	c, err := vn.equivocate(from, msg)
+	if err != nil {
+		from.logger.Debug("could not equivocate", zap.Error(err))
+		return data
+	}
+	encoded, err := c.Encode()
+	if err != nil {
+		from.logger.Debug("could not encode conflicting message", zap.Error(err))
+		return data
+	}
+	return encoded
+}
+
+// equivocate returns a message that conflicts with the given one, signed by the given operator.
+// only proposals, prepares and commits of a single signer are replaced.
+func (vn *virtualNetwork) equivocate(from *node, msg *spectypes.SignedSSVMessage) (*spectypes.SignedSSVMessage, error) {
+	if len(msg.OperatorIDs) != 1 || msg.SSVMessage.MsgType != spectypes.SSVConsensusMsgType {
+		return msg, nil
+	}
+	qbftMsg := &specqbft.Message{}
+	if err := qbftMsg.Decode(msg.SSVMessage.Data); err != nil {
+		return nil, errors.Wrap(err, "could not decode qbft message")
+	}
+
+	var fullData []byte
+	switch qbftMsg.MsgType {
+	case specqbft.ProposalMsgType:
+		value, err := conflictingValue(msg.FullData)
+		if err != nil {
+			return nil, err
+		}
+		root, err := specqbft.HashDataRoot(value)
+		if err != nil {
+			return nil, err
+		}
+		vn.equivocatedRoots[qbftMsg.Root] = root
+		qbftMsg.Root = root
+		fullData = value
+	case specqbft.PrepareMsgType, specqbft.CommitMsgType:
+		root, ok := vn.equivocatedRoots[qbftMsg.Root]
+		if !ok {
+			root = sha256.Sum256(qbftMsg.Root[:])
+		}
+		qbftMsg.Root = root
+	default:
+		return msg, nil
+	}
+
+	conflicting, err := ssvtypes.Sign(qbftMsg, from.id, from.signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign conflicting message")
+	}
+	conflicting.FullData = fullData
+	return conflicting, nil
+}
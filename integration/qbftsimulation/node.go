@@ -0,0 +1,454 @@
+package qbftsimulation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/message/signatureverifier"
+	"github.com/ssvlabs/ssv/message/validation"
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// node is a simulated operator, it runs the validator.Committee of the committee with real committee runners,
+// validates the messages it receives like the p2p layer does and fetches its duties from its own beacon node.
+// it implements specqbft.Network, roundtimer.Timer and roundtimer.DecisionObserver on top of the virtual network and clock.
+type node struct {
+	sim    *Simulation
+	id     spectypes.OperatorID
+	peerID peer.ID
+	logger *zap.Logger
+	signer *spectypes.OperatorSigner
+	member *spectypes.CommitteeMember
+	// storage holds the validator's share and the committee's operators, it survives restarts
+	storage operatorstorage.Storage
+	beacon  *beaconNode
+
+	// the following are reset when the node restarts
+	down      bool
+	committee *validator.Committee
+	validator validation.MessageValidator
+	duties    map[phase0.Epoch]*epochDuties
+	// queue holds messages that can't be processed yet, like the committee's queues do:
+	// messages of duties that didn't start yet, prepares and commits that arrived before the proposal of their round
+	// and post-consensus messages that arrived before the decision.
+	queue []*queue.SSVMessage
+	// timers invalidate the timeouts of a slot's instance that were scheduled before its timer was reset
+	timers map[phase0.Slot]uint64
+	// decidedRounds are the rounds in which the instances of the slots were decided
+	decidedRounds map[phase0.Slot]specqbft.Round
+	// adaptive derives the quick timeouts if adaptive timeouts are enabled, every operator learns on its own
+	// from the decisions it observes, so operators that observed the same decisions use the same timeouts
+	adaptive *roundtimer.AdaptiveTimeouts
+}
+
+// epochDuties are the duties of the validator in an epoch, as fetched from the operator's beacon node
+type epochDuties struct {
+	attester      []*eth2apiv1.AttesterDuty
+	syncCommittee []*eth2apiv1.SyncCommitteeDuty
+}
+
+func newNode(sim *Simulation, member *spectypes.CommitteeMember) (*node, error) {
+	n := &node{
+		sim:    sim,
+		id:     member.OperatorID,
+		peerID: peer.ID(fmt.Sprintf("operator-%d", member.OperatorID)),
+		logger: sim.logger.With(zap.Uint64("operator_id", member.OperatorID)),
+		signer: spectestingutils.NewOperatorSigner(sim.keySet, member.OperatorID),
+		member: member,
+	}
+	n.beacon = sim.newBeaconNode(n)
+
+	db, err := kv.NewInMemory(n.logger, basedb.Options{})
+	if err != nil {
+		return nil, err
+	}
+	n.storage, err = operatorstorage.NewNodeStorage(n.logger, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, operator := range member.Committee {
+		if _, err := n.storage.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:        operator.OperatorID,
+			PublicKey: []byte(base64.StdEncoding.EncodeToString(operator.SSVOperatorPubKey)),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	share := &ssvtypes.SSVShare{
+		Share: *sim.share(n.id),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  validatorIndex,
+			},
+		},
+	}
+	if err := n.storage.Shares().Save(nil, share); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// start starts the operator's stack, previous state is lost as with a restarted node
+func (n *node) start() {
+	ctx, cancel := context.WithCancel(n.sim.ctx)
+	signatureVerifier := signatureverifier.NewSignatureVerifier(n.storage)
+	share := n.sim.share(n.id)
+	n.committee = validator.NewCommittee(
+		ctx,
+		cancel,
+		n.logger,
+		n.sim.netCfg.Beacon.GetBeaconNetwork(),
+		n.member,
+		n.committeeRunner(signatureVerifier),
+		map[phase0.ValidatorIndex]*spectypes.Share{share.ValidatorIndex: share},
+		validator.NewCommitteeDutyGuard(),
+		signatureVerifier,
+	)
+
+	// the validator is in the sync committee, so the duty limit of the committee allows a duty in every slot
+	dutyStore := dutystore.New()
+	dutyStore.SyncCommittee.Set(0, []dutystore.StoreSyncCommitteeDuty{
+		{ValidatorIndex: validatorIndex, Duty: n.sim.syncCommitteeDuty(), InCommittee: true},
+	})
+	n.validator = validation.New(
+		n.sim.netCfg,
+		n.storage.ValidatorStore(),
+		dutyStore,
+		signatureVerifier,
+		validation.WithLogger(n.logger),
+		validation.WithClock(func() time.Time {
+			return genesis.Add(n.localTime(n.sim.sched.Now()))
+		}),
+	)
+
+	if adaptive := n.sim.cfg.AdaptiveTimeouts; adaptive != nil {
+		n.adaptive = roundtimer.NewAdaptiveTimeouts(adaptive.Config, n.sim.cfg.SlotsPerEpoch, n.sim.cfg.SlotDuration, adaptive.ForkEpoch)
+	}
+	n.duties = make(map[phase0.Epoch]*epochDuties)
+	n.queue = nil
+	n.timers = make(map[phase0.Slot]uint64)
+	n.decidedRounds = make(map[phase0.Slot]specqbft.Round)
+	n.down = false
+}
+
+// stop takes the node down
+func (n *node) stop() {
+	if n.committee != nil {
+		n.committee.Stop()
+	}
+	n.committee = nil
+	n.validator = nil
+	n.queue = nil
+	n.timers = nil
+	n.down = true
+}
+
+// committeeRunner creates the runners of the committee's duties, like the validator controller does
+func (n *node) committeeRunner(signatureVerifier qbft.SignatureVerifier) validator.CommitteeRunnerFunc {
+	keyManager := spectestingutils.NewTestingKeyManager()
+	return func(
+		slot phase0.Slot,
+		shares map[phase0.ValidatorIndex]*spectypes.Share,
+		attestingValidators []spectypes.ShareValidatorPK,
+		dutyGuard runner.CommitteeDutyGuard,
+	) (*runner.CommitteeRunner, error) {
+		epoch := n.sim.netCfg.Beacon.EstimatedEpochAtSlot(slot)
+		valCheck := ssv.BeaconVoteValueCheckF(keyManager, slot, attestingValidators, epoch)
+		config := &qbft.Config{
+			BeaconSigner:      keyManager,
+			Domain:            n.sim.netCfg.DomainType,
+			ValueCheckF:       valCheck,
+			ProposerF:         qbft.RoundRobinProposer,
+			Network:           n,
+			Timer:             n,
+			CutOffRound:       roundtimer.CutOffRound,
+			SignatureVerifier: signatureVerifier,
+		}
+		ctrl := controller.NewController(n.sim.identifier[:], n.member, config, n.signer, false)
+		r, err := runner.NewCommitteeRunner(n.sim.netCfg, shares, ctrl, n.beacon, n, keyManager, n.signer, valCheck, dutyGuard)
+		if err != nil {
+			return nil, err
+		}
+		return r.(*runner.CommitteeRunner), nil
+	}
+}
+
+// localTime returns the time of the node's clock at the given virtual time
+func (n *node) localTime(at time.Duration) time.Duration {
+	return at + n.sim.cfg.Faults.ClockSkew[n.id]
+}
+
+// virtualTime returns the virtual time at which the node's clock shows the given time
+func (n *node) virtualTime(local time.Duration) time.Duration {
+	return local - n.sim.cfg.Faults.ClockSkew[n.id]
+}
+
+// localSlot returns the current slot according to the node's clock
+func (n *node) localSlot() phase0.Slot {
+	return n.sim.slotAt(n.localTime(n.sim.sched.Now()))
+}
+
+// executeDuty fetches the duties of the given slot and starts them
+func (n *node) executeDuty(slot phase0.Slot) {
+	if n.down {
+		return
+	}
+	logger := n.logger.With(fields.Slot(slot))
+	duty, err := n.committeeDuty(slot)
+	if err != nil {
+		logger.Debug("could not fetch duties", zap.Error(err))
+		return
+	}
+	if len(duty.ValidatorDuties) == 0 {
+		return
+	}
+	if err := n.committee.StartDuty(n.sim.ctx, logger, duty); err != nil {
+		logger.Debug("could not start duty", zap.Error(err))
+	}
+	n.processQueue()
+}
+
+// committeeDuty returns the committee duty of the given slot, fetching the duties of its epoch if they weren't fetched yet
+func (n *node) committeeDuty(slot phase0.Slot) (*spectypes.CommitteeDuty, error) {
+	epoch := n.sim.netCfg.Beacon.EstimatedEpochAtSlot(slot)
+	duties, ok := n.duties[epoch]
+	if !ok {
+		indices := []phase0.ValidatorIndex{validatorIndex}
+		attester, err := n.beacon.AttesterDuties(n.sim.ctx, epoch, indices)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch attester duties: %w", err)
+		}
+		syncCommittee, err := n.beacon.SyncCommitteeDuties(n.sim.ctx, epoch, indices)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch sync committee duties: %w", err)
+		}
+		duties = &epochDuties{attester: attester, syncCommittee: syncCommittee}
+		n.duties[epoch] = duties
+	}
+
+	duty := &spectypes.CommitteeDuty{Slot: slot}
+	for _, d := range duties.attester {
+		if d.Slot != slot {
+			continue
+		}
+		duty.ValidatorDuties = append(duty.ValidatorDuties, &spectypes.ValidatorDuty{
+			Type:                    spectypes.BNRoleAttester,
+			PubKey:                  d.PubKey,
+			Slot:                    d.Slot,
+			ValidatorIndex:          d.ValidatorIndex,
+			CommitteeIndex:          d.CommitteeIndex,
+			CommitteeLength:         d.CommitteeLength,
+			CommitteesAtSlot:        d.CommitteesAtSlot,
+			ValidatorCommitteeIndex: d.ValidatorCommitteeIndex,
+		})
+	}
+	for _, d := range duties.syncCommittee {
+		indices := make([]uint64, len(d.ValidatorSyncCommitteeIndices))
+		for i, index := range d.ValidatorSyncCommitteeIndices {
+			indices[i] = uint64(index)
+		}
+		duty.ValidatorDuties = append(duty.ValidatorDuties, &spectypes.ValidatorDuty{
+			Type:                          spectypes.BNRoleSyncCommittee,
+			PubKey:                        d.PubKey,
+			Slot:                          slot,
+			ValidatorIndex:                d.ValidatorIndex,
+			ValidatorSyncCommitteeIndices: indices,
+		})
+	}
+	return duty, nil
+}
+
+// receive validates the given pubsub message like the p2p layer does, queues it if it's accepted
+// and processes the queue. returns false if the node is down.
+func (n *node) receive(from *node, data []byte) bool {
+	if n.down {
+		return false
+	}
+	topic := commons.GetTopicFullName(commons.CommitteeTopicID(n.member.CommitteeID)[0])
+	pmsg := &pubsub.Message{
+		Message:      &pspb.Message{Data: data, Topic: &topic},
+		ReceivedFrom: from.peerID,
+		Local:        from == n,
+	}
+	if result := n.validator.Validate(n.sim.ctx, from.peerID, pmsg); result != pubsub.ValidationAccept {
+		n.sim.network.stats.Invalid++
+		return true
+	}
+	msg, ok := pmsg.ValidatorData.(*queue.SSVMessage)
+	if !ok {
+		return true
+	}
+	n.queue = append(n.queue, msg)
+	n.processQueue()
+	return true
+}
+
+// processQueue processes queued messages in their arrival order until none of the remaining messages can be processed
+func (n *node) processQueue() {
+	for i := 0; i < len(n.queue); {
+		m := n.queue[i]
+		if n.deferred(m) {
+			i++
+			continue
+		}
+		n.queue = slices.Delete(n.queue, i, i+1)
+		n.process(m)
+		// processing might have changed the state of the runners, so deferred messages are checked again
+		i = 0
+	}
+}
+
+// deferred returns true if the given message should stay in the queue, following the filters of the committee's queues
+func (n *node) deferred(m *queue.SSVMessage) bool {
+	slot, err := m.Slot()
+	if err != nil || slot+1 < n.localSlot() {
+		// messages of past slots are processed anyway, so they don't pile up
+		return false
+	}
+	r, ok := n.committee.Runners[slot]
+	if !ok {
+		return true
+	}
+	if !r.HasRunningDuty() || r.GetBaseRunner().State.RunningInstance == nil {
+		return false
+	}
+	inst := r.GetBaseRunner().State.RunningInstance
+	decided, _ := inst.IsDecided()
+	switch body := m.Body.(type) {
+	case *specqbft.Message:
+		if decided || inst.State.ProposalAcceptedForCurrentRound != nil {
+			return false
+		}
+		aggregated := len(m.SignedSSVMessage.OperatorIDs) > 1
+		return !aggregated && body.Round == inst.State.Round &&
+			(body.MsgType == specqbft.PrepareMsgType || body.MsgType == specqbft.CommitMsgType)
+	case *spectypes.PartialSignatureMessages:
+		return !decided
+	default:
+		return false
+	}
+}
+
+func (n *node) process(m *queue.SSVMessage) {
+	if err := n.committee.ProcessMessage(n.sim.ctx, n.logger, m); err != nil {
+		n.logger.Debug("could not process message", zap.Error(err))
+	}
+	if slot, err := m.Slot(); err == nil {
+		n.recordDecision(slot)
+	}
+}
+
+// recordDecision records the decision of the given slot's runner in the report once it decided
+func (n *node) recordDecision(slot phase0.Slot) {
+	r, ok := n.committee.Runners[slot]
+	if !ok || r.GetBaseRunner().State == nil || len(r.GetBaseRunner().State.DecidedValue) == 0 {
+		return
+	}
+	n.sim.report.record(slot, n.id, Decision{
+		Value: r.GetBaseRunner().State.DecidedValue,
+		Round: n.decidedRounds[slot],
+		At:    n.sim.sched.Now(),
+	})
+}
+
+// Broadcast implements specqbft.Network
+func (n *node) Broadcast(_ spectypes.MessageID, msg *spectypes.SignedSSVMessage) error {
+	if n.down {
+		return fmt.Errorf("node is down")
+	}
+	n.sim.network.broadcast(n, msg)
+	return nil
+}
+
+// TimeoutForRound implements roundtimer.Timer, the timeout is relative to the start of the duty's slot
+// as measured by the node's clock. it's processed as the event message that the committee's timers queue.
+func (n *node) TimeoutForRound(height specqbft.Height, round specqbft.Round) {
+	slot := phase0.Slot(height)
+	n.timers[slot]++
+	gen, committee := n.timers[slot], n.committee
+
+	deadline := n.virtualTime(n.sim.slotStart(slot) + n.roundTimeout(slot, round))
+	n.sim.sched.At(deadline, func() {
+		if n.down || n.committee != committee || n.timers[slot] != gen {
+			return
+		}
+		msg, err := n.timeoutMessage(height, round)
+		if err != nil {
+			n.logger.Debug("could not create timeout message", zap.Error(err))
+			return
+		}
+		n.process(msg)
+		n.processQueue()
+	})
+}
+
+func (n *node) timeoutMessage(height specqbft.Height, round specqbft.Round) (*queue.SSVMessage, error) {
+	data, err := json.Marshal(&ssvtypes.TimeoutData{Height: height, Round: round})
+	if err != nil {
+		return nil, err
+	}
+	event, err := (&ssvtypes.EventMsg{Type: ssvtypes.Timeout, Data: data}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return queue.DecodeSSVMessage(&spectypes.SSVMessage{
+		MsgType: message.SSVEventMsgType,
+		MsgID:   n.sim.identifier,
+		Data:    event,
+	})
+}
+
+// Decided implements roundtimer.DecisionObserver
+func (n *node) Decided(height specqbft.Height, round specqbft.Round) {
+	n.decidedRounds[phase0.Slot(height)] = round
+	if n.adaptive != nil {
+		n.adaptive.Decided(phase0.Slot(height), round)
+	}
+}
+
+// roundTimeout returns the timeout of the given round of the instance of the given slot relative to the start of the slot,
+// following the timeouts of committee duties in roundtimer.
+func (n *node) roundTimeout(slot phase0.Slot, round specqbft.Round) time.Duration {
+	return roundtimer.RoundEnd(n.sim.dutyStart(), round, func(r specqbft.Round) time.Duration {
+		return n.quickTimeout(slot, r)
+	})
+}
+
+// quickTimeout returns the timeout of the given quick round of the instance of the given slot
+func (n *node) quickTimeout(slot phase0.Slot, round specqbft.Round) time.Duration {
+	if n.adaptive == nil {
+		return roundtimer.QuickTimeout
+	}
+	return n.adaptive.QuickTimeout(slot, round, n.sim.cfg.Committee, n.sim.dutyStart())
+}
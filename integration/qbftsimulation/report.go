@@ -0,0 +1,218 @@
+package qbftsimulation
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// Decision is the value that an operator decided for a slot
+type Decision struct {
+	// Value is the encoded spectypes.BeaconVote
+	Value []byte
+	Round specqbft.Round
+	// At is the virtual time of the decision
+	At time.Duration
+}
+
+// Submission is a duty that an operator submitted to its beacon node
+type Submission struct {
+	Role spectypes.BeaconRole
+	// Root is the block root that was attested or signed by the sync committee message
+	Root phase0.Root
+	// Data is the data of attestations
+	Data *phase0.AttestationData
+	// At is the virtual time of the submission
+	At time.Duration
+}
+
+// Report holds the decisions and submissions of a simulation and checks its safety and liveness
+type Report struct {
+	// Decisions holds the first decision of every operator by slot
+	Decisions map[phase0.Slot]map[spectypes.OperatorID]Decision
+	// Submissions holds the duties that every operator submitted by slot
+	Submissions map[phase0.Slot]map[spectypes.OperatorID][]Submission
+	Network     NetworkStats
+
+	slots        phase0.Slot
+	slotDuration time.Duration
+	operators    []spectypes.OperatorID
+	faults       *Faults
+	roles        func(phase0.Slot) []spectypes.BeaconRole
+}
+
+func newReport(sim *Simulation) *Report {
+	r := &Report{
+		Decisions:    make(map[phase0.Slot]map[spectypes.OperatorID]Decision),
+		Submissions:  make(map[phase0.Slot]map[spectypes.OperatorID][]Submission),
+		slots:        sim.cfg.Slots,
+		slotDuration: sim.cfg.SlotDuration,
+		faults:       &sim.cfg.Faults,
+		roles:        sim.roles,
+	}
+	for _, n := range sim.nodes {
+		r.operators = append(r.operators, n.id)
+	}
+	return r
+}
+
+func (r *Report) record(slot phase0.Slot, operator spectypes.OperatorID, d Decision) {
+	if r.Decisions[slot] == nil {
+		r.Decisions[slot] = make(map[spectypes.OperatorID]Decision)
+	}
+	if _, ok := r.Decisions[slot][operator]; ok {
+		return
+	}
+	r.Decisions[slot][operator] = d
+}
+
+func (r *Report) submitted(slot phase0.Slot, operator spectypes.OperatorID, s Submission) {
+	if r.Submissions[slot] == nil {
+		r.Submissions[slot] = make(map[spectypes.OperatorID][]Submission)
+	}
+	r.Submissions[slot][operator] = append(r.Submissions[slot][operator], s)
+}
+
+// Submitted returns the first submission of the given role by the given operator in the given slot, if there's one
+func (r *Report) Submitted(slot phase0.Slot, operator spectypes.OperatorID, role spectypes.BeaconRole) (Submission, bool) {
+	for _, s := range r.Submissions[slot][operator] {
+		if s.Role == role {
+			return s, true
+		}
+	}
+	return Submission{}, false
+}
+
+// CheckSafety returns an error if honest operators decided different votes for the same slot,
+// or submitted duties that don't follow the vote that was decided for their slot.
+func (r *Report) CheckSafety() error {
+	for slot := phase0.Slot(1); slot <= r.slots; slot++ {
+		var decided []byte
+		for _, operator := range r.operators {
+			d, ok := r.Decisions[slot][operator]
+			if !ok || !r.faults.honest(operator) {
+				continue
+			}
+			if err := (&spectypes.BeaconVote{}).Decode(d.Value); err != nil {
+				return fmt.Errorf("slot %d: operator %d decided an invalid value: %w", slot, operator, err)
+			}
+			if decided == nil {
+				decided = d.Value
+				continue
+			}
+			if !bytes.Equal(decided, d.Value) {
+				return fmt.Errorf("slot %d: operator %d decided a conflicting value", slot, operator)
+			}
+		}
+
+		for _, operator := range r.operators {
+			submissions := r.Submissions[slot][operator]
+			if len(submissions) == 0 || !r.faults.honest(operator) {
+				continue
+			}
+			if decided == nil {
+				return fmt.Errorf("slot %d: operator %d submitted duties of an undecided slot", slot, operator)
+			}
+			vote := &spectypes.BeaconVote{}
+			if err := vote.Decode(decided); err != nil {
+				return err
+			}
+			for _, s := range submissions {
+				if err := checkSubmission(slot, vote, s); err != nil {
+					return fmt.Errorf("slot %d: operator %d: %w", slot, operator, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkSubmission returns an error if the given submission doesn't follow the given vote
+func checkSubmission(slot phase0.Slot, vote *spectypes.BeaconVote, s Submission) error {
+	if s.Root != vote.BlockRoot {
+		return fmt.Errorf("submitted %s of block %x instead of the decided block %x", s.Role, s.Root, vote.BlockRoot)
+	}
+	if s.Role != spectypes.BNRoleAttester {
+		return nil
+	}
+	if s.Data.Slot != slot {
+		return fmt.Errorf("submitted an attestation of slot %d", s.Data.Slot)
+	}
+	if *s.Data.Source != *vote.Source || *s.Data.Target != *vote.Target {
+		return fmt.Errorf("submitted an attestation with checkpoints that weren't decided")
+	}
+	return nil
+}
+
+// Live returns true if all the honest operators that were up during the given slot decided it
+// and submitted all of its duties before the slot ended
+func (r *Report) Live(slot phase0.Slot) bool {
+	deadline := time.Duration(slot+1) * r.slotDuration // #nosec G115
+	for _, operator := range r.operators {
+		if !r.faults.honest(operator) || r.faults.crashed(operator, slot) {
+			continue
+		}
+		d, ok := r.Decisions[slot][operator]
+		if !ok || d.At >= deadline {
+			return false
+		}
+		for _, role := range r.roles(slot) {
+			s, ok := r.Submitted(slot, operator, role)
+			if !ok || s.At >= deadline {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Liveness returns the ratio of live slots in [from, to]
+func (r *Report) Liveness(from, to phase0.Slot) float64 {
+	from, to = max(from, 1), min(to, r.slots)
+	if to < from {
+		return 0
+	}
+	live := 0
+	for slot := from; slot <= to; slot++ {
+		if r.Live(slot) {
+			live++
+		}
+	}
+	return float64(live) / float64(to-from+1)
+}
+
+// DecidedRounds returns how many slots were decided in each round by the given operator
+func (r *Report) DecidedRounds(operator spectypes.OperatorID) map[specqbft.Round]int {
+	rounds := make(map[specqbft.Round]int)
+	for _, decisions := range r.Decisions {
+		if d, ok := decisions[operator]; ok {
+			rounds[d.Round]++
+		}
+	}
+	return rounds
+}
+
+// MeanDecisionTime returns the mean time from the start of the slot to the decision of the honest operators in slots [from, to]
+func (r *Report) MeanDecisionTime(from, to phase0.Slot) time.Duration {
+	var total time.Duration
+	count := 0
+	for slot := max(from, 1); slot <= min(to, r.slots); slot++ {
+		slotStart := time.Duration(slot) * r.slotDuration // #nosec G115
+		for _, operator := range r.operators {
+			d, ok := r.Decisions[slot][operator]
+			if !ok || !r.faults.honest(operator) {
+				continue
+			}
+			total += d.At - slotStart
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
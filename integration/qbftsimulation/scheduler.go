@@ -0,0 +1,80 @@
+package qbftsimulation
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// event is a callback that runs at a point in virtual time
+type event struct {
+	at  time.Duration
+	seq uint64
+	run func()
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at == q[j].at {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at < q[j].at
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x any) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
+
+// scheduler runs events in virtual time, starting from genesis (zero).
+// events that are scheduled for the same time run in the order they were scheduled,
+// which together with a seeded randomness makes a simulation deterministic.
+type scheduler struct {
+	now    time.Duration
+	seq    uint64
+	events eventQueue
+}
+
+// Now returns the current virtual time
+func (s *scheduler) Now() time.Duration {
+	return s.now
+}
+
+// At schedules the given callback at the given virtual time, past times are scheduled for now
+func (s *scheduler) At(at time.Duration, run func()) {
+	if at < s.now {
+		at = s.now
+	}
+	s.seq++
+	heap.Push(&s.events, &event{at: at, seq: s.seq, run: run})
+}
+
+// After schedules the given callback after the given duration
+func (s *scheduler) After(d time.Duration, run func()) {
+	s.At(s.now+d, run)
+}
+
+// RunUntil runs all the events that are scheduled up to the given virtual time
+func (s *scheduler) RunUntil(ctx context.Context, until time.Duration) error {
+	for s.events.Len() > 0 && s.events[0].at <= until {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e := heap.Pop(&s.events).(*event)
+		s.now = e.at
+		e.run()
+	}
+	s.now = until
+	return nil
+}
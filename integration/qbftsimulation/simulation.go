@@ -0,0 +1,227 @@
+// Package qbftsimulation runs the operator stacks of a committee in a single process over a virtual network
+// and a virtual clock, injecting network faults (latency, loss, partitions), clock skew, crashes, Byzantine
+// operators and beacon node faults, and checks the safety and liveness of the committee's duties over hundreds
+// of slots in a few seconds.
+//
+// Every operator runs a validator.Committee with real committee runners, validates the messages it receives
+// with the message validation of the p2p layer and fetches its duties from its own fakebeacon.Node,
+// which its attestations and sync committee messages are submitted to.
+// Libp2p itself and the duty scheduler aren't simulated, messages are delivered to the committee's operators
+// as pubsub messages of the committee's topic.
+package qbftsimulation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
+	"github.com/ssvlabs/ssv/utils/casts"
+)
+
+// genesis is the time of the simulated beacon chain's genesis, virtual time zero
+var genesis = time.Unix(1700000000, 0)
+
+// Config is the configuration of a simulation
+type Config struct {
+	// Committee is the number of operators, one of 4, 7, 10 or 13
+	Committee int
+	// Slots is the number of slots to simulate, starting from slot 1
+	Slots phase0.Slot
+	// SlotDuration defaults to 12 seconds, it must be a whole number of seconds
+	SlotDuration time.Duration
+	// SlotsPerEpoch defaults to 32
+	SlotsPerEpoch uint64
+	// AdaptiveTimeouts enables adaptive quick-round timeouts from the given epoch if set
+	AdaptiveTimeouts *AdaptiveTimeouts
+	// Seed makes the simulation deterministic
+	Seed   int64
+	Faults Faults
+}
+
+// AdaptiveTimeouts configures the adaptive quick-round timeouts of the operators
+type AdaptiveTimeouts struct {
+	Config roundtimer.AdaptiveTimeoutConfig
+	// ForkEpoch is the epoch from which the timeouts are tuned
+	ForkEpoch phase0.Epoch
+}
+
+// Simulation runs a committee of operators over a virtual network and a virtual clock.
+// the committee has a single validator with a committee duty in every slot (see duties.go),
+// and faults are injected by the virtual network, the operators' clocks and their beacon nodes.
+type Simulation struct {
+	cfg        Config
+	logger     *zap.Logger
+	ctx        context.Context
+	sched      *scheduler
+	network    *virtualNetwork
+	netCfg     networkconfig.NetworkConfig
+	keySet     *spectestingutils.TestKeySet
+	nodes      []*node
+	identifier spectypes.MessageID
+	report     *Report
+}
+
+// New creates a new simulation
+func New(logger *zap.Logger, cfg Config) (*Simulation, error) {
+	keySet, err := committeeKeySet(cfg.Committee)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Slots == 0 {
+		return nil, fmt.Errorf("no slots to simulate")
+	}
+	if cfg.SlotDuration == 0 {
+		cfg.SlotDuration = 12 * time.Second
+	}
+	if cfg.SlotDuration%time.Second != 0 {
+		return nil, fmt.Errorf("slot duration %s isn't a whole number of seconds", cfg.SlotDuration)
+	}
+	if cfg.SlotsPerEpoch == 0 {
+		cfg.SlotsPerEpoch = 32
+	}
+	if err := cfg.Faults.validate(); err != nil {
+		return nil, err
+	}
+
+	sim := &Simulation{
+		cfg:    cfg,
+		logger: logger,
+		sched:  &scheduler{},
+		keySet: keySet,
+	}
+	sim.network = newVirtualNetwork(sim, cfg.Seed)
+	sim.netCfg = networkconfig.NetworkConfig{
+		Name: "simulation",
+		Beacon: beacon.NewCustomNetwork(spectypes.BeaconTestNetwork, beacon.Params{
+			GenesisForkVersion:           spectypes.GenesisForkVersion,
+			GenesisTime:                  genesis.Unix(),
+			SlotDuration:                 cfg.SlotDuration,
+			SlotsPerEpoch:                cfg.SlotsPerEpoch,
+			EpochsPerSyncCommitteePeriod: syncCommitteePeriodEpochs,
+		}),
+		DomainType: spectestingutils.TestingSSVDomainType,
+	}
+
+	base := spectestingutils.TestingCommitteeMember(keySet)
+	sim.identifier = spectypes.NewMsgID(sim.netCfg.DomainType, base.CommitteeID[:], spectypes.RoleCommittee)
+	for _, operator := range base.Committee {
+		member := *base
+		member.OperatorID = operator.OperatorID
+		member.SSVOperatorPubKey = operator.SSVOperatorPubKey
+		n, err := newNode(sim, &member)
+		if err != nil {
+			return nil, fmt.Errorf("could not create operator %d: %w", operator.OperatorID, err)
+		}
+		sim.nodes = append(sim.nodes, n)
+	}
+	sim.report = newReport(sim)
+	return sim, nil
+}
+
+// Run runs the simulation until all the slots are over and returns its report
+func (sim *Simulation) Run(ctx context.Context) (*Report, error) {
+	sim.ctx = ctx
+	for _, n := range sim.nodes {
+		n.start()
+	}
+	sim.scheduleCrashes()
+	sim.scheduleDuties()
+
+	// the last slot is given an extra slot to finish
+	err := sim.sched.RunUntil(ctx, sim.slotStart(sim.cfg.Slots+2))
+	for _, n := range sim.nodes {
+		n.stop()
+	}
+	if err != nil {
+		return nil, err
+	}
+	sim.report.Network = sim.network.stats
+
+	sim.logger.Info("simulation finished",
+		zap.Uint64("slots", uint64(sim.cfg.Slots)),
+		zap.Int("committee", len(sim.nodes)),
+		zap.Uint64("messages_sent", sim.network.stats.Sent),
+		zap.Uint64("messages_delivered", sim.network.stats.Delivered),
+		zap.Uint64("messages_invalid", sim.network.stats.Invalid))
+	return sim.report, nil
+}
+
+func (sim *Simulation) scheduleCrashes() {
+	for _, c := range sim.cfg.Faults.Crashes {
+		n := sim.node(c.Operator)
+		if n == nil {
+			continue
+		}
+		sim.sched.At(sim.slotStart(c.From), n.stop)
+		if c.To != 0 {
+			sim.sched.At(sim.slotStart(c.To), n.start)
+		}
+	}
+}
+
+// scheduleDuties executes the duty of every slot a third into the slot, like the duty scheduler
+// executes committee duties, according to the clock of each operator.
+func (sim *Simulation) scheduleDuties() {
+	for slot := phase0.Slot(1); slot <= sim.cfg.Slots; slot++ {
+		for _, n := range sim.nodes {
+			n, slot := n, slot
+			sim.sched.At(n.virtualTime(sim.slotStart(slot)+sim.dutyStart()), func() {
+				n.executeDuty(slot)
+			})
+		}
+	}
+}
+
+func (sim *Simulation) node(id spectypes.OperatorID) *node {
+	for _, n := range sim.nodes {
+		if n.id == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// slotStart returns the start of the given slot, genesis is at zero
+func (sim *Simulation) slotStart(slot phase0.Slot) time.Duration {
+	return casts.DurationFromUint64(uint64(slot)) * sim.cfg.SlotDuration
+}
+
+// slotAt returns the slot at the given time
+func (sim *Simulation) slotAt(at time.Duration) phase0.Slot {
+	if at < 0 {
+		return 0
+	}
+	return phase0.Slot(at / sim.cfg.SlotDuration) // #nosec G115
+}
+
+func (sim *Simulation) currentSlot() phase0.Slot {
+	return sim.slotAt(sim.sched.Now())
+}
+
+// dutyStart returns the offset from the start of the slot at which duties start
+func (sim *Simulation) dutyStart() time.Duration {
+	return sim.cfg.SlotDuration / 3
+}
+
+func committeeKeySet(size int) (*spectestingutils.TestKeySet, error) {
+	switch size {
+	case 4:
+		return spectestingutils.Testing4SharesSet(), nil
+	case 7:
+		return spectestingutils.Testing7SharesSet(), nil
+	case 10:
+		return spectestingutils.Testing10SharesSet(), nil
+	case 13:
+		return spectestingutils.Testing13SharesSet(), nil
+	default:
+		return nil, fmt.Errorf("unsupported committee size %d", size)
+	}
+}
@@ -0,0 +1,169 @@
+package qbftsimulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+)
+
+func runSimulation(t *testing.T, cfg Config) *Report {
+	sim, err := New(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	report, err := sim.Run(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, report.CheckSafety())
+	return report
+}
+
+func TestSimulation_NoFaults(t *testing.T) {
+	report := runSimulation(t, Config{
+		Committee: 4,
+		Slots:     200,
+		Seed:      1,
+		Faults: Faults{
+			Link: Link{Latency: 50 * time.Millisecond, Jitter: 100 * time.Millisecond},
+		},
+	})
+	require.Equal(t, 1.0, report.Liveness(1, 200))
+	require.Equal(t, map[specqbft.Round]int{specqbft.FirstRound: 200}, report.DecidedRounds(1))
+	require.Zero(t, report.Network.Lost)
+}
+
+func TestSimulation_LatencyAndLoss(t *testing.T) {
+	report := runSimulation(t, Config{
+		Committee: 7,
+		Slots:     200,
+		Seed:      2,
+		Faults: Faults{
+			Link: Link{Latency: 100 * time.Millisecond, Jitter: 300 * time.Millisecond, Loss: 0.05},
+			OperatorLinks: map[spectypes.OperatorID]Link{
+				3: {Latency: 500 * time.Millisecond, Jitter: 500 * time.Millisecond},
+			},
+		},
+	})
+	require.NotZero(t, report.Network.Lost)
+	require.GreaterOrEqual(t, report.Liveness(1, 200), 0.9)
+}
+
+func TestSimulation_Partition(t *testing.T) {
+	report := runSimulation(t, Config{
+		Committee: 4,
+		Slots:     120,
+		Seed:      3,
+		Faults: Faults{
+			Link: Link{Latency: 100 * time.Millisecond},
+			Partitions: []Partition{
+				{From: 40, To: 80, Groups: [][]spectypes.OperatorID{{1, 2}, {3, 4}}},
+			},
+		},
+	})
+	require.NotZero(t, report.Network.Blocked)
+	// no group has a quorum during the partition
+	require.Zero(t, report.Liveness(41, 79))
+	require.Equal(t, 1.0, report.Liveness(1, 39))
+	require.Equal(t, 1.0, report.Liveness(81, 120))
+}
+
+func TestSimulation_ClockSkew(t *testing.T) {
+	report := runSimulation(t, Config{
+		Committee: 4,
+		Slots:     100,
+		Seed:      4,
+		Faults: Faults{
+			Link: Link{Latency: 100 * time.Millisecond},
+			ClockSkew: map[spectypes.OperatorID]time.Duration{
+				2: 1500 * time.Millisecond,
+				4: -1500 * time.Millisecond,
+			},
+		},
+	})
+	require.GreaterOrEqual(t, report.Liveness(1, 100), 0.95)
+}
+
+func TestSimulation_Crash(t *testing.T) {
+	report := runSimulation(t, Config{
+		Committee: 4,
+		Slots:     150,
+		Seed:      5,
+		Faults: Faults{
+			Link: Link{Latency: 100 * time.Millisecond},
+			Crashes: []Crash{
+				{Operator: 2, From: 30, To: 60},
+				{Operator: 3, From: 100},
+			},
+		},
+	})
+	require.Equal(t, 1.0, report.Liveness(1, 150))
+	// slots with a crashed leader are decided in a later round
+	require.NotZero(t, report.DecidedRounds(1)[specqbft.FirstRound+1])
+
+	for slot := range report.Decisions {
+		_, decided := report.Decisions[slot][3]
+		require.False(t, decided && slot >= 100)
+	}
+}
+
+func TestSimulation_Byzantine(t *testing.T) {
+	for _, behavior := range []ByzantineBehavior{Equivocate, Mute} {
+		t.Run(behavior.String(), func(t *testing.T) {
+			report := runSimulation(t, Config{
+				Committee: 4,
+				Slots:     200,
+				Seed:      6,
+				Faults: Faults{
+					Link:      Link{Latency: 100 * time.Millisecond, Jitter: 200 * time.Millisecond},
+					Byzantine: map[spectypes.OperatorID]ByzantineBehavior{1: behavior},
+				},
+			})
+			require.GreaterOrEqual(t, report.Liveness(1, 200), 0.95)
+			if behavior == Equivocate {
+				require.NotZero(t, report.Network.Equivocated)
+			}
+		})
+	}
+}
+
+func TestSimulation_DivergentValues(t *testing.T) {
+	// the beacon nodes of operators 2 and 3 follow different heads than the rest of the committee
+	inconsistent := map[fakebeacon.Endpoint]fakebeacon.Fault{
+		fakebeacon.EndpointAttestationData: {Inconsistent: true},
+	}
+	report := runSimulation(t, Config{
+		Committee: 4,
+		Slots:     100,
+		Seed:      7,
+		Faults: Faults{
+			Link:   Link{Latency: 100 * time.Millisecond},
+			Beacon: map[spectypes.OperatorID]map[fakebeacon.Endpoint]fakebeacon.Fault{2: inconsistent, 3: inconsistent},
+		},
+	})
+	require.Equal(t, 1.0, report.Liveness(1, 100))
+}
+
+func TestSimulation_Deterministic(t *testing.T) {
+	cfg := Config{
+		Committee: 4,
+		Slots:     50,
+		Seed:      8,
+		Faults: Faults{
+			Link:      Link{Latency: 100 * time.Millisecond, Jitter: time.Second, Loss: 0.1},
+			Byzantine: map[spectypes.OperatorID]ByzantineBehavior{4: Equivocate},
+		},
+	}
+	first := runSimulation(t, cfg)
+	second := runSimulation(t, cfg)
+	require.Equal(t, first.Network, second.Network)
+	for slot, decisions := range first.Decisions {
+		for operator, d := range decisions {
+			require.Equal(t, d.Round, second.Decisions[slot][operator].Round)
+			require.Equal(t, d.At, second.Decisions[slot][operator].At)
+		}
+	}
+}
@@ -48,9 +48,9 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	levelEncoder := parseConfigLevelEncoder(levelEncoderName)
 
-	lv := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
-	})
+	// The levels of the registry filter the logs, so the core itself logs all levels.
+	levels.SetDefaultLevel(level)
+	lv := zapcore.DebugLevel
 
 	cfg := zap.Config{
 		Encoding:    logFormat,
@@ -80,6 +80,8 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, lv)
 	}
 
+	usedcore = newSamplingCore(newLevelCore(usedcore, levels), levels)
+
 	if fileOptions == nil {
 		zap.ReplaceGlobals(zap.New(usedcore))
 		return nil
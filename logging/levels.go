@@ -0,0 +1,352 @@
+package logging
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+var levels = NewLevelRegistry(zapcore.InfoLevel)
+
+// Levels returns the log level registry of the global logger.
+func Levels() *LevelRegistry {
+	return levels
+}
+
+// LevelRegistry holds the log levels of the global logger, which can be changed at runtime:
+// a default level, levels of components overriding it, and validators whose debug logs are enabled
+// regardless of the level of the component logging them.
+//
+// Components are the names of named loggers (see names.go). A logger named by several components,
+// such as "Operator.P2PNetwork", logs at the level of its innermost component with a level.
+type LevelRegistry struct {
+	mu              sync.RWMutex
+	defaultLevel    zapcore.Level
+	components      map[string]zapcore.Level
+	debugValidators map[string]struct{}
+
+	// minLevel is the lowest level any logger may log at, checked on every log call.
+	minLevel atomic.Int32
+	// hasDebugValidators is whether any validator has its debug logs enabled.
+	hasDebugValidators atomic.Bool
+	// loggerLevels caches the level of each logger name, so that log calls neither lock nor parse the name.
+	// It's replaced whenever a level changes.
+	loggerLevels atomic.Pointer[sync.Map]
+
+	samplingInitial    atomic.Uint64
+	samplingThereafter atomic.Uint64
+}
+
+// LevelsSnapshot is the state of a LevelRegistry.
+type LevelsSnapshot struct {
+	Default         string            `json:"default"`
+	Components      map[string]string `json:"components"`
+	DebugValidators []string          `json:"debug_validators"`
+}
+
+func NewLevelRegistry(defaultLevel zapcore.Level) *LevelRegistry {
+	r := &LevelRegistry{
+		defaultLevel:    defaultLevel,
+		components:      make(map[string]zapcore.Level),
+		debugValidators: make(map[string]struct{}),
+	}
+	r.updateMinLevel()
+	return r
+}
+
+// SetDefaultLevel sets the level of components without a level of their own.
+func (r *LevelRegistry) SetDefaultLevel(level zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.defaultLevel = level
+	r.updateMinLevel()
+}
+
+// SetLevel sets the level of a component.
+func (r *LevelRegistry) SetLevel(component string, level zapcore.Level) error {
+	if component == "" || strings.Contains(component, ".") {
+		return fmt.Errorf("invalid component %q", component)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.components[component] = level
+	r.updateMinLevel()
+	return nil
+}
+
+// UnsetLevel resets the level of a component to the default level.
+func (r *LevelRegistry) UnsetLevel(component string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.components, component)
+	r.updateMinLevel()
+}
+
+// SetLevels parses and sets the levels of components, such as {"P2PNetwork": "debug"}.
+func (r *LevelRegistry) SetLevels(componentLevels map[string]string) error {
+	for component, levelName := range componentLevels {
+		level, err := zapcore.ParseLevel(levelName)
+		if err != nil {
+			return fmt.Errorf("invalid level of component %s: %w", component, err)
+		}
+		if err := r.SetLevel(component, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetValidatorDebug enables or disables the debug logs of a validator by its hex encoded public key.
+func (r *LevelRegistry) SetValidatorDebug(pubKey string, enabled bool) {
+	pubKey = normalizePubKey(pubKey)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if enabled {
+		r.debugValidators[pubKey] = struct{}{}
+	} else {
+		delete(r.debugValidators, pubKey)
+	}
+	r.updateMinLevel()
+}
+
+// SetDebugSampling samples the debug logs of each message to the first initial logs per second
+// and every thereafter-th log after that. Sampling is disabled if initial is 0.
+func (r *LevelRegistry) SetDebugSampling(initial, thereafter uint64) {
+	if thereafter == 0 {
+		thereafter = 1
+	}
+	r.samplingThereafter.Store(thereafter)
+	r.samplingInitial.Store(initial)
+}
+
+// Level returns the level a logger of the given name logs at.
+func (r *LevelRegistry) Level(loggerName string) zapcore.Level {
+	loggerLevels := r.loggerLevels.Load()
+	if level, ok := loggerLevels.Load(loggerName); ok {
+		return level.(zapcore.Level)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	level := r.resolveLevel(loggerName)
+	// A level set since loggerLevels was loaded replaced it, so a stale level is never cached.
+	loggerLevels.Store(loggerName, level)
+	return level
+}
+
+// resolveLevel must be called with mu locked.
+func (r *LevelRegistry) resolveLevel(loggerName string) zapcore.Level {
+	if len(r.components) > 0 {
+		components := strings.Split(loggerName, ".")
+		for i := len(components) - 1; i >= 0; i-- {
+			if level, ok := r.components[components[i]]; ok {
+				return level
+			}
+		}
+	}
+	return r.defaultLevel
+}
+
+// Snapshot returns the state of the registry.
+func (r *LevelRegistry) Snapshot() LevelsSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := LevelsSnapshot{
+		Default:         r.defaultLevel.String(),
+		Components:      make(map[string]string, len(r.components)),
+		DebugValidators: make([]string, 0, len(r.debugValidators)),
+	}
+	for component, level := range r.components {
+		snapshot.Components[component] = level.String()
+	}
+	for pubKey := range r.debugValidators {
+		snapshot.DebugValidators = append(snapshot.DebugValidators, pubKey)
+	}
+	sort.Strings(snapshot.DebugValidators)
+	return snapshot
+}
+
+func (r *LevelRegistry) enabled(level zapcore.Level) bool {
+	return level >= zapcore.Level(r.minLevel.Load())
+}
+
+func (r *LevelRegistry) debugValidator(pubKey string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.debugValidators[pubKey]
+	return ok
+}
+
+// updateMinLevel must be called with mu locked.
+func (r *LevelRegistry) updateMinLevel() {
+	r.loggerLevels.Store(&sync.Map{})
+	r.hasDebugValidators.Store(len(r.debugValidators) > 0)
+
+	minLevel := r.defaultLevel
+	for _, level := range r.components {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	if len(r.debugValidators) > 0 && zapcore.DebugLevel < minLevel {
+		minLevel = zapcore.DebugLevel
+	}
+	r.minLevel.Store(int32(minLevel))
+}
+
+func normalizePubKey(pubKey string) string {
+	return strings.ToLower(strings.TrimPrefix(pubKey, "0x"))
+}
+
+// levelCore filters the entries of a core by the levels of the registry.
+type levelCore struct {
+	zapcore.Core
+	registry *LevelRegistry
+	// pubKeys are the validator public keys in the fields of the logger.
+	pubKeys []string
+}
+
+func newLevelCore(core zapcore.Core, registry *LevelRegistry) zapcore.Core {
+	return &levelCore{Core: core, registry: registry}
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.registry.enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{
+		Core:     c.Core.With(fields),
+		registry: c.registry,
+		pubKeys:  append(c.pubKeys[:len(c.pubKeys):len(c.pubKeys)], validatorPubKeys(fields)...),
+	}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level >= c.registry.Level(entry.LoggerName) {
+		return c.Core.Check(entry, checked)
+	}
+	if entry.Level != zapcore.DebugLevel || !c.registry.hasDebugValidators.Load() {
+		return checked
+	}
+	for _, pubKey := range c.pubKeys {
+		if c.registry.debugValidator(pubKey) {
+			return c.Core.Check(entry, checked)
+		}
+	}
+	// The validator may be in the fields of the entry, which are only known when it's written.
+	return checked.AddCore(entry, c)
+}
+
+func (c *levelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	for _, pubKey := range validatorPubKeys(fields) {
+		if c.registry.debugValidator(pubKey) {
+			return c.Core.Write(entry, fields)
+		}
+	}
+	return nil
+}
+
+// validatorPubKeys returns the validator public keys in fields.
+func validatorPubKeys(zapFields []zapcore.Field) []string {
+	var pubKeys []string
+	for _, field := range zapFields {
+		if field.Key != fields.FieldPubKey && field.Key != fields.FieldValidator {
+			continue
+		}
+		switch {
+		case field.Type == zapcore.StringType:
+			pubKeys = append(pubKeys, normalizePubKey(field.String))
+		case field.Type == zapcore.StringerType && field.Interface != nil:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				pubKeys = append(pubKeys, normalizePubKey(stringer.String()))
+			}
+		}
+	}
+	return pubKeys
+}
+
+const (
+	samplingTick     = time.Second
+	samplingCounters = 4096
+)
+
+// samplingCore samples debug entries by their logger name and message, as configured in the registry.
+type samplingCore struct {
+	zapcore.Core
+	registry *LevelRegistry
+	counters *[samplingCounters]samplingCounter
+}
+
+type samplingCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+func newSamplingCore(core zapcore.Core, registry *LevelRegistry) zapcore.Core {
+	return &samplingCore{
+		Core:     core,
+		registry: registry,
+		counters: &[samplingCounters]samplingCounter{},
+	}
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{
+		Core:     c.Core.With(fields),
+		registry: c.registry,
+		counters: c.counters,
+	}
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level != zapcore.DebugLevel {
+		return c.Core.Check(entry, checked)
+	}
+	initial := c.registry.samplingInitial.Load()
+	if initial == 0 {
+		return c.Core.Check(entry, checked)
+	}
+
+	n := c.counter(entry).inc(entry.Time)
+	if n > initial && (n-initial)%c.registry.samplingThereafter.Load() != 0 {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+func (c *samplingCore) counter(entry zapcore.Entry) *samplingCounter {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(entry.LoggerName))
+	_, _ = h.Write([]byte(entry.Message))
+	return &c.counters[h.Sum32()%samplingCounters]
+}
+
+func (c *samplingCounter) inc(t time.Time) uint64 {
+	now := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if now > resetAt {
+		if c.resetAt.CompareAndSwap(resetAt, now+samplingTick.Nanoseconds()) {
+			c.count.Store(1)
+			return 1
+		}
+	}
+	return c.count.Add(1)
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+func TestLevelRegistry(t *testing.T) {
+	registry := NewLevelRegistry(zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(newLevelCore(core, registry))
+
+	p2pLogger := logger.Named(NameOperator).Named(NameP2PNetwork)
+	eventLogger := logger.Named(NameOperator).Named(NameEventHandler)
+
+	p2pLogger.Debug("p2p debug")
+	eventLogger.Info("event info")
+	require.Equal(t, 1, logs.Len())
+
+	require.NoError(t, registry.SetLevels(map[string]string{NameP2PNetwork: "debug", NameOperator: "warn"}))
+	p2pLogger.Debug("p2p debug")
+	eventLogger.Info("event info")
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, "p2p debug", logs.All()[1].Message)
+
+	registry.UnsetLevel(NameOperator)
+	eventLogger.Info("event info")
+	require.Equal(t, 3, logs.Len())
+
+	require.Error(t, registry.SetLevels(map[string]string{NameP2PNetwork: "loud"}))
+	require.Error(t, registry.SetLevel("Operator.P2PNetwork", zapcore.DebugLevel))
+
+	require.Equal(t, LevelsSnapshot{
+		Default:         "info",
+		Components:      map[string]string{NameP2PNetwork: "debug"},
+		DebugValidators: []string{},
+	}, registry.Snapshot())
+}
+
+func TestLevelRegistry_ValidatorDebug(t *testing.T) {
+	registry := NewLevelRegistry(zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(newLevelCore(core, registry)).Named(NameValidator)
+
+	pubKey := make([]byte, 48)
+	pubKey[0] = 0xab
+	otherPubKey := make([]byte, 48)
+
+	logger.Debug("validator debug", fields.PubKey(pubKey))
+	require.Equal(t, 0, logs.Len())
+
+	registry.SetValidatorDebug("0x"+hex.EncodeToString(pubKey), true)
+
+	// The validator is in the fields of the entry.
+	logger.Debug("validator debug", fields.PubKey(pubKey))
+	logger.Debug("other validator debug", fields.PubKey(otherPubKey))
+	logger.Debug("no validator debug")
+	require.Equal(t, 1, logs.Len())
+
+	// The validator is in the fields of the logger.
+	validatorLogger := logger.With(fields.Validator(pubKey))
+	validatorLogger.Debug("validator logger debug")
+	logger.With(fields.Validator(otherPubKey)).Debug("other validator logger debug")
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, "validator logger debug", logs.All()[1].Message)
+
+	require.Equal(t, []string{hex.EncodeToString(pubKey)}, registry.Snapshot().DebugValidators)
+
+	registry.SetValidatorDebug(hex.EncodeToString(pubKey), false)
+	validatorLogger.Debug("validator logger debug")
+	require.Equal(t, 2, logs.Len())
+}
+
+func TestSamplingCore(t *testing.T) {
+	registry := NewLevelRegistry(zapcore.DebugLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(newSamplingCore(core, registry))
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("hot path")
+	}
+	require.Equal(t, 10, logs.Len())
+
+	registry.SetDebugSampling(2, 3)
+	logs.TakeAll()
+	for i := 0; i < 11; i++ {
+		logger.Debug("hot path")
+		logger.Info("not sampled")
+	}
+	// The first 2 debug logs and every 3rd one after: the 5th, 8th and 11th.
+	require.Equal(t, 5, logs.FilterMessage("hot path").Len())
+	require.Equal(t, 11, logs.FilterMessage("not sampled").Len())
+}
+
+func TestSetGlobalLogger_FileLevels(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "node.log")
+	require.NoError(t, SetGlobalLogger("info", "capital", "json", &LogFileOptions{FileName: fileName, MaxSize: 1}))
+	t.Cleanup(func() { levels.SetDebugSampling(0, 0) })
+
+	levels.SetDebugSampling(1, 100)
+	logger := zap.L().Named(NameOperator)
+	logger.Debug("operator debug")
+	logger.Debug("operator debug")
+	logger.Info("operator info")
+
+	// The file is the debug log, which neither the levels of the registry nor sampling filter.
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(data), "operator debug"))
+	require.Contains(t, string(data), "operator info")
+}
+
+func BenchmarkLevelRegistry_Level(b *testing.B) {
+	registry := NewLevelRegistry(zapcore.InfoLevel)
+	require.NoError(b, registry.SetLevels(map[string]string{NameP2PNetwork: "debug"}))
+	loggerName := NameOperator + "." + NameP2PNetwork + "." + NameValidator
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if registry.Level(loggerName) != zapcore.DebugLevel {
+				b.Fatal("unexpected level")
+			}
+		}
+	})
+}
@@ -19,9 +19,13 @@ const (
 	NameCreateThreshold   = "CreateThreshold"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
+	NameRecovery          = "Recovery"
 	NameP2PStorage        = "P2PStorage"
 	NamePubsubTrace       = "PubsubTrace"
 	NameScoreInspector    = "ScoreInspector"
 	NameEventHandler      = "EventHandler"
 	NameDutyFetcher       = "DutyFetcher"
+
+	NameDecidedHistorySyncer = "DecidedHistorySyncer"
+	NameMessageValidation    = "MessageValidation"
 )
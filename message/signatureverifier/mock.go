@@ -10,6 +10,7 @@
 package signatureverifier
 
 import (
+	context "context"
 	reflect "reflect"
 
 	types "github.com/ssvlabs/ssv-spec/types"
@@ -41,18 +42,32 @@ func (m *MockSignatureVerifier) EXPECT() *MockSignatureVerifierMockRecorder {
 	return m.recorder
 }
 
+// Verify mocks base method.
+func (m *MockSignatureVerifier) Verify(ctx context.Context, msg *types.SignedSSVMessage, operators []*types.Operator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, msg, operators)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockSignatureVerifierMockRecorder) Verify(ctx, msg, operators any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSignatureVerifier)(nil).Verify), ctx, msg, operators)
+}
+
 // VerifySignature mocks base method.
-func (m *MockSignatureVerifier) VerifySignature(operatorID types.OperatorID, message *types.SSVMessage, signature []byte) error {
+func (m *MockSignatureVerifier) VerifySignature(ctx context.Context, operatorID types.OperatorID, message *types.SSVMessage, signature []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifySignature", operatorID, message, signature)
+	ret := m.ctrl.Call(m, "VerifySignature", ctx, operatorID, message, signature)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // VerifySignature indicates an expected call of VerifySignature.
-func (mr *MockSignatureVerifierMockRecorder) VerifySignature(operatorID, message, signature any) *gomock.Call {
+func (mr *MockSignatureVerifierMockRecorder) VerifySignature(ctx, operatorID, message, signature any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySignature", reflect.TypeOf((*MockSignatureVerifier)(nil).VerifySignature), operatorID, message, signature)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySignature", reflect.TypeOf((*MockSignatureVerifier)(nil).VerifySignature), ctx, operatorID, message, signature)
 }
 
 // MockOperatorStore is a mock of OperatorStore interface.
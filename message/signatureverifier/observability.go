@@ -0,0 +1,79 @@
+package signatureverifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/message/signatureverifier"
+	observabilityNamespace = "ssv.signature_verifier"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	verificationsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("verifications"),
+			metric.WithUnit("{signature}"),
+			metric.WithDescription("total number of operator signatures verified")))
+
+	cacheHitsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("cache.hits"),
+			metric.WithUnit("{signature}"),
+			metric.WithDescription("total number of operator signatures that were already verified")))
+
+	queueDurationHistogram = observability.NewMetric(
+		meter.Float64Histogram(
+			metricName("queue.duration"),
+			metric.WithUnit("s"),
+			metric.WithDescription("time spent waiting for a verification worker"),
+			metric.WithExplicitBucketBoundaries(observability.SecondsHistogramBuckets...)))
+
+	verificationDurationHistogram = observability.NewMetric(
+		meter.Float64Histogram(
+			metricName("duration"),
+			metric.WithUnit("s"),
+			metric.WithDescription("operator signature verification duration"),
+			metric.WithExplicitBucketBoundaries(observability.SecondsHistogramBuckets...)))
+
+	inFlightCounter = observability.NewMetric(
+		meter.Int64UpDownCounter(
+			metricName("in_flight"),
+			metric.WithUnit("{signature}"),
+			metric.WithDescription("number of operator signatures being verified")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
+func validAttribute(valid bool) attribute.KeyValue {
+	return attribute.Bool("ssv.signature_verifier.valid", valid)
+}
+
+func recordVerification(ctx context.Context, valid bool, duration time.Duration) {
+	verificationsCounter.Add(ctx, 1, metric.WithAttributes(validAttribute(valid)))
+	verificationDurationHistogram.Record(ctx, duration.Seconds())
+}
+
+func recordCacheHit(ctx context.Context) {
+	cacheHitsCounter.Add(ctx, 1)
+}
+
+func recordQueueDuration(ctx context.Context, duration time.Duration) {
+	queueDurationHistogram.Record(ctx, duration.Seconds())
+}
+
+func recordInFlight(ctx context.Context, delta int64) {
+	inFlightCounter.Add(ctx, delta)
+}
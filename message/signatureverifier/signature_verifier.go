@@ -1,8 +1,13 @@
 package signatureverifier
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"runtime"
 	"sync"
+	"time"
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
@@ -14,57 +19,197 @@ import (
 //go:generate mockgen -package=signatureverifier -destination=./mock.go -source=./signature_verifier.go
 
 type SignatureVerifier interface {
-	VerifySignature(operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error
+	VerifySignature(ctx context.Context, operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error
+	// Verify verifies all signatures of the given message with the public keys of the given operators
+	Verify(ctx context.Context, msg *spectypes.SignedSSVMessage, operators []*spectypes.Operator) error
 }
 
 type OperatorStore interface {
 	GetOperatorData(r basedb.Reader, id spectypes.OperatorID) (*registrystorage.OperatorData, bool, error)
 }
 
+// Option represents a functional option for configuring a signatureVerifier.
+type Option func(sv *signatureVerifier)
+
+// WithVerifiedCache sets the cache of verified signatures, allowing to share it between verifiers.
+func WithVerifiedCache(cache *VerifiedCache) Option {
+	return func(sv *signatureVerifier) {
+		sv.verified = cache
+	}
+}
+
+// WithWorkers sets the maximum number of signatures verified concurrently.
+func WithWorkers(workers int) Option {
+	return func(sv *signatureVerifier) {
+		if workers > 0 {
+			sv.workers = make(chan struct{}, workers)
+		}
+	}
+}
+
 type signatureVerifier struct {
-	operatorIDToPubkeyCache   map[spectypes.OperatorID]keys.OperatorPublicKey
-	operatorIDToPubkeyCacheMu sync.Mutex
-	operatorStore             OperatorStore
+	// pubKeyCache holds the parsed public keys by their PEM encoding rather than by operator ID,
+	// so a changed or removed operator key is never verified against.
+	pubKeyCache   map[string]keys.OperatorPublicKey
+	pubKeyCacheMu sync.Mutex
+	operatorStore OperatorStore
+
+	verified *VerifiedCache
+	// workers bounds the number of concurrent RSA verifications
+	workers chan struct{}
 }
 
-func NewSignatureVerifier(operatorStore OperatorStore) SignatureVerifier {
-	return &signatureVerifier{
-		operatorIDToPubkeyCache: make(map[spectypes.OperatorID]keys.OperatorPublicKey),
-		operatorStore:           operatorStore,
+func NewSignatureVerifier(operatorStore OperatorStore, opts ...Option) SignatureVerifier {
+	sv := &signatureVerifier{
+		pubKeyCache:   make(map[string]keys.OperatorPublicKey),
+		operatorStore: operatorStore,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if sv.verified == nil {
+		sv.verified = NewVerifiedCache(DefaultVerifiedCacheSize)
+	}
+	if sv.workers == nil {
+		sv.workers = make(chan struct{}, runtime.NumCPU())
 	}
+	return sv
 }
 
-func (sv *signatureVerifier) VerifySignature(operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error {
+func (sv *signatureVerifier) VerifySignature(ctx context.Context, operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error {
 	if len(signature) != 256 {
 		return fmt.Errorf("invalid signature length")
 	}
 
-	sv.operatorIDToPubkeyCacheMu.Lock()
-	operatorPubKey, ok := sv.operatorIDToPubkeyCache[operatorID]
-	sv.operatorIDToPubkeyCacheMu.Unlock()
-	if !ok {
-		operator, found, err := sv.operatorStore.GetOperatorData(nil, operatorID)
-		if err != nil {
-			return err
+	encodedMsg, err := message.Encode()
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(encodedMsg)
+
+	pubKeyPEM, err := sv.storedPublicKeyPEM(operatorID)
+	if err != nil {
+		return err
+	}
+	if sv.verified.Contains(digest, operatorID, pubKeyPEM, signature) {
+		recordCacheHit(ctx)
+		return nil
+	}
+
+	operatorPubKey, err := sv.parsePublicKey(pubKeyPEM)
+	if err != nil {
+		return fmt.Errorf("could not parse signer public key: %w", err)
+	}
+
+	return sv.verify(ctx, operatorPubKey, encodedMsg, digest, operatorID, pubKeyPEM, signature)
+}
+
+func (sv *signatureVerifier) Verify(ctx context.Context, msg *spectypes.SignedSSVMessage, operators []*spectypes.Operator) error {
+	encodedMsg, err := msg.SSVMessage.Encode()
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(encodedMsg)
+
+	// Find operator that matches ID with the signer and verify signature
+	for i, signer := range msg.OperatorIDs {
+		operator := findOperator(signer, operators)
+		if operator == nil {
+			return fmt.Errorf("unknown signer")
 		}
-		if !found {
-			return fmt.Errorf("operator not found")
+		if sv.verified.Contains(digest, signer, operator.SSVOperatorPubKey, msg.Signatures[i]) {
+			recordCacheHit(ctx)
+			continue
 		}
 
-		operatorPubKey, err = keys.PublicKeyFromString(string(operator.PublicKey))
+		operatorPubKey, err := sv.parsePublicKey(operator.SSVOperatorPubKey)
 		if err != nil {
+			return fmt.Errorf("could not parse signer public key: %w", err)
+		}
+		if err := sv.verify(ctx, operatorPubKey, encodedMsg, digest, signer, operator.SSVOperatorPubKey, msg.Signatures[i]); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		sv.operatorIDToPubkeyCacheMu.Lock()
-		sv.operatorIDToPubkeyCache[operatorID] = operatorPubKey
-		sv.operatorIDToPubkeyCacheMu.Unlock()
+// verify verifies the signature on one of the workers and remembers it if it's valid.
+// it returns the context's error if the context is done before a worker is available.
+func (sv *signatureVerifier) verify(
+	ctx context.Context,
+	operatorPubKey keys.OperatorPublicKey,
+	encodedMsg []byte,
+	digest [32]byte,
+	signer spectypes.OperatorID,
+	pubKeyPEM []byte,
+	signature []byte,
+) error {
+	queued := time.Now()
+	select {
+	case sv.workers <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("wait for verification worker: %w", ctx.Err())
 	}
+	defer func() { <-sv.workers }()
+	recordQueueDuration(ctx, time.Since(queued))
 
-	encodedMsg, err := message.Encode()
+	recordInFlight(ctx, 1)
+	defer recordInFlight(ctx, -1)
+
+	start := time.Now()
+	err := operatorPubKey.Verify(encodedMsg, signature)
+	recordVerification(ctx, err == nil, time.Since(start))
 	if err != nil {
 		return err
 	}
 
-	return operatorPubKey.Verify(encodedMsg, signature)
+	sv.verified.Add(digest, signer, pubKeyPEM, signature)
+	return nil
+}
+
+// storedPublicKeyPEM returns the current PEM encoded public key of the given operator from the operator store
+func (sv *signatureVerifier) storedPublicKeyPEM(operatorID spectypes.OperatorID) ([]byte, error) {
+	operator, found, err := sv.operatorStore.GetOperatorData(nil, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("operator not found")
+	}
+
+	pubKeyPEM, err := base64.StdEncoding.DecodeString(string(operator.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode operator public key: %w", err)
+	}
+	return pubKeyPEM, nil
+}
+
+// parsePublicKey returns the cached public key of the given PEM encoding, parsing it if it isn't cached yet
+func (sv *signatureVerifier) parsePublicKey(pubKeyPEM []byte) (keys.OperatorPublicKey, error) {
+	sv.pubKeyCacheMu.Lock()
+	operatorPubKey, ok := sv.pubKeyCache[string(pubKeyPEM)]
+	sv.pubKeyCacheMu.Unlock()
+	if ok {
+		return operatorPubKey, nil
+	}
+
+	operatorPubKey, err := keys.PublicKeyFromPEM(pubKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	sv.pubKeyCacheMu.Lock()
+	sv.pubKeyCache[string(pubKeyPEM)] = operatorPubKey
+	sv.pubKeyCacheMu.Unlock()
+
+	return operatorPubKey, nil
+}
+
+func findOperator(id spectypes.OperatorID, operators []*spectypes.Operator) *spectypes.Operator {
+	for _, op := range operators {
+		if op.OperatorID == id {
+			return op
+		}
+	}
+	return nil
 }
@@ -0,0 +1,137 @@
+package signatureverifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+type testOperatorStore map[spectypes.OperatorID]*registrystorage.OperatorData
+
+func (s testOperatorStore) GetOperatorData(_ basedb.Reader, id spectypes.OperatorID) (*registrystorage.OperatorData, bool, error) {
+	data, ok := s[id]
+	return data, ok, nil
+}
+
+func newTestOperators(t *testing.T, count int) ([]keys.OperatorPrivateKey, []*spectypes.Operator, testOperatorStore) {
+	privateKeys := make([]keys.OperatorPrivateKey, 0, count)
+	operators := make([]*spectypes.Operator, 0, count)
+	store := make(testOperatorStore)
+	for id := spectypes.OperatorID(1); id <= spectypes.OperatorID(count); id++ {
+		sk, err := keys.GeneratePrivateKey()
+		require.NoError(t, err)
+		pk, err := sk.Public().Base64()
+		require.NoError(t, err)
+		pem, err := base64.StdEncoding.DecodeString(string(pk))
+		require.NoError(t, err)
+
+		privateKeys = append(privateKeys, sk)
+		operators = append(operators, &spectypes.Operator{OperatorID: id, SSVOperatorPubKey: pem})
+		store[id] = &registrystorage.OperatorData{ID: id, PublicKey: pk}
+	}
+	return privateKeys, operators, store
+}
+
+func signTestMessage(t *testing.T, msg *spectypes.SSVMessage, privateKeys []keys.OperatorPrivateKey, operators []*spectypes.Operator) *spectypes.SignedSSVMessage {
+	encoded, err := msg.Encode()
+	require.NoError(t, err)
+
+	signed := &spectypes.SignedSSVMessage{SSVMessage: msg}
+	for i, sk := range privateKeys {
+		sig, err := sk.Sign(encoded)
+		require.NoError(t, err)
+		signed.OperatorIDs = append(signed.OperatorIDs, operators[i].OperatorID)
+		signed.Signatures = append(signed.Signatures, sig)
+	}
+	return signed
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	privateKeys, operators, store := newTestOperators(t, 4)
+	msg := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("data")}
+	signed := signTestMessage(t, msg, privateKeys[:3], operators)
+
+	sv := NewSignatureVerifier(store, WithWorkers(2)).(*signatureVerifier)
+
+	require.NoError(t, sv.Verify(context.Background(), signed, operators))
+	require.Equal(t, 3, sv.verified.cache.Len())
+
+	// a signature verified in message validation is remembered for the runners
+	other := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("other")}
+	otherSigned := signTestMessage(t, other, privateKeys[3:], operators[3:])
+	require.NoError(t, sv.VerifySignature(context.Background(), 4, other, otherSigned.Signatures[0]))
+	require.True(t, sv.verified.Contains(digest(t, other), 4, operators[3].SSVOperatorPubKey, otherSigned.Signatures[0]))
+	require.NoError(t, sv.Verify(context.Background(), otherSigned, operators))
+
+	t.Run("invalid signature", func(t *testing.T) {
+		invalid := signTestMessage(t, msg, privateKeys[:2], operators)
+		invalid.Signatures[1] = invalid.Signatures[0]
+		require.Error(t, sv.Verify(context.Background(), invalid, operators))
+		require.Error(t, sv.VerifySignature(context.Background(), 2, msg, invalid.Signatures[1]))
+	})
+
+	t.Run("cached signature of another signer", func(t *testing.T) {
+		// the signature of operator 1 is cached, but not for operator 2
+		invalid := signTestMessage(t, msg, privateKeys[:1], operators)
+		invalid.OperatorIDs[0] = 2
+		require.Error(t, sv.Verify(context.Background(), invalid, operators))
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		// a cached signature is still rejected if its signer isn't in the committee
+		require.ErrorContains(t, sv.Verify(context.Background(), signed, operators[1:]), "unknown signer")
+	})
+}
+
+func TestSignatureVerifier_ChangedOperatorKey(t *testing.T) {
+	privateKeys, operators, store := newTestOperators(t, 2)
+	msg := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("data")}
+	signed := signTestMessage(t, msg, privateKeys[:1], operators)
+
+	sv := NewSignatureVerifier(store).(*signatureVerifier)
+	require.NoError(t, sv.VerifySignature(context.Background(), 1, msg, signed.Signatures[0]))
+
+	// operator 1 is re-registered with the key of operator 2, so its old key must no longer be used
+	store[1] = &registrystorage.OperatorData{ID: 1, PublicKey: store[2].PublicKey}
+	other := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("other")}
+	otherSigned := signTestMessage(t, other, privateKeys[:1], operators)
+	require.Error(t, sv.VerifySignature(context.Background(), 1, other, otherSigned.Signatures[0]))
+
+	newKeySigned := signTestMessage(t, other, privateKeys[1:], operators)
+	require.NoError(t, sv.VerifySignature(context.Background(), 1, other, newKeySigned.Signatures[0]))
+
+	// the signature verified with the old key is no longer trusted, although it's cached
+	require.Error(t, sv.VerifySignature(context.Background(), 1, msg, signed.Signatures[0]))
+	rotated := []*spectypes.Operator{{OperatorID: 1, SSVOperatorPubKey: operators[1].SSVOperatorPubKey}}
+	require.Error(t, sv.Verify(context.Background(), signed, rotated))
+}
+
+func TestSignatureVerifier_CancelledWhileQueued(t *testing.T) {
+	privateKeys, operators, store := newTestOperators(t, 1)
+	msg := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("data")}
+	signed := signTestMessage(t, msg, privateKeys, operators)
+
+	sv := NewSignatureVerifier(store, WithWorkers(1)).(*signatureVerifier)
+	// occupy the only worker
+	sv.workers <- struct{}{}
+	defer func() { <-sv.workers }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, sv.Verify(ctx, signed, operators), context.DeadlineExceeded)
+}
+
+func digest(t *testing.T, msg *spectypes.SSVMessage) [32]byte {
+	encoded, err := msg.Encode()
+	require.NoError(t, err)
+	return sha256.Sum256(encoded)
+}
@@ -0,0 +1,60 @@
+package signatureverifier
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// DefaultVerifiedCacheSize is the number of verified signatures remembered by default,
+// enough for a few slots of messages of a large node.
+const DefaultVerifiedCacheSize = 100_000
+
+// VerifiedCache remembers the operator signatures that were already verified.
+// a key commits to the message digest, the signer, its public key and the signature, so a message
+// verified in message validation isn't verified again by the runners, and a signature verified
+// with a key the operator no longer has isn't trusted.
+type VerifiedCache struct {
+	cache *lru.Cache[[32]byte, struct{}]
+}
+
+// NewVerifiedCache creates a new VerifiedCache holding up to size signatures
+func NewVerifiedCache(size int) *VerifiedCache {
+	if size <= 0 {
+		size = DefaultVerifiedCacheSize
+	}
+	cache, err := lru.New[[32]byte, struct{}](size)
+	if err != nil {
+		// can only fail on a non-positive size
+		panic(err)
+	}
+	return &VerifiedCache{cache: cache}
+}
+
+// Contains returns true if the given signature of the signer over the message digest was verified
+// with the given PEM encoded public key of the signer
+func (c *VerifiedCache) Contains(digest [32]byte, signer spectypes.OperatorID, pubKeyPEM, signature []byte) bool {
+	return c.cache.Contains(verifiedKey(digest, signer, pubKeyPEM, signature))
+}
+
+// Add marks the given signature of the signer over the message digest as verified
+// with the given PEM encoded public key of the signer
+func (c *VerifiedCache) Add(digest [32]byte, signer spectypes.OperatorID, pubKeyPEM, signature []byte) {
+	c.cache.Add(verifiedKey(digest, signer, pubKeyPEM, signature), struct{}{})
+}
+
+func verifiedKey(digest [32]byte, signer spectypes.OperatorID, pubKeyPEM, signature []byte) [32]byte {
+	h := sha256.New()
+	h.Write(digest[:])
+	h.Write(binary.LittleEndian.AppendUint64(nil, signer))
+	// the public key is variable-length, so its length is written to keep the key unambiguous
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(pubKeyPEM))))
+	h.Write(pubKeyPEM)
+	h.Write(signature)
+
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
@@ -13,13 +13,13 @@ func (mv *messageValidator) committeeRole(role spectypes.RunnerRole) bool {
 }
 
 func (mv *messageValidator) validateSlotTime(messageSlot phase0.Slot, role spectypes.RunnerRole, receivedAt time.Time) error {
-	if earliness := mv.messageEarliness(messageSlot, receivedAt); earliness > clockErrorTolerance {
+	if earliness := mv.messageEarliness(messageSlot, receivedAt); earliness > mv.limits.clockErrorTolerance() {
 		e := ErrEarlySlotMessage
 		e.got = fmt.Sprintf("early by %v", earliness)
 		return e
 	}
 
-	if lateness := mv.messageLateness(messageSlot, role, receivedAt); lateness > clockErrorTolerance {
+	if lateness := mv.messageLateness(messageSlot, role, receivedAt); lateness > mv.limits.clockErrorTolerance() {
 		e := ErrLateSlotMessage
 		e.got = fmt.Sprintf("late by %v", lateness)
 		return e
@@ -38,15 +38,15 @@ func (mv *messageValidator) messageLateness(slot phase0.Slot, role spectypes.Run
 	var ttl phase0.Slot
 	switch role {
 	case spectypes.RoleProposer, spectypes.RoleSyncCommitteeContribution:
-		ttl = 1 + lateSlotAllowance
+		ttl = 1 + mv.limits.lateSlotAllowance()
 	case spectypes.RoleCommittee, spectypes.RoleAggregator:
-		ttl = phase0.Slot(mv.netCfg.Beacon.SlotsPerEpoch()) + lateSlotAllowance
+		ttl = phase0.Slot(mv.netCfg.Beacon.SlotsPerEpoch()) + mv.limits.lateSlotAllowance()
 	case spectypes.RoleValidatorRegistration, spectypes.RoleVoluntaryExit:
 		return 0
 	}
 
 	deadline := mv.netCfg.Beacon.GetSlotStartTime(slot + ttl).
-		Add(lateMessageMargin)
+		Add(mv.limits.lateMessageMargin())
 
 	return receivedAt.Sub(deadline)
 }
@@ -86,7 +86,7 @@ func (mv *messageValidator) dutyLimit(msgID spectypes.MessageID, slot phase0.Slo
 		return mv.dutyStore.VoluntaryExit.GetDutyCount(slot, pk), true
 
 	case spectypes.RoleAggregator, spectypes.RoleValidatorRegistration:
-		return 2, true
+		return mv.limits.maxDutiesPerEpoch(msgID.GetRoleType())
 
 	case spectypes.RoleCommittee:
 		validatorIndexCount := uint64(len(validatorIndices))
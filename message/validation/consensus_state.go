@@ -66,12 +66,16 @@ func (os *OperatorState) Set(slot phase0.Slot, epoch phase0.Epoch, state *Signer
 	if slot > os.maxSlot {
 		os.maxSlot = slot
 	}
-	if epoch > os.maxEpoch {
+	// late messages of the previous epoch count towards its duties, not the latest epoch's
+	switch {
+	case epoch > os.maxEpoch:
 		os.maxEpoch = epoch
 		os.prevEpochDuties = os.lastEpochDuties
 		os.lastEpochDuties = 1
-	} else {
+	case epoch == os.maxEpoch:
 		os.lastEpochDuties++
+	case epoch+1 == os.maxEpoch:
+		os.prevEpochDuties++
 	}
 }
 
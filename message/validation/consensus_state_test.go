@@ -93,4 +93,22 @@ func TestOperatorState(t *testing.T) {
 
 		require.Equal(t, os.DutyCount(epoch), uint64(2))
 	})
+
+	t.Run("TestPreviousEpochDuties", func(t *testing.T) {
+		size := phase0.Slot(10)
+		os := newOperatorState(size)
+
+		epoch := phase0.Epoch(2)
+		os.Set(phase0.Slot(6), epoch, &SignerState{Slot: 6})
+
+		// a late message of a slot in the previous epoch
+		os.Set(phase0.Slot(3), epoch-1, &SignerState{Slot: 3})
+		require.Equal(t, uint64(1), os.DutyCount(epoch))
+		require.Equal(t, uint64(1), os.DutyCount(epoch-1))
+
+		// a message of an older epoch doesn't count towards the stored epochs
+		os.Set(phase0.Slot(1), epoch-2, &SignerState{Slot: 1})
+		require.Equal(t, uint64(1), os.DutyCount(epoch))
+		require.Equal(t, uint64(1), os.DutyCount(epoch-1))
+	})
 }
@@ -4,6 +4,7 @@ package validation
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
@@ -20,172 +21,211 @@ import (
 	"github.com/ssvlabs/ssv/utils/casts"
 )
 
+// consensusRules are the rules of consensus messages, in the order in which they're evaluated
+var consensusRules = newRules([]rule[*consensusInput]{
+	{name: "size", check: (*messageValidator).checkConsensusSize},
+	{name: "decode", check: (*messageValidator).checkConsensusDecode},
+	{name: "signers", check: (*messageValidator).checkConsensusSigners},
+	{name: "full_data", check: (*messageValidator).checkConsensusFullData},
+	{name: "message_type", check: (*messageValidator).checkConsensusMessageType},
+	{name: "zero_round", check: (*messageValidator).checkConsensusZeroRound},
+	{name: "role", check: (*messageValidator).checkConsensusRole},
+	{name: "max_round", check: (*messageValidator).checkConsensusMaxRound},
+	{name: "identifier", check: (*messageValidator).checkConsensusIdentifier},
+	{name: "justifications", check: (*messageValidator).checkConsensusJustifications},
+	{name: "leader", check: (*messageValidator).checkConsensusLeader},
+	{name: "signer_state", check: (*messageValidator).checkConsensusSignerState},
+	{name: "round_spread", check: (*messageValidator).checkConsensusRoundSpread},
+	{name: "slot_advanced", check: (*messageValidator).checkConsensusSlotAdvanced},
+	{name: "beacon_duty", check: (*messageValidator).checkConsensusBeaconDuty},
+	{name: "slot_time", check: (*messageValidator).checkConsensusSlotTime},
+	{name: "duty_count", check: (*messageValidator).checkConsensusDutyCount},
+	{name: "signatures", check: (*messageValidator).checkConsensusSignatures},
+})
+
 func (mv *messageValidator) validateConsensusMessage(
+	ctx context.Context,
 	signedSSVMessage *spectypes.SignedSSVMessage,
 	committeeInfo CommitteeInfo,
 	receivedAt time.Time,
 ) (*specqbft.Message, error) {
-	ssvMessage := signedSSVMessage.SSVMessage
-
-	if len(ssvMessage.Data) > maxEncodedConsensusMsgSize {
-		e := ErrSSVDataTooBig
-		e.got = len(ssvMessage.Data)
-		e.want = maxEncodedConsensusMsgSize
-		return nil, e
+	in := &consensusInput{
+		ctx:              ctx,
+		signedSSVMessage: signedSSVMessage,
+		committeeInfo:    committeeInfo,
+		receivedAt:       receivedAt,
 	}
 
-	consensusMessage, err := specqbft.DecodeMessage(ssvMessage.Data)
-	if err != nil {
-		e := ErrUndecodableMessageData
-		e.innerErr = err
-		return nil, e
+	if err := runRules(mv, consensusRules, in); err != nil {
+		return in.consensusMessage, err
 	}
 
-	if err := mv.validateConsensusMessageSemantics(signedSSVMessage, consensusMessage, committeeInfo.operatorIDs); err != nil {
-		return consensusMessage, err
+	if err := mv.updateConsensusState(signedSSVMessage, in.consensusMessage, in.consensusState(mv)); err != nil {
+		return in.consensusMessage, err
 	}
 
-	state := mv.consensusState(signedSSVMessage.SSVMessage.GetID())
+	return in.consensusMessage, nil
+}
 
-	if err := mv.validateQBFTLogic(signedSSVMessage, consensusMessage, committeeInfo.operatorIDs, receivedAt, state); err != nil {
-		return consensusMessage, err
+func (mv *messageValidator) checkConsensusSize(in *consensusInput) error {
+	maxSize := mv.limits.maxConsensusMessageSize()
+	if len(in.signedSSVMessage.SSVMessage.Data) > maxSize {
+		e := ErrSSVDataTooBig
+		e.got = len(in.signedSSVMessage.SSVMessage.Data)
+		e.want = maxSize
+		return e
 	}
+	return nil
+}
 
-	if err := mv.validateQBFTMessageByDutyLogic(signedSSVMessage, consensusMessage, committeeInfo.indices, receivedAt, state); err != nil {
-		return consensusMessage, err
+func (mv *messageValidator) checkConsensusDecode(in *consensusInput) error {
+	consensusMessage, err := specqbft.DecodeMessage(in.signedSSVMessage.SSVMessage.Data)
+	if err != nil {
+		e := ErrUndecodableMessageData
+		e.innerErr = err
+		return e
 	}
+	in.consensusMessage = consensusMessage
+	return nil
+}
 
-	for i := range signedSSVMessage.Signatures {
-		operatorID := signedSSVMessage.OperatorIDs[i]
-		signature := signedSSVMessage.Signatures[i]
+func (mv *messageValidator) checkConsensusSigners(in *consensusInput) error {
+	signers := in.signedSSVMessage.OperatorIDs
+	if len(signers) <= 1 {
+		return nil
+	}
 
-		if err := mv.signatureVerifier.VerifySignature(operatorID, ssvMessage, signature); err != nil {
-			e := ErrSignatureVerification
-			e.innerErr = fmt.Errorf("verify opid: %v signature: %w", operatorID, err)
-			return consensusMessage, e
-		}
+	// Rule: Decided msg with different type than Commit
+	if in.consensusMessage.MsgType != specqbft.CommitMsgType {
+		e := ErrNonDecidedWithMultipleSigners
+		e.got = len(signers)
+		return e
 	}
 
-	if err := mv.updateConsensusState(signedSSVMessage, consensusMessage, state); err != nil {
-		return consensusMessage, err
+	// Rule: Number of signers must be >= quorum size
+	quorumSize, _ := ssvtypes.ComputeQuorumAndPartialQuorum(uint64(len(in.committeeInfo.operatorIDs)))
+	if uint64(len(signers)) < quorumSize {
+		e := ErrDecidedNotEnoughSigners
+		e.want = quorumSize
+		e.got = len(signers)
+		return e
 	}
 
-	return consensusMessage, nil
+	return nil
 }
 
-func (mv *messageValidator) validateConsensusMessageSemantics(
-	signedSSVMessage *spectypes.SignedSSVMessage,
-	consensusMessage *specqbft.Message,
-	committee []spectypes.OperatorID,
-) error {
-	signers := signedSSVMessage.OperatorIDs
-	quorumSize, _ := ssvtypes.ComputeQuorumAndPartialQuorum(uint64(len(committee)))
-	msgType := consensusMessage.MsgType
-
-	if len(signers) > 1 {
-		// Rule: Decided msg with different type than Commit
-		if msgType != specqbft.CommitMsgType {
-			e := ErrNonDecidedWithMultipleSigners
-			e.got = len(signers)
-			return e
-		}
-
-		// Rule: Number of signers must be >= quorum size
-		if uint64(len(signers)) < quorumSize {
-			e := ErrDecidedNotEnoughSigners
-			e.want = quorumSize
-			e.got = len(signers)
-			return e
-		}
+func (mv *messageValidator) checkConsensusFullData(in *consensusInput) error {
+	if len(in.signedSSVMessage.FullData) == 0 {
+		return nil
 	}
 
-	if len(signedSSVMessage.FullData) != 0 {
-		// Rule: Prepare or commit messages must not have full data
-		if msgType == specqbft.PrepareMsgType || (msgType == specqbft.CommitMsgType && len(signers) == 1) {
-			return ErrPrepareOrCommitWithFullData
-		}
+	// Rule: Prepare or commit messages must not have full data
+	msgType := in.consensusMessage.MsgType
+	if msgType == specqbft.PrepareMsgType || (msgType == specqbft.CommitMsgType && len(in.signedSSVMessage.OperatorIDs) == 1) {
+		return ErrPrepareOrCommitWithFullData
+	}
 
-		hashedFullData, err := specqbft.HashDataRoot(signedSSVMessage.FullData)
-		if err != nil {
-			e := ErrFullDataHash
-			e.innerErr = err
-			return e
-		}
+	hashedFullData, err := specqbft.HashDataRoot(in.signedSSVMessage.FullData)
+	if err != nil {
+		e := ErrFullDataHash
+		e.innerErr = err
+		return e
+	}
 
-		// Rule: Full data hash must match root
-		if hashedFullData != consensusMessage.Root {
-			return ErrInvalidHash
-		}
+	// Rule: Full data hash must match root
+	if hashedFullData != in.consensusMessage.Root {
+		return ErrInvalidHash
 	}
 
+	return nil
+}
+
+func (mv *messageValidator) checkConsensusMessageType(in *consensusInput) error {
 	// Rule: Consensus message type must be valid
-	if !mv.validConsensusMsgType(msgType) {
+	if !mv.validConsensusMsgType(in.consensusMessage.MsgType) {
 		return ErrUnknownQBFTMessageType
 	}
+	return nil
+}
 
+func (mv *messageValidator) checkConsensusZeroRound(in *consensusInput) error {
 	// Rule: Round must not be zero
-	if consensusMessage.Round == specqbft.NoRound {
+	if in.consensusMessage.Round == specqbft.NoRound {
 		e := ErrZeroRound
 		e.got = specqbft.NoRound
 		return e
 	}
+	return nil
+}
 
-	role := signedSSVMessage.SSVMessage.GetID().GetRoleType()
-
+func (mv *messageValidator) checkConsensusRole(in *consensusInput) error {
 	// Rule: Duty role has consensus (true except for ValidatorRegistration and VoluntaryExit)
+	role := in.signedSSVMessage.SSVMessage.GetID().GetRoleType()
 	if role == spectypes.RoleValidatorRegistration || role == spectypes.RoleVoluntaryExit {
 		e := ErrUnexpectedConsensusMessage
 		e.got = role
 		return e
 	}
+	return nil
+}
 
-	// Rule: Round cut-offs for roles:
+func (mv *messageValidator) checkConsensusMaxRound(in *consensusInput) error {
+	// Rule: Round cut-offs for roles, by default:
 	// - 12 (committee and aggregation)
 	// - 6 (other types)
+	role := in.signedSSVMessage.SSVMessage.GetID().GetRoleType()
 	maxRound, err := mv.maxRound(role)
 	if err != nil {
 		return fmt.Errorf("failed to get max round: %w", err)
 	}
 
-	if consensusMessage.Round > maxRound {
+	if in.consensusMessage.Round > maxRound {
 		err := ErrRoundTooHigh
-		err.got = fmt.Sprintf("%v (%v role)", consensusMessage.Round, message.RunnerRoleToString(role))
+		err.got = fmt.Sprintf("%v (%v role)", in.consensusMessage.Round, message.RunnerRoleToString(role))
 		err.want = fmt.Sprintf("%v (%v role)", maxRound, message.RunnerRoleToString(role))
 		return err
 	}
 
+	return nil
+}
+
+func (mv *messageValidator) checkConsensusIdentifier(in *consensusInput) error {
 	// Rule: consensus message must have the same identifier as the ssv message's identifier
-	if !bytes.Equal(consensusMessage.Identifier, signedSSVMessage.SSVMessage.MsgID[:]) {
+	msgID := in.signedSSVMessage.SSVMessage.MsgID
+	if !bytes.Equal(in.consensusMessage.Identifier, msgID[:]) {
 		e := ErrMismatchedIdentifier
-		e.want = hex.EncodeToString(signedSSVMessage.SSVMessage.MsgID[:])
-		e.got = hex.EncodeToString(consensusMessage.Identifier)
+		e.want = hex.EncodeToString(msgID[:])
+		e.got = hex.EncodeToString(in.consensusMessage.Identifier)
 		return e
 	}
+	return nil
+}
+
+func (mv *messageValidator) checkConsensusJustifications(in *consensusInput) error {
+	return mv.validateJustifications(in.consensusMessage)
+}
+
+func (mv *messageValidator) checkConsensusLeader(in *consensusInput) error {
+	if in.consensusMessage.MsgType != specqbft.ProposalMsgType {
+		return nil
+	}
 
-	if err := mv.validateJustifications(consensusMessage); err != nil {
+	// Rule: Signer must be the leader
+	leader := mv.roundRobinProposer(in.consensusMessage.Height, in.consensusMessage.Round, in.committeeInfo.operatorIDs)
+	if in.signedSSVMessage.OperatorIDs[0] != leader {
+		err := ErrSignerNotLeader
+		err.got = in.signedSSVMessage.OperatorIDs[0]
+		err.want = leader
 		return err
 	}
 
 	return nil
 }
 
-func (mv *messageValidator) validateQBFTLogic(
-	signedSSVMessage *spectypes.SignedSSVMessage,
-	consensusMessage *specqbft.Message,
-	committee []spectypes.OperatorID,
-	receivedAt time.Time,
-	state *consensusState,
-) error {
-	if consensusMessage.MsgType == specqbft.ProposalMsgType {
-		// Rule: Signer must be the leader
-		leader := mv.roundRobinProposer(consensusMessage.Height, consensusMessage.Round, committee)
-		if signedSSVMessage.OperatorIDs[0] != leader {
-			err := ErrSignerNotLeader
-			err.got = signedSSVMessage.OperatorIDs[0]
-			err.want = leader
-			return err
-		}
-	}
+func (mv *messageValidator) checkConsensusSignerState(in *consensusInput) error {
+	signedSSVMessage := in.signedSSVMessage
+	consensusMessage := in.consensusMessage
+	state := in.consensusState(mv)
 
 	msgSlot := phase0.Slot(consensusMessage.Height)
 	for _, signer := range signedSSVMessage.OperatorIDs {
@@ -233,58 +273,60 @@ func (mv *messageValidator) validateQBFTLogic(
 		}
 	}
 
-	if len(signedSSVMessage.OperatorIDs) == 1 {
-		// Rule: Round must not be smaller than current peer's round -1 or +1. Only for non-decided messages
-		if err := mv.roundBelongsToAllowedSpread(signedSSVMessage, consensusMessage, receivedAt); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
-func (mv *messageValidator) validateQBFTMessageByDutyLogic(
-	signedSSVMessage *spectypes.SignedSSVMessage,
-	consensusMessage *specqbft.Message,
-	validatorIndices []phase0.ValidatorIndex,
-	receivedAt time.Time,
-	state *consensusState,
-) error {
-	role := signedSSVMessage.SSVMessage.GetID().GetRoleType()
+func (mv *messageValidator) checkConsensusRoundSpread(in *consensusInput) error {
+	if len(in.signedSSVMessage.OperatorIDs) != 1 {
+		return nil
+	}
 
+	// Rule: Round must not be smaller than current peer's round -1 or +1. Only for non-decided messages
+	return mv.roundBelongsToAllowedSpread(in.signedSSVMessage, in.consensusMessage, in.receivedAt)
+}
+
+func (mv *messageValidator) checkConsensusSlotAdvanced(in *consensusInput) error {
 	// Rule: Height must not be "old". I.e., signer must not have already advanced to a later slot.
-	if role != spectypes.RoleCommittee { // Rule only for validator runners
-		for _, signer := range signedSSVMessage.OperatorIDs {
-			signerStateBySlot := state.GetOrCreate(signer)
-			if maxSlot := signerStateBySlot.MaxSlot(); maxSlot > phase0.Slot(consensusMessage.Height) {
-				e := ErrSlotAlreadyAdvanced
-				e.got = consensusMessage.Height
-				e.want = maxSlot
-				return e
-			}
+	if in.signedSSVMessage.SSVMessage.GetID().GetRoleType() == spectypes.RoleCommittee { // Rule only for validator runners
+		return nil
+	}
+
+	state := in.consensusState(mv)
+	for _, signer := range in.signedSSVMessage.OperatorIDs {
+		signerStateBySlot := state.GetOrCreate(signer)
+		if maxSlot := signerStateBySlot.MaxSlot(); maxSlot > phase0.Slot(in.consensusMessage.Height) {
+			e := ErrSlotAlreadyAdvanced
+			e.got = in.consensusMessage.Height
+			e.want = maxSlot
+			return e
 		}
 	}
 
-	msgSlot := phase0.Slot(consensusMessage.Height)
+	return nil
+}
+
+func (mv *messageValidator) checkConsensusBeaconDuty(in *consensusInput) error {
 	randaoMsg := false
-	if err := mv.validateBeaconDuty(signedSSVMessage.SSVMessage.GetID().GetRoleType(), msgSlot, validatorIndices, randaoMsg); err != nil {
-		return err
-	}
+	return mv.validateBeaconDuty(in.signedSSVMessage.SSVMessage.GetID().GetRoleType(), phase0.Slot(in.consensusMessage.Height), in.committeeInfo.indices, randaoMsg)
+}
 
-	// Rule: current slot(height) must be between duty's starting slot and:
+func (mv *messageValidator) checkConsensusSlotTime(in *consensusInput) error {
+	// Rule: current slot(height) must be between duty's starting slot and, by default:
 	// - duty's starting slot + 34 (committee and aggregation)
 	// - duty's starting slot + 3 (other types)
-	if err := mv.validateSlotTime(msgSlot, role, receivedAt); err != nil {
-		return err
-	}
+	return mv.validateSlotTime(phase0.Slot(in.consensusMessage.Height), in.signedSSVMessage.SSVMessage.GetID().GetRoleType(), in.receivedAt)
+}
 
-	// Rule: valid number of duties per epoch:
+func (mv *messageValidator) checkConsensusDutyCount(in *consensusInput) error {
+	// Rule: valid number of duties per epoch, by default:
 	// - 2 for aggregation, voluntary exit and validator registration
 	// - 2*V for Committee duty (where V is the number of validators in the cluster) (if no validator is doing sync committee in this epoch)
 	// - else, accept
-	for _, signer := range signedSSVMessage.OperatorIDs {
+	state := in.consensusState(mv)
+	msgSlot := phase0.Slot(in.consensusMessage.Height)
+	for _, signer := range in.signedSSVMessage.OperatorIDs {
 		signerStateBySlot := state.GetOrCreate(signer)
-		if err := mv.validateDutyCount(signedSSVMessage.SSVMessage.GetID(), msgSlot, validatorIndices, signerStateBySlot); err != nil {
+		if err := mv.validateDutyCount(in.signedSSVMessage.SSVMessage.GetID(), msgSlot, in.committeeInfo.indices, signerStateBySlot); err != nil {
 			return err
 		}
 	}
@@ -292,6 +334,21 @@ func (mv *messageValidator) validateQBFTMessageByDutyLogic(
 	return nil
 }
 
+func (mv *messageValidator) checkConsensusSignatures(in *consensusInput) error {
+	for i := range in.signedSSVMessage.Signatures {
+		operatorID := in.signedSSVMessage.OperatorIDs[i]
+		signature := in.signedSSVMessage.Signatures[i]
+
+		if err := mv.signatureVerifier.VerifySignature(in.ctx, operatorID, in.signedSSVMessage.SSVMessage, signature); err != nil {
+			e := ErrSignatureVerification
+			e.innerErr = fmt.Errorf("verify opid: %v signature: %w", operatorID, err)
+			return e
+		}
+	}
+
+	return nil
+}
+
 func (mv *messageValidator) updateConsensusState(signedSSVMessage *spectypes.SignedSSVMessage, consensusMessage *specqbft.Message, consensusState *consensusState) error {
 	msgSlot := phase0.Slot(consensusMessage.Height)
 	msgEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(msgSlot)
@@ -368,14 +425,7 @@ func (mv *messageValidator) validateJustifications(message *specqbft.Message) er
 }
 
 func (mv *messageValidator) maxRound(role spectypes.RunnerRole) (specqbft.Round, error) {
-	switch role {
-	case spectypes.RoleCommittee, spectypes.RoleAggregator: // TODO: check if value for aggregator is correct as there are messages on stage exceeding the limit
-		return 12, nil // TODO: consider calculating based on quick timeout and slow timeout
-	case spectypes.RoleProposer, spectypes.RoleSyncCommitteeContribution:
-		return 6, nil
-	default:
-		return 0, fmt.Errorf("unknown role")
-	}
+	return mv.limits.maxRound(role)
 }
 
 func (mv *messageValidator) currentEstimatedRound(sinceSlotStart time.Duration) (specqbft.Round, error) {
@@ -426,7 +476,7 @@ func (mv *messageValidator) roundBelongsToAllowedSpread(
 
 	// TODO: lowestAllowed is not supported yet because first round is non-deterministic now
 	lowestAllowed := /*estimatedRound - allowedRoundsInPast*/ specqbft.FirstRound
-	highestAllowed := estimatedRound + allowedRoundsInFuture
+	highestAllowed := estimatedRound + mv.limits.allowedRoundsInFuture()
 
 	role := signedSSVMessage.SSVMessage.GetID().GetRoleType()
 
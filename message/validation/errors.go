@@ -2,15 +2,18 @@ package validation
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
+	ssvmessage "github.com/ssvlabs/ssv/protocol/v2/message"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 )
 
@@ -71,6 +74,7 @@ var (
 	ErrTooManyDutiesPerEpoch                   = Error{text: "too many duties per epoch"}
 	ErrNoDuty                                  = Error{text: "no duty for this epoch"}
 	ErrEstimatedRoundNotInAllowedSpread        = Error{text: "message round is too far from estimated"}
+	ErrPeerRateLimited                         = Error{text: "peer exceeded its rate limit", silent: true}
 	ErrEmptyData                               = Error{text: "empty data", reject: true}
 	ErrMismatchedIdentifier                    = Error{text: "identifier mismatch", reject: true}
 	ErrSignatureVerification                   = Error{text: "signature verification", reject: true}
@@ -119,7 +123,7 @@ var (
 	ErrEncodeOperators                         = Error{text: "encode operators", reject: true}
 )
 
-func (mv *messageValidator) handleValidationError(ctx context.Context, peerID peer.ID, decodedMessage *queue.SSVMessage, err error) pubsub.ValidationResult {
+func (mv *messageValidator) handleValidationError(ctx context.Context, peerID peer.ID, topic string, decodedMessage *queue.SSVMessage, err error) pubsub.ValidationResult {
 	loggerFields := mv.buildLoggerFields(decodedMessage)
 
 	logger := mv.logger.
@@ -129,6 +133,7 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 	var valErr Error
 	if !errors.As(err, &valErr) {
 		recordIgnoredMessage(ctx, loggerFields.Role, err.Error())
+		mv.auditFailure(peerID, topic, decodedMessage, loggerFields, err.Error(), ruleResultIgnore.String(), false)
 		logger.Debug("ignoring invalid message", zap.Error(err))
 		return pubsub.ValidationIgnore
 	}
@@ -138,6 +143,7 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 			logger.Debug("ignoring invalid message", zap.Error(valErr))
 		}
 		recordIgnoredMessage(ctx, loggerFields.Role, valErr.Text())
+		mv.auditFailure(peerID, topic, decodedMessage, loggerFields, valErr.Text(), ruleResultIgnore.String(), valErr.Silent())
 		return pubsub.ValidationIgnore
 	}
 
@@ -146,9 +152,49 @@ func (mv *messageValidator) handleValidationError(ctx context.Context, peerID pe
 	}
 
 	recordRejectedMessage(ctx, loggerFields.Role, valErr.Text())
+	mv.auditFailure(peerID, topic, decodedMessage, loggerFields, valErr.Text(), ruleResultReject.String(), valErr.Silent())
 	return pubsub.ValidationReject
 }
 
+// auditFailure records the failure in the audit log, if there is one. silent failures are only sampled.
+func (mv *messageValidator) auditFailure(
+	peerID peer.ID,
+	topic string,
+	decodedMessage *queue.SSVMessage,
+	loggerFields *LoggerFields,
+	reason string,
+	result string,
+	silent bool,
+) {
+	if mv.failureAudit == nil {
+		return
+	}
+
+	failure := ValidationFailure{
+		Time:   time.Now(),
+		PeerID: peerID.String(),
+		Topic:  topic,
+		Slot:   loggerFields.Slot,
+		Error:  reason,
+		Result: result,
+	}
+	if decodedMessage != nil && decodedMessage.SignedSSVMessage != nil {
+		failure.Signers = decodedMessage.SignedSSVMessage.OperatorIDs
+	}
+	if decodedMessage != nil && decodedMessage.SSVMessage != nil {
+		msgID := decodedMessage.SSVMessage.GetID()
+		failure.MessageID = hex.EncodeToString(msgID[:])
+		failure.Role = ssvmessage.RunnerRoleToString(loggerFields.Role)
+		failure.DutyExecutorID = hex.EncodeToString(loggerFields.DutyExecutorID)
+	}
+
+	if silent {
+		mv.failureAudit.RecordSilent(failure)
+		return
+	}
+	mv.failureAudit.Record(failure)
+}
+
 func (mv *messageValidator) handleValidationSuccess(ctx context.Context, decodedMessage *queue.SSVMessage) pubsub.ValidationResult {
 	recordAcceptedMessage(ctx, decodedMessage.GetID().GetRoleType())
 	return pubsub.ValidationAccept
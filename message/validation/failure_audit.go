@@ -0,0 +1,302 @@
+package validation
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	defaultFailureAuditSize = 10_000
+	// defaultFailureAuditSilentSampleRate is the default rate at which silent failures are sampled
+	defaultFailureAuditSilentSampleRate = 100
+	// failureAuditPersistInterval is the interval in which recorded failures are persisted
+	failureAuditPersistInterval = 10 * time.Second
+)
+
+var failureAuditPrefix = []byte("validation_failures/")
+
+// FailureAuditConfig configures the audit log of messages that failed validation.
+type FailureAuditConfig struct {
+	Size    int  `yaml:"Size" env:"P2P_VALIDATION_AUDIT_SIZE" env-description:"Number of validation failures kept in the audit log"`
+	Persist bool `yaml:"Persist" env:"P2P_VALIDATION_AUDIT_PERSIST" env-description:"Whether the validation audit log is persisted across restarts"`
+	// SilentSampleRate keeps silent failures, such as rate-limited messages, from crowding out the others
+	SilentSampleRate int `yaml:"SilentSampleRate" env:"P2P_VALIDATION_AUDIT_SILENT_SAMPLE_RATE" env-description:"Only one of every this many silent validation failures (e.g. rate-limited messages) is kept in the audit log, 1 keeps all of them (default: 100)"`
+}
+
+// ValidationFailure is an audit record of a message that was ignored or rejected by message validation.
+// Signers are the ones claimed by the message, their signatures might not have been verified.
+type ValidationFailure struct {
+	Seq            uint64                 `json:"seq"`
+	Time           time.Time              `json:"time"`
+	PeerID         string                 `json:"peer_id"`
+	Topic          string                 `json:"topic"`
+	MessageID      string                 `json:"message_id,omitempty"`
+	Role           string                 `json:"role,omitempty"`
+	DutyExecutorID string                 `json:"duty_executor_id,omitempty"`
+	Slot           phase0.Slot            `json:"slot,omitempty"`
+	Signers        []spectypes.OperatorID `json:"signers,omitempty"`
+	Error          string                 `json:"error"`
+	Result         string                 `json:"result"`
+	// SampleRate is set on sampled failures, each of which stands for SampleRate failures of its kind
+	SampleRate int `json:"sample_rate,omitempty"`
+}
+
+// FailureFilter selects validation failures, zero fields match any failure.
+type FailureFilter struct {
+	Signer spectypes.OperatorID
+	// Validator is the public key of the validator, committee messages aren't tied to a single validator
+	Validator []byte
+	// Error matches failures whose error contains it, case-insensitively
+	Error string
+	Limit int
+}
+
+func (f FailureFilter) matches(failure ValidationFailure) bool {
+	if f.Signer != 0 && !slices.Contains(failure.Signers, f.Signer) {
+		return false
+	}
+	if len(f.Validator) != 0 && failure.DutyExecutorID != hex.EncodeToString(f.Validator) {
+		return false
+	}
+	if f.Error != "" && !strings.Contains(strings.ToLower(failure.Error), strings.ToLower(f.Error)) {
+		return false
+	}
+	return true
+}
+
+// FailureAudit is a bounded log of the latest validation failures, kept in a ring buffer and optionally persisted.
+type FailureAudit struct {
+	size             int
+	silentSampleRate int
+	db               basedb.Database
+
+	mu sync.Mutex
+	// entries is the ring buffer, the failure of sequence number seq is at seq % size
+	entries []ValidationFailure
+	count   int
+	// seq is the sequence number of the next failure
+	seq uint64
+	// persistedSeq is the sequence number of the first failure that wasn't persisted yet
+	persistedSeq uint64
+	// prunedSeq is the sequence number of the oldest failure that might still be persisted
+	prunedSeq uint64
+	// silentCount is the number of silent failures seen, recorded or not
+	silentCount uint64
+}
+
+// NewFailureAudit creates a new FailureAudit, loading the persisted failures when db is given and persistence is enabled.
+func NewFailureAudit(cfg FailureAuditConfig, db basedb.Database) (*FailureAudit, error) {
+	if cfg.Size <= 0 {
+		cfg.Size = defaultFailureAuditSize
+	}
+	if cfg.SilentSampleRate <= 0 {
+		cfg.SilentSampleRate = defaultFailureAuditSilentSampleRate
+	}
+
+	fa := &FailureAudit{
+		size:             cfg.Size,
+		silentSampleRate: cfg.SilentSampleRate,
+		entries:          make([]ValidationFailure, cfg.Size),
+	}
+	if !cfg.Persist || db == nil {
+		return fa, nil
+	}
+
+	fa.db = db
+	if err := fa.load(); err != nil {
+		return nil, fmt.Errorf("load validation failures: %w", err)
+	}
+	return fa, nil
+}
+
+// Record adds a failure to the log, overwriting the oldest one when it's full.
+func (fa *FailureAudit) Record(failure ValidationFailure) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+
+	fa.record(failure)
+}
+
+// RecordSilent samples a failure that isn't worth logging, such as a rate-limited message.
+// Such failures can come in floods, so only one of every SilentSampleRate of them is recorded.
+func (fa *FailureAudit) RecordSilent(failure ValidationFailure) {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+
+	fa.silentCount++
+	if (fa.silentCount-1)%uint64(fa.silentSampleRate) != 0 {
+		return
+	}
+	if fa.silentSampleRate > 1 {
+		failure.SampleRate = fa.silentSampleRate
+	}
+	fa.record(failure)
+}
+
+// record adds a failure to the ring buffer, must be called with the lock held
+func (fa *FailureAudit) record(failure ValidationFailure) {
+	failure.Seq = fa.seq
+	fa.seq++
+	fa.entries[failure.Seq%uint64(fa.size)] = failure
+	fa.count = min(fa.count+1, fa.size)
+}
+
+// Query returns the failures matching the filter, newest first.
+func (fa *FailureAudit) Query(filter FailureFilter) []ValidationFailure {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+
+	result := make([]ValidationFailure, 0)
+	for seq := fa.seq; seq > fa.seq-uint64(fa.count); seq-- {
+		failure := fa.entries[(seq-1)%uint64(fa.size)]
+		if !filter.matches(failure) {
+			continue
+		}
+		result = append(result, failure)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// Run persists the recorded failures periodically until the context is done, it's a no-op without persistence.
+func (fa *FailureAudit) Run(ctx context.Context, logger *zap.Logger) {
+	if fa.db == nil {
+		return
+	}
+
+	ticker := time.NewTicker(failureAuditPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := fa.Persist(); err != nil {
+				logger.Warn("failed to persist validation failures", zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := fa.Persist(); err != nil {
+				logger.Warn("failed to persist validation failures", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Persist writes the failures recorded since the last call to the database
+// and deletes the persisted failures which were overwritten since.
+func (fa *FailureAudit) Persist() error {
+	if fa.db == nil {
+		return nil
+	}
+
+	fa.mu.Lock()
+	oldest := fa.seq - uint64(fa.count)
+	from := max(fa.persistedSeq, oldest)
+	pending := make([]ValidationFailure, 0, fa.seq-from)
+	for seq := from; seq < fa.seq; seq++ {
+		pending = append(pending, fa.entries[seq%uint64(fa.size)])
+	}
+	pruneFrom, pruneTo := fa.prunedSeq, min(oldest, fa.persistedSeq)
+	fa.persistedSeq = fa.seq
+	fa.prunedSeq = max(fa.prunedSeq, oldest)
+	fa.mu.Unlock()
+
+	if len(pending) == 0 && pruneFrom >= pruneTo {
+		return nil
+	}
+
+	return fa.db.Update(func(txn basedb.Txn) error {
+		if len(pending) > 0 {
+			err := txn.SetMany(failureAuditPrefix, len(pending), func(i int) (basedb.Obj, error) {
+				value, err := json.Marshal(pending[i])
+				if err != nil {
+					return basedb.Obj{}, fmt.Errorf("marshal validation failure: %w", err)
+				}
+				return basedb.Obj{Key: failureAuditKey(pending[i].Seq), Value: value}, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return deleteFailures(txn, pruneFrom, pruneTo)
+	})
+}
+
+func (fa *FailureAudit) load() error {
+	var failures []ValidationFailure
+	err := fa.db.GetAll(failureAuditPrefix, func(_ int, obj basedb.Obj) error {
+		var failure ValidationFailure
+		if err := json.Unmarshal(obj.Value, &failure); err != nil {
+			return fmt.Errorf("unmarshal validation failure: %w", err)
+		}
+		failures = append(failures, failure)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(failures, func(a, b ValidationFailure) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	// the log might have been persisted with a larger size, in which case only the newest failures are kept
+	if len(failures) > fa.size {
+		dropped := failures[:len(failures)-fa.size]
+		failures = failures[len(failures)-fa.size:]
+		err := fa.db.Update(func(txn basedb.Txn) error {
+			for _, failure := range dropped {
+				if err := txn.Delete(failureAuditPrefix, failureAuditKey(failure.Seq)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("delete validation failures: %w", err)
+		}
+	}
+
+	fa.seq = failures[0].Seq
+	fa.prunedSeq = failures[0].Seq
+	for _, failure := range failures {
+		fa.Record(failure)
+	}
+	fa.persistedSeq = fa.seq
+	return nil
+}
+
+// deleteFailures deletes the persisted failures with sequence numbers in [from, to).
+func deleteFailures(txn basedb.Txn, from, to uint64) error {
+	for seq := from; seq < to; seq++ {
+		if err := txn.Delete(failureAuditPrefix, failureAuditKey(seq)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failureAuditKey returns the key of the failure in the database, which is its big-endian sequence number
+// so that the failures are ordered by it.
+func failureAuditKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestFailureAudit_Ring(t *testing.T) {
+	fa, err := NewFailureAudit(FailureAuditConfig{Size: 3}, nil)
+	require.NoError(t, err)
+
+	for _, reason := range []string{"a", "b", "c", "d"} {
+		fa.Record(ValidationFailure{Error: reason})
+	}
+
+	failures := fa.Query(FailureFilter{})
+	require.Len(t, failures, 3)
+	require.Equal(t, "d", failures[0].Error)
+	require.Equal(t, "b", failures[2].Error)
+	require.EqualValues(t, 3, failures[0].Seq)
+
+	require.Len(t, fa.Query(FailureFilter{Limit: 2}), 2)
+}
+
+func TestFailureAudit_RecordSilent(t *testing.T) {
+	fa, err := NewFailureAudit(FailureAuditConfig{Size: 10, SilentSampleRate: 50}, nil)
+	require.NoError(t, err)
+
+	fa.Record(ValidationFailure{Error: ErrRoundTooHigh.Text()})
+	for i := 0; i < 100; i++ {
+		fa.RecordSilent(ValidationFailure{Error: ErrPeerRateLimited.Text()})
+	}
+	fa.Record(ValidationFailure{Error: ErrSignatureVerification.Text()})
+
+	// a flood of silent failures doesn't push the others out of the log
+	require.Len(t, fa.Query(FailureFilter{Error: ErrRoundTooHigh.Text()}), 1)
+	require.Len(t, fa.Query(FailureFilter{Error: ErrSignatureVerification.Text()}), 1)
+
+	sampled := fa.Query(FailureFilter{Error: ErrPeerRateLimited.Text()})
+	require.Len(t, sampled, 2)
+	require.Equal(t, 50, sampled[0].SampleRate)
+}
+
+func TestFailureAudit_Filters(t *testing.T) {
+	fa, err := NewFailureAudit(FailureAuditConfig{}, nil)
+	require.NoError(t, err)
+
+	validatorPK := make([]byte, 48)
+	validatorPK[0] = 1
+
+	fa.Record(ValidationFailure{Signers: []spectypes.OperatorID{1}, Error: ErrRoundTooHigh.Text()})
+	fa.Record(ValidationFailure{Signers: []spectypes.OperatorID{1, 2, 3}, Error: ErrSignatureVerification.Text()})
+	fa.Record(ValidationFailure{Signers: []spectypes.OperatorID{4}, DutyExecutorID: hex.EncodeToString(validatorPK), Error: ErrNoDuty.Text()})
+
+	require.Len(t, fa.Query(FailureFilter{Signer: 1}), 2)
+	require.Len(t, fa.Query(FailureFilter{Signer: 2}), 1)
+	require.Len(t, fa.Query(FailureFilter{Validator: validatorPK}), 1)
+	require.Len(t, fa.Query(FailureFilter{Error: "ROUND is too high"}), 1)
+	require.Empty(t, fa.Query(FailureFilter{Signer: 4, Error: "round"}))
+}
+
+func TestFailureAudit_Persist(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	fa, err := NewFailureAudit(FailureAuditConfig{Size: 4, Persist: true}, db)
+	require.NoError(t, err)
+	for _, reason := range []string{"a", "b", "c", "d", "e", "f"} {
+		fa.Record(ValidationFailure{Error: reason})
+	}
+	require.NoError(t, fa.Persist())
+
+	restored, err := NewFailureAudit(FailureAuditConfig{Size: 4, Persist: true}, db)
+	require.NoError(t, err)
+	require.Equal(t, fa.Query(FailureFilter{}), restored.Query(FailureFilter{}))
+
+	// new failures continue the sequence of the restored ones
+	restored.Record(ValidationFailure{Error: "g"})
+	require.EqualValues(t, 6, restored.Query(FailureFilter{Limit: 1})[0].Seq)
+
+	// a smaller log keeps the newest failures
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	restored.Run(ctx, zap.NewNop())
+
+	smaller, err := NewFailureAudit(FailureAuditConfig{Size: 2, Persist: true}, db)
+	require.NoError(t, err)
+	failures := smaller.Query(FailureFilter{})
+	require.Len(t, failures, 2)
+	require.Equal(t, "g", failures[0].Error)
+	require.Equal(t, "f", failures[1].Error)
+
+	// the failures dropped from a smaller log are deleted rather than kept under colliding keys
+	count, err := db.CountPrefix(failureAuditPrefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	// restarting with a larger size keeps the order of the persisted failures
+	larger, err := NewFailureAudit(FailureAuditConfig{Size: 8, Persist: true}, db)
+	require.NoError(t, err)
+	for _, reason := range []string{"h", "i", "j", "k", "l", "m", "n", "o", "p"} {
+		larger.Record(ValidationFailure{Error: reason})
+	}
+	require.NoError(t, larger.Persist())
+
+	// overwritten failures are pruned from the database
+	count, err = db.CountPrefix(failureAuditPrefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, count)
+
+	restored, err = NewFailureAudit(FailureAuditConfig{Size: 8, Persist: true}, db)
+	require.NoError(t, err)
+	require.Equal(t, larger.Query(FailureFilter{}), restored.Query(FailureFilter{}))
+	require.Equal(t, "p", restored.Query(FailureFilter{Limit: 1})[0].Error)
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+)
+
+var (
+	defaultMaxRounds = map[spectypes.RunnerRole]specqbft.Round{
+		// TODO: check if value for aggregator is correct as there are messages on stage exceeding the limit
+		// TODO: consider calculating based on quick timeout and slow timeout
+		spectypes.RoleCommittee:                 12,
+		spectypes.RoleAggregator:                12,
+		spectypes.RoleProposer:                  6,
+		spectypes.RoleSyncCommitteeContribution: 6,
+	}
+
+	defaultMaxDutiesPerEpoch = map[spectypes.RunnerRole]uint64{
+		spectypes.RoleAggregator:            2,
+		spectypes.RoleValidatorRegistration: 2,
+	}
+)
+
+// limits resolves the limits enforced by the rules, those the network doesn't set keep their defaults.
+// The zero value enforces the defaults.
+type limits struct {
+	cfg networkconfig.MessageValidationLimits
+}
+
+func (l limits) lateMessageMargin() time.Duration {
+	if l.cfg.LateMessageMargin != nil {
+		return *l.cfg.LateMessageMargin
+	}
+	return lateMessageMargin
+}
+
+func (l limits) clockErrorTolerance() time.Duration {
+	if l.cfg.ClockErrorTolerance != nil {
+		return *l.cfg.ClockErrorTolerance
+	}
+	return clockErrorTolerance
+}
+
+func (l limits) lateSlotAllowance() phase0.Slot {
+	if l.cfg.LateSlotAllowance != nil {
+		return phase0.Slot(*l.cfg.LateSlotAllowance)
+	}
+	return lateSlotAllowance
+}
+
+func (l limits) allowedRoundsInFuture() specqbft.Round {
+	if l.cfg.AllowedRoundsInFuture != nil {
+		return specqbft.Round(*l.cfg.AllowedRoundsInFuture)
+	}
+	return allowedRoundsInFuture
+}
+
+func (l limits) maxRound(role spectypes.RunnerRole) (specqbft.Round, error) {
+	if maxRound, ok := l.cfg.MaxRounds[role]; ok {
+		return maxRound, nil
+	}
+	if maxRound, ok := defaultMaxRounds[role]; ok {
+		return maxRound, nil
+	}
+	return 0, fmt.Errorf("unknown role")
+}
+
+// maxDutiesPerEpoch returns the fixed duty limit of the role, if it has one
+func (l limits) maxDutiesPerEpoch(role spectypes.RunnerRole) (uint64, bool) {
+	limit, ok := defaultMaxDutiesPerEpoch[role]
+	if !ok {
+		return 0, false
+	}
+	if override, ok := l.cfg.MaxDutiesPerEpoch[role]; ok {
+		return override, true
+	}
+	return limit, true
+}
+
+func (l limits) maxSyncCommitteeContributionSignatures() int {
+	if l.cfg.MaxSyncCommitteeContributionSignatures != nil {
+		return *l.cfg.MaxSyncCommitteeContributionSignatures
+	}
+	return maxSignatures
+}
+
+func (l limits) maxConsensusMessageSize() int {
+	if l.cfg.MaxConsensusMessageSize != nil {
+		return min(*l.cfg.MaxConsensusMessageSize, maxEncodedConsensusMsgSize)
+	}
+	return maxEncodedConsensusMsgSize
+}
+
+func (l limits) maxPartialSignatureMessageSize() int {
+	if l.cfg.MaxPartialSignatureMessageSize != nil {
+		return min(*l.cfg.MaxPartialSignatureMessageSize, maxEncodedPartialSignatureSize)
+	}
+	return maxEncodedPartialSignatureSize
+}
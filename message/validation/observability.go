@@ -3,11 +3,14 @@ package validation
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/observability"
 )
@@ -50,8 +53,74 @@ var (
 			metric.WithUnit("s"),
 			metric.WithDescription("message validation duration"),
 			metric.WithExplicitBucketBoundaries(observability.SecondsHistogramBuckets...)))
+
+	peerRateLimitedCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("peer.rate_limited"),
+			metric.WithUnit("{message}"),
+			metric.WithDescription("total number of messages that were ignored because their peer exceeded its rate limit")))
+
+	peerReputationGauge = observability.NewMetric(
+		meter.Float64Gauge(
+			metricName("peer.reputation"),
+			metric.WithUnit("{score}"),
+			metric.WithDescription("application-specific score of a peer based on the messages it forwarded")))
+
+	ruleEvaluationsCounter = observability.NewMetric(
+		meter.Int64ObservableCounter(
+			metricName("rule.evaluations"),
+			metric.WithUnit("{rule_evaluation}"),
+			metric.WithDescription("total number of validation rule evaluations by rule and result"),
+			metric.WithInt64Callback(observeRuleEvaluations)))
+)
+
+// ruleEvaluations counts the outcomes of a rule. Rules are evaluated for every message,
+// so outcomes are aggregated in memory and reported when metrics are collected,
+// with attribute sets that are built once per rule.
+type ruleEvaluations struct {
+	counts     [ruleOutcomes]atomic.Int64
+	attributes [ruleOutcomes]metric.ObserveOption
+}
+
+var (
+	ruleEvaluationsMu     sync.Mutex
+	ruleEvaluationsByName = make(map[string]*ruleEvaluations)
 )
 
+// ruleEvaluationsOf returns the evaluation counts of the rule with the given name
+func ruleEvaluationsOf(rule string) *ruleEvaluations {
+	ruleEvaluationsMu.Lock()
+	defer ruleEvaluationsMu.Unlock()
+
+	if evaluations, ok := ruleEvaluationsByName[rule]; ok {
+		return evaluations
+	}
+	evaluations := &ruleEvaluations{}
+	for outcome := ruleResultPass; outcome < ruleOutcomes; outcome++ {
+		evaluations.attributes[outcome] = metric.WithAttributeSet(attribute.NewSet(
+			attribute.String("ssv.p2p.message.validation.rule", rule),
+			attribute.String("ssv.p2p.message.validation.rule.result", outcome.String())))
+	}
+	ruleEvaluationsByName[rule] = evaluations
+	return evaluations
+}
+
+func (e *ruleEvaluations) add(outcome ruleOutcome) {
+	e.counts[outcome].Add(1)
+}
+
+func observeRuleEvaluations(_ context.Context, observer metric.Int64Observer) error {
+	ruleEvaluationsMu.Lock()
+	defer ruleEvaluationsMu.Unlock()
+
+	for _, evaluations := range ruleEvaluationsByName {
+		for outcome := ruleResultPass; outcome < ruleOutcomes; outcome++ {
+			observer.Observe(evaluations.counts[outcome].Load(), evaluations.attributes[outcome])
+		}
+	}
+	return nil
+}
+
 func metricName(name string) string {
 	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
 }
@@ -75,3 +144,15 @@ func recordRejectedMessage(ctx context.Context, role types.RunnerRole, reason st
 func recordIgnoredMessage(ctx context.Context, role types.RunnerRole, reason string) {
 	messageValidationsIgnoredCounter.Add(ctx, 1, metric.WithAttributes(reasonAttribute(reason), observability.RunnerRoleAttribute(role)))
 }
+
+func peerIDAttribute(id peer.ID) attribute.KeyValue {
+	return attribute.String("ssv.p2p.peer.id", id.String())
+}
+
+func recordPeerRateLimited(ctx context.Context, id peer.ID) {
+	peerRateLimitedCounter.Add(ctx, 1, metric.WithAttributes(peerIDAttribute(id)))
+}
+
+func recordPeerReputation(id peer.ID, score float64) {
+	peerReputationGauge.Record(context.Background(), score, metric.WithAttributes(peerIDAttribute(id)))
+}
@@ -1,8 +1,12 @@
 package validation
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/operator/clockdrift"
 )
 
 // Option represents a functional option for configuring a messageValidator.
@@ -22,3 +26,33 @@ func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 		mv.selfAccept = selfAccept
 	}
 }
+
+// WithPeerReputation rate limits the messages of every peer and tracks their validation results.
+func WithPeerReputation(pr *PeerReputation) Option {
+	return func(mv *messageValidator) {
+		mv.peerReputation = pr
+	}
+}
+
+// WithFailureAudit records the messages that fail validation in the given audit log.
+func WithFailureAudit(fa *FailureAudit) Option {
+	return func(mv *messageValidator) {
+		mv.failureAudit = fa
+	}
+}
+
+// WithClockDrift samples the arrival of valid messages which peers send at the start of a slot
+// to estimate the drift of the local clock.
+func WithClockDrift(e *clockdrift.Estimator) Option {
+	return func(mv *messageValidator) {
+		mv.clockDrift = e
+	}
+}
+
+// WithClock sets the clock that the arrival time of messages is read from, time.Now is used by default.
+// Useful for validating messages on a virtual clock.
+func WithClock(now func() time.Time) Option {
+	return func(mv *messageValidator) {
+		mv.now = now
+	}
+}
@@ -3,6 +3,7 @@ package validation
 // partial_validation.go contains methods for validating partial signature messages
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"time"
@@ -11,9 +12,30 @@ import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	"github.com/ssvlabs/ssv-spec/types"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 )
 
+// partialSignatureRules are the rules of partial signature messages, in the order in which they're evaluated
+var partialSignatureRules = newRules([]rule[*partialSignatureInput]{
+	{name: "size", check: (*messageValidator).checkPartialSignatureSize},
+	{name: "decode", check: (*messageValidator).checkPartialSignatureDecode},
+	{name: "signer", check: (*messageValidator).checkPartialSignatureSigner},
+	{name: "full_data", check: (*messageValidator).checkPartialSignatureFullData},
+	{name: "type", check: (*messageValidator).checkPartialSignatureType},
+	{name: "type_role", check: (*messageValidator).checkPartialSignatureTypeRole},
+	{name: "messages", check: (*messageValidator).checkPartialSignatureMessages},
+	{name: "slot_advanced", check: (*messageValidator).checkPartialSignatureSlotAdvanced},
+	{name: "beacon_duty", check: (*messageValidator).checkPartialSignatureBeaconDuty},
+	{name: "message_counts", check: (*messageValidator).checkPartialSignatureMessageCounts},
+	{name: "slot_time", check: (*messageValidator).checkPartialSignatureSlotTime},
+	{name: "duty_count", check: (*messageValidator).checkPartialSignatureDutyCount},
+	{name: "signature_count", check: (*messageValidator).checkPartialSignatureCount},
+	{name: "signature", check: (*messageValidator).checkPartialSignatureSignature},
+})
+
 func (mv *messageValidator) validatePartialSignatureMessage(
+	ctx context.Context,
 	signedSSVMessage *spectypes.SignedSSVMessage,
 	committeeInfo CommitteeInfo,
 	receivedAt time.Time,
@@ -21,74 +43,90 @@ func (mv *messageValidator) validatePartialSignatureMessage(
 	*spectypes.PartialSignatureMessages,
 	error,
 ) {
-	ssvMessage := signedSSVMessage.SSVMessage
-
-	if len(ssvMessage.Data) > maxEncodedPartialSignatureSize {
-		e := ErrSSVDataTooBig
-		e.got = len(ssvMessage.Data)
-		e.want = maxEncodedPartialSignatureSize
-		return nil, e
+	in := &partialSignatureInput{
+		ctx:              ctx,
+		signedSSVMessage: signedSSVMessage,
+		committeeInfo:    committeeInfo,
+		receivedAt:       receivedAt,
 	}
 
-	partialSignatureMessages := &spectypes.PartialSignatureMessages{}
-	if err := partialSignatureMessages.Decode(ssvMessage.Data); err != nil {
-		e := ErrUndecodableMessageData
-		e.innerErr = err
-		return nil, e
+	if err := runRules(mv, partialSignatureRules, in); err != nil {
+		return in.partialSignatureMessages, err
 	}
 
-	if err := mv.validatePartialSignatureMessageSemantics(signedSSVMessage, partialSignatureMessages, committeeInfo.indices); err != nil {
+	signer := signedSSVMessage.OperatorIDs[0]
+	if err := mv.updatePartialSignatureState(in.partialSignatureMessages, in.consensusState(mv), signer); err != nil {
 		return nil, err
 	}
 
-	msgID := ssvMessage.GetID()
-	state := mv.consensusState(msgID)
-	if err := mv.validatePartialSigMessagesByDutyLogic(signedSSVMessage, partialSignatureMessages, committeeInfo, receivedAt, state); err != nil {
-		return nil, err
-	}
+	return in.partialSignatureMessages, nil
+}
 
-	signature := signedSSVMessage.Signatures[0]
-	signer := signedSSVMessage.OperatorIDs[0]
-	if err := mv.signatureVerifier.VerifySignature(signer, ssvMessage, signature); err != nil {
-		e := ErrSignatureVerification
-		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", signer, err)
-		return partialSignatureMessages, e
+// observeClockDrift samples the arrival of valid pre-consensus messages which are sent at the start of their slot.
+// Messages failing validation aren't sampled, as they could be forged to skew the estimate.
+func (mv *messageValidator) observeClockDrift(msg *queue.SSVMessage, receivedAt time.Time) {
+	if mv.clockDrift == nil {
+		return
 	}
-
-	if err := mv.updatePartialSignatureState(partialSignatureMessages, state, signer); err != nil {
-		return nil, err
+	partialSignatureMessages, ok := msg.Body.(*spectypes.PartialSignatureMessages)
+	if !ok {
+		return
 	}
+	switch partialSignatureMessages.Type {
+	case spectypes.RandaoPartialSig, spectypes.SelectionProofPartialSig, spectypes.ContributionProofs:
+		mv.clockDrift.ObservePeerMessage(partialSignatureMessages.Slot, receivedAt)
+	}
+}
 
-	return partialSignatureMessages, nil
+func (mv *messageValidator) checkPartialSignatureSize(in *partialSignatureInput) error {
+	maxSize := mv.limits.maxPartialSignatureMessageSize()
+	if len(in.signedSSVMessage.SSVMessage.Data) > maxSize {
+		e := ErrSSVDataTooBig
+		e.got = len(in.signedSSVMessage.SSVMessage.Data)
+		e.want = maxSize
+		return e
+	}
+	return nil
 }
 
-func (mv *messageValidator) validatePartialSignatureMessageSemantics(
-	signedSSVMessage *spectypes.SignedSSVMessage,
-	partialSignatureMessages *spectypes.PartialSignatureMessages,
-	validatorIndices []phase0.ValidatorIndex,
-) error {
-	role := signedSSVMessage.SSVMessage.GetID().GetRoleType()
+func (mv *messageValidator) checkPartialSignatureDecode(in *partialSignatureInput) error {
+	partialSignatureMessages := &spectypes.PartialSignatureMessages{}
+	if err := partialSignatureMessages.Decode(in.signedSSVMessage.SSVMessage.Data); err != nil {
+		e := ErrUndecodableMessageData
+		e.innerErr = err
+		return e
+	}
+	in.partialSignatureMessages = partialSignatureMessages
+	return nil
+}
 
+func (mv *messageValidator) checkPartialSignatureSigner(in *partialSignatureInput) error {
 	// Rule: Partial Signature message must have 1 signer
-	signers := signedSSVMessage.OperatorIDs
-	if len(signers) != 1 {
+	if len(in.signedSSVMessage.OperatorIDs) != 1 {
 		return ErrPartialSigOneSigner
 	}
+	return nil
+}
 
-	signer := signers[0]
-
+func (mv *messageValidator) checkPartialSignatureFullData(in *partialSignatureInput) error {
 	// Rule: Partial signature message must not have full data
-	if len(signedSSVMessage.FullData) > 0 {
+	if len(in.signedSSVMessage.FullData) > 0 {
 		return ErrFullDataNotInConsensusMessage
 	}
+	return nil
+}
 
+func (mv *messageValidator) checkPartialSignatureType(in *partialSignatureInput) error {
 	// Rule: Valid signature type
-	if !mv.validPartialSigMsgType(partialSignatureMessages.Type) {
+	if !mv.validPartialSigMsgType(in.partialSignatureMessages.Type) {
 		e := ErrInvalidPartialSignatureType
-		e.got = partialSignatureMessages.Type
+		e.got = in.partialSignatureMessages.Type
 		return e
 	}
+	return nil
+}
 
+func (mv *messageValidator) checkPartialSignatureTypeRole(in *partialSignatureInput) error {
 	// Rule: Partial signature type must match expected type:
 	// - PostConsensusPartialSig, for Committee duty
 	// - RandaoPartialSig or PostConsensusPartialSig for Proposer
@@ -96,16 +134,20 @@ func (mv *messageValidator) validatePartialSignatureMessageSemantics(
 	// - SelectionProofPartialSig or PostConsensusPartialSig for Sync committee contribution
 	// - ValidatorRegistrationPartialSig for Validator Registration
 	// - VoluntaryExitPartialSig for Voluntary Exit
-	if !mv.partialSignatureTypeMatchesRole(partialSignatureMessages.Type, role) {
+	if !mv.partialSignatureTypeMatchesRole(in.partialSignatureMessages.Type, in.signedSSVMessage.SSVMessage.GetID().GetRoleType()) {
 		return ErrPartialSignatureTypeRoleMismatch
 	}
+	return nil
+}
 
+func (mv *messageValidator) checkPartialSignatureMessages(in *partialSignatureInput) error {
 	// Rule: Partial signature message must have at least one signature
-	if len(partialSignatureMessages.Messages) == 0 {
+	if len(in.partialSignatureMessages.Messages) == 0 {
 		return ErrNoPartialSignatureMessages
 	}
 
-	for _, message := range partialSignatureMessages.Messages {
+	signer := in.signedSSVMessage.OperatorIDs[0]
+	for _, message := range in.partialSignatureMessages.Messages {
 		// Rule: Partial signature must have expected length. Already enforced by ssz.
 
 		// Rule: Partial signature signer must be consistent
@@ -119,11 +161,11 @@ func (mv *messageValidator) validatePartialSignatureMessageSemantics(
 		// Rule: (only for Validator duties) Validator index must match with validatorPK
 		// For Committee duties, we don't assume that operators are synced on the validators set
 		// So, we can't make this assertion
-		if !mv.committeeRole(signedSSVMessage.SSVMessage.GetID().GetRoleType()) {
-			if !slices.Contains(validatorIndices, message.ValidatorIndex) {
+		if !mv.committeeRole(in.signedSSVMessage.SSVMessage.GetID().GetRoleType()) {
+			if !slices.Contains(in.committeeInfo.indices, message.ValidatorIndex) {
 				e := ErrValidatorIndexMismatch
 				e.got = message.ValidatorIndex
-				e.want = validatorIndices
+				e.want = in.committeeInfo.indices
 				return e
 			}
 		}
@@ -132,67 +174,70 @@ func (mv *messageValidator) validatePartialSignatureMessageSemantics(
 	return nil
 }
 
-func (mv *messageValidator) validatePartialSigMessagesByDutyLogic(
-	signedSSVMessage *spectypes.SignedSSVMessage,
-	partialSignatureMessages *spectypes.PartialSignatureMessages,
-	committeeInfo CommitteeInfo,
-	receivedAt time.Time,
-	state *consensusState,
-) error {
-	role := signedSSVMessage.SSVMessage.GetID().GetRoleType()
-	messageSlot := partialSignatureMessages.Slot
-	signer := signedSSVMessage.OperatorIDs[0]
-	signerStateBySlot := state.GetOrCreate(signer)
-
+func (mv *messageValidator) checkPartialSignatureSlotAdvanced(in *partialSignatureInput) error {
 	// Rule: Height must not be "old". I.e., signer must not have already advanced to a later slot.
-	if role != types.RoleCommittee { // Rule only for validator runners
-		maxSlot := signerStateBySlot.MaxSlot()
-		if maxSlot != 0 && maxSlot > partialSignatureMessages.Slot {
-			e := ErrSlotAlreadyAdvanced
-			e.got = partialSignatureMessages.Slot
-			e.want = maxSlot
-			return e
-		}
+	if in.signedSSVMessage.SSVMessage.GetID().GetRoleType() == types.RoleCommittee { // Rule only for validator runners
+		return nil
 	}
 
-	randaoMsg := partialSignatureMessages.Type == spectypes.RandaoPartialSig
-	if err := mv.validateBeaconDuty(signedSSVMessage.SSVMessage.GetID().GetRoleType(), messageSlot, committeeInfo.indices, randaoMsg); err != nil {
-		return err
+	signerStateBySlot := in.consensusState(mv).GetOrCreate(in.signedSSVMessage.OperatorIDs[0])
+	maxSlot := signerStateBySlot.MaxSlot()
+	if maxSlot != 0 && maxSlot > in.partialSignatureMessages.Slot {
+		e := ErrSlotAlreadyAdvanced
+		e.got = in.partialSignatureMessages.Slot
+		e.want = maxSlot
+		return e
 	}
 
-	if signerState := signerStateBySlot.Get(messageSlot); signerState != nil && signerState.Slot == messageSlot {
-		// Rule: peer must send only:
-		// - 1 PostConsensusPartialSig, for Committee duty
-		// - 1 RandaoPartialSig and 1 PostConsensusPartialSig for Proposer
-		// - 1 SelectionProofPartialSig and 1 PostConsensusPartialSig for Aggregator
-		// - 1 SelectionProofPartialSig and 1 PostConsensusPartialSig for Sync committee contribution
-		// - 1 ValidatorRegistrationPartialSig for Validator Registration
-		// - 1 VoluntaryExitPartialSig for Voluntary Exit
-		limits := maxMessageCounts()
-		if err := signerState.MessageCounts.ValidatePartialSignatureMessage(partialSignatureMessages, limits); err != nil {
-			return err
-		}
+	return nil
+}
+
+func (mv *messageValidator) checkPartialSignatureBeaconDuty(in *partialSignatureInput) error {
+	randaoMsg := in.partialSignatureMessages.Type == spectypes.RandaoPartialSig
+	return mv.validateBeaconDuty(in.signedSSVMessage.SSVMessage.GetID().GetRoleType(), in.partialSignatureMessages.Slot, in.committeeInfo.indices, randaoMsg)
+}
+
+func (mv *messageValidator) checkPartialSignatureMessageCounts(in *partialSignatureInput) error {
+	messageSlot := in.partialSignatureMessages.Slot
+	signerStateBySlot := in.consensusState(mv).GetOrCreate(in.signedSSVMessage.OperatorIDs[0])
+	signerState := signerStateBySlot.Get(messageSlot)
+	if signerState == nil || signerState.Slot != messageSlot {
+		return nil
 	}
 
-	// Rule: current slot must be between duty's starting slot and:
+	// Rule: peer must send only:
+	// - 1 PostConsensusPartialSig, for Committee duty
+	// - 1 RandaoPartialSig and 1 PostConsensusPartialSig for Proposer
+	// - 1 SelectionProofPartialSig and 1 PostConsensusPartialSig for Aggregator
+	// - 1 SelectionProofPartialSig and 1 PostConsensusPartialSig for Sync committee contribution
+	// - 1 ValidatorRegistrationPartialSig for Validator Registration
+	// - 1 VoluntaryExitPartialSig for Voluntary Exit
+	limits := maxMessageCounts()
+	return signerState.MessageCounts.ValidatePartialSignatureMessage(in.partialSignatureMessages, limits)
+}
+
+func (mv *messageValidator) checkPartialSignatureSlotTime(in *partialSignatureInput) error {
+	// Rule: current slot must be between duty's starting slot and, by default:
 	// - duty's starting slot + 34 (committee and aggregation)
 	// - duty's starting slot + 3 (other duties)
-	if err := mv.validateSlotTime(messageSlot, role, receivedAt); err != nil {
-		return err
-	}
+	return mv.validateSlotTime(in.partialSignatureMessages.Slot, in.signedSSVMessage.SSVMessage.GetID().GetRoleType(), in.receivedAt)
+}
 
-	// Rule: valid number of duties per epoch:
+func (mv *messageValidator) checkPartialSignatureDutyCount(in *partialSignatureInput) error {
+	// Rule: valid number of duties per epoch, by default:
 	// - 2 for aggregation, voluntary exit and validator registration
 	// - 2*V for Committee duty (where V is the number of validators in the cluster) (if no validator is doing sync committee in this epoch)
 	// - else, accept
-	if err := mv.validateDutyCount(signedSSVMessage.SSVMessage.GetID(), messageSlot, committeeInfo.indices, signerStateBySlot); err != nil {
-		return err
-	}
+	signerStateBySlot := in.consensusState(mv).GetOrCreate(in.signedSSVMessage.OperatorIDs[0])
+	return mv.validateDutyCount(in.signedSSVMessage.SSVMessage.GetID(), in.partialSignatureMessages.Slot, in.committeeInfo.indices, signerStateBySlot)
+}
 
-	clusterValidatorCount := len(committeeInfo.indices)
-	partialSignatureMessageCount := len(partialSignatureMessages.Messages)
+func (mv *messageValidator) checkPartialSignatureCount(in *partialSignatureInput) error {
+	clusterValidatorCount := len(in.committeeInfo.indices)
+	partialSignatureMessageCount := len(in.partialSignatureMessages.Messages)
 
-	if signedSSVMessage.SSVMessage.MsgID.GetRoleType() == spectypes.RoleCommittee {
+	switch in.signedSSVMessage.SSVMessage.MsgID.GetRoleType() {
+	case spectypes.RoleCommittee:
 		// Rule: The number of signatures must be <= min(2*V, V + SYNC_COMMITTEE_SIZE) where V is the number of validators assigned to the cluster
 		if partialSignatureMessageCount > min(2*clusterValidatorCount, clusterValidatorCount+syncCommitteeSize) {
 			return ErrTooManyPartialSignatureMessages
@@ -200,31 +245,46 @@ func (mv *messageValidator) validatePartialSigMessagesByDutyLogic(
 
 		// Rule: a ValidatorIndex can't appear more than 2 times in the []*PartialSignatureMessage list
 		validatorIndexCount := make(map[phase0.ValidatorIndex]int)
-		for _, message := range partialSignatureMessages.Messages {
+		for _, message := range in.partialSignatureMessages.Messages {
 			validatorIndexCount[message.ValidatorIndex]++
 			if validatorIndexCount[message.ValidatorIndex] > 2 {
 				return ErrTripleValidatorIndexInPartialSignatures
 			}
 		}
-	} else if signedSSVMessage.SSVMessage.MsgID.GetRoleType() == types.RoleSyncCommitteeContribution {
+
+	case spectypes.RoleSyncCommitteeContribution:
 		// Rule: The number of signatures must be <= MaxSignaturesInSyncCommitteeContribution for the sync comittee contribution duty
-		if partialSignatureMessageCount > maxSignatures {
+		if maxCount := mv.limits.maxSyncCommitteeContributionSignatures(); partialSignatureMessageCount > maxCount {
 			e := ErrTooManyPartialSignatureMessages
 			e.got = partialSignatureMessageCount
-			e.want = maxSignatures
+			e.want = maxCount
 			return e
 		}
-	} else if partialSignatureMessageCount > 1 {
+
+	default:
 		// Rule: The number of signatures must be 1 for the other types of duties
-		e := ErrTooManyPartialSignatureMessages
-		e.got = partialSignatureMessageCount
-		e.want = 1
-		return e
+		if partialSignatureMessageCount > 1 {
+			e := ErrTooManyPartialSignatureMessages
+			e.got = partialSignatureMessageCount
+			e.want = 1
+			return e
+		}
 	}
 
 	return nil
 }
 
+func (mv *messageValidator) checkPartialSignatureSignature(in *partialSignatureInput) error {
+	signature := in.signedSSVMessage.Signatures[0]
+	signer := in.signedSSVMessage.OperatorIDs[0]
+	if err := mv.signatureVerifier.VerifySignature(in.ctx, signer, in.signedSSVMessage.SSVMessage, signature); err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", signer, err)
+		return e
+	}
+	return nil
+}
+
 func (mv *messageValidator) updatePartialSignatureState(
 	partialSignatureMessages *spectypes.PartialSignatureMessages,
 	state *consensusState,
@@ -0,0 +1,191 @@
+package validation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultPeerRateLimit      = 500
+	defaultPeerRateBurst      = 1000
+	defaultMaxRejectRatio     = 0.1
+	defaultMaxIgnoreRatio     = 0.9
+	defaultMinPeerMessages    = 200
+	defaultReputationHalfLife = 384 * time.Second // one epoch
+	// idlePeerHalfLives is the number of half-lives after which an idle peer is forgotten
+	idlePeerHalfLives = 5
+)
+
+// PeerReputationConfig configures the per-peer rate limiting and reputation of message validation.
+// zero values are replaced by defaults.
+type PeerReputationConfig struct {
+	RateLimit      float64       `yaml:"RateLimit" env:"P2P_PEER_RATE_LIMIT" env-description:"Maximum number of messages per second validated from a single peer"`
+	RateBurst      int           `yaml:"RateBurst" env:"P2P_PEER_RATE_BURST" env-description:"Maximum burst of messages validated from a single peer"`
+	MaxRejectRatio float64       `yaml:"MaxRejectRatio" env:"P2P_PEER_MAX_REJECT_RATIO" env-description:"Ratio of rejected messages above which a peer is considered bad"`
+	MaxIgnoreRatio float64       `yaml:"MaxIgnoreRatio" env:"P2P_PEER_MAX_IGNORE_RATIO" env-description:"Ratio of ignored messages at which the gossipsub score of a peer bottoms out"`
+	MinMessages    uint64        `yaml:"MinMessages" env:"P2P_PEER_MIN_MESSAGES" env-description:"Number of messages required before the ratios of a peer are considered"`
+	HalfLife       time.Duration `yaml:"HalfLife" env:"P2P_PEER_REPUTATION_HALF_LIFE" env-description:"Half-life of the message counts of a peer"`
+}
+
+func (c PeerReputationConfig) withDefaults() PeerReputationConfig {
+	if c.RateLimit == 0 {
+		c.RateLimit = defaultPeerRateLimit
+	}
+	if c.RateBurst == 0 {
+		c.RateBurst = defaultPeerRateBurst
+	}
+	if c.MaxRejectRatio == 0 {
+		c.MaxRejectRatio = defaultMaxRejectRatio
+	}
+	if c.MaxIgnoreRatio == 0 {
+		c.MaxIgnoreRatio = defaultMaxIgnoreRatio
+	}
+	if c.MinMessages == 0 {
+		c.MinMessages = defaultMinPeerMessages
+	}
+	if c.HalfLife == 0 {
+		c.HalfLife = defaultReputationHalfLife
+	}
+	return c
+}
+
+// PeerReputation rate limits the messages that every peer forwards with a token bucket,
+// and tracks the ratio of ignored and rejected messages of every peer.
+// counts decay exponentially, so a peer recovers once it stops misbehaving.
+type PeerReputation struct {
+	cfg PeerReputationConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	peers     map[peer.ID]*peerRecord
+	lastPrune time.Time
+}
+
+type peerRecord struct {
+	limiter *rate.Limiter
+
+	accepted float64
+	ignored  float64
+	rejected float64
+	updated  time.Time
+}
+
+// NewPeerReputation creates a new PeerReputation
+func NewPeerReputation(cfg PeerReputationConfig) *PeerReputation {
+	return &PeerReputation{
+		cfg:   cfg.withDefaults(),
+		now:   time.Now,
+		peers: make(map[peer.ID]*peerRecord),
+	}
+}
+
+// Allow takes a token from the bucket of the given peer, returns false if the peer exceeded its rate limit
+func (pr *PeerReputation) Allow(id peer.ID) bool {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	now := pr.now()
+	return pr.record(id, now).limiter.AllowN(now, 1)
+}
+
+// Record counts the validation result of a message that was forwarded by the given peer
+func (pr *PeerReputation) Record(id peer.ID, result pubsub.ValidationResult) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	rec := pr.record(id, pr.now())
+	switch result {
+	case pubsub.ValidationAccept:
+		rec.accepted++
+	case pubsub.ValidationIgnore:
+		rec.ignored++
+	case pubsub.ValidationReject:
+		rec.rejected++
+	}
+}
+
+// Score returns the application-specific gossipsub score of the given peer, between -1 and 0.
+// it grows quadratically as the ignore or reject ratio of the peer approaches its maximum.
+func (pr *PeerReputation) Score(id peer.ID) float64 {
+	rejectPenalty, ignorePenalty := pr.penalties(id)
+	penalty := max(rejectPenalty, ignorePenalty)
+	score := -penalty * penalty
+	recordPeerReputation(id, score)
+	return score
+}
+
+// HasBadReputation returns true if the reject ratio of the given peer exceeds its maximum.
+// ignored messages don't count, as most of them are caused by our own state (such as a missing share
+// or a lagging beacon node) rather than by the peer, so they only lower its gossipsub score.
+func (pr *PeerReputation) HasBadReputation(id peer.ID) bool {
+	rejectPenalty, _ := pr.penalties(id)
+	return rejectPenalty >= 1
+}
+
+// penalties returns the reject and ignore ratios of the given peer relative to their maximum, capped at 1
+func (pr *PeerReputation) penalties(id peer.ID) (rejectPenalty, ignorePenalty float64) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	rec, ok := pr.peers[id]
+	if !ok {
+		return 0, 0
+	}
+	rec.decay(pr.now(), pr.cfg.HalfLife)
+
+	total := rec.accepted + rec.ignored + rec.rejected
+	if total < float64(pr.cfg.MinMessages) {
+		return 0, 0
+	}
+	rejectPenalty = min(rec.rejected/total/pr.cfg.MaxRejectRatio, 1)
+	ignorePenalty = min(rec.ignored/total/pr.cfg.MaxIgnoreRatio, 1)
+	return rejectPenalty, ignorePenalty
+}
+
+// record returns the decayed record of the given peer, creating it if needed.
+// must be called with the lock held.
+func (pr *PeerReputation) record(id peer.ID, now time.Time) *peerRecord {
+	pr.prune(now)
+
+	rec, ok := pr.peers[id]
+	if !ok {
+		rec = &peerRecord{
+			limiter: rate.NewLimiter(rate.Limit(pr.cfg.RateLimit), pr.cfg.RateBurst),
+			updated: now,
+		}
+		pr.peers[id] = rec
+	}
+	rec.decay(now, pr.cfg.HalfLife)
+	return rec
+}
+
+// prune forgets peers that have been idle for a few half-lives, at most once per half-life.
+// must be called with the lock held.
+func (pr *PeerReputation) prune(now time.Time) {
+	if now.Sub(pr.lastPrune) < pr.cfg.HalfLife {
+		return
+	}
+	pr.lastPrune = now
+	for id, rec := range pr.peers {
+		if now.Sub(rec.updated) > idlePeerHalfLives*pr.cfg.HalfLife {
+			delete(pr.peers, id)
+		}
+	}
+}
+
+func (rec *peerRecord) decay(now time.Time, halfLife time.Duration) {
+	elapsed := now.Sub(rec.updated)
+	if elapsed <= 0 {
+		return
+	}
+	factor := math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	rec.accepted *= factor
+	rec.ignored *= factor
+	rec.rejected *= factor
+	rec.updated = now
+}
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+)
+
+func newTestPeerReputation(cfg PeerReputationConfig) (*PeerReputation, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	pr := NewPeerReputation(cfg)
+	pr.now = func() time.Time { return now }
+	return pr, &now
+}
+
+func TestPeerReputation_RateLimit(t *testing.T) {
+	pr, now := newTestPeerReputation(PeerReputationConfig{RateLimit: 10, RateBurst: 5})
+	p1, p2 := peer.ID("p1"), peer.ID("p2")
+
+	for i := 0; i < 5; i++ {
+		require.True(t, pr.Allow(p1))
+	}
+	require.False(t, pr.Allow(p1))
+	// buckets are per peer
+	require.True(t, pr.Allow(p2))
+
+	*now = now.Add(100 * time.Millisecond)
+	require.True(t, pr.Allow(p1))
+	require.False(t, pr.Allow(p1))
+}
+
+func TestPeerReputation_Ratios(t *testing.T) {
+	pr, now := newTestPeerReputation(PeerReputationConfig{MinMessages: 100, MaxRejectRatio: 0.1, MaxIgnoreRatio: 0.5, HalfLife: time.Minute})
+	good, rejecting, ignoring := peer.ID("good"), peer.ID("rejecting"), peer.ID("ignoring")
+
+	for i := 0; i < 100; i++ {
+		pr.Record(good, pubsub.ValidationAccept)
+		if i%20 == 0 {
+			pr.Record(rejecting, pubsub.ValidationReject)
+		} else {
+			pr.Record(rejecting, pubsub.ValidationAccept)
+		}
+		if i%2 == 0 {
+			pr.Record(ignoring, pubsub.ValidationIgnore)
+		} else {
+			pr.Record(ignoring, pubsub.ValidationAccept)
+		}
+	}
+
+	require.Zero(t, pr.Score(good))
+	require.False(t, pr.HasBadReputation(good))
+
+	// 5% rejected is half of the maximum
+	require.InDelta(t, -0.25, pr.Score(rejecting), 0.001)
+	require.False(t, pr.HasBadReputation(rejecting))
+
+	// ignores only lower the score, as they are mostly caused by our own state
+	require.Equal(t, -1.0, pr.Score(ignoring))
+	require.False(t, pr.HasBadReputation(ignoring))
+
+	for i := 0; i < 100; i++ {
+		pr.Record(rejecting, pubsub.ValidationReject)
+	}
+	require.Equal(t, -1.0, pr.Score(rejecting))
+	require.True(t, pr.HasBadReputation(rejecting))
+
+	// not enough messages after decay
+	*now = now.Add(time.Minute)
+	require.Zero(t, pr.Score(ignoring))
+	require.Less(t, pr.Score(rejecting), -0.9)
+	require.True(t, pr.HasBadReputation(rejecting))
+
+	// idle peers are forgotten
+	*now = now.Add(10 * time.Minute)
+	require.True(t, pr.Allow(good))
+	pr.mu.Lock()
+	require.Len(t, pr.peers, 1)
+	pr.mu.Unlock()
+}
+
+func TestMessageValidator_PeerRateLimit(t *testing.T) {
+	pr, _ := newTestPeerReputation(PeerReputationConfig{RateLimit: 1, RateBurst: 2, MinMessages: 3})
+	mv := New(networkconfig.TestNetwork, nil, nil, nil, WithPeerReputation(pr)).(*messageValidator)
+
+	p := peer.ID("peer")
+	msg := &pubsub.Message{Message: &pspb.Message{}}
+	for i := 0; i < 2; i++ {
+		require.Equal(t, pubsub.ValidationReject, mv.Validate(context.Background(), p, msg))
+	}
+	require.Equal(t, pubsub.ValidationIgnore, mv.Validate(context.Background(), p, msg))
+	require.False(t, pr.HasBadReputation(p), "rate limited messages shouldn't count towards the reputation")
+
+	pr.mu.Lock()
+	require.Zero(t, pr.peers[p].ignored)
+	require.InDelta(t, 2, pr.peers[p].rejected, 0.01)
+	pr.mu.Unlock()
+}
@@ -0,0 +1,114 @@
+package validation
+
+// rules.go contains the engine that evaluates the rules of consensus and partial signature messages
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// ruleOutcome is the result of evaluating a rule
+type ruleOutcome int
+
+const (
+	ruleResultPass ruleOutcome = iota
+	ruleResultIgnore
+	ruleResultReject
+	ruleOutcomes
+)
+
+func (o ruleOutcome) String() string {
+	switch o {
+	case ruleResultPass:
+		return "pass"
+	case ruleResultIgnore:
+		return "ignore"
+	case ruleResultReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// rule is a single named check of message validation. Rules are evaluated in order
+// and the first one that fails determines whether the message is ignored or rejected.
+type rule[T any] struct {
+	name  string
+	check func(mv *messageValidator, in T) error
+	// evaluations counts the outcomes of the rule, it's set by newRules
+	evaluations *ruleEvaluations
+}
+
+// newRules prepares the rules for evaluation, rules with the same name share their evaluation counts
+func newRules[T any](rules []rule[T]) []rule[T] {
+	for i := range rules {
+		rules[i].evaluations = ruleEvaluationsOf(rules[i].name)
+	}
+	return rules
+}
+
+// runRules evaluates the rules in order, counting the outcome of each, and returns the error of the first failing rule.
+func runRules[T any](mv *messageValidator, rules []rule[T], in T) error {
+	for _, r := range rules {
+		err := r.check(mv, in)
+		r.evaluations.add(ruleResult(err))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleResult maps the error of a rule to the result it causes, following handleValidationError
+func ruleResult(err error) ruleOutcome {
+	if err == nil {
+		return ruleResultPass
+	}
+	var valErr Error
+	if errors.As(err, &valErr) && valErr.Reject() {
+		return ruleResultReject
+	}
+	return ruleResultIgnore
+}
+
+// consensusInput is the input of the rules of consensus messages
+type consensusInput struct {
+	ctx              context.Context
+	signedSSVMessage *spectypes.SignedSSVMessage
+	// consensusMessage is set by the decode rule
+	consensusMessage *specqbft.Message
+	committeeInfo    CommitteeInfo
+	receivedAt       time.Time
+	state            *consensusState
+}
+
+// consensusState returns the state of the message's duty, it's fetched once the message passed its semantic rules
+func (in *consensusInput) consensusState(mv *messageValidator) *consensusState {
+	if in.state == nil {
+		in.state = mv.consensusState(in.signedSSVMessage.SSVMessage.GetID())
+	}
+	return in.state
+}
+
+// partialSignatureInput is the input of the rules of partial signature messages
+type partialSignatureInput struct {
+	ctx              context.Context
+	signedSSVMessage *spectypes.SignedSSVMessage
+	// partialSignatureMessages is set by the decode rule
+	partialSignatureMessages *spectypes.PartialSignatureMessages
+	committeeInfo            CommitteeInfo
+	receivedAt               time.Time
+	state                    *consensusState
+}
+
+// consensusState returns the state of the message's duty, it's fetched once the message passed its semantic rules
+func (in *partialSignatureInput) consensusState(mv *messageValidator) *consensusState {
+	if in.state == nil {
+		in.state = mv.consensusState(in.signedSSVMessage.SSVMessage.GetID())
+	}
+	return in.state
+}
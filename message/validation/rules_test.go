@@ -0,0 +1,191 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/clockdrift"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+)
+
+func testConsensusInput(role spectypes.RunnerRole, msg *specqbft.Message) *consensusInput {
+	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType, make([]byte, 48), role)
+	return &consensusInput{
+		signedSSVMessage: &spectypes.SignedSSVMessage{
+			OperatorIDs: []spectypes.OperatorID{1},
+			SSVMessage: &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   msgID,
+			},
+		},
+		consensusMessage: msg,
+	}
+}
+
+func TestRules_MaxRound(t *testing.T) {
+	netCfg := networkconfig.TestNetwork
+	netCfg.MessageValidation.MaxRounds = map[spectypes.RunnerRole]specqbft.Round{
+		spectypes.RoleProposer: 3,
+	}
+	mv := &messageValidator{netCfg: netCfg, limits: limits{cfg: netCfg.MessageValidation}}
+
+	t.Run("overridden role", func(t *testing.T) {
+		in := testConsensusInput(spectypes.RoleProposer, &specqbft.Message{Round: 3})
+		require.NoError(t, mv.checkConsensusMaxRound(in))
+
+		in = testConsensusInput(spectypes.RoleProposer, &specqbft.Message{Round: 4})
+		require.ErrorContains(t, mv.checkConsensusMaxRound(in), ErrRoundTooHigh.Error())
+	})
+
+	t.Run("default role", func(t *testing.T) {
+		in := testConsensusInput(spectypes.RoleCommittee, &specqbft.Message{Round: 12})
+		require.NoError(t, mv.checkConsensusMaxRound(in))
+
+		in = testConsensusInput(spectypes.RoleCommittee, &specqbft.Message{Round: 13})
+		require.ErrorContains(t, mv.checkConsensusMaxRound(in), ErrRoundTooHigh.Error())
+	})
+}
+
+func TestRules_SlotTime(t *testing.T) {
+	netCfg := networkconfig.TestNetwork
+	slot := phase0.Slot(100)
+	// the default allowance accepts proposer messages until the start of slot+3 plus the late message margin
+	receivedAt := netCfg.Beacon.GetSlotStartTime(slot + 4)
+	in := testConsensusInput(spectypes.RoleProposer, &specqbft.Message{Height: specqbft.Height(slot)})
+	in.receivedAt = receivedAt
+
+	mv := &messageValidator{netCfg: netCfg}
+	require.ErrorContains(t, mv.checkConsensusSlotTime(in), ErrLateSlotMessage.Error())
+
+	lateSlotAllowance := uint64(3)
+	netCfg.MessageValidation.LateSlotAllowance = &lateSlotAllowance
+	mv = &messageValidator{netCfg: netCfg, limits: limits{cfg: netCfg.MessageValidation}}
+	require.NoError(t, mv.checkConsensusSlotTime(in))
+
+	// a zero allowance is enforced rather than replaced by the default
+	lateSlotAllowance = 0
+	in.receivedAt = netCfg.Beacon.GetSlotStartTime(slot + 2)
+	require.ErrorContains(t, mv.checkConsensusSlotTime(in), ErrLateSlotMessage.Error())
+}
+
+func TestLimits_ExplicitZero(t *testing.T) {
+	zero := 0
+	l := limits{cfg: networkconfig.MessageValidationLimits{
+		MaxDutiesPerEpoch:                      map[spectypes.RunnerRole]uint64{spectypes.RoleAggregator: 0},
+		MaxRounds:                              map[spectypes.RunnerRole]specqbft.Round{spectypes.RoleProposer: 0},
+		MaxSyncCommitteeContributionSignatures: &zero,
+	}}
+
+	limit, ok := l.maxDutiesPerEpoch(spectypes.RoleAggregator)
+	require.True(t, ok)
+	require.Zero(t, limit)
+	maxRound, err := l.maxRound(spectypes.RoleProposer)
+	require.NoError(t, err)
+	require.Zero(t, maxRound)
+	require.Zero(t, l.maxSyncCommitteeContributionSignatures())
+
+	// unset limits keep the defaults
+	limit, ok = l.maxDutiesPerEpoch(spectypes.RoleValidatorRegistration)
+	require.True(t, ok)
+	require.EqualValues(t, 2, limit)
+	require.EqualValues(t, lateSlotAllowance, l.lateSlotAllowance())
+}
+
+func TestRules_Size(t *testing.T) {
+	netCfg := networkconfig.TestNetwork
+	maxSize := 100
+	netCfg.MessageValidation.MaxConsensusMessageSize = &maxSize
+	mv := &messageValidator{netCfg: netCfg, limits: limits{cfg: netCfg.MessageValidation}}
+
+	in := testConsensusInput(spectypes.RoleCommittee, nil)
+	in.signedSSVMessage.SSVMessage.Data = make([]byte, 100)
+	require.NoError(t, mv.checkConsensusSize(in))
+
+	in.signedSSVMessage.SSVMessage.Data = make([]byte, 101)
+	err := mv.checkConsensusSize(in)
+	require.ErrorContains(t, err, ErrSSVDataTooBig.Error())
+	require.Equal(t, ruleResultReject, ruleResult(err))
+
+	// limits can't be raised above the default
+	maxSize = maxEncodedConsensusMsgSize * 2
+	require.Equal(t, maxEncodedConsensusMsgSize, mv.limits.maxConsensusMessageSize())
+}
+
+func TestRules_PartialSignatureCount(t *testing.T) {
+	netCfg := networkconfig.TestNetwork
+	maxSignatures := 2
+	netCfg.MessageValidation.MaxSyncCommitteeContributionSignatures = &maxSignatures
+	mv := &messageValidator{netCfg: netCfg, limits: limits{cfg: netCfg.MessageValidation}}
+
+	msgID := spectypes.NewMsgID(netCfg.DomainType, make([]byte, 48), spectypes.RoleSyncCommitteeContribution)
+	in := &partialSignatureInput{
+		signedSSVMessage: &spectypes.SignedSSVMessage{
+			SSVMessage: &spectypes.SSVMessage{MsgID: msgID},
+		},
+		partialSignatureMessages: &spectypes.PartialSignatureMessages{
+			Messages: make([]*spectypes.PartialSignatureMessage, 2),
+		},
+	}
+	require.NoError(t, mv.checkPartialSignatureCount(in))
+
+	in.partialSignatureMessages.Messages = make([]*spectypes.PartialSignatureMessage, 3)
+	require.ErrorContains(t, mv.checkPartialSignatureCount(in), ErrTooManyPartialSignatureMessages.Error())
+}
+
+func TestRunRules(t *testing.T) {
+	var evaluated []string
+	check := func(name string, err error) rule[*consensusInput] {
+		return rule[*consensusInput]{name: name, check: func(*messageValidator, *consensusInput) error {
+			evaluated = append(evaluated, name)
+			return err
+		}}
+	}
+
+	rules := newRules([]rule[*consensusInput]{
+		check("first", nil),
+		check("second", ErrEarlySlotMessage),
+		check("third", nil),
+	})
+
+	err := runRules(&messageValidator{}, rules, &consensusInput{})
+	require.ErrorIs(t, err, ErrEarlySlotMessage)
+	require.Equal(t, []string{"first", "second"}, evaluated)
+	require.Equal(t, ruleResultIgnore, ruleResult(err))
+	require.Equal(t, ruleResultPass, ruleResult(nil))
+
+	// outcomes are counted per rule, without recording a metric per evaluation
+	require.EqualValues(t, 1, rules[0].evaluations.counts[ruleResultPass].Load())
+	require.EqualValues(t, 1, rules[1].evaluations.counts[ruleResultIgnore].Load())
+	require.EqualValues(t, 0, rules[2].evaluations.counts[ruleResultPass].Load())
+	require.Same(t, rules[0].evaluations, ruleEvaluationsOf("first"))
+}
+
+func TestObserveClockDrift(t *testing.T) {
+	netCfg := networkconfig.TestNetwork
+	clockDrift := clockdrift.New(zap.NewNop(), netCfg.Beacon, clockdrift.Config{})
+	mv := &messageValidator{netCfg: netCfg, clockDrift: clockDrift}
+
+	observe := func(msgType spectypes.PartialSigMsgType, n int) {
+		for slot := phase0.Slot(1); slot <= phase0.Slot(n); slot++ {
+			msg := &queue.SSVMessage{Body: &spectypes.PartialSignatureMessages{Type: msgType, Slot: slot}}
+			mv.observeClockDrift(msg, netCfg.Beacon.GetSlotStartTime(slot).Add(time.Second))
+		}
+	}
+
+	// Post-consensus messages aren't sent at the start of their slot.
+	observe(spectypes.PostConsensusPartialSig, 64)
+	_, ok := clockDrift.Estimate()
+	require.False(t, ok)
+
+	observe(spectypes.RandaoPartialSig, 64)
+	drift, ok := clockDrift.Estimate()
+	require.True(t, ok)
+	require.Equal(t, time.Second, drift)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ssvlabs/ssv/message/signatureverifier"
 	"github.com/ssvlabs/ssv/network/commons"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/clockdrift"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/registry/storage"
@@ -39,6 +40,7 @@ type messageValidator struct {
 	validatorStore        storage.ValidatorStore
 	dutyStore             *dutystore.Store
 	signatureVerifier     signatureverifier.SignatureVerifier // TODO: use spectypes.SignatureVerifier
+	limits                limits
 
 	// validationLocks is a map of lock per SSV message ID to
 	// prevent concurrent access to the same state.
@@ -47,6 +49,18 @@ type messageValidator struct {
 
 	selfPID    peer.ID
 	selfAccept bool
+
+	// peerReputation is optional, it rate limits peers and tracks the results of the messages they forward
+	peerReputation *PeerReputation
+
+	// failureAudit is optional, it keeps a log of the latest messages that failed validation
+	failureAudit *FailureAudit
+
+	// clockDrift is optional, it estimates the drift of the local clock from the arrival of valid messages
+	clockDrift *clockdrift.Estimator
+
+	// now returns the arrival time of messages
+	now func() time.Time
 }
 
 // New returns a new MessageValidator with the given network configuration and options.
@@ -65,6 +79,8 @@ func New(
 		validatorStore:      validatorStore,
 		dutyStore:           dutyStore,
 		signatureVerifier:   signatureVerifier,
+		limits:              limits{cfg: netCfg.MessageValidation},
+		now:                 time.Now,
 	}
 
 	for _, opt := range opts {
@@ -94,17 +110,37 @@ func (mv *messageValidator) Validate(ctx context.Context, peerID peer.ID, pmsg *
 
 	recordMessage(ctx)
 
-	decodedMessage, err := mv.handlePubsubMessage(pmsg, time.Now())
+	if mv.peerReputation == nil {
+		return mv.validate(ctx, peerID, pmsg)
+	}
+
+	if !mv.peerReputation.Allow(peerID) {
+		// Messages dropped by our own rate limiting aren't recorded, so they don't count towards the ignore ratio of the peer.
+		recordPeerRateLimited(ctx, peerID)
+		return mv.handleValidationError(ctx, peerID, pmsg.GetTopic(), nil, ErrPeerRateLimited)
+	}
+	result := mv.validate(ctx, peerID, pmsg)
+	mv.peerReputation.Record(peerID, result)
+	return result
+}
+
+func (mv *messageValidator) validate(ctx context.Context, peerID peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	receivedAt := mv.now()
+	decodedMessage, err := mv.handlePubsubMessage(ctx, pmsg, receivedAt)
 	if err != nil {
-		return mv.handleValidationError(ctx, peerID, decodedMessage, err)
+		return mv.handleValidationError(ctx, peerID, pmsg.GetTopic(), decodedMessage, err)
 	}
 
 	pmsg.ValidatorData = decodedMessage
 
+	if !pmsg.Local {
+		mv.observeClockDrift(decodedMessage, receivedAt)
+	}
+
 	return mv.handleValidationSuccess(ctx, decodedMessage)
 }
 
-func (mv *messageValidator) handlePubsubMessage(pMsg *pubsub.Message, receivedAt time.Time) (*queue.SSVMessage, error) {
+func (mv *messageValidator) handlePubsubMessage(ctx context.Context, pMsg *pubsub.Message, receivedAt time.Time) (*queue.SSVMessage, error) {
 	if err := mv.validatePubSubMessage(pMsg); err != nil {
 		return nil, err
 	}
@@ -114,10 +150,10 @@ func (mv *messageValidator) handlePubsubMessage(pMsg *pubsub.Message, receivedAt
 		return nil, err
 	}
 
-	return mv.handleSignedSSVMessage(signedSSVMessage, pMsg.GetTopic(), receivedAt)
+	return mv.handleSignedSSVMessage(ctx, signedSSVMessage, pMsg.GetTopic(), receivedAt)
 }
 
-func (mv *messageValidator) handleSignedSSVMessage(signedSSVMessage *spectypes.SignedSSVMessage, topic string, receivedAt time.Time) (*queue.SSVMessage, error) {
+func (mv *messageValidator) handleSignedSSVMessage(ctx context.Context, signedSSVMessage *spectypes.SignedSSVMessage, topic string, receivedAt time.Time) (*queue.SSVMessage, error) {
 	decodedMessage := &queue.SSVMessage{
 		SignedSSVMessage: signedSSVMessage,
 	}
@@ -149,14 +185,14 @@ func (mv *messageValidator) handleSignedSSVMessage(signedSSVMessage *spectypes.S
 
 	switch signedSSVMessage.SSVMessage.MsgType {
 	case spectypes.SSVConsensusMsgType:
-		consensusMessage, err := mv.validateConsensusMessage(signedSSVMessage, committeeInfo, receivedAt)
+		consensusMessage, err := mv.validateConsensusMessage(ctx, signedSSVMessage, committeeInfo, receivedAt)
 		decodedMessage.Body = consensusMessage
 		if err != nil {
 			return decodedMessage, err
 		}
 
 	case spectypes.SSVPartialSignatureMsgType:
-		partialSignatureMessages, err := mv.validatePartialSignatureMessage(signedSSVMessage, committeeInfo, receivedAt)
+		partialSignatureMessages, err := mv.validatePartialSignatureMessage(ctx, signedSSVMessage, committeeInfo, receivedAt)
 		decodedMessage.Body = partialSignatureMessages
 		if err != nil {
 			return decodedMessage, err
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/hex"
@@ -118,10 +119,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 	}).AnyTimes()
 
 	signatureVerifier := signatureverifier.NewMockSignatureVerifier(ctrl)
-	signatureVerifier.EXPECT().VerifySignature(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	signatureVerifier.EXPECT().VerifySignature(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	wrongSignatureVerifier := signatureverifier.NewMockSignatureVerifier(ctrl)
-	wrongSignatureVerifier.EXPECT().VerifySignature(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("test")).AnyTimes()
+	wrongSignatureVerifier.EXPECT().VerifySignature(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("test")).AnyTimes()
 
 	committeeRole := spectypes.RoleCommittee
 	nonCommitteeRole := spectypes.RoleAggregator
@@ -139,7 +140,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 	})
 
@@ -162,10 +163,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrDuplicatedMessage.Error())
 
 		stateBySlot := state.GetOrCreate(1)
@@ -186,7 +187,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		})
 		signedSSVMessage.FullData = nil
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
 		storedState = stateBySlot.Get(slot)
@@ -195,14 +196,14 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.EqualValues(t, 2, storedState.Round)
 		require.EqualValues(t, MessageCounts{Prepare: 1}, storedState.MessageCounts)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrDuplicatedMessage.Error())
 
 		signedSSVMessage = generateSignedMessage(ks, msgID, slot+1, func(message *specqbft.Message) {
 			message.MsgType = specqbft.CommitMsgType
 		})
 		signedSSVMessage.FullData = nil
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
 		require.NoError(t, err)
 
 		storedState = stateBySlot.Get(phase0.Slot(height) + 1)
@@ -210,11 +211,11 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.EqualValues(t, 1, storedState.Round)
 		require.EqualValues(t, MessageCounts{Commit: 1}, storedState.MessageCounts)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
 		require.ErrorContains(t, err, ErrDuplicatedMessage.Error())
 
 		signedSSVMessage = generateMultiSignedMessage(ks, msgID, slot+1)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt.Add(netCfg.Beacon.SlotDurationSec()))
 		require.NoError(t, err)
 		require.NotNil(t, stateBySlot)
 		require.EqualValues(t, 1, storedState.Round)
@@ -230,7 +231,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		pmsg := &pubsub.Message{}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err := validator.handlePubsubMessage(pmsg, receivedAt)
+		_, err := validator.handlePubsubMessage(context.Background(), pmsg, receivedAt)
 
 		require.ErrorIs(t, err, ErrPubSubMessageHasNoData)
 	})
@@ -253,7 +254,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handlePubsubMessage(pmsg, receivedAt)
+		_, err = validator.handlePubsubMessage(context.Background(), pmsg, receivedAt)
 
 		e := ErrPubSubDataTooBig
 		e.got = msgSize
@@ -276,7 +277,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handlePubsubMessage(pmsg, receivedAt)
+		_, err = validator.handlePubsubMessage(context.Background(), pmsg, receivedAt)
 
 		require.ErrorContains(t, err, ErrMalformedPubSubMessage.Error())
 	})
@@ -292,7 +293,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrUndecodableMessageData.Error())
 	})
@@ -308,11 +309,11 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrEmptyData)
 
 		signedSSVMessage.SSVMessage.Data = nil
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrEmptyData)
 	})
 
@@ -329,7 +330,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		expectedErr := ErrSSVDataTooBig
 		expectedErr.got = tooBigMsgSize
@@ -348,7 +349,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrUndecodableMessageData.Error())
 	})
@@ -363,7 +364,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		signedSSVMessage.SSVMessage.MsgType = math.MaxUint64
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, time.Now())
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, time.Now())
 		require.ErrorContains(t, err, ErrUnknownSSVMessageType.Error())
 	})
 
@@ -383,7 +384,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.False(t, exists)
 
 		topicID := commons.CommitteeTopicID(shares.active.CommitteeID())[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, time.Now())
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, time.Now())
 		expectedErr := ErrUnknownValidator
 		expectedErr.got = hex.EncodeToString(sk.PublicKey().Marshal())
 		require.ErrorIs(t, err, expectedErr)
@@ -400,7 +401,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		signedSSVMessage := generateSignedMessage(ks, unknownIdentifier, slot)
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, time.Now())
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, time.Now())
 		expectedErr := ErrNonExistentCommitteeID
 		expectedErr.got = hex.EncodeToString(unknownCommitteeID[16:])
 		require.ErrorIs(t, err, expectedErr)
@@ -418,7 +419,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrWrongDomain
 		expectedErr.got = hex.EncodeToString(wrongDomain[:])
 		domain := netCfg.DomainType
@@ -437,7 +438,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrInvalidRole)
 	})
 
@@ -452,7 +453,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		topicID := commons.CommitteeTopicID(committeeID)[0]
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrUnexpectedConsensusMessage
 		expectedErr.got = spectypes.RoleValidatorRegistration
 		require.ErrorIs(t, err, expectedErr)
@@ -460,7 +461,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		badIdentifier = spectypes.NewMsgID(netCfg.DomainType, shares.active.ValidatorPubKey[:], spectypes.RoleVoluntaryExit)
 		signedSSVMessage = generateSignedMessage(ks, badIdentifier, slot)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr.got = spectypes.RoleVoluntaryExit
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -476,7 +477,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrValidatorLiquidated
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -492,7 +493,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrValidatorNotAttesting
 		expectedErr.got = eth2apiv1.ValidatorStateUnknown.String()
 		require.ErrorIs(t, err, expectedErr)
@@ -509,7 +510,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrValidatorNotAttesting
 		expectedErr.got = eth2apiv1.ValidatorStatePendingQueued.String()
 		require.ErrorIs(t, err, expectedErr)
@@ -540,7 +541,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 	})
 
@@ -555,7 +556,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrNoShareMetadata)
 	})
 
@@ -578,32 +579,32 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		// First duty.
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
 		require.NoError(t, err)
 
 		// Second duty.
 		signedSSVMessage = generateSignedMessage(ks, identifier, slot+4)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+4))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+4))
 		require.NoError(t, err)
 
 		// Second duty (another message).
 		signedSSVMessage = generateSignedMessage(ks, identifier, slot+4, func(qbftMessage *specqbft.Message) {
 			qbftMessage.MsgType = specqbft.RoundChangeMsgType
 		})
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+4))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+4))
 		require.NoError(t, err)
 
 		// Third duty.
 		// TODO: this should fail, see https://github.com/ssvlabs/ssv/pull/1758
 		signedSSVMessage = generateSignedMessage(ks, identifier, slot+8)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+8))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+8))
 		require.NoError(t, err)
 
 		// Third duty (another message).
 		signedSSVMessage = generateSignedMessage(ks, identifier, slot+8, func(qbftMessage *specqbft.Message) {
 			qbftMessage.MsgType = specqbft.RoundChangeMsgType
 		})
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+8))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot+8))
 		require.ErrorContains(t, err, ErrTooManyDutiesPerEpoch.Error())
 	})
 
@@ -622,7 +623,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		signedSSVMessage := generateSignedMessage(ks, identifier, slot)
 
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
 		require.ErrorContains(t, err, ErrNoDuty.Error())
 
 		ds = dutystore.New()
@@ -630,7 +631,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			{Slot: slot, ValidatorIndex: shares.active.ValidatorIndex, Duty: &eth2apiv1.ProposerDuty{}, InCommittee: true},
 		})
 		validator = New(netCfg, validatorStore, ds, signatureVerifier).(*messageValidator)
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, netCfg.Beacon.GetSlotStartTime(slot))
 		require.NoError(t, err)
 	})
 
@@ -665,7 +666,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		require.False(t, ds.Proposer.IsEpochSet(epoch))
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 	})
 
@@ -701,7 +702,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		require.True(t, ds.Proposer.IsEpochSet(epoch))
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrNoDuty.Error())
 	})
 
@@ -740,7 +741,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrSignerNotInCommittee.Error())
 	})
 
@@ -755,7 +756,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(msg.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(msg, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), msg, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrZeroSigner)
 	})
 
@@ -772,7 +773,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(partialSigSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), partialSigSSVMessage, topicID, receivedAt)
 		expectedErr := ErrInconsistentSigners
 		expectedErr.got = spectypes.OperatorID(2)
 		expectedErr.want = spectypes.OperatorID(1)
@@ -793,7 +794,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrNoPartialSignatureMessages)
 	})
 
@@ -808,7 +809,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(partialSigSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(partialSigSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), partialSigSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrWrongRSASignatureSize.Error())
 	})
 
@@ -863,7 +864,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 						topicID := commons.CommitteeTopicID(committeeID)[0]
 
-						_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+						_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 						require.NoError(t, err)
 					})
 				}
@@ -890,7 +891,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(spectestingutils.TestingDutySlot)
 			topicID := commons.CommitteeTopicID(committeeID)[0]
-			_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+			_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 			require.ErrorContains(t, err, ErrInvalidPartialSignatureType.Error())
 		})
 
@@ -941,7 +942,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 						receivedAt := netCfg.Beacon.GetSlotStartTime(spectestingutils.TestingDutySlot)
 						topicID := commons.CommitteeTopicID(committeeID)[0]
 						t.Log(signedSSVMessage.SSVMessage.MsgID.GetDomain())
-						_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+						_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 						require.ErrorContains(t, err, ErrPartialSignatureTypeRoleMismatch.Error())
 					})
 				}
@@ -960,7 +961,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrUnknownQBFTMessageType
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -975,7 +976,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrWrongRSASignatureSize.Error())
 	})
 
@@ -989,7 +990,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrNoSigners)
 	})
 
@@ -1011,7 +1012,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrSignerNotInCommittee.Error())
 	})
 
@@ -1025,7 +1026,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrZeroSigner)
 	})
 
@@ -1039,7 +1040,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrDuplicatedSigner)
 	})
 
@@ -1053,7 +1054,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrSignersNotSorted)
 	})
 
@@ -1067,7 +1068,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrSignersAndSignaturesWithDifferentLength.Error())
 	})
@@ -1083,7 +1084,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrDecidedNotEnoughSigners.Error())
 	})
@@ -1099,7 +1100,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		expectedErr := ErrNonDecidedWithMultipleSigners
 		expectedErr.got = 3
@@ -1140,7 +1141,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 				signedSSVMessage := generateSignedMessage(ks, msgID, slot)
 
 				topicID := commons.CommitteeTopicID(committeeID)[0]
-				_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+				_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 				require.ErrorContains(t, err, ErrLateSlotMessage.Error())
 			})
 		}
@@ -1155,7 +1156,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot - 1)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrEarlySlotMessage.Error())
 	})
@@ -1170,7 +1171,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrSignerNotLeader.Error())
 	})
 
@@ -1186,7 +1187,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrMalformedPrepareJustifications.Error())
 	})
@@ -1209,7 +1210,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrUnexpectedPrepareJustifications.Error())
 	})
@@ -1232,7 +1233,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrUnexpectedRoundChangeJustifications.Error())
 	})
@@ -1250,7 +1251,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		require.ErrorContains(t, err, ErrMalformedRoundChangeJustifications.Error())
 	})
@@ -1266,7 +1267,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 
 		expectedErr := ErrInvalidHash
 		require.ErrorIs(t, err, expectedErr)
@@ -1282,7 +1283,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
 		anotherFullData := []byte{1}
@@ -1292,7 +1293,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		})
 		signedSSVMessage.FullData = anotherFullData
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrDifferentProposalData
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -1311,10 +1312,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrDuplicatedMessage
 		expectedErr.got = "prepare, having pre-consensus: 0, proposal: 0, prepare: 1, commit: 0, round change: 0, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1333,10 +1334,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrDuplicatedMessage
 		expectedErr.got = "commit, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 1, round change: 0, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1355,10 +1356,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		expectedErr := ErrDuplicatedMessage
 		expectedErr.got = "round change, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, round change: 1, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1378,10 +1379,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrDecidedWithSameSigners)
 	})
 
@@ -1397,14 +1398,14 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
 		signedSSVMessage = generateSignedMessage(ks, nonCommitteeIdentifier, slot, func(message *specqbft.Message) {
 			message.Height = 4
 		})
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrSlotAlreadyAdvanced.Error())
 	})
 
@@ -1420,14 +1421,14 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(5 * roundtimer.QuickTimeout)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.NoError(t, err)
 
 		signedSSVMessage = generateSignedMessage(ks, committeeIdentifier, slot, func(message *specqbft.Message) {
 			message.Round = 1
 		})
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrRoundAlreadyAdvanced.Error())
 	})
 
@@ -1481,7 +1482,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 				}
 
 				receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(sinceSlotStart)
-				_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+				_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 				require.ErrorContains(t, err, ErrRoundTooHigh.Error())
 			})
 		}
@@ -1499,7 +1500,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrEventMessage)
 	})
 
@@ -1516,7 +1517,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, fmt.Sprintf("%s, got %d", ErrUnknownSSVMessageType.Error(), unknownType))
 	})
 
@@ -1531,7 +1532,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrSignatureVerification.Error())
 	})
 
@@ -1546,7 +1547,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := "incorrect"
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrIncorrectTopic.Error())
 	})
 
@@ -1558,7 +1559,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 
-		_, err = validator.handleSignedSSVMessage(nil, "", receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), nil, "", receivedAt)
 		require.ErrorContains(t, err, ErrNilSignedSSVMessage.Error())
 	})
 
@@ -1573,7 +1574,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, "", receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, "", receivedAt)
 		require.ErrorContains(t, err, ErrNilSSVMessage.Error())
 	})
 
@@ -1589,7 +1590,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrZeroRound.Error())
 	})
 
@@ -1604,7 +1605,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrNoSignatures.Error())
 	})
 
@@ -1622,7 +1623,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrMismatchedIdentifier.Error())
 	})
 
@@ -1638,13 +1639,13 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrPrepareOrCommitWithFullData.Error())
 
 		signedSSVMessage = generateSignedMessage(ks, committeeIdentifier, slot, func(message *specqbft.Message) {
 			message.MsgType = specqbft.CommitMsgType
 		})
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrPrepareOrCommitWithFullData.Error())
 	})
 
@@ -1661,7 +1662,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrFullDataNotInConsensusMessage)
 	})
 
@@ -1679,7 +1680,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorIs(t, err, ErrPartialSigOneSigner)
 	})
 
@@ -1707,7 +1708,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrTooManyPartialSignatureMessages.Error())
 	})
 
@@ -1735,7 +1736,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(spectypes.CommitteeID(signedSSVMessage.SSVMessage.GetID().GetDutyExecutorID()[16:]))[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrTripleValidatorIndexInPartialSignatures.Error())
 	})
 
@@ -1761,7 +1762,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
 		topicID := commons.CommitteeTopicID(committeeID)[0]
-		_, err = validator.handleSignedSSVMessage(signedSSVMessage, topicID, receivedAt)
+		_, err = validator.handleSignedSSVMessage(context.Background(), signedSSVMessage, topicID, receivedAt)
 		require.ErrorContains(t, err, ErrValidatorIndexMismatch.Error())
 	})
 }
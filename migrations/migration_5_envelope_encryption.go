@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/ekm/kek"
+	"github.com/ssvlabs/ssv/operator/keys"
+)
+
+// This migration re-encrypts the signer storage, which is encrypted with the EKM hash of the operator key,
+// with a data key wrapped by the configured key-encryption key, or by the operator key if none is configured.
+var migration_5_envelope_encryption = Migration{
+	Name: "migration_5_envelope_encryption",
+	Run: func(ctx context.Context, logger *zap.Logger, opt Options, key []byte, completed CompletedFunc) error {
+		if opt.OperatorKey == nil {
+			return errors.New("operator key is required")
+		}
+
+		signerStorage := opt.signerStorage(logger)
+		enveloped, err := signerStorage.HasEnvelope()
+		if err != nil {
+			return err
+		}
+		if enveloped {
+			// The storage was envelope encrypted before the migration was marked as completed.
+			return completed(opt.Db)
+		}
+
+		ekmHash, err := accountsEKMHash(signerStorage, opt.OperatorKey)
+		if err != nil {
+			return err
+		}
+		if err := signerStorage.SetEncryptionKey(ekmHash); err != nil {
+			return fmt.Errorf("failed to set encryption key: %w", err)
+		}
+
+		kekProvider := opt.KEK
+		if kekProvider == nil {
+			kekProvider, err = kek.Operator(ekmHash)
+			if err != nil {
+				return err
+			}
+		}
+		if err := signerStorage.EnableEnvelope(ctx, kekProvider); err != nil {
+			return fmt.Errorf("failed to enable envelope encryption: %w", err)
+		}
+		logger.Info("envelope encrypted signer storage", zap.String("kek", kekProvider.ID()))
+
+		return completed(opt.Db)
+	},
+}
+
+// accountsEKMHash returns the EKM hash of the operator key the stored accounts are encrypted with,
+// trying both keys of a key being rotated.
+func accountsEKMHash(signerStorage ekm.Storage, operatorKey keys.OperatorPrivateKey) (string, error) {
+	candidates := []keys.OperatorPrivateKey{operatorKey}
+	if rotatingKey, ok := operatorKey.(*keys.RotatingKey); ok {
+		candidates = []keys.OperatorPrivateKey{rotatingKey.Old(), rotatingKey.New()}
+	}
+
+	var errs []error
+	for _, candidate := range candidates {
+		ekmHash, err := candidate.EKMHash()
+		if err != nil {
+			return "", fmt.Errorf("failed to get EKM hash: %w", err)
+		}
+		if err := signerStorage.SetEncryptionKey(ekmHash); err != nil {
+			return "", fmt.Errorf("failed to set encryption key: %w", err)
+		}
+		if _, err := signerStorage.ListAccounts(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return ekmHash, nil
+	}
+	return "", fmt.Errorf("failed to decrypt accounts with the operator key: %w", errors.Join(errs...))
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/ekm/kek"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+func TestMigration5EnvelopeEncryption(t *testing.T) {
+	threshold.Init()
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	opt.Network = networkconfig.TestNetwork.Beacon.GetNetwork()
+
+	oldKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newEKMHash, err := newKey.EKMHash()
+	require.NoError(t, err)
+
+	// The accounts are encrypted with the new key of a key being rotated.
+	signerStorage := opt.signerStorage(logger)
+	require.NoError(t, signerStorage.SetEncryptionKey(newEKMHash))
+	wallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(wallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := 1
+	account, err := wallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), &index)
+	require.NoError(t, err)
+
+	migrations := Migrations{migration_5_envelope_encryption}
+	_, err = migrations.Run(ctx, logger, opt)
+	require.ErrorContains(t, err, "operator key is required")
+
+	opt.OperatorKey = keys.NewRotatingKey(oldKey, newKey)
+	applied, err := migrations.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	require.Equal(t, 1, applied)
+
+	newKeyStorage := opt.signerStorage(logger)
+	require.NoError(t, newKeyStorage.SetEncryptionKey(newEKMHash))
+	_, err = newKeyStorage.ListAccounts()
+	require.Error(t, err)
+
+	operatorKEK, err := kek.Operator(newEKMHash)
+	require.NoError(t, err)
+	envelopeStorage := opt.signerStorage(logger)
+	require.NoError(t, envelopeStorage.OpenEnvelope(ctx, operatorKEK))
+	accounts, err := envelopeStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ValidatorPublicKey(), accounts[0].ValidatorPublicKey())
+
+	applied, err = migrations.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	require.Equal(t, 0, applied)
+
+	enveloped, err := opt.signerStorage(logger).HasEnvelope()
+	require.NoError(t, err)
+	require.True(t, enveloped)
+}
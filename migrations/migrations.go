@@ -11,6 +11,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/ekm/kek"
+	"github.com/ssvlabs/ssv/operator/keys"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/storage/basedb"
@@ -26,6 +28,7 @@ var (
 		migration_2_encrypt_shares,
 		migration_3_drop_registry_data,
 		migration_4_configlock_add_alan_fork_to_network_name,
+		migration_5_envelope_encryption,
 	}
 )
 
@@ -56,6 +59,9 @@ type Options struct {
 	NodeStorage operatorstorage.Storage
 	DbPath      string
 	Network     beacon.Network
+	OperatorKey keys.OperatorPrivateKey
+	// KEK wraps the data key of the signer storage, which is wrapped by OperatorKey if KEK is nil.
+	KEK kek.Provider
 }
 
 // nolint
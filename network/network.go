@@ -34,6 +34,7 @@ type MessageRouting interface {
 type P2PNetwork interface {
 	io.Closer
 	protocolp2p.Network
+	protocolp2p.DecidedHistorySyncer
 	MessageRouting
 	// Setup initialize the network layer and starts the libp2p host
 	Setup(logger *zap.Logger) error
@@ -77,6 +77,12 @@ type Config struct {
 	Network networkconfig.NetworkConfig
 	// MessageValidator validates incoming messages.
 	MessageValidator validation.MessageValidator
+	// PeerReputationConfig configures the rate limiting and reputation of peers in message validation.
+	PeerReputationConfig validation.PeerReputationConfig `yaml:"PeerReputation"`
+	// PeerReputation is shared with MessageValidator, it's used for bad peer decisions and gossipsub scoring, optional
+	PeerReputation *validation.PeerReputation
+	// ValidationAuditConfig configures the audit log of messages that failed validation.
+	ValidationAuditConfig validation.FailureAuditConfig `yaml:"ValidationAudit"`
 
 	PubsubMsgCacheTTL         time.Duration `yaml:"PubsubMsgCacheTTL" env:"PUBSUB_MSG_CACHE_TTL" env-description:"How long a message ID will be remembered as seen"`
 	PubsubOutQueueSize        int           `yaml:"PubsubOutQueueSize" env:"PUBSUB_OUT_Q_SIZE" env-description:"The size that we assign to the outbound pubsub message queue"`
@@ -87,6 +93,15 @@ type Config struct {
 	// If false, SyncDecidedByRange becomes a no-op.
 	FullNode bool
 
+	// Exporter determines whether the node runs in exporter mode, it is advertised to peers as a capability.
+	Exporter bool
+
+	// DecidedStores is used by exporters to serve decided history to peers, optional.
+	DecidedStores DecidedStores
+
+	// ResourceManager overrides the limits of the libp2p resource manager
+	ResourceManager ResourceManagerConfig `yaml:"ResourceManager"`
+
 	DisableIPRateLimit bool `yaml:"DisableIPRateLimit" env:"DISABLE_IP_RATE_LIMIT" default:"false" env-description:"Flag to turn on/off IP rate limiting"`
 
 	GetValidatorStats network.GetValidatorStats
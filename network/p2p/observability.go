@@ -49,6 +49,24 @@ var (
 			metricName("peers.per_version"),
 			metric.WithUnit("{peer}"),
 			metric.WithDescription("number of connected peers per node version")))
+
+	resourceManagerBlockedCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("resource_manager.blocked"),
+			metric.WithUnit("{resource}"),
+			metric.WithDescription("number of resources that were blocked by the resource manager")))
+
+	resourceManagerUsageGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("resource_manager.usage"),
+			metric.WithUnit("{resource}"),
+			metric.WithDescription("resources in use per resource manager scope")))
+
+	resourceManagerLimitGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("resource_manager.limit"),
+			metric.WithUnit("{resource}"),
+			metric.WithDescription("resource limits per resource manager scope")))
 )
 
 func metricName(name string) string {
@@ -153,3 +171,49 @@ func recordPeerIdentities(ctx context.Context, host host.Host, index peers.Index
 		}
 	}
 }
+
+func recordResourceBlocked(resource, scope string) {
+	resourceManagerBlockedCounter.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("ssv.p2p.resource.name", resource),
+		attribute.String("ssv.p2p.resource.scope", scope),
+	))
+}
+
+// recordResourceUsage records the usage and limits of the system, transient and protocol scopes,
+// peer scopes are left out to keep the cardinality of the metrics bounded.
+func recordResourceUsage(ctx context.Context, logger *zap.Logger, provider ResourceUsageProvider) func() {
+	return func() {
+		usage, err := provider.ResourceUsage()
+		if err != nil {
+			logger.Debug("could not get resource manager usage", zap.Error(err))
+			return
+		}
+		recordScopeUsage(ctx, "system", usage.System)
+		recordScopeUsage(ctx, "transient", usage.Transient)
+		for id, scope := range usage.Protocols {
+			recordScopeUsage(ctx, "protocol:"+string(id), scope)
+		}
+	}
+}
+
+func recordScopeUsage(ctx context.Context, scope string, u ResourceScopeUsage) {
+	resources := []struct {
+		name         string
+		usage, limit int64
+	}{
+		{"streams_inbound", int64(u.Usage.StreamsInbound), int64(u.Limits.StreamsInbound)},
+		{"streams_outbound", int64(u.Usage.StreamsOutbound), int64(u.Limits.StreamsOutbound)},
+		{"conns_inbound", int64(u.Usage.ConnsInbound), int64(u.Limits.ConnsInbound)},
+		{"conns_outbound", int64(u.Usage.ConnsOutbound), int64(u.Limits.ConnsOutbound)},
+		{"fd", int64(u.Usage.FD), int64(u.Limits.FD)},
+		{"memory", u.Usage.Memory, u.Limits.Memory},
+	}
+	for _, r := range resources {
+		attrs := metric.WithAttributes(
+			attribute.String("ssv.p2p.resource.name", r.name),
+			attribute.String("ssv.p2p.resource.scope", scope),
+		)
+		resourceManagerUsageGauge.Record(ctx, r.usage, attrs)
+		resourceManagerLimitGauge.Record(ctx, r.limit, attrs)
+	}
+}
@@ -53,6 +53,7 @@ const (
 	peersReportingInterval             = 60 * time.Second
 	peerIdentitiesReportingInterval    = 5 * time.Minute
 	topicsReportingInterval            = 90 * time.Second
+	resourceUsageReportingInterval     = 60 * time.Second
 	maximumIrrelevantPeersToDisconnect = 3
 )
 
@@ -262,6 +263,8 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 
 	async.Interval(n.ctx, topicsReportingInterval, recordPeerCountPerTopic(n.ctx, logger, n.topicsCtrl, 2))
 
+	async.Interval(n.ctx, resourceUsageReportingInterval, recordResourceUsage(n.ctx, logger, n))
+
 	if err := n.subscribeToSubnets(logger); err != nil {
 		return err
 	}
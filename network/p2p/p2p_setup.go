@@ -123,9 +123,13 @@ func (n *p2pNetwork) SetupHost(logger *zap.Logger) error {
 		return errors.Wrap(err, "could not create libp2p options")
 	}
 
-	limitsCfg := rcmgr.DefaultLimits.AutoScale()
-	// TODO: enable and extract resource manager params as config
-	rmgr, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limitsCfg))
+	if err := n.cfg.ResourceManager.Validate(); err != nil {
+		return errors.Wrap(err, "invalid resource manager config")
+	}
+	rmgr, err := rcmgr.NewResourceManager(
+		rcmgr.NewFixedLimiter(n.cfg.ResourceManager.Limits()),
+		rcmgr.WithTraceReporter(resourceManagerTracer{}),
+	)
 	if err != nil {
 		return errors.Wrap(err, "could not create resource manager")
 	}
@@ -181,14 +185,19 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	domain := "0x" + hex.EncodeToString(d[:])
 	self := records.NewNodeInfo(domain)
 	self.Metadata = &records.NodeMetadata{
-		NodeVersion: commons.GetNodeVersion(),
-		Subnets:     records.Subnets(n.fixedSubnets).String(),
+		NodeVersion:  commons.GetNodeVersion(),
+		Subnets:      records.Subnets(n.fixedSubnets).String(),
+		Capabilities: n.selfCapabilities(),
 	}
 	getPrivKey := func() crypto.PrivKey {
 		return libPrivKey
 	}
 
-	n.idx = peers.NewPeersIndex(logger, n.host.Network(), self, n.getMaxPeers, getPrivKey, p2pcommons.Subnets(), 10*time.Minute, peers.NewGossipScoreIndex())
+	var reputationIndex peers.ReputationIndex
+	if n.cfg.PeerReputation != nil {
+		reputationIndex = n.cfg.PeerReputation
+	}
+	n.idx = peers.NewPeersIndex(logger, n.host.Network(), self, n.getMaxPeers, getPrivKey, p2pcommons.Subnets(), 10*time.Minute, peers.NewGossipScoreIndex(), reputationIndex)
 	logger.Debug("peers index is ready")
 
 	var ids identify.IDService
@@ -209,6 +218,9 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 		return []connections.HandshakeFilter{
 			connections.NetworkIDFilter(newDomainString),
 			connections.BadPeerFilter(logger, n.idx),
+			connections.CapabilitiesFilter(func() *records.Capabilities {
+				return n.idx.Self().Metadata.Capabilities
+			}),
 		}
 	}
 
@@ -227,6 +239,11 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	n.host.SetStreamHandler(peers.NodeInfoProtocol, handshaker.Handler(logger))
 	logger.Debug("handshaker is ready")
 
+	if n.servesDecidedHistory() {
+		n.host.SetStreamHandler(peers.DecidedHistoryProtocol, n.handleDecidedHistory(logger))
+		logger.Debug("decided history handler is ready")
+	}
+
 	n.connHandler = connections.NewConnHandler(n.ctx, handshaker, n.ActiveSubnets, n.idx, n.idx, n.idx)
 	n.host.Network().Notify(n.connHandler.Handle(logger))
 	logger.Debug("connection handler is ready")
@@ -234,6 +251,22 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	return nil
 }
 
+// selfCapabilities returns the capabilities that this node advertises during the handshake
+func (n *p2pNetwork) selfCapabilities() *records.Capabilities {
+	protocols := []string{peers.NodeInfoProtocol}
+	if n.servesDecidedHistory() {
+		protocols = append(protocols, peers.DecidedHistoryProtocol)
+	}
+	return &records.Capabilities{
+		Version:         records.CapabilitiesVersion,
+		MessageVersions: []uint64{records.MessageVersion},
+		Protocols:       protocols,
+		Exporter:        n.cfg.Exporter,
+		FullNode:        n.cfg.FullNode,
+		Forks:           []string{n.cfg.Network.ForkName()},
+	}
+}
+
 func (n *p2pNetwork) ActiveSubnets() records.Subnets {
 	return n.activeSubnets
 }
@@ -305,6 +338,10 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
 		GetValidatorStats:   n.cfg.GetValidatorStats,
 	}
 
+	if n.cfg.PeerReputation != nil {
+		cfg.AppSpecificScore = n.cfg.PeerReputation.Score
+	}
+
 	if n.cfg.PeerScoreInspector != nil && n.cfg.PeerScoreInspectorInterval > 0 {
 		cfg.ScoreInspector = n.cfg.PeerScoreInspector
 		cfg.ScoreInspectorInterval = n.cfg.PeerScoreInspectorInterval
@@ -0,0 +1,199 @@
+package p2pv1
+
+import (
+	"math/rand"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/records"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+)
+
+const (
+	// maxDecidedHistorySlots caps the slot range that is scanned for a single request
+	maxDecidedHistorySlots = 1024
+)
+
+// DecidedStores provides the stores of decided participants by role
+type DecidedStores interface {
+	Get(role convert.RunnerRole) qbftstorage.QBFTStore
+}
+
+// servesDecidedHistory returns true if this node answers decided history requests
+func (n *p2pNetwork) servesDecidedHistory() bool {
+	return n.cfg.Exporter && n.cfg.DecidedStores != nil
+}
+
+// DecidedHistoryPeers returns the number of connected peers that serve decided history
+func (n *p2pNetwork) DecidedHistoryPeers() int {
+	if !n.isReady() {
+		return 0
+	}
+	return len(n.decidedHistoryPeers())
+}
+
+func (n *p2pNetwork) decidedHistoryPeers() []peer.ID {
+	return n.idx.PeersWithCapabilities(records.WithProtocol(peers.DecidedHistoryProtocol))
+}
+
+// SyncDecidedByRange requests the decided participants of the given identifier in the given slot range.
+// the range is requested in batches, each batch is requested from a random peer that serves decided history
+// and is retried on the other peers in case of failure.
+func (n *p2pNetwork) SyncDecidedByRange(logger *zap.Logger, identifier convert.MessageID, from, to phase0.Slot) ([]protocolp2p.DecidedHistoryEntry, error) {
+	if !n.cfg.FullNode {
+		return nil, nil
+	}
+	if !n.isReady() {
+		return nil, protocolp2p.ErrNetworkIsNotReady
+	}
+	candidates := n.decidedHistoryPeers()
+	if len(candidates) == 0 {
+		return nil, protocolp2p.ErrNoDecidedHistoryPeers
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	var entries []protocolp2p.DecidedHistoryEntry
+	next := 0
+	for from <= to {
+		req := &protocolp2p.DecidedHistoryRequest{
+			Identifier: identifier,
+			From:       from,
+			To:         to,
+			Limit:      n.cfg.MaxBatchResponse,
+		}
+
+		var res *protocolp2p.DecidedHistoryResponse
+		var err error
+		for attempt := 0; attempt < len(candidates); attempt++ {
+			pid := candidates[next%len(candidates)]
+			next++
+			res, err = n.requestDecidedHistory(logger, pid, req)
+			if err == nil {
+				break
+			}
+			logger.Debug("could not request decided history", fields.PeerID(pid), zap.Error(err))
+		}
+		if err != nil {
+			return entries, errors.Wrap(err, "could not request decided history from any peer")
+		}
+
+		for _, entry := range res.Entries {
+			if entry.Slot < from || entry.Slot > res.To {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if res.To == to {
+			break
+		}
+		from = res.To + 1
+	}
+
+	return entries, nil
+}
+
+func (n *p2pNetwork) requestDecidedHistory(logger *zap.Logger, pid peer.ID, req *protocolp2p.DecidedHistoryRequest) (*protocolp2p.DecidedHistoryResponse, error) {
+	data, err := req.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode request")
+	}
+	raw, err := n.streamCtrl.Request(logger, pid, peers.DecidedHistoryProtocol, data)
+	if err != nil {
+		return nil, err
+	}
+	res := &protocolp2p.DecidedHistoryResponse{}
+	if err := res.Decode(raw); err != nil {
+		return nil, errors.Wrap(err, "could not decode response")
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	if res.To < req.From || res.To > req.To {
+		return nil, errors.Errorf("response range end %d is out of the requested range [%d, %d]", res.To, req.From, req.To)
+	}
+	if uint64(len(res.Entries)) > n.cfg.MaxBatchResponse {
+		return nil, errors.Errorf("too many entries in response: %d", len(res.Entries))
+	}
+	return res, nil
+}
+
+// handleDecidedHistory returns the stream handler of decided history requests
+func (n *p2pNetwork) handleDecidedHistory(logger *zap.Logger) libp2pnetwork.StreamHandler {
+	return func(stream libp2pnetwork.Stream) {
+		logger := logger.With(fields.PeerID(stream.Conn().RemotePeer()))
+
+		data, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
+		defer done()
+		if err != nil {
+			logger.Debug("could not handle decided history stream", zap.Error(err))
+			return
+		}
+
+		res := n.decidedHistory(data)
+		raw, err := res.Encode()
+		if err != nil {
+			logger.Debug("could not encode decided history response", zap.Error(err))
+			return
+		}
+		if err := respond(raw); err != nil {
+			logger.Debug("could not respond to decided history request", zap.Error(err))
+		}
+	}
+}
+
+// decidedHistory builds the response for the given raw request,
+// entries are capped by MaxBatchResponse and the scanned range by maxDecidedHistorySlots.
+func (n *p2pNetwork) decidedHistory(data []byte) *protocolp2p.DecidedHistoryResponse {
+	req := &protocolp2p.DecidedHistoryRequest{}
+	if err := req.Decode(data); err != nil {
+		return &protocolp2p.DecidedHistoryResponse{Error: "could not decode request"}
+	}
+	if req.To < req.From {
+		return &protocolp2p.DecidedHistoryResponse{Error: "invalid slot range"}
+	}
+	store := n.cfg.DecidedStores.Get(req.Identifier.GetRoleType())
+	if store == nil {
+		return &protocolp2p.DecidedHistoryResponse{Error: "unknown role"}
+	}
+
+	limit := max(n.cfg.MaxBatchResponse, 1)
+	if req.Limit > 0 && req.Limit < limit {
+		limit = req.Limit
+	}
+	to := min(req.To, req.From+maxDecidedHistorySlots-1)
+
+	res := &protocolp2p.DecidedHistoryResponse{To: to}
+	for slot := req.From; slot <= to; slot++ {
+		signers, err := store.GetParticipants(req.Identifier, slot)
+		if err != nil {
+			return &protocolp2p.DecidedHistoryResponse{Error: "could not get participants"}
+		}
+		if len(signers) == 0 {
+			continue
+		}
+		proof, err := store.GetParticipantsProof(req.Identifier, slot)
+		if err != nil {
+			return &protocolp2p.DecidedHistoryResponse{Error: "could not get participants proof"}
+		}
+		res.Entries = append(res.Entries, protocolp2p.DecidedHistoryEntry{
+			Slot:    slot,
+			Signers: signers,
+			Proof:   proof,
+		})
+		if uint64(len(res.Entries)) >= limit {
+			res.To = slot
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package p2pv1
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestP2pNetwork_DecidedHistory(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	stores := ibftstorage.NewStoresFromRoles(db, convert.RoleProposer)
+	identifier := convert.NewMsgID(spectypes.DomainType{}, []byte{1, 2, 3}, convert.RoleProposer)
+	for _, slot := range []phase0.Slot{3, 5, 6, 9} {
+		_, err := stores.Get(convert.RoleProposer).UpdateParticipants(identifier, slot, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+	}
+
+	n := &p2pNetwork{cfg: &Config{MaxBatchResponse: 2, DecidedStores: stores}}
+
+	request := func(req *protocolp2p.DecidedHistoryRequest) *protocolp2p.DecidedHistoryResponse {
+		data, err := req.Encode()
+		require.NoError(t, err)
+		return n.decidedHistory(data)
+	}
+
+	t.Run("capped by max batch response", func(t *testing.T) {
+		res := request(&protocolp2p.DecidedHistoryRequest{Identifier: identifier, From: 0, To: 10})
+		require.Empty(t, res.Error)
+		require.Len(t, res.Entries, 2)
+		require.Equal(t, phase0.Slot(5), res.To)
+
+		res = request(&protocolp2p.DecidedHistoryRequest{Identifier: identifier, From: res.To + 1, To: 10})
+		require.Empty(t, res.Error)
+		require.Len(t, res.Entries, 2)
+		require.Equal(t, phase0.Slot(9), res.To)
+	})
+
+	t.Run("capped by requested limit", func(t *testing.T) {
+		res := request(&protocolp2p.DecidedHistoryRequest{Identifier: identifier, From: 0, To: 10, Limit: 1})
+		require.Len(t, res.Entries, 1)
+		require.Equal(t, phase0.Slot(3), res.To)
+	})
+
+	t.Run("whole range", func(t *testing.T) {
+		res := request(&protocolp2p.DecidedHistoryRequest{Identifier: identifier, From: 7, To: 100})
+		require.Len(t, res.Entries, 1)
+		require.Equal(t, phase0.Slot(100), res.To)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		res := request(&protocolp2p.DecidedHistoryRequest{Identifier: identifier, From: 10, To: 1})
+		require.NotEmpty(t, res.Error)
+
+		unknownRole := convert.NewMsgID(spectypes.DomainType{}, []byte{1, 2, 3}, convert.RoleAttester)
+		res = request(&protocolp2p.DecidedHistoryRequest{Identifier: unknownRole, From: 0, To: 1})
+		require.NotEmpty(t, res.Error)
+
+		require.NotEmpty(t, n.decidedHistory([]byte("invalid")).Error)
+	})
+}
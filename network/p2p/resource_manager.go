@@ -0,0 +1,286 @@
+package p2pv1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/pkg/errors"
+)
+
+// unlimited is the value of a limit that is not enforced
+const unlimited = -1
+
+// ResourceLimits overrides the limits of a resource manager scope.
+// zero keeps the auto-scaled default of libp2p and -1 removes the limit.
+type ResourceLimits struct {
+	Streams         int   `yaml:"Streams"`
+	StreamsInbound  int   `yaml:"StreamsInbound"`
+	StreamsOutbound int   `yaml:"StreamsOutbound"`
+	Conns           int   `yaml:"Conns"`
+	ConnsInbound    int   `yaml:"ConnsInbound"`
+	ConnsOutbound   int   `yaml:"ConnsOutbound"`
+	FD              int   `yaml:"FD"`
+	Memory          int64 `yaml:"Memory"`
+}
+
+// ResourceManagerConfig holds the limits of the libp2p resource manager
+type ResourceManagerConfig struct {
+	System          ResourceLimits `yaml:"System"`
+	Transient       ResourceLimits `yaml:"Transient"`
+	ProtocolDefault ResourceLimits `yaml:"ProtocolDefault"`
+	// Protocols overrides the limits of specific protocols, e.g. "/meshsub/1.1.0"
+	Protocols   map[string]ResourceLimits `yaml:"Protocols"`
+	PeerDefault ResourceLimits            `yaml:"PeerDefault"`
+}
+
+// Validate returns an error if any of the limits is invalid
+func (c *ResourceManagerConfig) Validate() error {
+	scopes := map[string]ResourceLimits{
+		"system":           c.System,
+		"transient":        c.Transient,
+		"protocol default": c.ProtocolDefault,
+		"peer default":     c.PeerDefault,
+	}
+	for id, limits := range c.Protocols {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("empty protocol id in resource manager limits")
+		}
+		scopes["protocol "+id] = limits
+	}
+	for scope, limits := range scopes {
+		if err := limits.validate(); err != nil {
+			return errors.Wrapf(err, "invalid %s resource limits", scope)
+		}
+	}
+	return nil
+}
+
+// Limits returns the limits of the resource manager, scaled to the resources of the machine
+// and overridden by the configured limits.
+func (c *ResourceManagerConfig) Limits() rcmgr.ConcreteLimitConfig {
+	partial := rcmgr.PartialLimitConfig{
+		System:          c.System.toRcmgr(),
+		Transient:       c.Transient.toRcmgr(),
+		ProtocolDefault: c.ProtocolDefault.toRcmgr(),
+		PeerDefault:     c.PeerDefault.toRcmgr(),
+	}
+	if len(c.Protocols) > 0 {
+		partial.Protocol = make(map[protocol.ID]rcmgr.ResourceLimits, len(c.Protocols))
+		for id, limits := range c.Protocols {
+			partial.Protocol[protocol.ID(id)] = limits.toRcmgr()
+		}
+	}
+	return partial.Build(rcmgr.DefaultLimits.AutoScale())
+}
+
+func (l ResourceLimits) validate() error {
+	values := map[string]int64{
+		"Streams":         int64(l.Streams),
+		"StreamsInbound":  int64(l.StreamsInbound),
+		"StreamsOutbound": int64(l.StreamsOutbound),
+		"Conns":           int64(l.Conns),
+		"ConnsInbound":    int64(l.ConnsInbound),
+		"ConnsOutbound":   int64(l.ConnsOutbound),
+		"FD":              int64(l.FD),
+		"Memory":          l.Memory,
+	}
+	for name, v := range values {
+		if v < unlimited {
+			return fmt.Errorf("%s must be -1 (unlimited), 0 (default) or positive, got %d", name, v)
+		}
+	}
+	return nil
+}
+
+func (l ResourceLimits) toRcmgr() rcmgr.ResourceLimits {
+	return rcmgr.ResourceLimits{
+		Streams:         limitVal(l.Streams),
+		StreamsInbound:  limitVal(l.StreamsInbound),
+		StreamsOutbound: limitVal(l.StreamsOutbound),
+		Conns:           limitVal(l.Conns),
+		ConnsInbound:    limitVal(l.ConnsInbound),
+		ConnsOutbound:   limitVal(l.ConnsOutbound),
+		FD:              limitVal(l.FD),
+		Memory:          limitVal64(l.Memory),
+	}
+}
+
+func limitVal(v int) rcmgr.LimitVal {
+	switch {
+	case v == unlimited:
+		return rcmgr.Unlimited
+	case v <= 0:
+		return rcmgr.DefaultLimit
+	default:
+		return rcmgr.LimitVal(v)
+	}
+}
+
+func limitVal64(v int64) rcmgr.LimitVal64 {
+	switch {
+	case v == unlimited:
+		return rcmgr.Unlimited64
+	case v <= 0:
+		return rcmgr.DefaultLimit64
+	default:
+		return rcmgr.LimitVal64(v)
+	}
+}
+
+// ResourceStat holds the amount of every resource of a scope
+type ResourceStat struct {
+	StreamsInbound  int   `json:"streams_inbound"`
+	StreamsOutbound int   `json:"streams_outbound"`
+	Streams         int   `json:"streams"`
+	ConnsInbound    int   `json:"conns_inbound"`
+	ConnsOutbound   int   `json:"conns_outbound"`
+	Conns           int   `json:"conns"`
+	FD              int   `json:"fd"`
+	Memory          int64 `json:"memory"`
+}
+
+// ResourceScopeUsage holds the current usage of a scope next to its limits
+type ResourceScopeUsage struct {
+	Usage  ResourceStat `json:"usage"`
+	Limits ResourceStat `json:"limits"`
+}
+
+// ResourceUsage holds the usage of the resource manager scopes that are in use
+type ResourceUsage struct {
+	System    ResourceScopeUsage                 `json:"system"`
+	Transient ResourceScopeUsage                 `json:"transient"`
+	Protocols map[protocol.ID]ResourceScopeUsage `json:"protocols"`
+	Peers     map[peer.ID]ResourceScopeUsage     `json:"peers"`
+}
+
+// ResourceUsageProvider provides the usage of the resource manager
+type ResourceUsageProvider interface {
+	ResourceUsage() (*ResourceUsage, error)
+}
+
+// ResourceUsage implements ResourceUsageProvider
+func (n *p2pNetwork) ResourceUsage() (*ResourceUsage, error) {
+	if n.host == nil {
+		return nil, errors.New("host is not ready")
+	}
+	return resourceUsage(n.host.Network().ResourceManager())
+}
+
+func resourceUsage(rm network.ResourceManager) (*ResourceUsage, error) {
+	state, ok := rm.(rcmgr.ResourceManagerState)
+	if !ok {
+		return nil, errors.New("resource manager does not expose its state")
+	}
+	usage := &ResourceUsage{
+		Protocols: make(map[protocol.ID]ResourceScopeUsage),
+		Peers:     make(map[peer.ID]ResourceScopeUsage),
+	}
+	if err := rm.ViewSystem(func(scope network.ResourceScope) error {
+		usage.System = scopeUsage(scope)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not view system scope")
+	}
+	if err := rm.ViewTransient(func(scope network.ResourceScope) error {
+		usage.Transient = scopeUsage(scope)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not view transient scope")
+	}
+	for _, id := range state.ListProtocols() {
+		if err := rm.ViewProtocol(id, func(scope network.ProtocolScope) error {
+			usage.Protocols[id] = scopeUsage(scope)
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not view protocol scope %s", id)
+		}
+	}
+	for _, pid := range state.ListPeers() {
+		if err := rm.ViewPeer(pid, func(scope network.PeerScope) error {
+			usage.Peers[pid] = scopeUsage(scope)
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not view peer scope %s", pid)
+		}
+	}
+	return usage, nil
+}
+
+func scopeUsage(scope network.ResourceScope) ResourceScopeUsage {
+	stat := scope.Stat()
+	u := ResourceScopeUsage{
+		Usage: ResourceStat{
+			StreamsInbound:  stat.NumStreamsInbound,
+			StreamsOutbound: stat.NumStreamsOutbound,
+			Streams:         stat.NumStreamsInbound + stat.NumStreamsOutbound,
+			ConnsInbound:    stat.NumConnsInbound,
+			ConnsOutbound:   stat.NumConnsOutbound,
+			Conns:           stat.NumConnsInbound + stat.NumConnsOutbound,
+			FD:              stat.NumFD,
+			Memory:          stat.Memory,
+		},
+	}
+	if limiter, ok := scope.(rcmgr.ResourceScopeLimiter); ok {
+		limit := limiter.Limit()
+		u.Limits = ResourceStat{
+			StreamsInbound:  limit.GetStreamLimit(network.DirInbound),
+			StreamsOutbound: limit.GetStreamLimit(network.DirOutbound),
+			Streams:         limit.GetStreamTotalLimit(),
+			ConnsInbound:    limit.GetConnLimit(network.DirInbound),
+			ConnsOutbound:   limit.GetConnLimit(network.DirOutbound),
+			Conns:           limit.GetConnTotalLimit(),
+			FD:              limit.GetFDLimit(),
+			Memory:          limit.GetMemoryLimit(),
+		}
+	}
+	return u
+}
+
+// resourceManagerTracer counts the resources that were blocked by the resource manager
+type resourceManagerTracer struct{}
+
+// ConsumeEvent implements rcmgr.TraceReporter
+func (resourceManagerTracer) ConsumeEvent(evt rcmgr.TraceEvt) {
+	var resource string
+	switch evt.Type {
+	case rcmgr.TraceBlockAddStreamEvt:
+		resource = "stream"
+	case rcmgr.TraceBlockAddConnEvt:
+		resource = "conn"
+	case rcmgr.TraceBlockReserveMemoryEvt:
+		resource = "memory"
+	default:
+		return
+	}
+	recordResourceBlocked(resource, scopeClass(evt.Name))
+}
+
+// scopeClass returns the class of the given scope name, e.g. "peer" for "peer:<id>",
+// so that metrics don't carry a label per peer or connection.
+func scopeClass(name string) string {
+	if i := strings.Index(name, ".span-"); i >= 0 {
+		name = name[:i]
+	}
+	switch {
+	case name == "system", name == "transient":
+		return name
+	case strings.HasPrefix(name, "peer:"):
+		return "peer"
+	case strings.HasPrefix(name, "conn-"):
+		return "conn"
+	case strings.HasPrefix(name, "stream-"):
+		return "stream"
+	case strings.HasPrefix(name, "protocol:"), strings.HasPrefix(name, "service:"):
+		class, _, _ := strings.Cut(name, ":")
+		if strings.Contains(name, ".peer:") {
+			return class + "_peer"
+		}
+		return class
+	default:
+		return "other"
+	}
+}
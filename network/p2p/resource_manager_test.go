@@ -0,0 +1,81 @@
+package p2pv1
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceManagerConfig_Limits(t *testing.T) {
+	defaults := rcmgr.DefaultLimits.AutoScale().ToPartialLimitConfig()
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg := ResourceManagerConfig{}
+		require.NoError(t, cfg.Validate())
+		require.Equal(t, defaults, cfg.Limits().ToPartialLimitConfig())
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		cfg := ResourceManagerConfig{
+			System:      ResourceLimits{Conns: 100, Memory: 1 << 30},
+			Transient:   ResourceLimits{FD: unlimited},
+			PeerDefault: ResourceLimits{StreamsInbound: 64},
+			Protocols: map[string]ResourceLimits{
+				"/meshsub/1.1.0": {StreamsInbound: 512},
+			},
+		}
+		require.NoError(t, cfg.Validate())
+		limits := cfg.Limits().ToPartialLimitConfig()
+
+		require.Equal(t, rcmgr.LimitVal(100), limits.System.Conns)
+		require.Equal(t, rcmgr.LimitVal64(1<<30), limits.System.Memory)
+		require.Equal(t, defaults.System.Streams, limits.System.Streams)
+		require.Equal(t, rcmgr.Unlimited, limits.Transient.FD)
+		require.Equal(t, rcmgr.LimitVal(64), limits.PeerDefault.StreamsInbound)
+		require.Equal(t, rcmgr.LimitVal(512), limits.Protocol["/meshsub/1.1.0"].StreamsInbound)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := ResourceManagerConfig{System: ResourceLimits{Streams: -2}}
+		require.ErrorContains(t, cfg.Validate(), "invalid system resource limits")
+
+		cfg = ResourceManagerConfig{Protocols: map[string]ResourceLimits{"": {}}}
+		require.Error(t, cfg.Validate())
+	})
+}
+
+func TestResourceUsage(t *testing.T) {
+	cfg := ResourceManagerConfig{System: ResourceLimits{ConnsInbound: 10}}
+	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(cfg.Limits()))
+	require.NoError(t, err)
+	defer rm.Close()
+
+	conn, err := rm.OpenConnection(network.DirInbound, true, ma.StringCast("/ip4/1.2.3.4/tcp/13001"))
+	require.NoError(t, err)
+	defer conn.Done()
+
+	usage, err := resourceUsage(rm)
+	require.NoError(t, err)
+	require.Equal(t, 1, usage.System.Usage.ConnsInbound)
+	require.Equal(t, 1, usage.System.Usage.FD)
+	require.Equal(t, 10, usage.System.Limits.ConnsInbound)
+	require.Equal(t, 1, usage.Transient.Usage.ConnsInbound)
+}
+
+func TestScopeClass(t *testing.T) {
+	for name, class := range map[string]string{
+		"system":                           "system",
+		"transient":                        "transient",
+		"peer:16Uiu2HAm":                   "peer",
+		"conn-12":                          "conn",
+		"stream-3.span-1":                  "stream",
+		"protocol:/meshsub/1.1.0":          "protocol",
+		"protocol:/meshsub/1.1.0.peer:16U": "protocol_peer",
+		"service:libp2p.identify":          "service",
+	} {
+		require.Equal(t, class, scopeClass(name), name)
+	}
+}
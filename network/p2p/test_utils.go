@@ -125,7 +125,11 @@ func CreateAndStartLocalNet(pCtx context.Context, logger *zap.Logger, options Lo
 
 type mockSignatureVerifier struct{}
 
-func (mockSignatureVerifier) VerifySignature(operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error {
+func (mockSignatureVerifier) VerifySignature(ctx context.Context, operatorID spectypes.OperatorID, message *spectypes.SSVMessage, signature []byte) error {
+	return nil
+}
+
+func (mockSignatureVerifier) Verify(ctx context.Context, msg *spectypes.SignedSSVMessage, operators []*spectypes.Operator) error {
 	return nil
 }
 
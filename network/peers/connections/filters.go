@@ -30,4 +30,25 @@ func BadPeerFilter(logger *zap.Logger, n peers.Index) HandshakeFilter {
 	}
 }
 
+// CapabilitiesFilter avoids connecting to peers that advertise capabilities
+// but have no message version in common with us.
+// peers that don't advertise capabilities are accepted for backward compatibility.
+func CapabilitiesFilter(self func() *records.Capabilities) HandshakeFilter {
+	return func(sender peer.ID, ni *records.NodeInfo) error {
+		md := ni.GetNodeInfo().Metadata
+		if md == nil || md.Capabilities == nil {
+			return nil
+		}
+		ours := self()
+		if ours == nil {
+			return nil
+		}
+		negotiated := ours.Negotiate(md.Capabilities)
+		if len(negotiated.MessageVersions) == 0 {
+			return errors.Errorf("no common message version (ours %v, theirs %v)", ours.MessageVersions, md.Capabilities.MessageVersions)
+		}
+		return nil
+	}
+}
+
 // TODO: filter based on domaintype
@@ -20,3 +20,24 @@ func TestNetworkIDFilter(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestCapabilitiesFilter(t *testing.T) {
+	self := &records.Capabilities{MessageVersions: []uint64{1}}
+	f := CapabilitiesFilter(func() *records.Capabilities {
+		return self
+	})
+
+	// legacy peers without capabilities are accepted
+	require.NoError(t, f("", &records.NodeInfo{}))
+	require.NoError(t, f("", &records.NodeInfo{Metadata: &records.NodeMetadata{}}))
+
+	err := f("", &records.NodeInfo{Metadata: &records.NodeMetadata{
+		Capabilities: &records.Capabilities{MessageVersions: []uint64{1, 2}},
+	}})
+	require.NoError(t, err)
+
+	err = f("", &records.NodeInfo{Metadata: &records.NodeMetadata{
+		Capabilities: &records.Capabilities{MessageVersions: []uint64{2}},
+	}})
+	require.Error(t, err)
+}
@@ -150,11 +150,13 @@ func (h *handshaker) verifyTheirNodeInfo(logger *zap.Logger, sender peer.ID, ni
 	}
 
 	h.nodeInfos.SetNodeInfo(sender, ni.GetNodeInfo())
+	negotiated := h.negotiateCapabilities(sender, ni.GetNodeInfo())
 
 	logger.Info("Verified handshake nodeinfo",
 		fields.PeerID(sender),
 		zap.Any("metadata", ni.GetNodeInfo().Metadata),
 		zap.String("networkID", ni.GetNodeInfo().NetworkID),
+		zap.Any("capabilities", negotiated),
 	)
 
 	return nil
@@ -208,6 +210,24 @@ func (h *handshaker) updateNodeSubnets(logger *zap.Logger, pid peer.ID, ni *reco
 	}
 }
 
+// negotiateCapabilities intersects our capabilities with the ones of the given peer
+// and saves the result, so it can be used later on for selecting peers.
+// legacy peers that don't advertise capabilities end up with nil capabilities.
+func (h *handshaker) negotiateCapabilities(pid peer.ID, ni *records.NodeInfo) *records.Capabilities {
+	var ours, theirs *records.Capabilities
+	if self := h.nodeInfos.Self(); self != nil && self.Metadata != nil {
+		ours = self.Metadata.Capabilities
+	}
+	if ni.Metadata != nil {
+		theirs = ni.Metadata.Capabilities
+	}
+	negotiated := ours.Negotiate(theirs)
+	h.peerInfos.UpdatePeerInfo(pid, func(info *peers.PeerInfo) {
+		info.Capabilities = negotiated
+	})
+	return negotiated
+}
+
 func (h *handshaker) requestNodeInfo(logger *zap.Logger, conn libp2pnetwork.Conn) (*records.NodeInfo, error) {
 	data, err := h.sealedNodeRecord()
 
@@ -24,8 +24,7 @@ func (m NodeInfoIndex) SelfSealed() ([]byte, error) {
 }
 
 func (m NodeInfoIndex) Self() *records.NodeInfo {
-	//TODO implement me
-	panic("implement me")
+	return m.MockNodeInfo
 }
 
 func (m NodeInfoIndex) UpdateSelfRecord(update func(self *records.NodeInfo) *records.NodeInfo) {
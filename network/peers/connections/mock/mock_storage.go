@@ -154,7 +154,7 @@ func (m NodeStorage) GetPrivateKeyHash() (string, bool, error) {
 	}
 }
 
-func (m NodeStorage) SavePrivateKeyHash(privKeyHash string) error {
+func (m NodeStorage) SavePrivateKeyHash(rw basedb.ReadWriter, privKeyHash string) error {
 	//TODO implement me
 	panic("implement me")
 }
@@ -16,6 +16,8 @@ import (
 const (
 	// NodeInfoProtocol is the protocol.ID used for handshake
 	NodeInfoProtocol = "/ssv/info/0.0.1"
+	// DecidedHistoryProtocol is the protocol.ID used for syncing decided history
+	DecidedHistoryProtocol = "/ssv/sync/decided/0.0.1"
 )
 
 var (
@@ -122,10 +124,24 @@ type GossipScoreIndex interface {
 	HasBadGossipScore(peerID peer.ID) (bool, float64)
 }
 
+// ReputationIndex provides the reputation of peers based on the messages they forwarded
+type ReputationIndex interface {
+	// HasBadReputation returns true if the peer exceeded the allowed ratio of invalid messages
+	HasBadReputation(id peer.ID) bool
+}
+
+// CapabilitiesIndex allows to select peers by their negotiated records.Capabilities
+type CapabilitiesIndex interface {
+	// PeersWithCapabilities returns the connected peers that match all the given filters,
+	// peers that didn't advertise capabilities (legacy) are never returned
+	PeersWithCapabilities(filters ...records.CapabilityFilter) []peer.ID
+}
+
 // Index is a facade interface of this package
 type Index interface {
 	ConnectionIndex
 	NodeInfoIndex
+	CapabilitiesIndex
 	PeerInfoIndex
 	ScoreIndex
 	SubnetsIndex
@@ -16,6 +16,7 @@ type PeerInfo struct {
 	Address            ma.Multiaddr
 	Direction          network.Direction
 	NodeInfo           *records.NodeInfo
+	Capabilities       *records.Capabilities
 	LastHandshake      time.Time
 	LastHandshakeError error
 }
@@ -35,11 +35,14 @@ type peersIndex struct {
 	maxPeers MaxPeersProvider
 
 	gossipScoreIndex GossipScoreIndex
+
+	// reputationIndex is optional
+	reputationIndex ReputationIndex
 }
 
 // NewPeersIndex creates a new Index
 func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *records.NodeInfo, maxPeers MaxPeersProvider,
-	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration, gossipScoreIndex GossipScoreIndex) *peersIndex {
+	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration, gossipScoreIndex GossipScoreIndex, reputationIndex ReputationIndex) *peersIndex {
 
 	return &peersIndex{
 		network:          network,
@@ -51,12 +54,14 @@ func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *reco
 		maxPeers:         maxPeers,
 		netKeyProvider:   netKeyProvider,
 		gossipScoreIndex: gossipScoreIndex,
+		reputationIndex:  reputationIndex,
 	}
 }
 
 // IsBad returns whether the given peer is bad.
 // a peer is considered to be bad if one of the following applies:
 // - bad gossip score
+// - bad reputation (too many ignored or rejected messages)
 // - pruned (that was not expired)
 // - bad score
 func (pi *peersIndex) IsBad(logger *zap.Logger, id peer.ID) bool {
@@ -64,6 +69,11 @@ func (pi *peersIndex) IsBad(logger *zap.Logger, id peer.ID) bool {
 		return true
 	}
 
+	if pi.reputationIndex != nil && pi.reputationIndex.HasBadReputation(id) {
+		logger.Debug("bad peer (bad reputation)")
+		return true
+	}
+
 	// TODO: check scores
 	threshold := -10000.0
 	scores, err := pi.GetScore(id, "")
@@ -138,6 +148,28 @@ func (pi *peersIndex) NodeInfo(id peer.ID) *records.NodeInfo {
 	return nil
 }
 
+// PeersWithCapabilities returns the connected peers whose negotiated capabilities match all the given filters
+func (pi *peersIndex) PeersWithCapabilities(filters ...records.CapabilityFilter) []peer.ID {
+	var res []peer.ID
+	for _, id := range pi.network.Peers() {
+		info := pi.PeerInfo(id)
+		if info == nil || info.Capabilities == nil {
+			continue
+		}
+		matched := true
+		for _, f := range filters {
+			if !f(info.Capabilities) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
 // Score adds score to the given peer
 func (pi *peersIndex) Score(id peer.ID, scores ...*NodeScore) error {
 	return pi.scoreIdx.Score(id, scores...)
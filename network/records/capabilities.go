@@ -0,0 +1,134 @@
+package records
+
+import (
+	"slices"
+)
+
+// MessageVersion is the version of the SSV messages that this node produces and processes
+const MessageVersion uint64 = 1
+
+// CapabilitiesVersion is the version of the Capabilities schema that this node advertises.
+// Bump it whenever the semantics of an existing field change.
+const CapabilitiesVersion uint8 = 1
+
+// Capabilities describes what a node is able to do, it is exchanged as part of NodeMetadata
+// during the handshake so peers can be selected by capability rather than by node version.
+type Capabilities struct {
+	// Version is the version of the capabilities schema
+	Version uint8
+	// MessageVersions are the SSV message versions that the node can process
+	MessageVersions []uint64
+	// Protocols are the libp2p stream protocols that the node serves
+	Protocols []string
+	// Exporter is true if the node runs in exporter mode
+	Exporter bool `json:",omitempty"`
+	// FullNode is true if the node keeps the full decided history
+	FullNode bool `json:",omitempty"`
+	// Forks are the names of the network forks that the node is ready for
+	Forks []string `json:",omitempty"`
+}
+
+// CapabilityFilter returns true if the given capabilities match some criteria
+type CapabilityFilter func(caps *Capabilities) bool
+
+// SupportsMessageVersion returns true if the given message version is supported
+func (c *Capabilities) SupportsMessageVersion(v uint64) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.MessageVersions, v)
+}
+
+// SupportsProtocol returns true if the given stream protocol is served
+func (c *Capabilities) SupportsProtocol(p string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Protocols, p)
+}
+
+// ReadyFor returns true if the node is ready for the given fork
+func (c *Capabilities) ReadyFor(fork string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Forks, fork)
+}
+
+// Negotiate returns the capabilities that both sides have in common:
+// message versions, protocols and forks are intersected, the schema version is the lowest of both
+// and the roles are taken from the other side (as they describe the remote peer).
+// returns nil if one of the sides didn't advertise capabilities.
+func (c *Capabilities) Negotiate(other *Capabilities) *Capabilities {
+	if c == nil || other == nil {
+		return nil
+	}
+	return &Capabilities{
+		Version:         min(c.Version, other.Version),
+		MessageVersions: intersect(c.MessageVersions, other.MessageVersions),
+		Protocols:       intersect(c.Protocols, other.Protocols),
+		Exporter:        other.Exporter,
+		FullNode:        other.FullNode,
+		Forks:           intersect(c.Forks, other.Forks),
+	}
+}
+
+// Clone returns a deep copy of the capabilities
+func (c *Capabilities) Clone() *Capabilities {
+	if c == nil {
+		return nil
+	}
+	return &Capabilities{
+		Version:         c.Version,
+		MessageVersions: slices.Clone(c.MessageVersions),
+		Protocols:       slices.Clone(c.Protocols),
+		Exporter:        c.Exporter,
+		FullNode:        c.FullNode,
+		Forks:           slices.Clone(c.Forks),
+	}
+}
+
+// WithMessageVersion filters peers that support the given message version
+func WithMessageVersion(v uint64) CapabilityFilter {
+	return func(caps *Capabilities) bool {
+		return caps.SupportsMessageVersion(v)
+	}
+}
+
+// WithProtocol filters peers that serve the given stream protocol
+func WithProtocol(p string) CapabilityFilter {
+	return func(caps *Capabilities) bool {
+		return caps.SupportsProtocol(p)
+	}
+}
+
+// WithFork filters peers that are ready for the given fork
+func WithFork(fork string) CapabilityFilter {
+	return func(caps *Capabilities) bool {
+		return caps.ReadyFor(fork)
+	}
+}
+
+// FullNodes filters peers that keep the full decided history
+func FullNodes() CapabilityFilter {
+	return func(caps *Capabilities) bool {
+		return caps != nil && caps.FullNode
+	}
+}
+
+// Exporters filters peers that run in exporter mode
+func Exporters() CapabilityFilter {
+	return func(caps *Capabilities) bool {
+		return caps != nil && caps.Exporter
+	}
+}
+
+func intersect[T comparable](a, b []T) []T {
+	var res []T
+	for _, v := range a {
+		if slices.Contains(b, v) && !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package records
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities_Negotiate(t *testing.T) {
+	ours := &Capabilities{
+		Version:         2,
+		MessageVersions: []uint64{1, 2},
+		Protocols:       []string{"/ssv/info/0.0.1", "/ssv/sync/decided/0.0.1"},
+		Forks:           []string{"alan"},
+	}
+	theirs := &Capabilities{
+		Version:         1,
+		MessageVersions: []uint64{1},
+		Protocols:       []string{"/ssv/info/0.0.1"},
+		Exporter:        true,
+		Forks:           []string{"alan", "next"},
+	}
+
+	negotiated := ours.Negotiate(theirs)
+	require.Equal(t, uint8(1), negotiated.Version)
+	require.Equal(t, []uint64{1}, negotiated.MessageVersions)
+	require.Equal(t, []string{"/ssv/info/0.0.1"}, negotiated.Protocols)
+	require.Equal(t, []string{"alan"}, negotiated.Forks)
+	require.True(t, negotiated.Exporter)
+	require.False(t, negotiated.FullNode)
+
+	require.True(t, WithProtocol("/ssv/info/0.0.1")(negotiated))
+	require.False(t, WithProtocol("/ssv/sync/decided/0.0.1")(negotiated))
+	require.True(t, Exporters()(negotiated))
+	require.False(t, FullNodes()(negotiated))
+
+	t.Run("legacy peer", func(t *testing.T) {
+		require.Nil(t, ours.Negotiate(nil))
+		var none *Capabilities
+		require.Nil(t, none.Negotiate(theirs))
+		require.False(t, WithMessageVersion(1)(nil))
+	})
+}
+
+func TestNodeInfo_Capabilities_Seal_Consume(t *testing.T) {
+	netKey, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	ni := &NodeInfo{
+		NetworkID: "testnet",
+		Metadata: &NodeMetadata{
+			NodeVersion: "v0.1.12",
+			Subnets:     AllSubnets,
+			Capabilities: &Capabilities{
+				Version:         CapabilitiesVersion,
+				MessageVersions: []uint64{MessageVersion},
+				Protocols:       []string{"/ssv/info/0.0.1"},
+				FullNode:        true,
+				Forks:           []string{"alan"},
+			},
+		},
+	}
+
+	data, err := ni.Seal(netKey)
+	require.NoError(t, err)
+
+	parsed := &NodeInfo{}
+	require.NoError(t, parsed.Consume(data))
+	require.Equal(t, ni, parsed)
+
+	cloned := parsed.Clone()
+	cloned.Metadata.Capabilities.Protocols[0] = "changed"
+	require.Equal(t, "/ssv/info/0.0.1", parsed.Metadata.Capabilities.Protocols[0])
+}
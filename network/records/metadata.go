@@ -19,6 +19,8 @@ type NodeMetadata struct {
 	ConsensusNode string
 	// Subnets represents the subnets that our node is subscribed to
 	Subnets string
+	// Capabilities describes what the node supports, it is optional for backward compatibility
+	Capabilities *Capabilities `json:",omitempty"`
 }
 
 // Encode encodes the metadata into bytes
@@ -43,5 +45,6 @@ func (nm *NodeMetadata) Decode(data []byte) error {
 
 func (nm *NodeMetadata) Clone() *NodeMetadata {
 	cpy := *nm
+	cpy.Capabilities = nm.Capabilities.Clone()
 	return &cpy
 }
@@ -24,6 +24,9 @@ const (
 
 	// P5
 	appSpecificWeight = 0
+	// ReputationWeight is the weight of the application-specific score when it's based on peer reputation,
+	// a peer with the worst reputation (score -1) reaches the gossip threshold
+	ReputationWeight = -gossipThreshold
 
 	// P6
 	ipColocationFactorThreshold = 10
@@ -70,6 +70,8 @@ type PubSubConfig struct {
 	GetValidatorStats      network.GetValidatorStats
 	ScoreInspector         pubsub.ExtendedPeerScoreInspectFn
 	ScoreInspectorInterval time.Duration
+	// AppSpecificScore is the application-specific score of peers (P5), optional
+	AppSpecificScore func(p peer.ID) float64
 }
 
 // ScoringConfig is the configuration for peer scoring
@@ -168,6 +170,10 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 
 		// Get overall score params
 		peerScoreParams := params.PeerScoreParams(cfg.Scoring.OneEpochDuration, cfg.MsgIDCacheTTL, cfg.DisableIPRateLimit, cfg.Scoring.IPWhitelist...)
+		if cfg.AppSpecificScore != nil {
+			peerScoreParams.AppSpecificScore = cfg.AppSpecificScore
+			peerScoreParams.AppSpecificWeight = params.ReputationWeight
+		}
 
 		// Define score inspector
 		if inspector == nil {
@@ -39,6 +39,12 @@ type NetworkConfig struct {
 	RegistryContractAddr string // TODO: ethcommon.Address
 	Bootnodes            []string
 	DiscoveryProtocolID  [6]byte
+	// AdaptiveRoundTimeoutsEpoch is the fork epoch from which the quick-round timeouts of every committee
+	// are derived from its size and the round, and learned from the rounds in which its recent instances were decided.
+	// zero means that adaptive round timeouts aren't scheduled.
+	AdaptiveRoundTimeoutsEpoch phase0.Epoch
+	// MessageValidation overrides the limits of message validation, the zero value keeps the defaults.
+	MessageValidation MessageValidationLimits
 }
 
 func (n NetworkConfig) String() string {
@@ -54,6 +60,16 @@ func (n NetworkConfig) NetworkName() string {
 	return fmt.Sprintf("%s:%s", n.Name, forkName)
 }
 
+// ForkName returns the name of the current SSV fork.
+func (n NetworkConfig) ForkName() string {
+	return forkName
+}
+
+// AdaptiveRoundTimeoutsScheduled returns true if the network schedules adaptive round timeouts.
+func (n NetworkConfig) AdaptiveRoundTimeoutsScheduled() bool {
+	return n.AdaptiveRoundTimeoutsEpoch != 0
+}
+
 // ForkVersion returns the fork version of the network.
 func (n NetworkConfig) ForkVersion() [4]byte {
 	return n.Beacon.ForkVersion()
@@ -0,0 +1,285 @@
+package networkconfig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+const defaultEpochsPerSyncCommitteePeriod = 256
+
+// networkConfigFile is the format of network configuration files. JSON files are read as YAML.
+type networkConfigFile struct {
+	Name                       string                      `yaml:"Name"`
+	DomainType                 string                      `yaml:"DomainType"`
+	GenesisEpoch               uint64                      `yaml:"GenesisEpoch"`
+	RegistrySyncOffset         uint64                      `yaml:"RegistrySyncOffset"`
+	RegistryContractAddr       string                      `yaml:"RegistryContractAddr"`
+	Bootnodes                  []string                    `yaml:"Bootnodes"`
+	DiscoveryProtocolID        string                      `yaml:"DiscoveryProtocolID"`
+	AdaptiveRoundTimeoutsEpoch uint64                      `yaml:"AdaptiveRoundTimeoutsEpoch"`
+	MessageValidation          messageValidationConfigFile `yaml:"MessageValidation"`
+	Beacon                     beaconConfigFile            `yaml:"Beacon"`
+}
+
+// messageValidationConfigFile overrides the limits of message validation, see MessageValidationLimits.
+// The maps are keyed by runner role names, such as COMMITTEE_RUNNER.
+type messageValidationConfigFile struct {
+	LateMessageMargin                      *time.Duration    `yaml:"LateMessageMargin"`
+	ClockErrorTolerance                    *time.Duration    `yaml:"ClockErrorTolerance"`
+	LateSlotAllowance                      *uint64           `yaml:"LateSlotAllowance"`
+	AllowedRoundsInFuture                  *uint64           `yaml:"AllowedRoundsInFuture"`
+	MaxRounds                              map[string]uint64 `yaml:"MaxRounds"`
+	MaxDutiesPerEpoch                      map[string]uint64 `yaml:"MaxDutiesPerEpoch"`
+	MaxSyncCommitteeContributionSignatures *int              `yaml:"MaxSyncCommitteeContributionSignatures"`
+	MaxConsensusMessageSize                *int              `yaml:"MaxConsensusMessageSize"`
+	MaxPartialSignatureMessageSize         *int              `yaml:"MaxPartialSignatureMessageSize"`
+}
+
+// beaconConfigFile configures the beacon network. Unset parameters default to those of the named network
+// if the spec knows it, otherwise they're required.
+type beaconConfigFile struct {
+	Network                      string `yaml:"Network"`
+	GenesisForkVersion           string `yaml:"GenesisForkVersion"`
+	GenesisTime                  int64  `yaml:"GenesisTime"`
+	SecondsPerSlot               uint64 `yaml:"SecondsPerSlot"`
+	SlotsPerEpoch                uint64 `yaml:"SlotsPerEpoch"`
+	EpochsPerSyncCommitteePeriod uint64 `yaml:"EpochsPerSyncCommitteePeriod"`
+}
+
+// LoadFromFile loads a network configuration from a YAML or JSON file.
+func LoadFromFile(path string) (NetworkConfig, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("failed to read network config file: %w", err)
+	}
+	return parseNetworkConfig(data)
+}
+
+func parseNetworkConfig(data []byte) (NetworkConfig, error) {
+	var file networkConfigFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return NetworkConfig{}, fmt.Errorf("failed to decode network config: %w", err)
+	}
+
+	if file.Name == "" {
+		return NetworkConfig{}, fmt.Errorf("network name is required")
+	}
+	if _, ok := SupportedConfigs[file.Name]; ok {
+		return NetworkConfig{}, fmt.Errorf("network name %q is taken by a supported network", file.Name)
+	}
+
+	domainType, err := decodeHex(file.DomainType, len(spectypes.DomainType{}))
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid domain type: %w", err)
+	}
+	if !ethcommon.IsHexAddress(file.RegistryContractAddr) {
+		return NetworkConfig{}, fmt.Errorf("invalid registry contract address %q", file.RegistryContractAddr)
+	}
+	if len(file.DiscoveryProtocolID) != len([6]byte{}) {
+		return NetworkConfig{}, fmt.Errorf("discovery protocol ID must be %d characters long", len([6]byte{}))
+	}
+	beaconNetwork, err := file.Beacon.network()
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid beacon network: %w", err)
+	}
+	messageValidation, err := file.MessageValidation.limits()
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid message validation limits: %w", err)
+	}
+
+	config := NetworkConfig{
+		Name:                       file.Name,
+		Beacon:                     beaconNetwork,
+		DomainType:                 spectypes.DomainType(domainType),
+		GenesisEpoch:               phase0.Epoch(file.GenesisEpoch),
+		RegistrySyncOffset:         new(big.Int).SetUint64(file.RegistrySyncOffset),
+		RegistryContractAddr:       file.RegistryContractAddr,
+		Bootnodes:                  file.Bootnodes,
+		AdaptiveRoundTimeoutsEpoch: phase0.Epoch(file.AdaptiveRoundTimeoutsEpoch),
+		MessageValidation:          messageValidation,
+	}
+	copy(config.DiscoveryProtocolID[:], file.DiscoveryProtocolID)
+	return config, nil
+}
+
+func (f beaconConfigFile) network() (beacon.Network, error) {
+	if f.Network == "" {
+		return beacon.Network{}, fmt.Errorf("network name is required")
+	}
+	name := spectypes.BeaconNetwork(f.Network)
+	known := spectypes.NetworkFromString(f.Network) != ""
+
+	var explicit beacon.Params
+	if f.GenesisForkVersion != "" {
+		forkVersion, err := decodeHex(f.GenesisForkVersion, len(explicit.GenesisForkVersion))
+		if err != nil {
+			return beacon.Network{}, fmt.Errorf("invalid genesis fork version: %w", err)
+		}
+		explicit.GenesisForkVersion = [4]byte(forkVersion)
+	}
+	explicit.GenesisTime = f.GenesisTime
+	explicit.SlotDuration = time.Duration(f.SecondsPerSlot) * time.Second
+	explicit.SlotsPerEpoch = f.SlotsPerEpoch
+	explicit.EpochsPerSyncCommitteePeriod = f.EpochsPerSyncCommitteePeriod
+
+	if known && explicit == (beacon.Params{}) {
+		return beacon.NewNetwork(name), nil
+	}
+
+	defaults := beacon.Params{EpochsPerSyncCommitteePeriod: defaultEpochsPerSyncCommitteePeriod}
+	if known {
+		defaults = beacon.NewNetwork(name).Params()
+	}
+	params := explicit.Fill(defaults)
+
+	switch {
+	case f.GenesisForkVersion == "" && !known:
+		return beacon.Network{}, fmt.Errorf("genesis fork version is required")
+	case params.GenesisTime == 0:
+		return beacon.Network{}, fmt.Errorf("genesis time is required")
+	case params.SlotDuration == 0:
+		return beacon.Network{}, fmt.Errorf("seconds per slot is required")
+	case params.SlotsPerEpoch == 0:
+		return beacon.Network{}, fmt.Errorf("slots per epoch is required")
+	}
+	return beacon.NewConfiguredNetwork(name, params, explicit), nil
+}
+
+func (f messageValidationConfigFile) limits() (MessageValidationLimits, error) {
+	switch {
+	case f.LateMessageMargin != nil && *f.LateMessageMargin < 0:
+		return MessageValidationLimits{}, fmt.Errorf("late message margin can't be negative")
+	case f.ClockErrorTolerance != nil && *f.ClockErrorTolerance < 0:
+		return MessageValidationLimits{}, fmt.Errorf("clock error tolerance can't be negative")
+	case f.MaxSyncCommitteeContributionSignatures != nil && *f.MaxSyncCommitteeContributionSignatures <= 0:
+		return MessageValidationLimits{}, fmt.Errorf("max sync committee contribution signatures must be positive")
+	case f.MaxConsensusMessageSize != nil && *f.MaxConsensusMessageSize <= 0:
+		return MessageValidationLimits{}, fmt.Errorf("max consensus message size must be positive")
+	case f.MaxPartialSignatureMessageSize != nil && *f.MaxPartialSignatureMessageSize <= 0:
+		return MessageValidationLimits{}, fmt.Errorf("max partial signature message size must be positive")
+	}
+
+	limits := MessageValidationLimits{
+		LateMessageMargin:                      f.LateMessageMargin,
+		ClockErrorTolerance:                    f.ClockErrorTolerance,
+		LateSlotAllowance:                      f.LateSlotAllowance,
+		AllowedRoundsInFuture:                  f.AllowedRoundsInFuture,
+		MaxSyncCommitteeContributionSignatures: f.MaxSyncCommitteeContributionSignatures,
+		MaxConsensusMessageSize:                f.MaxConsensusMessageSize,
+		MaxPartialSignatureMessageSize:         f.MaxPartialSignatureMessageSize,
+	}
+
+	// only roles that run consensus have a max round
+	consensusRoles := []spectypes.RunnerRole{
+		spectypes.RoleCommittee,
+		spectypes.RoleAggregator,
+		spectypes.RoleProposer,
+		spectypes.RoleSyncCommitteeContribution,
+	}
+	for name, maxRound := range f.MaxRounds {
+		role, err := parseRunnerRole(name, consensusRoles)
+		if err != nil {
+			return MessageValidationLimits{}, fmt.Errorf("invalid max rounds: %w", err)
+		}
+		if maxRound < uint64(specqbft.FirstRound) {
+			return MessageValidationLimits{}, fmt.Errorf("max rounds of %s must be at least %d", name, specqbft.FirstRound)
+		}
+		if limits.MaxRounds == nil {
+			limits.MaxRounds = make(map[spectypes.RunnerRole]specqbft.Round, len(f.MaxRounds))
+		}
+		limits.MaxRounds[role] = specqbft.Round(maxRound)
+	}
+
+	// only roles with a fixed duty limit can be overridden
+	fixedDutyRoles := []spectypes.RunnerRole{
+		spectypes.RoleAggregator,
+		spectypes.RoleValidatorRegistration,
+	}
+	for name, maxDuties := range f.MaxDutiesPerEpoch {
+		role, err := parseRunnerRole(name, fixedDutyRoles)
+		if err != nil {
+			return MessageValidationLimits{}, fmt.Errorf("invalid max duties per epoch: %w", err)
+		}
+		if limits.MaxDutiesPerEpoch == nil {
+			limits.MaxDutiesPerEpoch = make(map[spectypes.RunnerRole]uint64, len(f.MaxDutiesPerEpoch))
+		}
+		limits.MaxDutiesPerEpoch[role] = maxDuties
+	}
+
+	return limits, nil
+}
+
+func parseRunnerRole(name string, allowed []spectypes.RunnerRole) (spectypes.RunnerRole, error) {
+	for _, role := range allowed {
+		if role.String() == name {
+			return role, nil
+		}
+	}
+	names := make([]string, 0, len(allowed))
+	for _, role := range allowed {
+		names = append(names, role.String())
+	}
+	return spectypes.RoleUnknown, fmt.Errorf("unexpected role %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+func decodeHex(s string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(b))
+	}
+	return b, nil
+}
+
+// Hash returns a hash of the parameters of the network that the data of a node depends on.
+// Bootnodes aren't hashed, as they may change during the life of the network.
+func (n NetworkConfig) Hash() (string, error) {
+	hashed := struct {
+		Name                       string
+		Beacon                     string
+		BeaconParams               beacon.Params
+		DomainType                 spectypes.DomainType
+		GenesisEpoch               phase0.Epoch
+		RegistrySyncOffset         *big.Int
+		RegistryContractAddr       string
+		DiscoveryProtocolID        [6]byte
+		AdaptiveRoundTimeoutsEpoch phase0.Epoch
+		MessageValidation          MessageValidationLimits
+	}{
+		Name:                       n.Name,
+		Beacon:                     string(n.Beacon.GetBeaconNetwork()),
+		BeaconParams:               n.Beacon.GetNetwork().Params(),
+		DomainType:                 n.DomainType,
+		GenesisEpoch:               n.GenesisEpoch,
+		RegistrySyncOffset:         n.RegistrySyncOffset,
+		RegistryContractAddr:       strings.ToLower(n.RegistryContractAddr),
+		DiscoveryProtocolID:        n.DiscoveryProtocolID,
+		AdaptiveRoundTimeoutsEpoch: n.AdaptiveRoundTimeoutsEpoch,
+		MessageValidation:          n.MessageValidation,
+	}
+	b, err := json.Marshal(hashed)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode network config: %w", err)
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:]), nil
+}
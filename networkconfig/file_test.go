@@ -0,0 +1,178 @@
+package networkconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestLoadFromFile(t *testing.T) {
+	config, err := LoadFromFile("custom-network.example.yaml")
+	require.NoError(t, err)
+
+	require.Equal(t, "my-devnet", config.Name)
+	require.Equal(t, spectypes.DomainType{0x0, 0x0, 0x5, 0x2}, config.DomainType)
+	require.EqualValues(t, 1, config.GenesisEpoch)
+	require.EqualValues(t, 181612, config.RegistrySyncOffset.Int64())
+	require.Equal(t, "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA", config.RegistryContractAddr)
+	require.Equal(t, [6]byte{'s', 's', 'v', 'd', 'v', '5'}, config.DiscoveryProtocolID)
+	require.Len(t, config.Bootnodes, 1)
+	require.Equal(t, beacon.Params{
+		GenesisForkVersion:           [4]byte{0x10, 0x0, 0x0, 0x38},
+		GenesisTime:                  1727000000,
+		SlotDuration:                 12 * time.Second,
+		SlotsPerEpoch:                32,
+		EpochsPerSyncCommitteePeriod: 256,
+	}, config.Beacon.GetNetwork().Params())
+	require.EqualValues(t, "my-devnet", config.Beacon.GetBeaconNetwork())
+	require.Equal(t, time.Unix(1727000000, 0), config.GetGenesisTime())
+}
+
+func TestLoadFromFile_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"Name": "json-devnet",
+		"DomainType": "0x00000502",
+		"RegistryContractAddr": "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA",
+		"DiscoveryProtocolID": "ssvdv5",
+		"Beacon": {"Network": "holesky", "SecondsPerSlot": 6}
+	}`), 0o600))
+
+	config, err := LoadFromFile(path)
+	require.NoError(t, err)
+
+	// unset parameters of a network known to the spec default to the spec's
+	params := config.Beacon.GetNetwork().Params()
+	require.Equal(t, 6*time.Second, params.SlotDuration)
+	require.Equal(t, spectypes.HoleskyNetwork.ForkVersion(), params.GenesisForkVersion)
+	require.EqualValues(t, spectypes.HoleskyNetwork.MinGenesisTime(), params.GenesisTime)
+	require.EqualValues(t, 32, params.SlotsPerEpoch)
+	require.Equal(t, beacon.Params{SlotDuration: 6 * time.Second}, config.Beacon.GetNetwork().ExplicitParams())
+}
+
+func TestParseNetworkConfig_MessageValidation(t *testing.T) {
+	config, err := parseNetworkConfig([]byte(`
+Name: devnet
+DomainType: "0x00000502"
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+DiscoveryProtocolID: ssvdv5
+MessageValidation:
+  LateSlotAllowance: 0
+  ClockErrorTolerance: 50ms
+  MaxRounds:
+    COMMITTEE_RUNNER: 20
+  MaxDutiesPerEpoch:
+    VALIDATOR_REGISTRATION_RUNNER: 4
+Beacon:
+  Network: holesky
+`))
+	require.NoError(t, err)
+
+	limits := config.MessageValidation
+	// a limit set to zero is kept, unlike an unset one
+	require.NotNil(t, limits.LateSlotAllowance)
+	require.Zero(t, *limits.LateSlotAllowance)
+	require.Nil(t, limits.LateMessageMargin)
+	require.NotNil(t, limits.ClockErrorTolerance)
+	require.Equal(t, 50*time.Millisecond, *limits.ClockErrorTolerance)
+	require.Equal(t, map[spectypes.RunnerRole]specqbft.Round{spectypes.RoleCommittee: 20}, limits.MaxRounds)
+	require.Equal(t, map[spectypes.RunnerRole]uint64{spectypes.RoleValidatorRegistration: 4}, limits.MaxDutiesPerEpoch)
+
+	// the limits are part of the hash
+	hash, err := config.Hash()
+	require.NoError(t, err)
+	config.MessageValidation.MaxRounds[spectypes.RoleCommittee]++
+	otherHash, err := config.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+}
+
+func TestParseNetworkConfig_Invalid(t *testing.T) {
+	const valid = `
+Name: devnet
+DomainType: "0x00000502"
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+DiscoveryProtocolID: ssvdv5
+Beacon:
+  Network: holesky
+`
+	_, err := parseNetworkConfig([]byte(valid))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		config  string
+		wantErr string
+	}{
+		"supported name": {
+			config:  "Name: mainnet\nDomainType: \"0x00000502\"\nRegistryContractAddr: \"0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA\"\nDiscoveryProtocolID: ssvdv5\nBeacon:\n  Network: holesky\n",
+			wantErr: "is taken by a supported network",
+		},
+		"unknown field": {
+			config:  valid + "Unknown: 1\n",
+			wantErr: "field Unknown not found",
+		},
+		"short domain type": {
+			config:  "Name: devnet\nDomainType: \"0x0005\"\nRegistryContractAddr: \"0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA\"\nDiscoveryProtocolID: ssvdv5\nBeacon:\n  Network: holesky\n",
+			wantErr: "invalid domain type",
+		},
+		"unknown beacon network without params": {
+			config:  "Name: devnet\nDomainType: \"0x00000502\"\nRegistryContractAddr: \"0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA\"\nDiscoveryProtocolID: ssvdv5\nBeacon:\n  Network: devnet\n",
+			wantErr: "genesis fork version is required",
+		},
+		"unknown max rounds role": {
+			config:  valid + "MessageValidation:\n  MaxRounds:\n    VALIDATOR_REGISTRATION_RUNNER: 3\n",
+			wantErr: `unexpected role "VALIDATOR_REGISTRATION_RUNNER"`,
+		},
+		"zero max rounds": {
+			config:  valid + "MessageValidation:\n  MaxRounds:\n    COMMITTEE_RUNNER: 0\n",
+			wantErr: "max rounds of COMMITTEE_RUNNER must be at least 1",
+		},
+		"role without a fixed duty limit": {
+			config:  valid + "MessageValidation:\n  MaxDutiesPerEpoch:\n    COMMITTEE_RUNNER: 3\n",
+			wantErr: "invalid max duties per epoch",
+		},
+		"negative clock error tolerance": {
+			config:  valid + "MessageValidation:\n  ClockErrorTolerance: -1s\n",
+			wantErr: "clock error tolerance can't be negative",
+		},
+		"zero message size": {
+			config:  valid + "MessageValidation:\n  MaxConsensusMessageSize: 0\n",
+			wantErr: "max consensus message size must be positive",
+		},
+		"unknown message validation field": {
+			config:  valid + "MessageValidation:\n  MaxRound: 3\n",
+			wantErr: "field MaxRound not found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseNetworkConfig([]byte(tt.config))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNetworkConfig_Hash(t *testing.T) {
+	config, err := LoadFromFile("custom-network.example.yaml")
+	require.NoError(t, err)
+	hash, err := config.Hash()
+	require.NoError(t, err)
+
+	// bootnodes may change
+	config.Bootnodes = nil
+	sameHash, err := config.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, sameHash)
+
+	config.GenesisEpoch++
+	otherHash, err := config.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+}
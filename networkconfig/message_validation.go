@@ -0,0 +1,33 @@
+package networkconfig
+
+import (
+	"time"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// MessageValidationLimits overrides the limits enforced by message validation on a network,
+// so that testnets can change them without forking the code. Unset (nil) limits and roles missing
+// from the maps keep the defaults of message validation, a set limit is enforced even if it's zero.
+type MessageValidationLimits struct {
+	// LateMessageMargin is the duration past a message's TTL in which it is still considered valid.
+	LateMessageMargin *time.Duration `json:",omitempty"`
+	// ClockErrorTolerance is the maximum amount of clock error expected between nodes.
+	ClockErrorTolerance *time.Duration `json:",omitempty"`
+	// LateSlotAllowance is the number of slots past the duty's slots in which its messages are still accepted.
+	LateSlotAllowance *uint64 `json:",omitempty"`
+	// AllowedRoundsInFuture is the number of rounds a message can be ahead of the round estimated from the time.
+	AllowedRoundsInFuture *uint64 `json:",omitempty"`
+	// MaxRounds is the highest round of the consensus messages of each role.
+	MaxRounds map[spectypes.RunnerRole]specqbft.Round `json:",omitempty"`
+	// MaxDutiesPerEpoch is the number of duties a signer can perform per epoch, for roles with a fixed limit
+	// (aggregator and validator registration).
+	MaxDutiesPerEpoch map[spectypes.RunnerRole]uint64 `json:",omitempty"`
+	// MaxSyncCommitteeContributionSignatures is the number of partial signatures of a sync committee contribution message.
+	MaxSyncCommitteeContributionSignatures *int `json:",omitempty"`
+	// MaxConsensusMessageSize and MaxPartialSignatureMessageSize cap the size of encoded messages,
+	// they can only tighten the defaults as larger messages are dropped by pubsub.
+	MaxConsensusMessageSize        *int `json:",omitempty"`
+	MaxPartialSignatureMessageSize *int `json:",omitempty"`
+}
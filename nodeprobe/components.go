@@ -0,0 +1,280 @@
+package nodeprobe
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	healthyPeers       = 20
+	healthySubnetPeers = 3
+
+	degradedQueueSaturation = 0.8
+
+	degradedDBLatency = 100 * time.Millisecond
+	failedDBLatency   = time.Second
+
+	degradedSignLatency = time.Second
+
+	degradedEventSyncerLag = 10
+	failedEventSyncerLag   = 100
+
+	degradedClockDrift = 500 * time.Millisecond
+	failedClockDrift   = 2 * time.Second
+)
+
+var (
+	healthCheckPrefix = []byte("health-check")
+	healthCheckData   = []byte("ssv-node-health-check")
+)
+
+// Component returns a component failing when the node of the given name isn't healthy.
+func (p *Prober) Component(name string) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		p.nodesMu.Lock()
+		node, ok := p.nodes[name]
+		p.nodesMu.Unlock()
+
+		health := OK(nil)
+		if !ok {
+			health.Fail("%s not found", name)
+			return health
+		}
+		if err := node.Healthy(ctx); err != nil {
+			health.Fail("%s is not healthy: %s", name, err)
+		}
+		return health
+	})
+}
+
+// TopicIndex indexes the connected peers by pubsub topic.
+type TopicIndex interface {
+	PeersByTopic() ([]peer.ID, map[string][]peer.ID)
+}
+
+// P2PComponent reports the number of peers in total and in each subscribed subnet.
+// It's degraded when there are too few peers and failed when there are none.
+func P2PComponent(topics TopicIndex) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		peers, byTopic := topics.PeersByTopic()
+
+		bySubnet := make(map[string]int, len(byTopic))
+		for topic, topicPeers := range byTopic {
+			bySubnet[commons.GetTopicBaseName(topic)] = len(topicPeers)
+		}
+		health := OK(map[string]any{
+			"peers":           len(peers),
+			"peers_by_subnet": bySubnet,
+		})
+
+		if len(peers) == 0 {
+			health.Fail("no peers are connected")
+			return health
+		}
+		if len(peers) < healthyPeers {
+			health.Degrade("not enough connected peers (%d < %d)", len(peers), healthyPeers)
+		}
+		var weakSubnets int
+		for _, n := range bySubnet {
+			if n < healthySubnetPeers {
+				weakSubnets++
+			}
+		}
+		if weakSubnets > 0 {
+			health.Degrade("%d subnets have less than %d peers", weakSubnets, healthySubnetPeers)
+		}
+		return health
+	})
+}
+
+// QueueSaturationProvider provides how full the message queues of the node are.
+type QueueSaturationProvider interface {
+	QueueSaturation() queue.Saturation
+}
+
+// QueueComponent reports the saturation of the message queues. It's degraded when a queue is nearly full,
+// as messages pushed to a full queue are dropped, and failed when at least half of the queues are full.
+func QueueComponent(provider QueueSaturationProvider) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		s := provider.QueueSaturation()
+		health := OK(map[string]any{
+			"queues":         s.Queues,
+			"full_queues":    s.Full,
+			"max_saturation": s.Max,
+		})
+
+		if s.Full > 0 && s.Full*2 >= s.Queues {
+			health.Fail("%d of %d queues are full", s.Full, s.Queues)
+		} else if s.Max >= degradedQueueSaturation {
+			health.Degrade("a queue is %.0f%% full", s.Max*100)
+		}
+		return health
+	})
+}
+
+// DBComponent reports the latency of reading from the database.
+// It's degraded when reading is slow and failed when it errors or is very slow.
+func DBComponent(db basedb.Reader) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		start := time.Now()
+		_, _, err := db.Get(healthCheckPrefix, healthCheckData)
+		latency := time.Since(start)
+
+		health := OK(map[string]any{
+			"latency": latency.String(),
+		})
+		switch {
+		case err != nil:
+			health.Fail("failed to read from database: %s", err)
+		case latency >= failedDBLatency:
+			health.Fail("reading from database took %s", latency)
+		case latency >= degradedDBLatency:
+			health.Degrade("reading from database took %s", latency)
+		}
+		return health
+	})
+}
+
+// SlotTickFunc returns the time of the latest tick of the slot ticker and its delay from the start
+// of the slot, or false if it didn't tick yet.
+type SlotTickFunc func() (tickTime time.Time, delay time.Duration, ok bool)
+
+// SlotTickerComponent reports the drift of the slot ticker. It's degraded when the ticker hasn't
+// ticked yet or ticks late, and failed when it's stuck.
+func SlotTickerComponent(lastTick SlotTickFunc, slotDuration time.Duration) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		tickTime, delay, ok := lastTick()
+		if !ok {
+			health := OK(nil)
+			health.Degrade("slot ticker didn't tick yet")
+			return health
+		}
+
+		sinceTick := time.Since(tickTime)
+		health := OK(map[string]any{
+			"last_tick":  tickTime,
+			"tick_delay": delay.String(),
+			"since_tick": sinceTick.String(),
+		})
+		if sinceTick > 2*slotDuration {
+			health.Fail("slot ticker didn't tick for %s", sinceTick.Truncate(time.Second))
+		}
+		if delay > slotDuration/3 {
+			health.Degrade("slot ticker ticked %s late", delay)
+		}
+		return health
+	})
+}
+
+// Signer signs data, such as the operator signer.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// SignerComponent reports the availability of a signer by signing a fixed payload.
+// It's degraded when signing is slow and failed when it errors.
+func SignerComponent(signer Signer) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		start := time.Now()
+		_, err := signer.Sign(healthCheckData)
+		latency := time.Since(start)
+
+		health := OK(map[string]any{
+			"latency": latency.String(),
+		})
+		if err != nil {
+			health.Fail("failed to sign: %s", err)
+		} else if latency >= degradedSignLatency {
+			health.Degrade("signing took %s", latency)
+		}
+		return health
+	})
+}
+
+// EventSyncer syncs registry events.
+type EventSyncer interface {
+	Node
+	LastProcessedBlock() (uint64, error)
+}
+
+// ExecutionHead provides the head block of the execution client.
+type ExecutionHead interface {
+	HeadBlock(ctx context.Context) (uint64, error)
+	FollowDistance() uint64
+}
+
+// EventSyncerComponent reports the lag in blocks of the event syncer behind the block it follows.
+// It's degraded when it lags and failed when it lags far behind or isn't healthy.
+func EventSyncerComponent(syncer EventSyncer, head ExecutionHead) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		health := OK(nil)
+		if err := syncer.Healthy(ctx); err != nil {
+			health.Fail("event syncer is not healthy: %s", err)
+		}
+
+		lastProcessed, err := syncer.LastProcessedBlock()
+		if err != nil {
+			health.Fail("%s", err)
+			return health
+		}
+		headBlock, err := head.HeadBlock(ctx)
+		if err != nil {
+			health.Degrade("failed to get head block: %s", err)
+			return health
+		}
+
+		var lag uint64
+		if followed := headBlock - min(headBlock, head.FollowDistance()); followed > lastProcessed {
+			lag = followed - lastProcessed
+		}
+		health.Details = map[string]any{
+			"last_processed_block": lastProcessed,
+			"head_block":           headBlock,
+			"lag":                  lag,
+		}
+		if lag >= failedEventSyncerLag {
+			health.Fail("event syncer lags %d blocks behind", lag)
+		} else if lag >= degradedEventSyncerLag {
+			health.Degrade("event syncer lags %d blocks behind", lag)
+		}
+		return health
+	})
+}
+
+// ClockDrift estimates the drift of the local clock and the offset compensating for it.
+type ClockDrift interface {
+	Estimate() (time.Duration, bool)
+	Offset() time.Duration
+}
+
+// ClockDriftComponent reports the estimated drift of the local clock. It's degraded when the clock drifts
+// and failed when the drift isn't compensated enough for duties to meet their deadlines.
+func ClockDriftComponent(clock ClockDrift) Component {
+	return ComponentFunc(func(ctx context.Context) Health {
+		drift, ok := clock.Estimate()
+		if !ok {
+			return OK(map[string]any{
+				"estimated": false,
+			})
+		}
+
+		offset := clock.Offset()
+		health := OK(map[string]any{
+			"estimated": true,
+			"drift":     drift.String(),
+			"offset":    offset.String(),
+		})
+		if uncompensated := (drift - offset).Abs(); uncompensated > failedClockDrift {
+			health.Fail("local clock drifts by %s, of which %s isn't compensated", drift, uncompensated)
+		} else if drift.Abs() > degradedClockDrift {
+			health.Degrade("local clock drifts by %s", drift)
+		}
+		return health
+	})
+}
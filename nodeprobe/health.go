@@ -0,0 +1,249 @@
+package nodeprobe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Status is the health status of a component.
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusFailed   Status = "failed"
+)
+
+func (s Status) severity() int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Health is the health of a component, with the reasons it isn't ok and details for debugging.
+type Health struct {
+	Status  Status         `json:"status"`
+	Reasons []string       `json:"reasons,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// OK returns a healthy Health with the given details.
+func OK(details map[string]any) Health {
+	return Health{Status: StatusOK, Details: details}
+}
+
+// Degrade adds a reason the component is degraded, keeping its status if it's failed.
+func (h *Health) Degrade(format string, args ...any) {
+	h.worsen(StatusDegraded, fmt.Sprintf(format, args...))
+}
+
+// Fail adds a reason the component failed.
+func (h *Health) Fail(format string, args ...any) {
+	h.worsen(StatusFailed, fmt.Sprintf(format, args...))
+}
+
+func (h *Health) worsen(status Status, reason string) {
+	if status.severity() > h.Status.severity() {
+		h.Status = status
+	}
+	h.Reasons = append(h.Reasons, reason)
+}
+
+// Component is a part of the node reporting its health.
+type Component interface {
+	Health(ctx context.Context) Health
+}
+
+// ComponentFunc is a function implementing Component.
+type ComponentFunc func(ctx context.Context) Health
+
+func (f ComponentFunc) Health(ctx context.Context) Health {
+	return f(ctx)
+}
+
+// RegisterOption configures a registered component.
+type RegisterOption func(*registeredComponent)
+
+// WithLiveness makes a failure of the component fail the liveness of the node,
+// which should then be restarted. Failures of other components only fail its readiness.
+func WithLiveness() RegisterOption {
+	return func(c *registeredComponent) {
+		c.liveness = true
+	}
+}
+
+type registeredComponent struct {
+	component Component
+	liveness  bool
+}
+
+// Registry holds the components of the node and reports their health.
+type Registry struct {
+	mu         sync.RWMutex
+	components map[string]registeredComponent
+	timeout    time.Duration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		components: make(map[string]registeredComponent),
+		timeout:    healthCheckTimeout,
+	}
+}
+
+// Register adds a component by name, replacing any component of the same name.
+func (r *Registry) Register(name string, component Component, opts ...RegisterOption) {
+	c := registeredComponent{component: component}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.components[name] = c
+}
+
+// Report is the health of the node and its components.
+type Report struct {
+	// Status is the worst status of the components.
+	Status Status `json:"status"`
+	// Live is false if any liveness component failed.
+	Live bool `json:"live"`
+	// Ready is false if any component failed.
+	Ready      bool              `json:"ready"`
+	Components map[string]Health `json:"components"`
+	Time       time.Time         `json:"time"`
+
+	// liveness holds the names of the liveness components.
+	liveness map[string]bool
+}
+
+func (r Report) String() string {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("error marshalling health report: %s", err.Error())
+	}
+	return string(b)
+}
+
+// Summary lists the status of each component, with the reasons of those that aren't ok, sorted by name.
+// If liveness is true, it lists only the liveness components.
+func (r Report) Summary(liveness bool) string {
+	names := make([]string, 0, len(r.Components))
+	for name := range r.Components {
+		if liveness && !r.liveness[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		health := r.Components[name]
+		fmt.Fprintf(&sb, "[%s] %s", health.Status, name)
+		if len(health.Reasons) > 0 {
+			fmt.Fprintf(&sb, ": %s", strings.Join(health.Reasons, "; "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Report checks all components in parallel. Components that don't report in time are failed.
+func (r *Registry) Report(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	r.mu.RLock()
+	components := make(map[string]registeredComponent, len(r.components))
+	for name, c := range r.components {
+		components[name] = c
+	}
+	r.mu.RUnlock()
+
+	type result struct {
+		name   string
+		health Health
+	}
+	results := make(chan result, len(components))
+	for name, c := range components {
+		go func(name string, c Component) {
+			results <- result{name: name, health: checkComponent(ctx, c)}
+		}(name, c.component)
+	}
+
+	report := Report{
+		Status:     StatusOK,
+		Live:       true,
+		Ready:      true,
+		Components: make(map[string]Health, len(components)),
+		Time:       time.Now(),
+		liveness:   make(map[string]bool),
+	}
+	for range components {
+		var res result
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			// Fail the components that didn't report in time.
+			for name := range components {
+				if _, ok := report.Components[name]; !ok {
+					health := Health{Status: StatusOK}
+					health.Fail("health check timed out")
+					report.add(name, health, components[name].liveness)
+				}
+			}
+			return report
+		}
+		report.add(res.name, res.health, components[res.name].liveness)
+	}
+	return report
+}
+
+func (r *Report) add(name string, health Health, liveness bool) {
+	r.Components[name] = health
+	if health.Status.severity() > r.Status.severity() {
+		r.Status = health.Status
+	}
+	if liveness {
+		r.liveness[name] = true
+	}
+	if health.Status == StatusFailed {
+		r.Ready = false
+		if liveness {
+			r.Live = false
+		}
+	}
+}
+
+func checkComponent(ctx context.Context, c Component) (health Health) {
+	defer func() {
+		// Catch panics.
+		if e := recover(); e != nil {
+			health = Health{Status: StatusOK}
+			health.Fail("panic: %v", e)
+		}
+	}()
+
+	health = c.Health(ctx)
+	if health.Status == "" {
+		health.Status = StatusOK
+	}
+	return health
+}
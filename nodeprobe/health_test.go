@@ -0,0 +1,271 @@
+package nodeprobe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	status := func(status Status, reasons ...string) Component {
+		return ComponentFunc(func(ctx context.Context) Health {
+			return Health{Status: status, Reasons: reasons}
+		})
+	}
+
+	registry := NewRegistry()
+	registry.Register("healthy", status(StatusOK), WithLiveness())
+	registry.Register("degraded", status(StatusDegraded, "slow"))
+
+	report := registry.Report(ctx)
+	require.Equal(t, StatusDegraded, report.Status)
+	require.True(t, report.Live)
+	require.True(t, report.Ready)
+	require.Len(t, report.Components, 2)
+	require.Equal(t, "[degraded] degraded: slow\n[ok] healthy\n", report.Summary(false))
+	require.Equal(t, "[ok] healthy\n", report.Summary(true))
+
+	// A failed component fails readiness.
+	registry.Register("failed", status(StatusFailed, "down"))
+	report = registry.Report(ctx)
+	require.Equal(t, StatusFailed, report.Status)
+	require.True(t, report.Live)
+	require.False(t, report.Ready)
+
+	// A failed liveness component fails liveness.
+	registry.Register("healthy", status(StatusFailed, "stuck"), WithLiveness())
+	report = registry.Report(ctx)
+	require.False(t, report.Live)
+	require.Equal(t, "[failed] healthy: stuck\n", report.Summary(true))
+
+	// Components that panic or don't report in time are failed.
+	registry = NewRegistry()
+	registry.timeout = 10 * time.Millisecond
+	registry.Register("panic", ComponentFunc(func(ctx context.Context) Health {
+		panic("oops")
+	}))
+	registry.Register("slow", ComponentFunc(func(ctx context.Context) Health {
+		time.Sleep(time.Second)
+		return OK(nil)
+	}))
+	registry.Register("unset", ComponentFunc(func(ctx context.Context) Health {
+		return Health{}
+	}))
+	report = registry.Report(ctx)
+	require.Equal(t, StatusFailed, report.Components["panic"].Status)
+	require.Equal(t, []string{"panic: oops"}, report.Components["panic"].Reasons)
+	require.Equal(t, StatusFailed, report.Components["slow"].Status)
+	require.Equal(t, []string{"health check timed out"}, report.Components["slow"].Reasons)
+	require.Equal(t, StatusOK, report.Components["unset"].Status)
+}
+
+func TestHealth(t *testing.T) {
+	health := OK(nil)
+	health.Fail("down")
+	health.Degrade("slow")
+	require.Equal(t, StatusFailed, health.Status)
+	require.Equal(t, []string{"down", "slow"}, health.Reasons)
+}
+
+type topicIndex map[string]int
+
+func (ti topicIndex) PeersByTopic() ([]peer.ID, map[string][]peer.ID) {
+	var all []peer.ID
+	byTopic := make(map[string][]peer.ID)
+	for topic, n := range ti {
+		for i := 0; i < n; i++ {
+			byTopic[topic] = append(byTopic[topic], peer.ID(rune(i)))
+		}
+		all = append(all, byTopic[topic]...)
+	}
+	return all, byTopic
+}
+
+func TestP2PComponent(t *testing.T) {
+	ctx := context.Background()
+
+	health := P2PComponent(topicIndex{}).Health(ctx)
+	require.Equal(t, StatusFailed, health.Status)
+
+	health = P2PComponent(topicIndex{"ssv.v2.1": 20, "ssv.v2.2": 2}).Health(ctx)
+	require.Equal(t, StatusDegraded, health.Status)
+	require.Equal(t, []string{"1 subnets have less than 3 peers"}, health.Reasons)
+	require.Equal(t, map[string]int{"1": 20, "2": 2}, health.Details["peers_by_subnet"])
+
+	health = P2PComponent(topicIndex{"ssv.v2.1": 20}).Health(ctx)
+	require.Equal(t, StatusOK, health.Status)
+}
+
+type queueSaturation queue.Saturation
+
+func (s queueSaturation) QueueSaturation() queue.Saturation {
+	return queue.Saturation(s)
+}
+
+func TestQueueComponent(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		saturation queue.Saturation
+		status     Status
+	}{
+		{queue.Saturation{}, StatusOK},
+		{queue.Saturation{Queues: 4, Max: 0.5}, StatusOK},
+		{queue.Saturation{Queues: 4, Max: 0.9}, StatusDegraded},
+		{queue.Saturation{Queues: 4, Full: 1, Max: 1}, StatusDegraded},
+		{queue.Saturation{Queues: 4, Full: 2, Max: 1}, StatusFailed},
+	} {
+		health := QueueComponent(queueSaturation(tc.saturation)).Health(ctx)
+		require.Equal(t, tc.status, health.Status, tc.saturation)
+	}
+}
+
+type reader struct {
+	basedb.Reader
+	delay time.Duration
+	err   error
+}
+
+func (r reader) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	time.Sleep(r.delay)
+	return basedb.Obj{}, false, r.err
+}
+
+func TestDBComponent(t *testing.T) {
+	ctx := context.Background()
+
+	require.Equal(t, StatusOK, DBComponent(reader{}).Health(ctx).Status)
+	require.Equal(t, StatusDegraded, DBComponent(reader{delay: degradedDBLatency}).Health(ctx).Status)
+	require.Equal(t, StatusFailed, DBComponent(reader{err: errors.New("closed")}).Health(ctx).Status)
+}
+
+func TestSlotTickerComponent(t *testing.T) {
+	ctx := context.Background()
+	slotDuration := 12 * time.Second
+
+	tick := func(since, delay time.Duration, ok bool) SlotTickFunc {
+		return func() (time.Time, time.Duration, bool) {
+			return time.Now().Add(-since), delay, ok
+		}
+	}
+
+	require.Equal(t, StatusDegraded, SlotTickerComponent(tick(0, 0, false), slotDuration).Health(ctx).Status)
+	require.Equal(t, StatusOK, SlotTickerComponent(tick(time.Second, 10*time.Millisecond, true), slotDuration).Health(ctx).Status)
+	require.Equal(t, StatusDegraded, SlotTickerComponent(tick(time.Second, 5*time.Second, true), slotDuration).Health(ctx).Status)
+	require.Equal(t, StatusFailed, SlotTickerComponent(tick(time.Minute, 0, true), slotDuration).Health(ctx).Status)
+}
+
+type signer struct {
+	err error
+}
+
+func (s signer) Sign([]byte) ([]byte, error) {
+	return nil, s.err
+}
+
+func TestSignerComponent(t *testing.T) {
+	ctx := context.Background()
+
+	require.Equal(t, StatusOK, SignerComponent(signer{}).Health(ctx).Status)
+	require.Equal(t, StatusFailed, SignerComponent(signer{err: errors.New("token removed")}).Health(ctx).Status)
+}
+
+type eventSyncer struct {
+	node
+	lastProcessed uint64
+}
+
+func (es *eventSyncer) LastProcessedBlock() (uint64, error) {
+	return es.lastProcessed, nil
+}
+
+type executionHead uint64
+
+func (h executionHead) HeadBlock(context.Context) (uint64, error) {
+	return uint64(h), nil
+}
+
+func (h executionHead) FollowDistance() uint64 {
+	return 8
+}
+
+func TestEventSyncerComponent(t *testing.T) {
+	ctx := context.Background()
+
+	syncer := &eventSyncer{lastProcessed: 1000}
+	syncer.healthy.Store(nil)
+
+	health := EventSyncerComponent(syncer, executionHead(1008)).Health(ctx)
+	require.Equal(t, StatusOK, health.Status)
+	require.Equal(t, uint64(0), health.Details["lag"])
+
+	health = EventSyncerComponent(syncer, executionHead(1020)).Health(ctx)
+	require.Equal(t, StatusDegraded, health.Status)
+	require.Equal(t, uint64(12), health.Details["lag"])
+
+	health = EventSyncerComponent(syncer, executionHead(1200)).Health(ctx)
+	require.Equal(t, StatusFailed, health.Status)
+
+	err := errors.New("stuck")
+	syncer.healthy.Store(&err)
+	health = EventSyncerComponent(syncer, executionHead(1008)).Health(ctx)
+	require.Equal(t, StatusFailed, health.Status)
+}
+
+func TestProberComponent(t *testing.T) {
+	ctx := context.Background()
+
+	n := &node{}
+	n.healthy.Store(nil)
+	prober := NewProber(zap.L(), nil, map[string]Node{"test node": n})
+
+	require.Equal(t, StatusOK, prober.Component("test node").Health(ctx).Status)
+	require.Equal(t, StatusFailed, prober.Component("other node").Health(ctx).Status)
+
+	err := errors.New("syncing")
+	n.healthy.Store(&err)
+	health := prober.Component("test node").Health(ctx)
+	require.Equal(t, StatusFailed, health.Status)
+	require.Equal(t, []string{"test node is not healthy: syncing"}, health.Reasons)
+}
+
+type clockDrift struct {
+	drift, offset time.Duration
+	ok            bool
+}
+
+func (c clockDrift) Estimate() (time.Duration, bool) {
+	return c.drift, c.ok
+}
+
+func (c clockDrift) Offset() time.Duration {
+	return c.offset
+}
+
+func TestClockDriftComponent(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		clock  clockDrift
+		status Status
+	}{
+		{clockDrift{}, StatusOK},
+		{clockDrift{drift: 100 * time.Millisecond, ok: true}, StatusOK},
+		{clockDrift{drift: -time.Second, ok: true}, StatusDegraded},
+		{clockDrift{drift: 3 * time.Second, offset: 2 * time.Second, ok: true}, StatusDegraded},
+		{clockDrift{drift: -3 * time.Second, ok: true}, StatusFailed},
+	} {
+		health := ClockDriftComponent(tc.clock).Health(ctx)
+		require.Equal(t, tc.status, health.Status, tc.clock)
+	}
+}
@@ -1,12 +1,14 @@
 package observability
 
 import (
+	"encoding/hex"
 	"math"
 	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/ssvlabs/ssv-spec/qbft"
 	"github.com/ssvlabs/ssv-spec/types"
@@ -32,6 +34,36 @@ func DutyRoundAttribute(round qbft.Round) attribute.KeyValue {
 	}
 }
 
+func BeaconSlotAttribute(slot phase0.Slot) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ethereum.beacon.slot",
+		Value: Uint64AttributeValue(uint64(slot)),
+	}
+}
+
+func ValidatorPublicKeyAttribute(pubKey phase0.BLSPubKey) attribute.KeyValue {
+	return attribute.String("ssv.validator.pubkey", pubKey.String())
+}
+
+func ValidatorIndexAttribute(index phase0.ValidatorIndex) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ssv.validator.index",
+		Value: Uint64AttributeValue(uint64(index)),
+	}
+}
+
+func ValidatorIndicesAttribute(indices []phase0.ValidatorIndex) attribute.KeyValue {
+	values := make([]int64, 0, len(indices))
+	for _, index := range indices {
+		values = append(values, int64(index)) // nolint: gosec
+	}
+	return attribute.Int64Slice("ssv.validator.indices", values)
+}
+
+func CommitteeIDAttribute(id types.CommitteeID) attribute.KeyValue {
+	return attribute.String("ssv.committee.id", hex.EncodeToString(id[:]))
+}
+
 func NetworkDirectionAttribute(direction network.Direction) attribute.KeyValue {
 	return attribute.String("ssv.p2p.connection.direction", strings.ToLower(direction.String()))
 }
@@ -1,5 +1,11 @@
 package observability
 
+import (
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
 type Config struct {
 	metricsEnabled bool
+	tracesEndpoint string
+	traceExporter  trace.SpanExporter
 }
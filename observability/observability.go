@@ -5,16 +5,25 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 var config Config
 
 func Initialize(appName, appVersion string, options ...Option) (shutdown func(context.Context) error, err error) {
-	shutdown = func(ctx context.Context) error { return nil }
+	var shutdowns []func(context.Context) error
+	shutdown = func(ctx context.Context) error {
+		var errs error
+		for _, f := range shutdowns {
+			errs = errors.Join(errs, f(ctx))
+		}
+		return errs
+	}
 
 	for _, option := range options {
 		option(&config)
@@ -42,7 +51,26 @@ func Initialize(appName, appVersion string, options ...Option) (shutdown func(co
 			metric.WithReader(promExporter),
 		)
 		otel.SetMeterProvider(meterProvider)
-		shutdown = meterProvider.Shutdown
+		shutdowns = append(shutdowns, meterProvider.Shutdown)
+	}
+
+	var traceOptions []trace.TracerProviderOption
+	if config.tracesEndpoint != "" {
+		var otlpExporter *otlptrace.Exporter
+		otlpExporter, err = newOTLPExporter(context.Background(), config.tracesEndpoint)
+		if err != nil {
+			err = errors.Join(errors.New("failed to instantiate trace OTLP exporter"), err)
+			return shutdown, err
+		}
+		traceOptions = append(traceOptions, trace.WithBatcher(otlpExporter))
+	}
+	if config.traceExporter != nil {
+		traceOptions = append(traceOptions, trace.WithSyncer(config.traceExporter))
+	}
+	if len(traceOptions) > 0 {
+		tracerProvider := trace.NewTracerProvider(append(traceOptions, trace.WithResource(resources))...)
+		otel.SetTracerProvider(tracerProvider)
+		shutdowns = append(shutdowns, tracerProvider.Shutdown)
 	}
 
 	return shutdown, err
@@ -1,5 +1,9 @@
 package observability
 
+import (
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
 type (
 	Option func(*Config)
 )
@@ -9,3 +13,17 @@ func WithMetrics() Option {
 		cfg.metricsEnabled = true
 	}
 }
+
+// WithTraces exports traces to the given OTLP/HTTP endpoint, such as http://collector:4318.
+func WithTraces(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.tracesEndpoint = endpoint
+	}
+}
+
+// WithTraceExporter exports traces synchronously to the given exporter, such as an in-memory exporter in tests.
+func WithTraceExporter(exporter trace.SpanExporter) Option {
+	return func(cfg *Config) {
+		cfg.traceExporter = exporter
+	}
+}
@@ -0,0 +1,41 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+const (
+	otlpTracesPath    = "/v1/traces"
+	otlpExportTimeout = 10 * time.Second
+)
+
+// newOTLPExporter returns an exporter of spans to the given OTLP/HTTP endpoint, such as http://collector:4318.
+// Spans are sent to its /v1/traces path unless the endpoint has a path of its own.
+func newOTLPExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid OTLP endpoint scheme %q", u.Scheme)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpTracesPath
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(u.Path),
+		otlptracehttp.WithTimeout(otlpExportTimeout),
+	}
+	if u.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, options...)
+}
@@ -0,0 +1,91 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ssvlabs/ssv-spec/types"
+)
+
+func TestInitializeTraces(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown, err := Initialize("ssv-node", "v0.0.0", WithTraceExporter(exporter))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	tracer := otel.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "ssv.test.parent")
+	_, child := tracer.Start(ctx, "ssv.test.child", trace.WithAttributes(RunnerRoleAttribute(types.RoleCommittee)))
+	EndSpan(child, errors.New("failed"))
+	EndSpan(parent, nil)
+	EndSpan(nil, nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "ssv.test.child", spans[0].Name)
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	require.Equal(t, codes.Error, spans[0].Status.Code)
+	require.Equal(t, "failed", spans[0].Status.Description)
+	require.Contains(t, spans[0].Attributes, RunnerRoleAttribute(types.RoleCommittee))
+	require.Len(t, spans[0].Events, 1)
+
+	require.Equal(t, "ssv.test.parent", spans[1].Name)
+	require.Equal(t, codes.Unset, spans[1].Status.Code)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+	}))
+	defer server.Close()
+
+	exporter, err := newOTLPExporter(context.Background(), server.URL)
+	require.NoError(t, err)
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() {
+		require.NoError(t, provider.Shutdown(context.Background()))
+	}()
+
+	_, span := provider.Tracer("test").Start(context.Background(), "ssv.test.span")
+	span.End()
+
+	r := <-requests
+	require.Equal(t, otlpTracesPath, r.URL.Path)
+	require.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+}
+
+func TestOTLPExporterErrors(t *testing.T) {
+	_, err := newOTLPExporter(context.Background(), "collector:4318")
+	require.Error(t, err)
+
+	paths := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter, err := newOTLPExporter(context.Background(), server.URL+"/custom/traces")
+	require.NoError(t, err)
+
+	_, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "ssv.test.span")
+	span.End()
+	err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	require.ErrorContains(t, err, "400")
+	require.Equal(t, "/custom/traces", <-paths)
+}
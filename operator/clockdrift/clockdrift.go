@@ -0,0 +1,223 @@
+// Package clockdrift estimates the drift of the local clock from the clock of the network,
+// without NTP, from the arrival times of events that happen at the start of a slot.
+package clockdrift
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+)
+
+const (
+	// Tolerance is the drift attributed to the noise of propagation delays. Drift within it isn't compensated.
+	Tolerance = 500 * time.Millisecond
+
+	// quantile is the quantile of the samples estimating the drift. A low quantile rather than the minimum
+	// excludes outliers, such as the messages of peers whose clocks are ahead.
+	quantile = 0.1
+)
+
+// Source is a source of samples of the drift.
+type Source string
+
+const (
+	// SourceBeacon samples the head events of the beacon node.
+	SourceBeacon Source = "beacon"
+	// SourcePeers samples the messages peers send at the start of a slot.
+	SourcePeers Source = "peers"
+)
+
+var windows = map[Source]struct {
+	size       int
+	minSamples int
+}{
+	SourceBeacon: {size: 64, minSamples: 8},
+	SourcePeers:  {size: 512, minSamples: 32},
+}
+
+// Config configures the compensation of the drift.
+type Config struct {
+	MaxOffset time.Duration `yaml:"MaxOffset" env:"CLOCK_DRIFT_MAX_OFFSET" env-description:"Maximum offset applied to the slot ticker to compensate for the estimated drift of the local clock (0 disables compensation)"`
+}
+
+// SlotClock returns the start time of slots.
+type SlotClock interface {
+	GetSlotStartTime(slot phase0.Slot) time.Time
+}
+
+// Estimator estimates the drift of the local clock, positive when it's ahead of the network.
+//
+// Each sample is the time from the start of a slot, per the local clock, to the arrival of an event which
+// doesn't happen before the start of the slot, so it's the drift plus a non-negative delay.
+// A low quantile of the samples of a source estimates the drift plus the minimal delay of the source,
+// an upper bound of the drift:
+//   - Pre-consensus messages of peers (randao, selection proofs and contribution proofs) are sent at the start
+//     of the slot, so their minimal delay is the propagation delay and the estimate is close to the drift.
+//   - Head events are delayed by the production and propagation of blocks, so they only bound the drift
+//     of a clock that's behind.
+//
+// A nil Estimator doesn't estimate.
+type Estimator struct {
+	logger    *zap.Logger
+	slots     SlotClock
+	maxOffset time.Duration
+
+	mu      sync.Mutex
+	samples map[Source]*window
+	drifted bool
+}
+
+func New(logger *zap.Logger, slots SlotClock, cfg Config) *Estimator {
+	e := &Estimator{
+		logger:    logger,
+		slots:     slots,
+		maxOffset: cfg.MaxOffset,
+		samples:   make(map[Source]*window, len(windows)),
+	}
+	for source, w := range windows {
+		e.samples[source] = &window{values: make([]time.Duration, 0, w.size), size: w.size}
+	}
+	return e
+}
+
+// ObserveHead observes a head event of the beacon node for the given slot, received at the given time.
+func (e *Estimator) ObserveHead(slot phase0.Slot, receivedAt time.Time) {
+	if e == nil {
+		return
+	}
+	e.observe(SourceBeacon, slot, receivedAt)
+
+	// Head events arrive once per slot, which is often enough to report the drift.
+	drift, ok := e.Estimate()
+	if !ok {
+		return
+	}
+	ctx := context.Background()
+	driftGauge.Record(ctx, drift.Seconds())
+	offsetGauge.Record(ctx, e.Offset().Seconds())
+	e.logDrift(drift)
+}
+
+// ObservePeerMessage observes a message of a peer sent at the start of the given slot, received at the given time.
+func (e *Estimator) ObservePeerMessage(slot phase0.Slot, receivedAt time.Time) {
+	if e == nil {
+		return
+	}
+	e.observe(SourcePeers, slot, receivedAt)
+}
+
+func (e *Estimator) observe(source Source, slot phase0.Slot, receivedAt time.Time) {
+	sample := receivedAt.Sub(e.slots.GetSlotStartTime(slot))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.samples[source].add(sample)
+}
+
+// SourceEstimate is the estimate of a source.
+type SourceEstimate struct {
+	// Drift is the upper bound of the drift estimated by the source, if it has enough samples.
+	Drift   time.Duration `json:"drift"`
+	Samples int           `json:"samples"`
+	Enough  bool          `json:"enough"`
+}
+
+// Sources returns the estimates of each source.
+func (e *Estimator) Sources() map[Source]SourceEstimate {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	estimates := make(map[Source]SourceEstimate, len(e.samples))
+	for source, w := range e.samples {
+		estimates[source] = SourceEstimate{
+			Drift:   w.quantile(quantile),
+			Samples: len(w.values),
+			Enough:  len(w.values) >= windows[source].minSamples,
+		}
+	}
+	return estimates
+}
+
+// Estimate returns the estimated drift, or false if there aren't enough samples to estimate it.
+func (e *Estimator) Estimate() (time.Duration, bool) {
+	sources := e.Sources()
+
+	peers, beacon := sources[SourcePeers], sources[SourceBeacon]
+	switch {
+	case peers.Enough && beacon.Enough:
+		return min(peers.Drift, beacon.Drift), true
+	case peers.Enough:
+		return peers.Drift, true
+	case beacon.Enough && beacon.Drift < 0:
+		return beacon.Drift, true
+	default:
+		return 0, false
+	}
+}
+
+// Offset returns the offset of the slot ticker compensating for the drift: the estimated drift
+// bounded by the configured maximum, or 0 if the drift is within Tolerance or compensation is disabled.
+func (e *Estimator) Offset() time.Duration {
+	if e == nil || e.maxOffset <= 0 {
+		return 0
+	}
+	drift, ok := e.Estimate()
+	if !ok || (drift > -Tolerance && drift < Tolerance) {
+		return 0
+	}
+	return max(-e.maxOffset, min(e.maxOffset, drift))
+}
+
+// logDrift warns when the drift exceeds Tolerance and when it's back within it.
+func (e *Estimator) logDrift(drift time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	drifted := drift <= -Tolerance || drift >= Tolerance
+	if drifted == e.drifted {
+		return
+	}
+	e.drifted = drifted
+
+	if drifted {
+		e.logger.Warn("local clock is drifting from the network, ensure it's synchronized (such as with NTP)",
+			zap.Duration("drift", drift),
+			zap.Duration("max_offset", e.maxOffset))
+	} else {
+		e.logger.Info("local clock is synchronized with the network", zap.Duration("drift", drift))
+	}
+}
+
+// window holds the latest samples of a source.
+type window struct {
+	values []time.Duration
+	size   int
+	next   int
+}
+
+func (w *window) add(sample time.Duration) {
+	if len(w.values) < w.size {
+		w.values = append(w.values, sample)
+		return
+	}
+	w.values[w.next] = sample
+	w.next = (w.next + 1) % w.size
+}
+
+func (w *window) quantile(q float64) time.Duration {
+	if len(w.values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(w.values)
+	slices.Sort(sorted)
+	return sorted[int(q*float64(len(sorted)-1))]
+}
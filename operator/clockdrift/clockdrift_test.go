@@ -0,0 +1,106 @@
+package clockdrift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+type slotClock time.Time
+
+func (c slotClock) GetSlotStartTime(slot phase0.Slot) time.Time {
+	return time.Time(c).Add(time.Duration(slot) * 12 * time.Second)
+}
+
+func TestEstimator(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+
+	// observe observes samples for consecutive slots, received after the given delays by a clock drifting by drift.
+	observe := func(observe func(phase0.Slot, time.Time), drift time.Duration, delays ...time.Duration) {
+		for i, delay := range delays {
+			slot := phase0.Slot(i)
+			observe(slot, slotClock(genesis).GetSlotStartTime(slot).Add(drift+delay))
+		}
+	}
+	delays := func(n int, delay time.Duration) []time.Duration {
+		d := make([]time.Duration, n)
+		for i := range d {
+			d[i] = delay + time.Duration(i%10)*100*time.Millisecond
+		}
+		return d
+	}
+
+	t.Run("not enough samples", func(t *testing.T) {
+		e := New(logging.TestLogger(t), slotClock(genesis), Config{MaxOffset: 2 * time.Second})
+		observe(e.ObservePeerMessage, time.Second, delays(10, 0)...)
+		_, ok := e.Estimate()
+		require.False(t, ok)
+		require.Zero(t, e.Offset())
+	})
+
+	t.Run("clock ahead", func(t *testing.T) {
+		e := New(logging.TestLogger(t), slotClock(genesis), Config{MaxOffset: 2 * time.Second})
+
+		// Head events can't tell a clock is ahead.
+		observe(e.ObserveHead, 3*time.Second, delays(16, time.Second)...)
+		_, ok := e.Estimate()
+		require.False(t, ok)
+
+		observe(e.ObservePeerMessage, 3*time.Second, delays(64, 20*time.Millisecond)...)
+		drift, ok := e.Estimate()
+		require.True(t, ok)
+		require.InDelta(t, 3*time.Second, drift, float64(200*time.Millisecond))
+
+		// The offset is bounded by MaxOffset.
+		require.Equal(t, 2*time.Second, e.Offset())
+	})
+
+	t.Run("clock behind", func(t *testing.T) {
+		e := New(logging.TestLogger(t), slotClock(genesis), Config{MaxOffset: 2 * time.Second})
+		observe(e.ObserveHead, -time.Second, delays(16, 0)...)
+		drift, ok := e.Estimate()
+		require.True(t, ok)
+		require.InDelta(t, -time.Second, drift, float64(200*time.Millisecond))
+		require.Equal(t, drift, e.Offset())
+	})
+
+	t.Run("within tolerance", func(t *testing.T) {
+		e := New(logging.TestLogger(t), slotClock(genesis), Config{MaxOffset: 2 * time.Second})
+		observe(e.ObservePeerMessage, 0, delays(64, 50*time.Millisecond)...)
+		_, ok := e.Estimate()
+		require.True(t, ok)
+		require.Zero(t, e.Offset())
+	})
+
+	t.Run("compensation disabled", func(t *testing.T) {
+		e := New(logging.TestLogger(t), slotClock(genesis), Config{})
+		observe(e.ObservePeerMessage, 3*time.Second, delays(64, 0)...)
+		_, ok := e.Estimate()
+		require.True(t, ok)
+		require.Zero(t, e.Offset())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var e *Estimator
+		e.ObserveHead(1, time.Now())
+		e.ObservePeerMessage(1, time.Now())
+		_, ok := e.Estimate()
+		require.False(t, ok)
+		require.Zero(t, e.Offset())
+	})
+}
+
+func TestWindow(t *testing.T) {
+	w := &window{size: 3}
+	for _, v := range []time.Duration{5, 1, 4, 3} {
+		w.add(v)
+	}
+	// The oldest sample is replaced.
+	require.ElementsMatch(t, []time.Duration{3, 1, 4}, w.values)
+	require.Equal(t, time.Duration(1), w.quantile(0))
+	require.Equal(t, time.Duration(4), w.quantile(1))
+}
@@ -0,0 +1,35 @@
+package clockdrift
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/operator/clockdrift"
+	observabilityNamespace = "ssv.clock"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	driftGauge = observability.NewMetric(
+		meter.Float64Gauge(
+			metricName("drift"),
+			metric.WithUnit("s"),
+			metric.WithDescription("estimated drift of the local clock from the network's in seconds, positive when it's ahead")))
+
+	offsetGauge = observability.NewMetric(
+		meter.Float64Gauge(
+			metricName("offset"),
+			metric.WithUnit("s"),
+			metric.WithDescription("offset applied to the slot ticker to compensate for the drift of the local clock in seconds")))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
@@ -5,6 +5,7 @@ import (
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
@@ -15,11 +16,16 @@ type OperatorDataStore interface {
 	OperatorIDReady() bool
 	AwaitOperatorID() spectypes.OperatorID
 	SetOperatorData(data *registrystorage.OperatorData)
+	GetNewOperatorData() *registrystorage.OperatorData
+	SetNewOperatorData(data *registrystorage.OperatorData)
+	GetOperatorIDs() []spectypes.OperatorID
+	ShareOperatorID(share *ssvtypes.SSVShare) (spectypes.OperatorID, bool)
 }
 
 // operatorDataStore provides a thread-safe implementation of OperatorDataStore.
 type operatorDataStore struct {
 	operatorData    *registrystorage.OperatorData
+	newOperatorData *registrystorage.OperatorData
 	operatorDataMu  sync.RWMutex
 	operatorIDReady bool
 	readyCond       *sync.Cond
@@ -87,6 +93,51 @@ func (ods *operatorDataStore) SetOperatorData(od *registrystorage.OperatorData)
 	}
 }
 
+// GetNewOperatorData returns the data of the operator registered with the key the node is rotating to,
+// or nil if the node isn't rotating its key or the new key isn't registered yet.
+func (ods *operatorDataStore) GetNewOperatorData() *registrystorage.OperatorData {
+	ods.operatorDataMu.RLock()
+	defer ods.operatorDataMu.RUnlock()
+
+	return ods.newOperatorData
+}
+
+// SetNewOperatorData sets the data of the operator registered with the key the node is rotating to.
+// Until it's cleared, the node runs as both operators.
+func (ods *operatorDataStore) SetNewOperatorData(od *registrystorage.OperatorData) {
+	ods.operatorDataMu.Lock()
+	defer ods.operatorDataMu.Unlock()
+
+	ods.newOperatorData = od
+}
+
+// GetOperatorIDs returns the IDs of the operators the node runs as: its own,
+// followed by the one of the new operator while it's rotating its key.
+func (ods *operatorDataStore) GetOperatorIDs() []spectypes.OperatorID {
+	ods.operatorDataMu.RLock()
+	defer ods.operatorDataMu.RUnlock()
+
+	var ids []spectypes.OperatorID
+	if ods.operatorData != nil && ods.operatorData.ID != 0 {
+		ids = append(ids, ods.operatorData.ID)
+	}
+	if ods.newOperatorData != nil && ods.newOperatorData.ID != 0 {
+		ids = append(ids, ods.newOperatorData.ID)
+	}
+	return ids
+}
+
+// ShareOperatorID returns the ID of the operator the node is a member of the share's committee as,
+// preferring its own over the new operator's, and whether it's a member at all.
+func (ods *operatorDataStore) ShareOperatorID(share *ssvtypes.SSVShare) (spectypes.OperatorID, bool) {
+	for _, id := range ods.GetOperatorIDs() {
+		if share.BelongsToOperator(id) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
 // setOperatorIDReady marks the operator ID as ready and notifies waiting goroutines.
 func (ods *operatorDataStore) setOperatorIDReady() {
 	ods.operatorIDReady = true
@@ -9,6 +9,7 @@ import (
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
@@ -102,3 +103,37 @@ func TestAwaitOperatorID(t *testing.T) {
 	receivedID := store.AwaitOperatorID()
 	assert.Equal(t, spectypes.OperatorID(456), receivedID)
 }
+
+func TestNewOperatorData(t *testing.T) {
+	store := New(&registrystorage.OperatorData{ID: 1})
+	share := func(ids ...spectypes.OperatorID) *ssvtypes.SSVShare {
+		s := &ssvtypes.SSVShare{}
+		for _, id := range ids {
+			s.Committee = append(s.Committee, &spectypes.ShareMember{Signer: id})
+		}
+		return s
+	}
+
+	assert.Nil(t, store.GetNewOperatorData())
+	assert.Equal(t, []spectypes.OperatorID{1}, store.GetOperatorIDs())
+	_, ok := store.ShareOperatorID(share(2, 3, 4, 5))
+	assert.False(t, ok)
+
+	store.SetNewOperatorData(&registrystorage.OperatorData{ID: 2})
+	assert.Equal(t, []spectypes.OperatorID{1, 2}, store.GetOperatorIDs())
+	for ids, want := range map[[4]spectypes.OperatorID]spectypes.OperatorID{
+		{1, 3, 4, 5}: 1,
+		{2, 3, 4, 5}: 2,
+		{1, 2, 4, 5}: 1,
+	} {
+		id, ok := store.ShareOperatorID(share(ids[:]...))
+		assert.True(t, ok)
+		assert.Equal(t, want, id)
+	}
+
+	store.SetOperatorData(store.GetNewOperatorData())
+	store.SetNewOperatorData(nil)
+	assert.Equal(t, []spectypes.OperatorID{2}, store.GetOperatorIDs())
+	_, ok = store.ShareOperatorID(share(1, 3, 4, 5))
+	assert.False(t, ok)
+}
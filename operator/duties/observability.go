@@ -17,7 +17,8 @@ const (
 )
 
 var (
-	meter = otel.Meter(observabilityName)
+	meter  = otel.Meter(observabilityName)
+	tracer = otel.Tracer(observabilityName)
 
 	slotDelayHistogram = observability.NewMetric(
 		meter.Float64Histogram(
@@ -37,6 +38,10 @@ func metricName(name string) string {
 	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
 }
 
+func spanName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
 func recordDutyExecuted(ctx context.Context, role types.RunnerRole) {
 	dutiesExecutedCounter.Add(ctx, 1,
 		metric.WithAttributes(
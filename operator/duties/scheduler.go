@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -14,6 +15,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -22,6 +24,8 @@ import (
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/network"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/observability"
+	"github.com/ssvlabs/ssv/operator/clockdrift"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
@@ -86,6 +90,7 @@ type SchedulerOptions struct {
 	SlotTickerProvider  slotticker.Provider
 	DutyStore           *dutystore.Store
 	P2PNetwork          network.P2PNetwork
+	ClockDrift          *clockdrift.Estimator
 }
 
 type Scheduler struct {
@@ -96,6 +101,7 @@ type Scheduler struct {
 	validatorController ValidatorController
 	slotTickerProvider  slotticker.Provider
 	dutyExecutor        DutyExecutor
+	clockDrift          *clockdrift.Estimator
 
 	handlers            []dutyHandler
 	blockPropagateDelay time.Duration
@@ -107,6 +113,7 @@ type Scheduler struct {
 	pool       *pool.ContextPool
 
 	headSlot                  phase0.Slot
+	lastSlotTick              atomic.Pointer[SlotTick]
 	lastBlockEpoch            phase0.Epoch
 	currentDutyDependentRoot  phase0.Root
 	previousDutyDependentRoot phase0.Root
@@ -127,6 +134,7 @@ func NewScheduler(opts *SchedulerOptions) *Scheduler {
 		validatorProvider:   opts.ValidatorProvider,
 		validatorController: opts.ValidatorController,
 		indicesChg:          opts.IndicesChg,
+		clockDrift:          opts.ClockDrift,
 		blockPropagateDelay: blockPropagationDelay,
 
 		handlers: []dutyHandler{
@@ -246,14 +254,36 @@ func (f *EventFeed[T]) FanOut(ctx context.Context, in <-chan T) {
 	}
 }
 
+// SlotTick describes a tick of the slot ticker.
+type SlotTick struct {
+	Slot phase0.Slot
+	Time time.Time
+	// Delay is the time from the start of the slot to its tick, per the local clock.
+	Delay time.Duration
+}
+
+// LastSlotTick returns the latest tick of the slot ticker, if it ticked.
+func (s *Scheduler) LastSlotTick() (SlotTick, bool) {
+	tick := s.lastSlotTick.Load()
+	if tick == nil {
+		return SlotTick{}, false
+	}
+	return *tick, true
+}
+
 // SlotTicker handles the "head" events from the beacon node.
 func (s *Scheduler) SlotTicker(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-s.ticker.Next():
+		case tickTime := <-s.ticker.Next():
 			slot := s.ticker.Slot()
+			s.lastSlotTick.Store(&SlotTick{
+				Slot:  slot,
+				Time:  tickTime,
+				Delay: tickTime.Sub(s.network.Beacon.GetSlotStartTime(slot)),
+			})
 
 			delay := s.network.SlotDurationSec() / casts.DurationFromUint64(goclient.IntervalsPerSlot) /* a third of the slot duration */
 			finalTime := s.network.Beacon.GetSlotStartTime(slot).Add(delay)
@@ -282,6 +312,10 @@ func (s *Scheduler) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.Ev
 		var zeroRoot phase0.Root
 
 		data := event.Data.(*eth2apiv1.HeadEvent)
+
+		// Sample the arrival of head events before filtering them by the local clock, which may be drifting.
+		s.clockDrift.ObserveHead(data.Slot, time.Now())
+
 		if data.Slot != s.network.Beacon.EstimatedCurrentSlot() {
 			return
 		}
@@ -390,10 +424,23 @@ func (s *Scheduler) ExecuteCommitteeDuties(ctx context.Context, logger *zap.Logg
 			logger.Debug("⚠️ late duty execution", zap.Int64("slot_delay", slotDelay.Milliseconds()))
 		}
 		slotDelayHistogram.Record(ctx, slotDelay.Seconds())
+
+		validatorIndices := make([]phase0.ValidatorIndex, 0, len(duty.ValidatorDuties))
+		for _, validatorDuty := range duty.ValidatorDuties {
+			validatorIndices = append(validatorIndices, validatorDuty.ValidatorIndex)
+		}
+		dutyCtx, span := tracer.Start(ctx, spanName("execute_committee_duty"),
+			trace.WithAttributes(
+				observability.CommitteeIDAttribute(committee.id),
+				observability.RunnerRoleAttribute(duty.RunnerRole()),
+				observability.BeaconSlotAttribute(duty.Slot),
+				observability.ValidatorIndicesAttribute(validatorIndices)))
 		go func() {
+			defer span.End()
 			s.waitOneThirdOrValidBlock(duty.Slot)
-			recordDutyExecuted(ctx, duty.RunnerRole())
-			s.dutyExecutor.ExecuteCommitteeDuty(ctx, logger, committee.id, duty)
+			span.AddEvent("waited for one third of slot or valid block")
+			recordDutyExecuted(dutyCtx, duty.RunnerRole())
+			s.dutyExecutor.ExecuteCommitteeDuty(dutyCtx, logger, committee.id, duty)
 		}()
 	}
 }
@@ -426,3 +426,41 @@ func TestScheduler_Regression_IndicesChangeStuck(t *testing.T) {
 	}
 
 }
+
+func TestScheduler_LastSlotTick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockTicker := mockslotticker.NewMockSlotTicker(ctrl)
+	s := NewScheduler(&SchedulerOptions{
+		Ctx:     ctx,
+		Network: networkconfig.TestNetwork,
+		SlotTickerProvider: func() slotticker.SlotTicker {
+			return mockTicker
+		},
+	})
+
+	_, ok := s.LastSlotTick()
+	require.False(t, ok)
+
+	// Tick a second late for a slot in the past.
+	slot := phase0.Slot(100)
+	tickTime := networkconfig.TestNetwork.Beacon.GetSlotStartTime(slot).Add(time.Second)
+	ticks := make(chan time.Time, 1)
+	ticks <- tickTime
+	mockTicker.EXPECT().Next().Return((<-chan time.Time)(ticks)).AnyTimes()
+	mockTicker.EXPECT().Slot().Return(slot).AnyTimes()
+
+	go s.SlotTicker(ctx)
+
+	require.Eventually(t, func() bool {
+		_, ok := s.LastSlotTick()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	tick, _ := s.LastSlotTick()
+	require.Equal(t, SlotTick{Slot: slot, Time: tickTime, Delay: time.Second}, tick)
+}
@@ -0,0 +1,87 @@
+// Package keyrotation rotates the operator private key of a node.
+//
+// The registry contract has no event updating the public key of an existing operator,
+// so a key is rotated by registering the new public key as an operator and migrating
+// the clusters to it. Until the new public key is registered, the node runs as the old
+// operator. Once it is, the node runs as both operators while the clusters migrate,
+// signing for each committee with the key of the operator it's a member of it as.
+// Once the old operator is removed, the node re-encrypts its signer storage under the
+// new key and runs as the new operator only. Shares encrypted to either key are decrypted throughout.
+package keyrotation
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// PrivateKeyHashStore stores the hash of the operator private key the node storage belongs to.
+type PrivateKeyHashStore interface {
+	SavePrivateKeyHash(rw basedb.ReadWriter, hashedKey string) error
+}
+
+// Rotator switches the node from the old key of a RotatingKey to the new one.
+type Rotator struct {
+	logger       *zap.Logger
+	key          *keys.RotatingKey
+	newPublicKey []byte
+	keyManager   ekm.KeyManager
+	nodeStorage  PrivateKeyHashStore
+	mu           sync.Mutex
+}
+
+func New(logger *zap.Logger, key *keys.RotatingKey, keyManager ekm.KeyManager, nodeStorage PrivateKeyHashStore) (*Rotator, error) {
+	newPublicKey, err := key.New().Public().Base64()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode new public key: %w", err)
+	}
+	return &Rotator{
+		logger:       logger,
+		key:          key,
+		newPublicKey: newPublicKey,
+		keyManager:   keyManager,
+		nodeStorage:  nodeStorage,
+	}, nil
+}
+
+// NewPublicKey returns the base64 encoded public key being rotated to.
+func (r *Rotator) NewPublicKey() []byte {
+	return r.newPublicKey
+}
+
+// Rotate re-encrypts the signer storage under the new key and pins the node storage to it in txn,
+// so that both are committed or discarded together, and makes it the active key.
+// It's a no-op once the key is rotated.
+func (r *Rotator) Rotate(txn basedb.Txn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.key.Rotated() {
+		return nil
+	}
+
+	ekmHash, err := r.key.New().EKMHash()
+	if err != nil {
+		return fmt.Errorf("could not get new private key hash: %w", err)
+	}
+	if err := r.keyManager.RotateEncryptionKey(txn, ekmHash); err != nil {
+		return err
+	}
+
+	storageHash, err := r.key.New().StorageHash()
+	if err != nil {
+		return fmt.Errorf("could not hash new private key: %w", err)
+	}
+	if err := r.nodeStorage.SavePrivateKeyHash(txn, storageHash); err != nil {
+		return fmt.Errorf("could not save new private key hash: %w", err)
+	}
+
+	r.key.Rotate()
+	r.logger.Info("rotated operator private key", zap.String("pubkey", string(r.newPublicKey)))
+	return nil
+}
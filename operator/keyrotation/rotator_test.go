@@ -0,0 +1,97 @@
+package keyrotation
+
+import (
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+type testHashStore struct {
+	hash string
+}
+
+func (s *testHashStore) SavePrivateKeyHash(rw basedb.ReadWriter, hashedKey string) error {
+	return rw.Set([]byte("test"), []byte("hash"), []byte(hashedKey))
+}
+
+func (s *testHashStore) load(t *testing.T, db basedb.Database) {
+	obj, found, err := db.Get([]byte("test"), []byte("hash"))
+	require.NoError(t, err)
+	s.hash = ""
+	if found {
+		s.hash = string(obj.Value)
+	}
+}
+
+func TestRotator(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	key := keys.NewRotatingKey(oldKey, newKey)
+
+	oldEKMHash, err := oldKey.EKMHash()
+	require.NoError(t, err)
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, oldEKMHash)
+	require.NoError(t, err)
+	share := &bls.SecretKey{}
+	share.SetByCSPRNG()
+	require.NoError(t, keyManager.AddShare(share))
+
+	hashStore := &testHashStore{}
+	rotator, err := New(logger, key, keyManager, hashStore)
+	require.NoError(t, err)
+	newPublicKey, err := newKey.Public().Base64()
+	require.NoError(t, err)
+	require.Equal(t, newPublicKey, rotator.NewPublicKey())
+
+	// nothing is persisted until the transaction is committed
+	txn := db.Begin()
+	require.NoError(t, rotator.Rotate(txn))
+	require.True(t, key.Rotated())
+	hashStore.load(t, db)
+	require.Empty(t, hashStore.hash)
+	require.NoError(t, txn.Commit())
+	hashStore.load(t, db)
+	newStorageHash, err := newKey.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, newStorageHash, hashStore.hash)
+
+	// rotating again is a no-op
+	txn = db.Begin()
+	require.NoError(t, txn.Delete([]byte("test"), []byte("hash")))
+	require.NoError(t, rotator.Rotate(txn))
+	require.NoError(t, txn.Commit())
+	hashStore.load(t, db)
+	require.Empty(t, hashStore.hash)
+
+	// the shares are now only readable under the new key
+	newEKMHash, err := newKey.EKMHash()
+	require.NoError(t, err)
+	for hash, wantErr := range map[string]bool{oldEKMHash: true, newEKMHash: false} {
+		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, hash)
+		require.NoError(t, err)
+		accounts, err := keyManager.(ekm.StorageProvider).ListAccounts()
+		if wantErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.Len(t, accounts, 1)
+		}
+	}
+}
@@ -112,6 +112,11 @@ func PublicKeyFromString(pubKeyString string) (OperatorPublicKey, error) {
 		return nil, err
 	}
 
+	return PublicKeyFromPEM(pubPem)
+}
+
+// PublicKeyFromPEM parses a PEM encoded operator public key
+func PublicKeyFromPEM(pubPem []byte) (OperatorPublicKey, error) {
 	pubKey, err := rsaencryption.ConvertPemToPublicKey(pubPem)
 	if err != nil {
 		return nil, err
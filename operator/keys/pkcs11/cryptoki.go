@@ -0,0 +1,475 @@
+package pkcs11
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include <stdlib.h>
+#include <string.h>
+
+// Cryptoki types, as defined by the PKCS #11 v2.40 specification.
+
+typedef unsigned long ck_ulong;
+typedef ck_ulong ck_rv;
+
+typedef struct {
+	unsigned char major;
+	unsigned char minor;
+} ck_version;
+
+typedef struct {
+	unsigned char label[32];
+	unsigned char manufacturer_id[32];
+	unsigned char model[16];
+	unsigned char serial_number[16];
+	ck_ulong flags;
+	ck_ulong max_session_count;
+	ck_ulong session_count;
+	ck_ulong max_rw_session_count;
+	ck_ulong rw_session_count;
+	ck_ulong max_pin_len;
+	ck_ulong min_pin_len;
+	ck_ulong total_public_memory;
+	ck_ulong free_public_memory;
+	ck_ulong total_private_memory;
+	ck_ulong free_private_memory;
+	ck_version hardware_version;
+	ck_version firmware_version;
+	unsigned char utc_time[16];
+} ck_token_info;
+
+typedef struct {
+	ck_ulong type;
+	void *value;
+	ck_ulong value_len;
+} ck_attribute;
+
+typedef struct {
+	ck_ulong mechanism;
+	void *parameter;
+	ck_ulong parameter_len;
+} ck_mechanism;
+
+typedef struct {
+	void *create_mutex;
+	void *destroy_mutex;
+	void *lock_mutex;
+	void *unlock_mutex;
+	ck_ulong flags;
+	void *reserved;
+} ck_c_initialize_args;
+
+// ck_function_list holds the 68 functions of a module in the order of the specification.
+typedef struct {
+	ck_version version;
+	void *functions[68];
+} ck_function_list;
+
+enum {
+	fn_initialize = 0,
+	fn_finalize = 1,
+	fn_get_slot_list = 4,
+	fn_get_token_info = 6,
+	fn_open_session = 12,
+	fn_close_session = 13,
+	fn_login = 18,
+	fn_get_attribute_value = 24,
+	fn_find_objects_init = 26,
+	fn_find_objects = 27,
+	fn_find_objects_final = 28,
+	fn_decrypt_init = 33,
+	fn_decrypt = 34,
+	fn_sign_init = 42,
+	fn_sign = 43,
+	fn_generate_key_pair = 59,
+};
+
+#define CKF_OS_LOCKING_OK (1UL << 1)
+
+static void *ssv_dlopen(const char *path) {
+	return dlopen(path, RTLD_NOW | RTLD_LOCAL);
+}
+
+static ck_rv ssv_get_function_list(void *module, ck_function_list **list) {
+	ck_rv (*get_function_list)(ck_function_list **) = (ck_rv (*)(ck_function_list **))dlsym(module, "C_GetFunctionList");
+	if (get_function_list == NULL) {
+		return (ck_rv)-1;
+	}
+	return get_function_list(list);
+}
+
+static ck_rv ssv_initialize(ck_function_list *f) {
+	ck_c_initialize_args args;
+	memset(&args, 0, sizeof(args));
+	args.flags = CKF_OS_LOCKING_OK;
+	return ((ck_rv (*)(void *))f->functions[fn_initialize])(&args);
+}
+
+static ck_rv ssv_finalize(ck_function_list *f) {
+	return ((ck_rv (*)(void *))f->functions[fn_finalize])(NULL);
+}
+
+static ck_rv ssv_get_slot_list(ck_function_list *f, ck_ulong *slots, ck_ulong *count) {
+	return ((ck_rv (*)(unsigned char, ck_ulong *, ck_ulong *))f->functions[fn_get_slot_list])(1, slots, count);
+}
+
+static ck_rv ssv_get_token_info(ck_function_list *f, ck_ulong slot, ck_token_info *info) {
+	return ((ck_rv (*)(ck_ulong, ck_token_info *))f->functions[fn_get_token_info])(slot, info);
+}
+
+static ck_rv ssv_open_session(ck_function_list *f, ck_ulong slot, ck_ulong flags, ck_ulong *session) {
+	return ((ck_rv (*)(ck_ulong, ck_ulong, void *, void *, ck_ulong *))f->functions[fn_open_session])(slot, flags, NULL, NULL, session);
+}
+
+static ck_rv ssv_close_session(ck_function_list *f, ck_ulong session) {
+	return ((ck_rv (*)(ck_ulong))f->functions[fn_close_session])(session);
+}
+
+static ck_rv ssv_login(ck_function_list *f, ck_ulong session, ck_ulong user_type, unsigned char *pin, ck_ulong pin_len) {
+	return ((ck_rv (*)(ck_ulong, ck_ulong, unsigned char *, ck_ulong))f->functions[fn_login])(session, user_type, pin, pin_len);
+}
+
+static ck_rv ssv_get_attribute_value(ck_function_list *f, ck_ulong session, ck_ulong object, ck_attribute *attrs, ck_ulong count) {
+	return ((ck_rv (*)(ck_ulong, ck_ulong, ck_attribute *, ck_ulong))f->functions[fn_get_attribute_value])(session, object, attrs, count);
+}
+
+static ck_rv ssv_find_objects_init(ck_function_list *f, ck_ulong session, ck_attribute *attrs, ck_ulong count) {
+	return ((ck_rv (*)(ck_ulong, ck_attribute *, ck_ulong))f->functions[fn_find_objects_init])(session, attrs, count);
+}
+
+static ck_rv ssv_find_objects(ck_function_list *f, ck_ulong session, ck_ulong *objects, ck_ulong max_count, ck_ulong *count) {
+	return ((ck_rv (*)(ck_ulong, ck_ulong *, ck_ulong, ck_ulong *))f->functions[fn_find_objects])(session, objects, max_count, count);
+}
+
+static ck_rv ssv_find_objects_final(ck_function_list *f, ck_ulong session) {
+	return ((ck_rv (*)(ck_ulong))f->functions[fn_find_objects_final])(session);
+}
+
+static ck_rv ssv_decrypt(ck_function_list *f, ck_ulong session, ck_mechanism *mechanism, ck_ulong key,
+		unsigned char *data, ck_ulong data_len, unsigned char *out, ck_ulong *out_len) {
+	ck_rv rv = ((ck_rv (*)(ck_ulong, ck_mechanism *, ck_ulong))f->functions[fn_decrypt_init])(session, mechanism, key);
+	if (rv != 0) {
+		return rv;
+	}
+	return ((ck_rv (*)(ck_ulong, unsigned char *, ck_ulong, unsigned char *, ck_ulong *))f->functions[fn_decrypt])(session, data, data_len, out, out_len);
+}
+
+static ck_rv ssv_sign(ck_function_list *f, ck_ulong session, ck_mechanism *mechanism, ck_ulong key,
+		unsigned char *data, ck_ulong data_len, unsigned char *out, ck_ulong *out_len) {
+	ck_rv rv = ((ck_rv (*)(ck_ulong, ck_mechanism *, ck_ulong))f->functions[fn_sign_init])(session, mechanism, key);
+	if (rv != 0) {
+		return rv;
+	}
+	return ((ck_rv (*)(ck_ulong, unsigned char *, ck_ulong, unsigned char *, ck_ulong *))f->functions[fn_sign])(session, data, data_len, out, out_len);
+}
+
+static ck_rv ssv_generate_key_pair(ck_function_list *f, ck_ulong session, ck_mechanism *mechanism,
+		ck_attribute *public_attrs, ck_ulong public_count, ck_attribute *private_attrs, ck_ulong private_count,
+		ck_ulong *public_key, ck_ulong *private_key) {
+	return ((ck_rv (*)(ck_ulong, ck_mechanism *, ck_attribute *, ck_ulong, ck_attribute *, ck_ulong, ck_ulong *, ck_ulong *))f->functions[fn_generate_key_pair])(
+		session, mechanism, public_attrs, public_count, private_attrs, private_count, public_key, private_key);
+}
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+)
+
+// Cryptoki constants, as defined by the PKCS #11 v2.40 specification.
+const (
+	ckfRWSession     = 1 << 1
+	ckfSerialSession = 1 << 2
+
+	ckuUser = 1
+
+	ckoPublicKey  = 2
+	ckoPrivateKey = 3
+
+	ckkRSA = 0
+
+	ckaClass          = 0x0
+	ckaToken          = 0x1
+	ckaPrivate        = 0x2
+	ckaLabel          = 0x3
+	ckaKeyType        = 0x100
+	ckaSensitive      = 0x103
+	ckaEncrypt        = 0x104
+	ckaDecrypt        = 0x105
+	ckaSign           = 0x108
+	ckaVerify         = 0x10a
+	ckaModulus        = 0x120
+	ckaModulusBits    = 0x121
+	ckaPublicExponent = 0x122
+	ckaExtractable    = 0x162
+
+	ckmRSAPKCSKeyPairGen = 0x0
+	ckmRSAPKCS           = 0x1
+	ckmSHA256RSAPKCS     = 0x40
+
+	ckrOK                         = 0x0
+	ckrUserAlreadyLoggedIn        = 0x100
+	ckrCryptokiAlreadyInitialized = 0x191
+)
+
+// errNoFunctionList is returned by ssv_get_function_list when the module doesn't export C_GetFunctionList.
+const errNoFunctionList = ^uint64(0)
+
+// maxFindObjects is the number of objects looked up when finding an object, enough to tell if it's ambiguous.
+const maxFindObjects = 2
+
+// handle is a handle of a session or an object.
+type handle = C.ck_ulong
+
+// Error is an error returned by a PKCS#11 module.
+type Error struct {
+	Function string
+	Code     uint64
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("pkcs11: %s failed with 0x%X", e.Function, e.Code)
+}
+
+func check(function string, rv C.ck_rv) error {
+	if rv == ckrOK {
+		return nil
+	}
+	return &Error{Function: function, Code: uint64(rv)}
+}
+
+// module is a loaded PKCS#11 module.
+type module struct {
+	handle    unsafe.Pointer
+	functions *C.ck_function_list
+}
+
+func loadModule(path string) (*module, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	handle := C.ssv_dlopen(cPath)
+	if handle == nil {
+		return nil, fmt.Errorf("could not load PKCS#11 module %s: %s", path, C.GoString(C.dlerror()))
+	}
+
+	var functions *C.ck_function_list
+	if rv := C.ssv_get_function_list(handle, &functions); uint64(rv) == errNoFunctionList {
+		C.dlclose(handle)
+		return nil, fmt.Errorf("%s is not a PKCS#11 module", path)
+	} else if err := check("C_GetFunctionList", rv); err != nil {
+		C.dlclose(handle)
+		return nil, err
+	}
+
+	m := &module{handle: handle, functions: functions}
+	if rv := C.ssv_initialize(functions); rv != ckrCryptokiAlreadyInitialized {
+		if err := check("C_Initialize", rv); err != nil {
+			C.dlclose(handle)
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *module) close() error {
+	err := check("C_Finalize", C.ssv_finalize(m.functions))
+	C.dlclose(m.handle)
+	return err
+}
+
+// findSlot returns the slot of the token with the given label.
+func (m *module) findSlot(tokenLabel string) (C.ck_ulong, error) {
+	var count C.ck_ulong
+	if err := check("C_GetSlotList", C.ssv_get_slot_list(m.functions, nil, &count)); err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no PKCS#11 tokens present")
+	}
+	slots := make([]C.ck_ulong, count)
+	if err := check("C_GetSlotList", C.ssv_get_slot_list(m.functions, &slots[0], &count)); err != nil {
+		return 0, err
+	}
+
+	for _, slot := range slots[:count] {
+		var info C.ck_token_info
+		if err := check("C_GetTokenInfo", C.ssv_get_token_info(m.functions, slot, &info)); err != nil {
+			return 0, err
+		}
+		label := C.GoBytes(unsafe.Pointer(&info.label[0]), C.int(len(info.label)))
+		if string(bytes.TrimRight(label, " \x00")) == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+}
+
+// openSession opens a read-write session with the token in the slot and logs the user in.
+func (m *module) openSession(slot C.ck_ulong, pin string) (C.ck_ulong, error) {
+	var session C.ck_ulong
+	if err := check("C_OpenSession", C.ssv_open_session(m.functions, slot, ckfSerialSession|ckfRWSession, &session)); err != nil {
+		return 0, err
+	}
+
+	cPIN := C.CBytes([]byte(pin))
+	defer C.free(cPIN)
+	rv := C.ssv_login(m.functions, session, ckuUser, (*C.uchar)(cPIN), C.ck_ulong(len(pin)))
+	if rv != ckrUserAlreadyLoggedIn {
+		if err := check("C_Login", rv); err != nil {
+			_ = m.closeSession(session)
+			return 0, err
+		}
+	}
+	return session, nil
+}
+
+func (m *module) closeSession(session C.ck_ulong) error {
+	return check("C_CloseSession", C.ssv_close_session(m.functions, session))
+}
+
+// attribute is a PKCS#11 object attribute.
+type attribute struct {
+	typ   uint64
+	value []byte
+}
+
+func ulongAttribute(typ uint64, value uint64) attribute {
+	b := make([]byte, C.sizeof_ck_ulong)
+	switch len(b) {
+	case 4:
+		binary.NativeEndian.PutUint32(b, uint32(value))
+	default:
+		binary.NativeEndian.PutUint64(b, value)
+	}
+	return attribute{typ: typ, value: b}
+}
+
+func boolAttribute(typ uint64, value bool) attribute {
+	if value {
+		return attribute{typ: typ, value: []byte{1}}
+	}
+	return attribute{typ: typ, value: []byte{0}}
+}
+
+func bytesAttribute(typ uint64, value []byte) attribute {
+	return attribute{typ: typ, value: value}
+}
+
+// cAttributes copies the attributes to C memory, which must be freed with the returned function.
+func cAttributes(attrs []attribute) (*C.ck_attribute, func()) {
+	if len(attrs) == 0 {
+		return nil, func() {}
+	}
+	cAttrs := (*[1 << 20]C.ck_attribute)(C.calloc(C.size_t(len(attrs)), C.sizeof_ck_attribute))[:len(attrs):len(attrs)]
+	for i, attr := range attrs {
+		cAttrs[i]._type = C.ck_ulong(attr.typ)
+		if len(attr.value) > 0 {
+			cAttrs[i].value = C.CBytes(attr.value)
+		}
+		cAttrs[i].value_len = C.ck_ulong(len(attr.value))
+	}
+	return &cAttrs[0], func() {
+		for i := range cAttrs {
+			C.free(cAttrs[i].value)
+		}
+		C.free(unsafe.Pointer(&cAttrs[0]))
+	}
+}
+
+// findObject returns the only object matching the attributes.
+func (m *module) findObject(session C.ck_ulong, attrs []attribute) (C.ck_ulong, error) {
+	cAttrs, free := cAttributes(attrs)
+	defer free()
+
+	if err := check("C_FindObjectsInit", C.ssv_find_objects_init(m.functions, session, cAttrs, C.ck_ulong(len(attrs)))); err != nil {
+		return 0, err
+	}
+	objects := make([]C.ck_ulong, maxFindObjects)
+	var count C.ck_ulong
+	findErr := check("C_FindObjects", C.ssv_find_objects(m.functions, session, &objects[0], C.ck_ulong(len(objects)), &count))
+	if err := check("C_FindObjectsFinal", C.ssv_find_objects_final(m.functions, session)); err != nil && findErr == nil {
+		findErr = err
+	}
+	if findErr != nil {
+		return 0, findErr
+	}
+
+	switch count {
+	case 0:
+		return 0, errObjectNotFound
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("multiple objects match")
+	}
+}
+
+// getAttribute returns the value of an attribute of an object.
+func (m *module) getAttribute(session, object C.ck_ulong, typ uint64) ([]byte, error) {
+	attr := C.ck_attribute{_type: C.ck_ulong(typ)}
+	if err := check("C_GetAttributeValue", C.ssv_get_attribute_value(m.functions, session, object, &attr, 1)); err != nil {
+		return nil, err
+	}
+
+	value := C.malloc(C.size_t(attr.value_len))
+	defer C.free(value)
+	attr.value = value
+	if err := check("C_GetAttributeValue", C.ssv_get_attribute_value(m.functions, session, object, &attr, 1)); err != nil {
+		return nil, err
+	}
+	return C.GoBytes(value, C.int(attr.value_len)), nil
+}
+
+// operation is a single-part cryptographic operation of a key.
+type operation func(f *C.ck_function_list, session C.ck_ulong, mechanism *C.ck_mechanism, key C.ck_ulong,
+	data *C.uchar, dataLen C.ck_ulong, out *C.uchar, outLen *C.ck_ulong) C.ck_rv
+
+func signOperation(f *C.ck_function_list, session C.ck_ulong, mechanism *C.ck_mechanism, key C.ck_ulong,
+	data *C.uchar, dataLen C.ck_ulong, out *C.uchar, outLen *C.ck_ulong) C.ck_rv {
+	return C.ssv_sign(f, session, mechanism, key, data, dataLen, out, outLen)
+}
+
+func decryptOperation(f *C.ck_function_list, session C.ck_ulong, mechanism *C.ck_mechanism, key C.ck_ulong,
+	data *C.uchar, dataLen C.ck_ulong, out *C.uchar, outLen *C.ck_ulong) C.ck_rv {
+	return C.ssv_decrypt(f, session, mechanism, key, data, dataLen, out, outLen)
+}
+
+// run runs a single-part operation with an output of at most maxOutLen bytes.
+func (m *module) run(name string, op operation, session, key C.ck_ulong, mechanism uint64, data []byte, maxOutLen int) ([]byte, error) {
+	cMechanism := C.ck_mechanism{mechanism: C.ck_ulong(mechanism)}
+
+	cData := C.CBytes(data)
+	defer C.free(cData)
+	out := C.malloc(C.size_t(maxOutLen))
+	defer C.free(out)
+
+	outLen := C.ck_ulong(maxOutLen)
+	rv := op(m.functions, session, &cMechanism, key, (*C.uchar)(cData), C.ck_ulong(len(data)), (*C.uchar)(out), &outLen)
+	if err := check(name, rv); err != nil {
+		return nil, err
+	}
+	return C.GoBytes(out, C.int(outLen)), nil
+}
+
+// generateKeyPair generates an RSA key pair on the token, returning the handles of its public and private keys.
+func (m *module) generateKeyPair(session C.ck_ulong, publicAttrs, privateAttrs []attribute) (C.ck_ulong, C.ck_ulong, error) {
+	cMechanism := C.ck_mechanism{mechanism: ckmRSAPKCSKeyPairGen}
+
+	cPublicAttrs, freePublic := cAttributes(publicAttrs)
+	defer freePublic()
+	cPrivateAttrs, freePrivate := cAttributes(privateAttrs)
+	defer freePrivate()
+
+	var publicKey, privateKey C.ck_ulong
+	rv := C.ssv_generate_key_pair(m.functions, session, &cMechanism,
+		cPublicAttrs, C.ck_ulong(len(publicAttrs)), cPrivateAttrs, C.ck_ulong(len(privateAttrs)),
+		&publicKey, &privateKey)
+	if err := check("C_GenerateKeyPair", rv); err != nil {
+		return 0, 0, err
+	}
+	return publicKey, privateKey, nil
+}
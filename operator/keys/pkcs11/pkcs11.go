@@ -0,0 +1,268 @@
+// Package pkcs11 implements an operator private key held by a PKCS#11 token, such as an HSM,
+// so that signing and share decryption happen inside the token and the key never leaves it.
+package pkcs11
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+const (
+	// DefaultKeyLabel is the label of the operator key on the token if none is configured.
+	DefaultKeyLabel = "ssv-operator"
+
+	keySize = 2048
+
+	// ekmHashMessage is signed to derive the encryption key of the signer storage,
+	// as the private key itself can't be read from the token.
+	ekmHashMessage = "ssv-ekm-encryption-key"
+)
+
+var errObjectNotFound = errors.New("object not found")
+
+// Config configures the PKCS#11 token holding the operator private key.
+type Config struct {
+	Module     string `yaml:"Module" env:"PKCS11_MODULE" env-description:"Path to the PKCS#11 module library of the token holding the operator private key"`
+	TokenLabel string `yaml:"TokenLabel" env:"PKCS11_TOKEN_LABEL" env-description:"Label of the PKCS#11 token holding the operator private key"`
+	PINFile    string `yaml:"PINFile" env:"PKCS11_PIN_FILE" env-description:"File with the user PIN of the PKCS#11 token"`
+	KeyLabel   string `yaml:"KeyLabel" env:"PKCS11_KEY_LABEL" env-description:"Label of the operator private key on the PKCS#11 token"`
+}
+
+// Enabled returns whether the operator private key is held by a PKCS#11 token.
+func (c Config) Enabled() bool {
+	return c.Module != ""
+}
+
+func (c Config) keyLabel() string {
+	if c.KeyLabel == "" {
+		return DefaultKeyLabel
+	}
+	return c.KeyLabel
+}
+
+func (c Config) pin() (string, error) {
+	if c.PINFile == "" {
+		return "", fmt.Errorf("PKCS#11 PIN file is required")
+	}
+	// #nosec G304
+	pin, err := os.ReadFile(c.PINFile)
+	if err != nil {
+		return "", fmt.Errorf("could not read PKCS#11 PIN file: %w", err)
+	}
+	return strings.TrimSpace(string(pin)), nil
+}
+
+// PrivateKey is an RSA operator private key held by a PKCS#11 token.
+// Its Bytes and Base64 are empty, as the key can't be read from the token.
+type PrivateKey struct {
+	module     *module
+	session    handle
+	privateKey handle
+	public     keys.OperatorPublicKey
+	publicPEM  []byte
+	label      string
+	size       int
+
+	// mu serializes the operations of the session, which can run one operation at a time.
+	mu sync.Mutex
+}
+
+var _ keys.OperatorPrivateKey = (*PrivateKey)(nil)
+
+// Open opens the operator private key on the configured token.
+func Open(cfg Config) (*PrivateKey, error) {
+	return open(cfg, false)
+}
+
+// Generate generates an operator private key on the configured token.
+// It fails if the token already holds a key with the configured label.
+func Generate(cfg Config) (*PrivateKey, error) {
+	return open(cfg, true)
+}
+
+func open(cfg Config, generate bool) (_ *PrivateKey, err error) {
+	pin, err := cfg.pin()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := loadModule(cfg.Module)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = m.close()
+		}
+	}()
+
+	slot, err := m.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := m.openSession(slot, pin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = m.closeSession(session)
+		}
+	}()
+
+	label := []byte(cfg.keyLabel())
+	privateTemplate := []attribute{
+		ulongAttribute(ckaClass, ckoPrivateKey),
+		ulongAttribute(ckaKeyType, ckkRSA),
+		bytesAttribute(ckaLabel, label),
+	}
+	publicTemplate := []attribute{
+		ulongAttribute(ckaClass, ckoPublicKey),
+		ulongAttribute(ckaKeyType, ckkRSA),
+		bytesAttribute(ckaLabel, label),
+	}
+
+	privateKey, err := m.findObject(session, privateTemplate)
+	switch {
+	case generate && err == nil:
+		return nil, fmt.Errorf("token already holds a private key labeled %q", label)
+	case generate && errors.Is(err, errObjectNotFound):
+		_, privateKey, err = m.generateKeyPair(session,
+			append(publicTemplate,
+				boolAttribute(ckaToken, true),
+				boolAttribute(ckaEncrypt, true),
+				boolAttribute(ckaVerify, true),
+				ulongAttribute(ckaModulusBits, keySize),
+				bytesAttribute(ckaPublicExponent, big.NewInt(65537).Bytes()),
+			),
+			append(privateTemplate,
+				boolAttribute(ckaToken, true),
+				boolAttribute(ckaPrivate, true),
+				boolAttribute(ckaSensitive, true),
+				boolAttribute(ckaExtractable, false),
+				boolAttribute(ckaDecrypt, true),
+				boolAttribute(ckaSign, true),
+			),
+		)
+		if err != nil {
+			return nil, err
+		}
+	case errors.Is(err, errObjectNotFound):
+		return nil, fmt.Errorf("token holds no private key labeled %q", label)
+	case err != nil:
+		return nil, fmt.Errorf("could not find private key labeled %q: %w", label, err)
+	}
+
+	// The public key is read from the public key object, as the private key object
+	// of some tokens doesn't expose its public exponent.
+	publicKey, err := m.findObject(session, publicTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not find public key labeled %q: %w", label, err)
+	}
+	modulus, err := m.getAttribute(session, publicKey, ckaModulus)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := m.getAttribute(session, publicKey, ckaPublicExponent)
+	if err != nil {
+		return nil, err
+	}
+	rsaPublicKey := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}
+
+	publicBase64, err := rsaencryption.ExtractPublicKey(rsaPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	publicPEM, err := base64.StdEncoding.DecodeString(publicBase64)
+	if err != nil {
+		return nil, err
+	}
+	public, err := keys.PublicKeyFromPEM(publicPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		module:     m,
+		session:    session,
+		privateKey: privateKey,
+		public:     public,
+		publicPEM:  publicPEM,
+		label:      string(label),
+		size:       rsaPublicKey.Size(),
+	}, nil
+}
+
+// Label returns the label of the key on the token.
+func (k *PrivateKey) Label() string {
+	return k.label
+}
+
+// Close closes the session with the token.
+func (k *PrivateKey) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return errors.Join(k.module.closeSession(k.session), k.module.close())
+}
+
+// Sign signs the SHA-256 hash of data with RSASSA-PKCS1-v1_5 on the token.
+func (k *PrivateKey) Sign(data []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.module.run("C_Sign", signOperation, k.session, k.privateKey, ckmSHA256RSAPKCS, data, k.size)
+}
+
+func (k *PrivateKey) Public() keys.OperatorPublicKey {
+	return k.public
+}
+
+// Decrypt decrypts data encrypted with RSAES-PKCS1-v1_5 on the token.
+func (k *PrivateKey) Decrypt(data []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	decrypted, err := k.module.run("C_Decrypt", decryptOperation, k.session, k.privateKey, ckmRSAPKCS, data, k.size)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt key: %w", err)
+	}
+	return decrypted, nil
+}
+
+// StorageHash hashes the public key, which identifies the key the node storage belongs to.
+func (k *PrivateKey) StorageHash() (string, error) {
+	return rsaencryption.HashRsaKey(k.publicPEM)
+}
+
+// EKMHash hashes a signature of a fixed message, which only the holder of the key can produce,
+// as RSASSA-PKCS1-v1_5 signatures are deterministic.
+func (k *PrivateKey) EKMHash() (string, error) {
+	signature, err := k.Sign([]byte(ekmHashMessage))
+	if err != nil {
+		return "", fmt.Errorf("could not sign EKM hash message: %w", err)
+	}
+	hash := sha256.Sum256(signature)
+	return fmt.Sprintf("%x", hash), nil
+}
+
+func (k *PrivateKey) Bytes() []byte {
+	return nil
+}
+
+func (k *PrivateKey) Base64() []byte {
+	return nil
+}
@@ -0,0 +1,86 @@
+package pkcs11
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig returns the config of a token to test with, such as a SoftHSM token initialized with
+//
+//	softhsm2-util --init-token --free --label ssv-test --pin 1234 --so-pin 1234
+//
+// and PKCS11_TEST_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TEST_TOKEN_LABEL=ssv-test PKCS11_TEST_PIN=1234.
+func testConfig(t *testing.T) Config {
+	module := os.Getenv("PKCS11_TEST_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_TEST_MODULE is not set")
+	}
+
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte(os.Getenv("PKCS11_TEST_PIN")+"\n"), 0600))
+
+	return Config{
+		Module:     module,
+		TokenLabel: os.Getenv("PKCS11_TEST_TOKEN_LABEL"),
+		PINFile:    pinFile,
+		KeyLabel:   t.Name(),
+	}
+}
+
+func TestPrivateKey(t *testing.T) {
+	cfg := testConfig(t)
+
+	key, err := Generate(cfg)
+	require.NoError(t, err)
+	publicKey, err := key.Public().Base64()
+	require.NoError(t, err)
+	ekmHash, err := key.EKMHash()
+	require.NoError(t, err)
+	require.NoError(t, key.Close())
+
+	_, err = Generate(cfg)
+	require.ErrorContains(t, err, "token already holds a private key")
+
+	key, err = Open(cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, key.Close())
+	}()
+
+	reopenedPublicKey, err := key.Public().Base64()
+	require.NoError(t, err)
+	require.Equal(t, publicKey, reopenedPublicKey)
+
+	// the EKM hash is stable, as it encrypts the signer storage
+	reopenedEKMHash, err := key.EKMHash()
+	require.NoError(t, err)
+	require.Equal(t, ekmHash, reopenedEKMHash)
+	require.Len(t, ekmHash, 64)
+
+	data := []byte("message")
+	signature, err := key.Sign(data)
+	require.NoError(t, err)
+	require.NoError(t, key.Public().Verify(data, signature))
+
+	encrypted, err := key.Public().Encrypt(data)
+	require.NoError(t, err)
+	decrypted, err := key.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	require.Empty(t, key.Bytes())
+	require.Empty(t, key.Base64())
+}
+
+func TestOpen_Errors(t *testing.T) {
+	_, err := Open(Config{Module: "/nonexistent/module.so"})
+	require.ErrorContains(t, err, "PIN file is required")
+
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte("1234"), 0600))
+	_, err = Open(Config{Module: "/nonexistent/module.so", PINFile: pinFile})
+	require.ErrorContains(t, err, "could not load PKCS#11 module")
+}
@@ -0,0 +1,91 @@
+package keys
+
+import (
+	"sync/atomic"
+)
+
+// RotatingKey is an operator private key that's being rotated from an old key to a new one.
+// It acts as the old key until Rotate is called and as the new key afterwards,
+// while decrypting data encrypted to either of them throughout.
+type RotatingKey struct {
+	old     OperatorPrivateKey
+	new     OperatorPrivateKey
+	rotated atomic.Bool
+}
+
+func NewRotatingKey(old, new OperatorPrivateKey) *RotatingKey {
+	return &RotatingKey{
+		old: old,
+		new: new,
+	}
+}
+
+// Old returns the key being rotated from.
+func (k *RotatingKey) Old() OperatorPrivateKey {
+	return k.old
+}
+
+// New returns the key being rotated to.
+func (k *RotatingKey) New() OperatorPrivateKey {
+	return k.new
+}
+
+// Rotate makes the new key the active one.
+func (k *RotatingKey) Rotate() {
+	k.rotated.Store(true)
+}
+
+// Rotated returns whether the new key is the active one.
+func (k *RotatingKey) Rotated() bool {
+	return k.rotated.Load()
+}
+
+func (k *RotatingKey) active() OperatorPrivateKey {
+	if k.rotated.Load() {
+		return k.new
+	}
+	return k.old
+}
+
+func (k *RotatingKey) inactive() OperatorPrivateKey {
+	if k.rotated.Load() {
+		return k.old
+	}
+	return k.new
+}
+
+func (k *RotatingKey) Sign(data []byte) ([]byte, error) {
+	return k.active().Sign(data)
+}
+
+func (k *RotatingKey) Public() OperatorPublicKey {
+	return k.active().Public()
+}
+
+// Decrypt decrypts data encrypted to either key, trying the active one first.
+func (k *RotatingKey) Decrypt(data []byte) ([]byte, error) {
+	decrypted, err := k.active().Decrypt(data)
+	if err == nil {
+		return decrypted, nil
+	}
+	if decrypted, inactiveErr := k.inactive().Decrypt(data); inactiveErr == nil {
+		return decrypted, nil
+	}
+	return nil, err
+}
+
+func (k *RotatingKey) StorageHash() (string, error) {
+	return k.active().StorageHash()
+}
+
+func (k *RotatingKey) EKMHash() (string, error) {
+	return k.active().EKMHash()
+}
+
+func (k *RotatingKey) Bytes() []byte {
+	return k.active().Bytes()
+}
+
+func (k *RotatingKey) Base64() []byte {
+	return k.active().Base64()
+}
@@ -0,0 +1,57 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+)
+
+func TestRotatingKey(t *testing.T) {
+	oldKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	key := keys.NewRotatingKey(oldKey, newKey)
+
+	data := []byte("share")
+	encryptedToOld, err := oldKey.Public().Encrypt(data)
+	require.NoError(t, err)
+	encryptedToNew, err := newKey.Public().Encrypt(data)
+	require.NoError(t, err)
+	encryptedToOther, err := otherKey.Public().Encrypt(data)
+	require.NoError(t, err)
+
+	requireActive := func(active keys.OperatorPrivateKey) {
+		require.Equal(t, active.Base64(), key.Base64())
+		activeHash, err := active.EKMHash()
+		require.NoError(t, err)
+		hash, err := key.EKMHash()
+		require.NoError(t, err)
+		require.Equal(t, activeHash, hash)
+
+		signature, err := key.Sign(data)
+		require.NoError(t, err)
+		require.NoError(t, active.Public().Verify(data, signature))
+
+		// shares encrypted to either key are decrypted regardless of which one is active
+		for _, encrypted := range [][]byte{encryptedToOld, encryptedToNew} {
+			decrypted, err := key.Decrypt(encrypted)
+			require.NoError(t, err)
+			require.Equal(t, data, decrypted)
+		}
+		_, err = key.Decrypt(encryptedToOther)
+		require.Error(t, err)
+	}
+
+	require.False(t, key.Rotated())
+	requireActive(oldKey)
+
+	key.Rotate()
+	require.True(t, key.Rotated())
+	requireActive(newKey)
+}
@@ -1,9 +1,14 @@
 package keystore
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"github.com/stretchr/testify/require"
 	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/ssvlabs/ssv/utils/threshold"
 )
 
 func TestDecryptKeystoreWithInvalidData(t *testing.T) {
@@ -43,3 +48,29 @@ func TestEncryptKeystoreWithEmptyPassword(t *testing.T) {
 	_, err := EncryptKeystore(privkey, pubKeyBase64, password)
 	require.NotNil(t, err)
 }
+
+func TestValidatorKeystore(t *testing.T) {
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+
+	data, err := EncryptValidatorKeystore(sk, "password", "test")
+	require.NoError(t, err)
+
+	var jsonData map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &jsonData))
+	require.Equal(t, hex.EncodeToString(sk.GetPublicKey().Serialize()), jsonData["pubkey"])
+	require.EqualValues(t, 4, jsonData["version"])
+	require.Equal(t, "", jsonData["path"])
+	require.NotEmpty(t, jsonData["uuid"])
+
+	decrypted, err := DecryptValidatorKeystore(data, "password")
+	require.NoError(t, err)
+	require.True(t, sk.IsEqual(decrypted))
+
+	_, err = DecryptValidatorKeystore(data, "wrong")
+	require.Error(t, err)
+
+	_, err = EncryptValidatorKeystore(sk, " ", "test")
+	require.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// validatorKeystore is an EIP-2335 keystore of a validator private key.
+type validatorKeystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	PubKey      string                 `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        uuid.UUID              `json:"uuid"`
+	Version     uint                   `json:"version"`
+}
+
+// EncryptValidatorKeystore encrypts a validator private key into an EIP-2335 keystore using the provided password.
+// The keystore has no derivation path, as the key isn't derived from a seed.
+func EncryptValidatorKeystore(sk *bls.SecretKey, password, description string) ([]byte, error) {
+	if strings.TrimSpace(password) == "" {
+		return nil, fmt.Errorf("password required for encrypting keystore")
+	}
+
+	encryptor := keystorev4.New()
+	crypto, err := encryptor.Encrypt(sk.Serialize(), password)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt validator private key: %w", err)
+	}
+
+	encryptedData, err := json.MarshalIndent(validatorKeystore{
+		Crypto:      crypto,
+		Description: description,
+		PubKey:      hex.EncodeToString(sk.GetPublicKey().Serialize()),
+		UUID:        uuid.New(),
+		Version:     encryptor.Version(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal validator keystore: %w", err)
+	}
+
+	return encryptedData, nil
+}
+
+// DecryptValidatorKeystore decrypts an EIP-2335 keystore of a validator private key using the provided password,
+// checking the private key against the public key of the keystore.
+func DecryptValidatorKeystore(encryptedJSONData []byte, password string) (*bls.SecretKey, error) {
+	var ks validatorKeystore
+	if err := json.Unmarshal(encryptedJSONData, &ks); err != nil {
+		return nil, fmt.Errorf("parse JSON data: %w", err)
+	}
+
+	decrypted, err := keystorev4.New().Decrypt(ks.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt validator private key: %w", err)
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(decrypted); err != nil {
+		return nil, fmt.Errorf("deserialize validator private key: %w", err)
+	}
+	if hex.EncodeToString(sk.GetPublicKey().Serialize()) != strings.TrimPrefix(ks.PubKey, "0x") {
+		return nil, fmt.Errorf("validator private key doesn't match the keystore public key")
+	}
+
+	return sk, nil
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/eth/executionclient"
 	"github.com/ssvlabs/ssv/exporter/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
 	qbftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/network"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/clockdrift"
 	"github.com/ssvlabs/ssv/operator/duties"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/operator/fee_recipient"
@@ -39,6 +43,7 @@ type Options struct {
 	ValidatorStore      storage2.ValidatorStore
 	ValidatorOptions    validator.ControllerOptions `yaml:"ValidatorOptions"`
 	DutyStore           *dutystore.Store
+	ClockDrift          *clockdrift.Estimator
 	WS                  api.WebSocketServer
 	WsAPIPort           int
 }
@@ -53,6 +58,7 @@ type Node struct {
 	net              network.P2PNetwork
 	storage          storage.Storage
 	qbftStorage      *qbftstorage.QBFTStores
+	validatorStore   storage2.ValidatorStore
 	dutyScheduler    *duties.Scheduler
 	feeRecipientCtrl fee_recipient.RecipientController
 
@@ -72,12 +78,13 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 		net:              opts.P2PNetwork,
 		storage:          opts.ValidatorOptions.RegistryStorage,
 		qbftStorage:      qbftStorage,
+		validatorStore:   opts.ValidatorStore,
 		dutyScheduler: duties.NewScheduler(&duties.SchedulerOptions{
 			Ctx:                 opts.Context,
 			BeaconNode:          opts.BeaconNode,
 			ExecutionClient:     opts.ExecutionClient,
 			Network:             opts.Network,
-			ValidatorProvider:   opts.ValidatorStore.WithOperatorID(opts.ValidatorOptions.OperatorDataStore.GetOperatorID),
+			ValidatorProvider:   opts.ValidatorStore.WithOperatorIDs(opts.ValidatorOptions.OperatorDataStore.GetOperatorIDs),
 			ValidatorController: opts.ValidatorController,
 			DutyExecutor:        opts.ValidatorController,
 			IndicesChg:          opts.ValidatorController.IndicesChangeChan(),
@@ -85,6 +92,7 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 			DutyStore:           opts.DutyStore,
 			SlotTickerProvider:  slotTickerProvider,
 			P2PNetwork:          opts.P2PNetwork,
+			ClockDrift:          opts.ClockDrift,
 		}),
 		feeRecipientCtrl: fee_recipient.NewController(&fee_recipient.ControllerOptions{
 			Ctx:                opts.Context,
@@ -135,6 +143,9 @@ func (n *Node) Start(logger *zap.Logger) error {
 	go n.net.UpdateSubnets(logger)
 	go n.net.UpdateScoreParams(logger)
 	n.validatorsCtrl.StartValidators()
+	if n.validatorOptions.Exporter && n.validatorOptions.FullNode {
+		go n.syncDecidedHistory(logger)
+	}
 	go n.reportOperators(logger)
 
 	go n.feeRecipientCtrl.Start(logger)
@@ -148,6 +159,34 @@ func (n *Node) Start(logger *zap.Logger) error {
 	return nil
 }
 
+// syncDecidedHistory fills the decided participants that were missed while the node was down
+func (n *Node) syncDecidedHistory(logger *zap.Logger) {
+	// Only committee roles are synced, as only their participants can be verified against the slot.
+	roles := []convert.RunnerRole{
+		convert.RoleAttester,
+		convert.RoleSyncCommittee,
+	}
+	var identifiers []convert.MessageID
+	for _, share := range n.validatorStore.Validators() {
+		for _, role := range roles {
+			identifiers = append(identifiers, convert.NewMsgID(n.network.DomainType, share.ValidatorPubKey[:], role))
+		}
+	}
+
+	syncer := qbftstorage.NewHistorySyncer(
+		logger.Named(logging.NameDecidedHistorySyncer),
+		n.qbftStorage,
+		n.net,
+		qbftstorage.NewParticipantsVerifier(n.validatorStore.Validator, n.consensusClient.DomainData, n.network.Beacon),
+		n.validatorOptions.MinPeers,
+	)
+	currentSlot := n.network.Beacon.EstimatedCurrentSlot()
+	maxSlots := phase0.Slot(n.validatorOptions.HistorySyncMaxSlots)
+	if err := syncer.FillGaps(n.context, identifiers, currentSlot, maxSlots); err != nil {
+		logger.Warn("failed to sync decided history", zap.Error(err))
+	}
+}
+
 // HealthCheck returns a list of issues regards the state of the operator node
 func (n *Node) HealthCheck() error {
 	// TODO: previously this checked availability of consensus & execution clients.
@@ -156,6 +195,11 @@ func (n *Node) HealthCheck() error {
 	return nil
 }
 
+// LastSlotTick returns the latest tick of the slot ticker of the duty scheduler, if it ticked.
+func (n *Node) LastSlotTick() (duties.SlotTick, bool) {
+	return n.dutyScheduler.LastSlotTick()
+}
+
 // handleQueryRequests waits for incoming messages and
 func (n *Node) handleQueryRequests(logger *zap.Logger, nm *api.NetworkMessage) {
 	if nm.Err != nil {
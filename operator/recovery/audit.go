@@ -0,0 +1,63 @@
+package recovery
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"time"
+)
+
+// AuditRecord records the recovery of key material from the shares of a validator.
+// It never holds key material, only who recovered what and when.
+type AuditRecord struct {
+	Time               time.Time `json:"time"`
+	User               string    `json:"user"`
+	Host               string    `json:"host"`
+	Action             string    `json:"action"`
+	ValidatorPublicKey string    `json:"validator_public_key"`
+	// OperatorIDs are the operators whose shares were used.
+	OperatorIDs []uint64 `json:"operator_ids"`
+	Output      string   `json:"output"`
+}
+
+// NewAuditRecord returns the record of action on the validator of s with the shares of operatorIDs,
+// by the current user of this host.
+func (s *Shares) NewAuditRecord(action string, operatorIDs []uint64, output string) AuditRecord {
+	record := AuditRecord{
+		Time:               time.Now().UTC(),
+		Action:             action,
+		ValidatorPublicKey: hex.EncodeToString(s.ValidatorPublicKey),
+		OperatorIDs:        slices.Clone(operatorIDs),
+		Output:             output,
+	}
+	slices.Sort(record.OperatorIDs)
+	if u, err := user.Current(); err == nil {
+		record.User = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		record.Host = host
+	}
+	return record
+}
+
+// WriteAuditRecord appends record as a JSON line to the audit log at path.
+func WriteAuditRecord(path string, record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("could not marshal audit record: %w", err)
+	}
+
+	// #nosec G304
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("could not write audit record: %w", err)
+	}
+	return f.Close()
+}
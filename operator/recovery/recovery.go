@@ -0,0 +1,199 @@
+// Package recovery recovers validator keys offline from the encrypted shares of ValidatorAdded events,
+// for disaster recovery when the operators of a validator can no longer run it.
+package recovery
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/operator/keys"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+// encryptedKeyLength is the length of a share private key encrypted to a 2048-bit RSA operator key.
+const encryptedKeyLength = 256
+
+// Shares are the shares of a validator, as registered by a ValidatorAdded event.
+type Shares struct {
+	ValidatorPublicKey []byte
+	OperatorIDs        []uint64
+	SharePublicKeys    [][]byte
+	EncryptedKeys      [][]byte
+}
+
+// ParseShares parses the shares of a ValidatorAdded event, which are the signature of the owner
+// followed by the share public keys and the encrypted share private keys in the order of operatorIDs.
+func ParseShares(validatorPublicKey []byte, operatorIDs []uint64, sharesData []byte) (*Shares, error) {
+	if _, err := ssvtypes.DeserializeBLSPublicKey(validatorPublicKey); err != nil {
+		return nil, fmt.Errorf("invalid validator public key: %w", err)
+	}
+	if !ssvtypes.ValidCommitteeSize(uint64(len(operatorIDs))) {
+		return nil, fmt.Errorf("invalid committee size %d", len(operatorIDs))
+	}
+
+	operatorCount := len(operatorIDs)
+	pubKeysOffset := phase0.SignatureLength
+	encryptedKeysOffset := pubKeysOffset + phase0.PublicKeyLength*operatorCount
+	if expected := encryptedKeysOffset + encryptedKeyLength*operatorCount; len(sharesData) != expected {
+		return nil, fmt.Errorf("shares length is %d, expected %d", len(sharesData), expected)
+	}
+
+	shares := &Shares{
+		ValidatorPublicKey: validatorPublicKey,
+		OperatorIDs:        operatorIDs,
+	}
+	for i := 0; i < operatorCount; i++ {
+		pubKeyOffset := pubKeysOffset + phase0.PublicKeyLength*i
+		encryptedKeyOffset := encryptedKeysOffset + encryptedKeyLength*i
+		shares.SharePublicKeys = append(shares.SharePublicKeys, sharesData[pubKeyOffset:pubKeyOffset+phase0.PublicKeyLength])
+		shares.EncryptedKeys = append(shares.EncryptedKeys, sharesData[encryptedKeyOffset:encryptedKeyOffset+encryptedKeyLength])
+	}
+	return shares, nil
+}
+
+// SharesFromEvent parses the shares of a ValidatorAdded event.
+func SharesFromEvent(event *contract.ContractValidatorAdded) (*Shares, error) {
+	return ParseShares(event.PublicKey, event.OperatorIds, event.Shares)
+}
+
+// ExportedValidator is a ValidatorAdded event as exported from a database of the events,
+// with the public key and the shares hex encoded.
+type ExportedValidator struct {
+	PublicKey   string   `json:"public_key"`
+	OperatorIDs []uint64 `json:"operator_ids"`
+	Shares      string   `json:"shares"`
+}
+
+// SharesFromExport parses the shares of the validator from a JSON array of exported ValidatorAdded events.
+// The validator must have exactly one event in the export, as the shares of a re-registered validator are ambiguous.
+func SharesFromExport(r io.Reader, validatorPublicKey []byte) (*Shares, error) {
+	var exported []ExportedValidator
+	if err := json.NewDecoder(r).Decode(&exported); err != nil {
+		return nil, fmt.Errorf("could not decode export: %w", err)
+	}
+
+	var found *ExportedValidator
+	for i := range exported {
+		publicKey, err := hex.DecodeString(strings.TrimPrefix(exported[i].PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode public key of exported validator %d: %w", i, err)
+		}
+		if !bytes.Equal(publicKey, validatorPublicKey) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("validator %x is exported more than once", validatorPublicKey)
+		}
+		found = &exported[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("validator %x isn't in the export", validatorPublicKey)
+	}
+
+	sharesData, err := hex.DecodeString(strings.TrimPrefix(found.Shares, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode exported shares: %w", err)
+	}
+	return ParseShares(validatorPublicKey, found.OperatorIDs, sharesData)
+}
+
+// Decrypt decrypts the share private key of operatorID with the key of the operator,
+// checking it against the share public key of the operator.
+func (s *Shares) Decrypt(operatorID uint64, decrypter keys.OperatorDecrypter) (*bls.SecretKey, error) {
+	i := slices.Index(s.OperatorIDs, operatorID)
+	if i == -1 {
+		return nil, fmt.Errorf("operator %d isn't in the committee of the validator", operatorID)
+	}
+
+	decrypted, err := decrypter.Decrypt(s.EncryptedKeys[i])
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt share private key of operator %d: %w", operatorID, err)
+	}
+	share := &bls.SecretKey{}
+	if err := share.SetHexString(string(decrypted)); err != nil {
+		return nil, fmt.Errorf("could not decode share private key of operator %d: %w", operatorID, err)
+	}
+	if err := s.checkShare(operatorID, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// Reconstruct reconstructs the validator private key from the share private keys of at least
+// a quorum of the operators, checking each share and the reconstructed key against their public keys.
+func (s *Shares) Reconstruct(shares map[uint64]*bls.SecretKey) (*bls.SecretKey, error) {
+	quorum, _ := ssvtypes.ComputeQuorumAndPartialQuorum(uint64(len(s.OperatorIDs)))
+	if uint64(len(shares)) < quorum {
+		return nil, fmt.Errorf("%d shares are required to reconstruct the validator key, got %d", quorum, len(shares))
+	}
+	for operatorID, share := range shares {
+		if err := s.checkShare(operatorID, share); err != nil {
+			return nil, err
+		}
+	}
+
+	validatorKey, err := threshold.ReconstructSecretKey(shares)
+	if err != nil {
+		return nil, fmt.Errorf("could not reconstruct validator key: %w", err)
+	}
+	if !bytes.Equal(validatorKey.GetPublicKey().Serialize(), s.ValidatorPublicKey) {
+		return nil, errors.New("reconstructed validator key doesn't match the validator public key")
+	}
+	return validatorKey, nil
+}
+
+func (s *Shares) checkShare(operatorID uint64, share *bls.SecretKey) error {
+	i := slices.Index(s.OperatorIDs, operatorID)
+	if i == -1 {
+		return fmt.Errorf("operator %d isn't in the committee of the validator", operatorID)
+	}
+	if !bytes.Equal(share.GetPublicKey().Serialize(), s.SharePublicKeys[i]) {
+		return fmt.Errorf("share private key of operator %d doesn't match its share public key %s",
+			operatorID, hex.EncodeToString(s.SharePublicKeys[i]))
+	}
+	return nil
+}
+
+// DecryptedShare is a decrypted share private key, as exchanged between operators to reconstruct a validator key.
+type DecryptedShare struct {
+	OperatorID         uint64 `json:"operator_id"`
+	ValidatorPublicKey string `json:"validator_public_key"`
+	SharePublicKey     string `json:"share_public_key"`
+	SharePrivateKey    string `json:"share_private_key"`
+}
+
+// Export returns the decrypted share private key of operatorID, to be handed to the operator reconstructing the validator key.
+func (s *Shares) Export(operatorID uint64, share *bls.SecretKey) DecryptedShare {
+	return DecryptedShare{
+		OperatorID:         operatorID,
+		ValidatorPublicKey: hex.EncodeToString(s.ValidatorPublicKey),
+		SharePublicKey:     share.GetPublicKey().SerializeToHexStr(),
+		SharePrivateKey:    share.SerializeToHexStr(),
+	}
+}
+
+// Import returns the share private key of a decrypted share, checking that it's a share of the validator of s.
+func (s *Shares) Import(share DecryptedShare) (*bls.SecretKey, error) {
+	if share.ValidatorPublicKey != hex.EncodeToString(s.ValidatorPublicKey) {
+		return nil, fmt.Errorf("share of operator %d belongs to validator %s", share.OperatorID, share.ValidatorPublicKey)
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.SetHexString(share.SharePrivateKey); err != nil {
+		return nil, fmt.Errorf("could not decode share private key of operator %d: %w", share.OperatorID, err)
+	}
+	if err := s.checkShare(share.OperatorID, sk); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
@@ -0,0 +1,209 @@
+package recovery
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+type testValidator struct {
+	key          *bls.SecretKey
+	operatorIDs  []uint64
+	operatorKeys map[uint64]keys.OperatorPrivateKey
+	shareKeys    map[uint64]*bls.SecretKey
+	sharesData   []byte
+}
+
+func newTestValidator(t *testing.T, operatorIDs []uint64) *testValidator {
+	threshold.Init()
+
+	validatorKey := &bls.SecretKey{}
+	validatorKey.SetByCSPRNG()
+
+	// Shares are indexed by operator ID, so they are evaluated at the IDs rather than at 1..n.
+	shareKeys := make(map[uint64]*bls.SecretKey)
+	polynomial := validatorKey.GetMasterSecretKey(len(operatorIDs) - len(operatorIDs)/3)
+	for _, operatorID := range operatorIDs {
+		id := bls.ID{}
+		require.NoError(t, id.SetDecString(strconv.FormatUint(operatorID, 10)))
+		share := bls.SecretKey{}
+		require.NoError(t, share.Set(polynomial, &id))
+		shareKeys[operatorID] = &share
+	}
+
+	v := &testValidator{
+		key:          validatorKey,
+		operatorIDs:  operatorIDs,
+		operatorKeys: make(map[uint64]keys.OperatorPrivateKey),
+		shareKeys:    shareKeys,
+		sharesData:   make([]byte, phase0.SignatureLength),
+	}
+	var encryptedKeys []byte
+	for _, operatorID := range operatorIDs {
+		operatorKey, err := keys.GeneratePrivateKey()
+		require.NoError(t, err)
+		v.operatorKeys[operatorID] = operatorKey
+
+		encryptedKey, err := operatorKey.Public().Encrypt([]byte(shareKeys[operatorID].SerializeToHexStr()))
+		require.NoError(t, err)
+		encryptedKeys = append(encryptedKeys, encryptedKey...)
+		v.sharesData = append(v.sharesData, shareKeys[operatorID].GetPublicKey().Serialize()...)
+	}
+	v.sharesData = append(v.sharesData, encryptedKeys...)
+	return v
+}
+
+func TestReconstruct(t *testing.T) {
+	v := newTestValidator(t, []uint64{3, 7, 11, 42})
+	validatorPublicKey := v.key.GetPublicKey().Serialize()
+
+	shares, err := ParseShares(validatorPublicKey, v.operatorIDs, v.sharesData)
+	require.NoError(t, err)
+
+	decrypted := make(map[uint64]*bls.SecretKey)
+	for _, operatorID := range []uint64{3, 11, 42} {
+		share, err := shares.Decrypt(operatorID, v.operatorKeys[operatorID])
+		require.NoError(t, err)
+		require.Equal(t, v.shareKeys[operatorID].Serialize(), share.Serialize())
+		decrypted[operatorID] = share
+	}
+
+	validatorKey, err := shares.Reconstruct(decrypted)
+	require.NoError(t, err)
+	require.Equal(t, v.key.Serialize(), validatorKey.Serialize())
+
+	// Decrypted shares are exchanged between operators.
+	imported, err := shares.Import(shares.Export(11, decrypted[11]))
+	require.NoError(t, err)
+	require.Equal(t, decrypted[11].Serialize(), imported.Serialize())
+
+	t.Run("not enough shares", func(t *testing.T) {
+		_, err := shares.Reconstruct(map[uint64]*bls.SecretKey{3: decrypted[3], 11: decrypted[11]})
+		require.ErrorContains(t, err, "3 shares are required")
+	})
+
+	t.Run("share of another operator", func(t *testing.T) {
+		_, err := shares.Reconstruct(map[uint64]*bls.SecretKey{3: decrypted[11], 11: decrypted[3], 42: decrypted[42]})
+		require.ErrorContains(t, err, "doesn't match its share public key")
+	})
+
+	t.Run("operator not in committee", func(t *testing.T) {
+		_, err := shares.Decrypt(5, v.operatorKeys[3])
+		require.ErrorContains(t, err, "isn't in the committee")
+	})
+
+	t.Run("wrong operator key", func(t *testing.T) {
+		_, err := shares.Decrypt(3, v.operatorKeys[7])
+		require.Error(t, err)
+	})
+
+	t.Run("share of another validator", func(t *testing.T) {
+		other := newTestValidator(t, v.operatorIDs)
+		otherShares, err := ParseShares(other.key.GetPublicKey().Serialize(), other.operatorIDs, other.sharesData)
+		require.NoError(t, err)
+		_, err = shares.Import(otherShares.Export(3, other.shareKeys[3]))
+		require.ErrorContains(t, err, "belongs to validator")
+	})
+
+	t.Run("shares of another validator", func(t *testing.T) {
+		other := newTestValidator(t, v.operatorIDs)
+		// The share public keys match, but the validator public key doesn't.
+		mismatched, err := ParseShares(other.key.GetPublicKey().Serialize(), v.operatorIDs, v.sharesData)
+		require.NoError(t, err)
+		_, err = mismatched.Reconstruct(decrypted)
+		require.ErrorContains(t, err, "doesn't match the validator public key")
+	})
+}
+
+func TestParseShares_Errors(t *testing.T) {
+	v := newTestValidator(t, []uint64{1, 2, 3, 4})
+	validatorPublicKey := v.key.GetPublicKey().Serialize()
+
+	_, err := ParseShares(validatorPublicKey, v.operatorIDs, v.sharesData[1:])
+	require.ErrorContains(t, err, "shares length")
+
+	_, err = ParseShares(validatorPublicKey, []uint64{1, 2, 3}, v.sharesData)
+	require.ErrorContains(t, err, "invalid committee size")
+
+	_, err = ParseShares([]byte{1, 2, 3}, v.operatorIDs, v.sharesData)
+	require.ErrorContains(t, err, "invalid validator public key")
+}
+
+func TestSharesFromExport(t *testing.T) {
+	v := newTestValidator(t, []uint64{1, 2, 3, 4})
+	other := newTestValidator(t, []uint64{5, 6, 7, 8})
+	validatorPublicKey := v.key.GetPublicKey().Serialize()
+
+	export := func(validators ...*testValidator) string {
+		var exported []ExportedValidator
+		for _, validator := range validators {
+			exported = append(exported, ExportedValidator{
+				PublicKey:   "0x" + hex.EncodeToString(validator.key.GetPublicKey().Serialize()),
+				OperatorIDs: validator.operatorIDs,
+				Shares:      "0x" + hex.EncodeToString(validator.sharesData),
+			})
+		}
+		data, err := json.Marshal(exported)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	shares, err := SharesFromExport(strings.NewReader(export(other, v)), validatorPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, v.operatorIDs, shares.OperatorIDs)
+	share, err := shares.Decrypt(2, v.operatorKeys[2])
+	require.NoError(t, err)
+	require.Equal(t, v.shareKeys[2].Serialize(), share.Serialize())
+
+	_, err = SharesFromExport(strings.NewReader(export(other)), validatorPublicKey)
+	require.ErrorContains(t, err, "isn't in the export")
+
+	_, err = SharesFromExport(strings.NewReader(export(v, other, v)), validatorPublicKey)
+	require.ErrorContains(t, err, "exported more than once")
+}
+
+func TestWriteAuditRecord(t *testing.T) {
+	v := newTestValidator(t, []uint64{1, 2, 3, 4})
+	shares, err := ParseShares(v.key.GetPublicKey().Serialize(), v.operatorIDs, v.sharesData)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, WriteAuditRecord(path, shares.NewAuditRecord("decrypt-share", []uint64{2}, "share.json")))
+	require.NoError(t, WriteAuditRecord(path, shares.NewAuditRecord("reconstruct-validator-key", []uint64{4, 1, 2}, "keystore.json")))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Records never hold key material.
+		for _, share := range v.shareKeys {
+			require.NotContains(t, scanner.Text(), share.SerializeToHexStr())
+		}
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, records, 2)
+	require.Equal(t, "reconstruct-validator-key", records[1].Action)
+	require.Equal(t, hex.EncodeToString(v.key.GetPublicKey().Serialize()), records[1].ValidatorPublicKey)
+	require.Equal(t, []uint64{1, 2, 4}, records[1].OperatorIDs)
+	require.Equal(t, "keystore.json", records[1].Output)
+	require.False(t, records[1].Time.IsZero())
+}
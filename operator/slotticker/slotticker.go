@@ -20,6 +20,9 @@ type SlotTicker interface {
 type Config struct {
 	SlotDuration time.Duration
 	GenesisTime  time.Time
+	// Offset optionally returns the drift of the local clock from the network's (positive when it's ahead),
+	// by which the ticks are shifted to happen at the start of slots per the network's clock.
+	Offset func() time.Duration
 }
 
 type slotTicker struct {
@@ -27,6 +30,7 @@ type slotTicker struct {
 	timer        Timer
 	slotDuration time.Duration
 	genesisTime  time.Time
+	offset       func() time.Duration
 	slot         phase0.Slot
 }
 
@@ -36,8 +40,16 @@ func New(logger *zap.Logger, cfg Config) *slotTicker {
 }
 
 func newWithCustomTimer(logger *zap.Logger, cfg Config, timerProvider TimerProvider) *slotTicker {
-	now := time.Now()
-	timeSinceGenesis := now.Sub(cfg.GenesisTime)
+	s := &slotTicker{
+		logger:       logger,
+		slotDuration: cfg.SlotDuration,
+		genesisTime:  cfg.GenesisTime,
+		offset:       cfg.Offset,
+		slot:         0,
+	}
+
+	genesisTime := s.localGenesisTime()
+	timeSinceGenesis := time.Since(genesisTime)
 
 	var initialDelay time.Duration
 	if timeSinceGenesis < 0 {
@@ -45,24 +57,28 @@ func newWithCustomTimer(logger *zap.Logger, cfg Config, timerProvider TimerProvi
 		initialDelay = -timeSinceGenesis // Wait until the genesis time
 	} else {
 		slotsSinceGenesis := timeSinceGenesis / cfg.SlotDuration
-		nextSlotStartTime := cfg.GenesisTime.Add((slotsSinceGenesis + 1) * cfg.SlotDuration)
+		nextSlotStartTime := genesisTime.Add((slotsSinceGenesis + 1) * cfg.SlotDuration)
 		initialDelay = time.Until(nextSlotStartTime)
 	}
+	s.timer = timerProvider(initialDelay)
 
-	return &slotTicker{
-		logger:       logger,
-		timer:        timerProvider(initialDelay),
-		slotDuration: cfg.SlotDuration,
-		genesisTime:  cfg.GenesisTime,
-		slot:         0,
+	return s
+}
+
+// localGenesisTime returns the genesis time per the local clock, shifted by its offset if any.
+func (s *slotTicker) localGenesisTime() time.Time {
+	if s.offset == nil {
+		return s.genesisTime
 	}
+	return s.genesisTime.Add(s.offset())
 }
 
 // Next returns a channel that signals when the next slot should start.
 // Note: This function is not thread-safe and should be called in a serialized fashion.
 // Make sure no concurrent calls happen, as it can result in unexpected behavior.
 func (s *slotTicker) Next() <-chan time.Time {
-	timeSinceGenesis := time.Since(s.genesisTime)
+	genesisTime := s.localGenesisTime()
+	timeSinceGenesis := time.Since(genesisTime)
 	if timeSinceGenesis < 0 {
 		return s.timer.C()
 	}
@@ -79,7 +95,7 @@ func (s *slotTicker) Next() <-chan time.Time {
 		nextSlot = s.slot + 1
 		s.logger.Debug("double tick", zap.Uint64("slot", uint64(s.slot)))
 	}
-	nextSlotStartTime := s.genesisTime.Add(casts.DurationFromUint64(uint64(nextSlot)) * s.slotDuration)
+	nextSlotStartTime := genesisTime.Add(casts.DurationFromUint64(uint64(nextSlot)) * s.slotDuration)
 	s.timer.Reset(time.Until(nextSlotStartTime))
 	s.slot = nextSlot
 	return s.timer.C()
@@ -22,7 +22,7 @@ func TestSlotTicker(t *testing.T) {
 	timeSinceGenesis := time.Since(genesisTime)
 	expectedSlot := phase0.Slot(timeSinceGenesis/slotDuration) + 1
 
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 
 	for i := 0; i < numTicks; i++ {
 		<-ticker.Next()
@@ -42,7 +42,7 @@ func TestSlotTicker2(t *testing.T) {
 	// Calculate the expected starting slot based on genesisTime
 	//timeSinceGenesis := time.Since(genesisTime)
 	//expectedSlot := phase0.Slot(timeSinceGenesis/slotDuration) + 1
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 	<-ticker.Next()
 	firstSlot := ticker.Slot()
 	require.Equal(t, phase0.Slot(1), firstSlot)
@@ -63,7 +63,7 @@ func TestSlotTicker2(t *testing.T) {
 func TestTickerInitialization(t *testing.T) {
 	slotDuration := 200 * time.Millisecond
 	genesisTime := time.Now()
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 
 	start := time.Now()
 	<-ticker.Next()
@@ -81,7 +81,7 @@ func TestSlotNumberConsistency(t *testing.T) {
 	slotDuration := 200 * time.Millisecond
 	genesisTime := time.Now()
 
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 	var lastSlot phase0.Slot
 
 	for i := 0; i < 10; i++ {
@@ -93,11 +93,37 @@ func TestSlotNumberConsistency(t *testing.T) {
 	}
 }
 
+func TestSlotTickerOffset(t *testing.T) {
+	slotDuration := 200 * time.Millisecond
+	genesisTime := time.Now().Add(-5 * slotDuration)
+
+	// The local clock is behind by half a slot, so the slots start half a slot later per the local clock.
+	offset := -slotDuration / 2
+	ticker := New(zap.NewNop(), Config{
+		SlotDuration: slotDuration,
+		GenesisTime:  genesisTime,
+		Offset:       func() time.Duration { return offset },
+	})
+
+	start := time.Now()
+	<-ticker.Next()
+	elapsed := time.Since(start)
+
+	// Allow a small buffer (e.g., 10ms) due to code execution overhead
+	buffer := 10 * time.Millisecond
+
+	require.Equal(t, phase0.Slot(6), ticker.Slot())
+	require.InDelta(t, slotDuration/2, elapsed, float64(buffer), "Expected the tick to be shifted by the offset")
+
+	<-ticker.Next()
+	require.Equal(t, phase0.Slot(7), ticker.Slot())
+}
+
 func TestGenesisInFuture(t *testing.T) {
 	slotDuration := 200 * time.Millisecond
 	genesisTime := time.Now().Add(1 * time.Second) // Setting genesis time 1s in the future
 
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 	start := time.Now()
 
 	<-ticker.Next()
@@ -116,7 +142,7 @@ func TestBoundedDrift(t *testing.T) {
 	slotDuration := 20 * time.Millisecond
 	genesisTime := time.Now()
 
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 	ticks := 100
 
 	start := time.Now()
@@ -149,7 +175,7 @@ func TestMultipleSlotTickers(t *testing.T) {
 	for i := 0; i < numTickers; i++ {
 		go func() {
 			defer wg.Done()
-			ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+			ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 			for j := 0; j < ticksPerTimer; j++ {
 				<-ticker.Next()
 			}
@@ -175,7 +201,7 @@ func TestSlotSkipping(t *testing.T) {
 	)
 
 	genesisTime := time.Now()
-	ticker := New(zap.NewNop(), Config{slotDuration, genesisTime})
+	ticker := New(zap.NewNop(), Config{SlotDuration: slotDuration, GenesisTime: genesisTime})
 
 	var lastSlot phase0.Slot
 	for i := 1; i <= numTicks; i++ { // Starting loop from 1 for ease of skipInterval check
@@ -7,6 +7,8 @@ import (
 type ConfigLock struct {
 	NetworkName      string `json:"network_name"`
 	UsingLocalEvents bool   `json:"using_local_events"`
+	// NetworkConfigHash is the hash of the network config if it was loaded from a file, empty for supported networks
+	NetworkConfigHash string `json:"network_config_hash,omitempty"`
 }
 
 func (stored *ConfigLock) ValidateCompatibility(current *ConfigLock) error {
@@ -14,6 +16,10 @@ func (stored *ConfigLock) ValidateCompatibility(current *ConfigLock) error {
 		return fmt.Errorf("network mismatch. Stored network %s does not match current network %s. The database must be removed or reinitialized", stored.NetworkName, current.NetworkName)
 	}
 
+	if stored.NetworkConfigHash != current.NetworkConfigHash {
+		return fmt.Errorf("network config mismatch. Stored network config hash %q does not match current network config hash %q. The database must be removed or reinitialized", stored.NetworkConfigHash, current.NetworkConfigHash)
+	}
+
 	if stored.UsingLocalEvents && !current.UsingLocalEvents {
 		return fmt.Errorf("disabling local events is not allowed. The database must be removed or reinitialized")
 	}
@@ -62,4 +62,17 @@ func TestConfigLock(t *testing.T) {
 
 		require.Error(t, c1.ValidateCompatibility(c2))
 	})
+
+	t.Run("only network config hash is different", func(t *testing.T) {
+		c1 := &ConfigLock{
+			NetworkName:       "test",
+			NetworkConfigHash: "aa",
+		}
+
+		c2 := &ConfigLock{
+			NetworkName: "test",
+		}
+
+		require.Error(t, c1.ValidateCompatibility(c2))
+	})
 }
@@ -46,7 +46,7 @@ type Storage interface {
 	ValidatorStore() registrystorage.ValidatorStore
 
 	GetPrivateKeyHash() (string, bool, error)
-	SavePrivateKeyHash(privKeyHash string) error
+	SavePrivateKeyHash(rw basedb.ReadWriter, privKeyHash string) error
 }
 
 type storage struct {
@@ -219,8 +219,8 @@ func (s *storage) GetPrivateKeyHash() (string, bool, error) {
 }
 
 // SavePrivateKeyHash saves operator private key hash
-func (s *storage) SavePrivateKeyHash(hashedKey string) error {
-	return s.db.Set(storagePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
+func (s *storage) SavePrivateKeyHash(rw basedb.ReadWriter, hashedKey string) error {
+	return s.db.Using(rw).Set(storagePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
 }
 
 func (s *storage) GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error) {
@@ -19,6 +19,7 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/exporter/analytics"
 	"github.com/ssvlabs/ssv/exporter/convert"
 	"github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/logging"
@@ -43,10 +44,12 @@ import (
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/valuecheck"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/utils/casts"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
@@ -68,20 +71,25 @@ type ControllerOptions struct {
 	MetadataUpdateInterval     time.Duration `yaml:"MetadataUpdateInterval" env:"METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"Interval for updating metadata"`
 	HistorySyncBatchSize       int           `yaml:"HistorySyncBatchSize" env:"HISTORY_SYNC_BATCH_SIZE" env-default:"25" env-description:"Maximum number of messages to sync in a single batch"`
 	MinPeers                   int           `yaml:"MinimumPeers" env:"MINIMUM_PEERS" env-default:"2" env-description:"The required minimum peers for sync"`
+	HistorySyncMaxSlots        uint64        `yaml:"HistorySyncMaxSlots" env:"HISTORY_SYNC_MAX_SLOTS" env-default:"7200" env-description:"Maximum number of slots to look back when syncing missed decided history on startup"`
 	Network                    P2PNetwork
 	Beacon                     beaconprotocol.BeaconNode
 	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
 	Exporter                   bool `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
 	BeaconSigner               spectypes.BeaconSigner
 	OperatorSigner             ssvtypes.OperatorSigner
+	NewOperatorSigner          ssvtypes.OperatorSigner
 	OperatorDataStore          operatordatastore.OperatorDataStore
 	RegistryStorage            nodestorage.Storage
 	RecipientsStorage          Recipients
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
+	ParticipationTracker       *analytics.Tracker
+	ValueCheckPolicy           *valuecheck.Policy
 	DutyRoles                  []spectypes.BeaconRole
 	StorageMap                 *storage.QBFTStores
 	ValidatorStore             registrystorage.ValidatorStore
 	MessageValidator           validation.MessageValidator
+	SignatureVerifier          qbft.SignatureVerifier
 	ValidatorsMap              *validators.ValidatorsMap
 	NetworkConfig              networkconfig.NetworkConfig
 	Graffiti                   []byte
@@ -106,6 +114,8 @@ type Controller interface {
 	//  - the amount of active validators (i.e. not slashed or existed)
 	//  - the amount of validators assigned to this operator
 	GetValidatorStats() (uint64, uint64, uint64, error)
+	// QueueSaturation returns how full the message queues of validators and committees are.
+	QueueSaturation() queue.Saturation
 	IndicesChangeChan() chan struct{}
 	ValidatorExitChan() <-chan duties.ExitDescriptor
 
@@ -156,9 +166,10 @@ type controller struct {
 	recipientsStorage Recipients
 	ibftStorageMap    *storage.QBFTStores
 
-	beacon         beaconprotocol.BeaconNode
-	beaconSigner   spectypes.BeaconSigner
-	operatorSigner ssvtypes.OperatorSigner
+	beacon            beaconprotocol.BeaconNode
+	beaconSigner      spectypes.BeaconSigner
+	operatorSigner    ssvtypes.OperatorSigner
+	newOperatorSigner ssvtypes.OperatorSigner
 
 	operatorDataStore operatordatastore.OperatorDataStore
 
@@ -181,9 +192,10 @@ type controller struct {
 	// nonCommittees is a cache of initialized committeeObserver instances
 	committeesObservers      *ttlcache.Cache[spectypes.MessageID, *committeeObserver]
 	committeesObserversMutex sync.Mutex
-	attesterRoots            *ttlcache.Cache[phase0.Root, struct{}]
-	syncCommRoots            *ttlcache.Cache[phase0.Root, struct{}]
+	attesterRoots            *ttlcache.Cache[phase0.Root, validator.RootOrigin]
+	syncCommRoots            *ttlcache.Cache[phase0.Root, validator.RootOrigin]
 	domainCache              *validator.DomainCache
+	participationTracker     *analytics.Tracker
 
 	recentlyStartedValidators uint64
 	indicesChange             chan struct{}
@@ -218,6 +230,8 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		GasLimit:          options.GasLimit,
 		MessageValidator:  options.MessageValidator,
 		Graffiti:          options.Graffiti,
+		SignatureVerifier: options.SignatureVerifier,
+		ValueCheckPolicy:  options.ValueCheckPolicy,
 	}
 
 	// If full node, increase queue size to make enough room
@@ -245,6 +259,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		operatorDataStore: options.OperatorDataStore,
 		beaconSigner:      options.BeaconSigner,
 		operatorSigner:    options.OperatorSigner,
+		newOperatorSigner: options.NewOperatorSigner,
 		network:           options.Network,
 
 		validatorsMap:    options.ValidatorsMap,
@@ -262,12 +277,13 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 			ttlcache.WithTTL[spectypes.MessageID, *committeeObserver](cacheTTL),
 		),
 		attesterRoots: ttlcache.New(
-			ttlcache.WithTTL[phase0.Root, struct{}](cacheTTL),
+			ttlcache.WithTTL[phase0.Root, validator.RootOrigin](cacheTTL),
 		),
 		syncCommRoots: ttlcache.New(
-			ttlcache.WithTTL[phase0.Root, struct{}](cacheTTL),
+			ttlcache.WithTTL[phase0.Root, validator.RootOrigin](cacheTTL),
 		),
-		domainCache: validator.NewDomainCache(options.Beacon, cacheTTL),
+		domainCache:          validator.NewDomainCache(options.Beacon, cacheTTL),
+		participationTracker: options.ParticipationTracker,
 
 		indicesChange:           make(chan struct{}),
 		validatorExitCh:         make(chan duties.ExitDescriptor),
@@ -290,7 +306,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 func (c *controller) GetOperatorShares() []*ssvtypes.SSVShare {
 	return c.sharesStorage.List(
 		nil,
-		registrystorage.ByOperatorID(c.operatorDataStore.GetOperatorID()),
+		registrystorage.ByOperatorIDs(c.operatorDataStore.GetOperatorIDs()...),
 		registrystorage.ByActiveValidator(),
 	)
 }
@@ -308,16 +324,29 @@ func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 	operatorShares := uint64(0)
 	active := uint64(0)
 	for _, s := range allShares {
-		if ok := s.BelongsToOperator(c.operatorDataStore.GetOperatorID()); ok {
+		if _, ok := c.operatorDataStore.ShareOperatorID(s); ok {
 			operatorShares++
 		}
-		if s.IsParticipating(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch()) {
+		if s.IsParticipating(c.networkConfig.Beacon.EstimatedCurrentEpoch()) {
 			active++
 		}
 	}
 	return uint64(len(allShares)), active, operatorShares, nil
 }
 
+func (c *controller) QueueSaturation() queue.Saturation {
+	var s queue.Saturation
+	c.validatorsMap.ForEachValidator(func(v *validator.Validator) bool {
+		s.Merge(v.QueueSaturation())
+		return true
+	})
+	c.validatorsMap.ForEachCommittee(func(cm *validator.Committee) bool {
+		s.Merge(cm.QueueSaturation())
+		return true
+	})
+	return s
+}
+
 func (c *controller) handleRouterMessages() {
 	ctx, cancel := context.WithCancel(c.ctx)
 	defer cancel()
@@ -396,7 +425,7 @@ func (c *controller) handleWorkerMessages(msg network.DecodedSSVMessage) error {
 		c.committeesObservers.Set(
 			ssvMsg.GetID(),
 			ncv,
-			time.Duration(ttlSlots)*c.beacon.GetBeaconNetwork().SlotDurationSec(),
+			time.Duration(ttlSlots)*c.networkConfig.SlotDurationSec(),
 		)
 	} else {
 		ncv = item
@@ -412,12 +441,16 @@ func (c *controller) handleNonCommitteeMessages(msg *queue.SSVMessage, ncv *comm
 	defer c.committeesObserversMutex.Unlock()
 
 	if msg.MsgType == spectypes.SSVConsensusMsgType {
+		subMsg, ok := msg.Body.(*specqbft.Message)
+		if ok && c.participationTracker != nil {
+			c.participationTracker.OnConsensusMessage(msg.MsgID, subMsg, msg.SignedSSVMessage.OperatorIDs)
+		}
+
 		// Process proposal messages for committee consensus only to get the roots
 		if msg.MsgID.GetRoleType() != spectypes.RoleCommittee {
 			return nil
 		}
 
-		subMsg, ok := msg.Body.(*specqbft.Message)
 		if !ok || subMsg.MsgType != specqbft.ProposalMsgType {
 			return nil
 		}
@@ -429,6 +462,10 @@ func (c *controller) handleNonCommitteeMessages(msg *queue.SSVMessage, ncv *comm
 			return err
 		}
 
+		if c.participationTracker != nil && pSigMessages.Type == spectypes.PostConsensusPartialSig && len(msg.SignedSSVMessage.OperatorIDs) == 1 {
+			c.participationTracker.OnPostConsensusMessage(msg.MsgID, pSigMessages.Slot, msg.SignedSSVMessage.OperatorIDs[0])
+		}
+
 		return ncv.ProcessMessage(msg)
 	}
 	return nil
@@ -459,7 +496,7 @@ func (c *controller) StartValidators() {
 	var ownShares []*ssvtypes.SSVShare
 	var pubKeysToFetch [][]byte
 	for _, share := range shares {
-		if c.operatorDataStore.GetOperatorID() != 0 && share.BelongsToOperator(c.operatorDataStore.GetOperatorID()) {
+		if _, ok := c.operatorDataStore.ShareOperatorID(share); ok {
 			ownShares = append(ownShares, share)
 		}
 		networkcommons.SetCommitteeSubnet(intBuf, share.CommitteeID())
@@ -525,10 +562,12 @@ func (c *controller) selfSubnets(buf *big.Int) records.Subnets {
 	copy(mySubnets, c.network.FixedSubnets())
 
 	// Compute the new subnets according to the active committees/validators.
-	myValidators := c.validatorStore.OperatorValidators(c.operatorDataStore.GetOperatorID())
-	for _, v := range myValidators {
-		networkcommons.SetCommitteeSubnet(localBuf, v.CommitteeID())
-		mySubnets[localBuf.Uint64()] = 1
+	for _, operatorID := range c.operatorDataStore.GetOperatorIDs() {
+		myValidators := c.validatorStore.OperatorValidators(operatorID)
+		for _, v := range myValidators {
+			networkcommons.SetCommitteeSubnet(localBuf, v.CommitteeID())
+			mySubnets[localBuf.Uint64()] = 1
+		}
 	}
 
 	return mySubnets
@@ -619,7 +658,7 @@ func (c *controller) UpdateValidatorsMetadata(data map[spectypes.ValidatorPK]*be
 	shares := c.sharesStorage.List(
 		nil,
 		registrystorage.ByNotLiquidated(),
-		registrystorage.ByOperatorID(c.operatorDataStore.GetOperatorID()),
+		registrystorage.ByOperatorIDs(c.operatorDataStore.GetOperatorIDs()...),
 		func(share *ssvtypes.SSVShare) bool {
 			return data[share.ValidatorPubKey] != nil
 		},
@@ -820,6 +859,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 		opts := c.validatorOptions
 		opts.SSVShare = share
 		opts.Operator = operator
+		opts.OperatorSigner = c.operatorSignerFor(operator.OperatorID)
 		opts.DutyRunners, err = SetupRunners(validatorCtx, c.logger, opts)
 		if err != nil {
 			validatorCancel()
@@ -844,6 +884,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 		opts := c.validatorOptions
 		opts.SSVShare = share
 		opts.Operator = operator
+		opts.OperatorSigner = c.operatorSignerFor(operator.OperatorID)
 
 		committeeOpIDs := types.OperatorIDsFromOperators(operator.Committee)
 
@@ -863,6 +904,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 			committeeRunnerFunc,
 			nil,
 			c.dutyGuard,
+			opts.SignatureVerifier,
 		)
 		vc.AddShare(&share.Share)
 		c.validatorsMap.PutCommittee(operator.CommitteeID, vc)
@@ -878,6 +920,13 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 }
 
 func (c *controller) committeeMemberFromShare(share *ssvtypes.SSVShare) (*spectypes.CommitteeMember, error) {
+	// While the operator key is rotated, the node is a member of the committee as either operator.
+	operatorID, ok := c.operatorDataStore.ShareOperatorID(share)
+	if !ok {
+		operatorID = c.operatorDataStore.GetOperatorID()
+	}
+
+	var operatorPEM []byte
 	operators := make([]*spectypes.Operator, len(share.Committee))
 	for i, cm := range share.Committee {
 		opdata, found, err := c.operatorsStorage.GetOperatorData(nil, cm.Signer)
@@ -889,26 +938,32 @@ func (c *controller) committeeMemberFromShare(share *ssvtypes.SSVShare) (*specty
 			return nil, fmt.Errorf("operator not found")
 		}
 
-		operatorPEM, err := base64.StdEncoding.DecodeString(string(opdata.PublicKey))
+		pem, err := base64.StdEncoding.DecodeString(string(opdata.PublicKey))
 		if err != nil {
 			return nil, fmt.Errorf("could not decode public key: %w", err)
 		}
 
 		operators[i] = &spectypes.Operator{
 			OperatorID:        cm.Signer,
-			SSVOperatorPubKey: operatorPEM,
+			SSVOperatorPubKey: pem,
+		}
+		if cm.Signer == operatorID {
+			operatorPEM = pem
 		}
 	}
 
 	f := ssvtypes.ComputeF(uint64(len(share.Committee)))
 
-	operatorPEM, err := base64.StdEncoding.DecodeString(string(c.operatorDataStore.GetOperatorData().PublicKey))
-	if err != nil {
-		return nil, fmt.Errorf("could not decode public key: %w", err)
+	if operatorPEM == nil {
+		var err error
+		operatorPEM, err = base64.StdEncoding.DecodeString(string(c.operatorDataStore.GetOperatorData().PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode public key: %w", err)
+		}
 	}
 
 	return &spectypes.CommitteeMember{
-		OperatorID:        c.operatorDataStore.GetOperatorID(),
+		OperatorID:        operatorID,
 		CommitteeID:       share.CommitteeID(),
 		SSVOperatorPubKey: operatorPEM,
 		FaultyNodes:       f,
@@ -916,6 +971,15 @@ func (c *controller) committeeMemberFromShare(share *ssvtypes.SSVShare) (*specty
 	}, nil
 }
 
+// operatorSignerFor returns the signer of the given operator the node runs as:
+// the new operator's while the operator key is rotated, or its own.
+func (c *controller) operatorSignerFor(operatorID spectypes.OperatorID) ssvtypes.OperatorSigner {
+	if newOperator := c.operatorDataStore.GetNewOperatorData(); c.newOperatorSigner != nil && newOperator != nil && newOperator.ID == operatorID {
+		return c.newOperatorSigner
+	}
+	return c.validatorOptions.OperatorSigner
+}
+
 func (c *controller) onShareStart(share *ssvtypes.SSVShare) (bool, error) {
 	v, _, err := c.onShareInit(share)
 	if err != nil || v == nil {
@@ -935,9 +999,10 @@ func (c *controller) printShare(s *ssvtypes.SSVShare, msg string) {
 	for i, c := range s.Committee {
 		committee[i] = fmt.Sprintf(`[OperatorID=%d, PubKey=%x]`, c.Signer, c.SharePubKey)
 	}
+	_, ownValidator := c.operatorDataStore.ShareOperatorID(s)
 	c.logger.Debug(msg,
 		fields.PubKey(s.ValidatorPubKey[:]),
-		zap.Bool("own_validator", s.BelongsToOperator(c.operatorDataStore.GetOperatorID())),
+		zap.Bool("own_validator", ownValidator),
 		zap.Strings("committee", committee),
 		fields.FeeRecipient(s.FeeRecipientAddress[:]),
 	)
@@ -1063,7 +1128,7 @@ func (c *controller) UpdateValidatorMetaDataLoop() {
 func (c *controller) fetchAndUpdateValidatorsMetadata(logger *zap.Logger, pks [][]byte, beacon beaconprotocol.BeaconNode) error {
 	// Fetch metadata for all validators.
 	c.recentlyStartedValidators = 0
-	beforeUpdate := c.AllActiveIndices(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch(), false)
+	beforeUpdate := c.AllActiveIndices(c.networkConfig.Beacon.EstimatedCurrentEpoch(), false)
 
 	err := beaconprotocol.UpdateValidatorsMetadata(logger, pks, beacon, c.UpdateValidatorsMetadata)
 	if err != nil {
@@ -1071,7 +1136,7 @@ func (c *controller) fetchAndUpdateValidatorsMetadata(logger *zap.Logger, pks []
 	}
 
 	// Refresh duties if there are any new active validators.
-	afterUpdate := c.AllActiveIndices(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch(), false)
+	afterUpdate := c.AllActiveIndices(c.networkConfig.Beacon.EstimatedCurrentEpoch(), false)
 	if c.recentlyStartedValidators > 0 || hasNewValidators(beforeUpdate, afterUpdate) {
 		c.logger.Debug("new validators found after metadata update",
 			zap.Int("before", len(beforeUpdate)),
@@ -1080,7 +1145,7 @@ func (c *controller) fetchAndUpdateValidatorsMetadata(logger *zap.Logger, pks []
 		)
 		select {
 		case c.indicesChange <- struct{}{}:
-		case <-time.After(2 * c.beacon.GetBeaconNetwork().SlotDurationSec()):
+		case <-time.After(2 * c.networkConfig.SlotDurationSec()):
 			c.logger.Warn("timed out while notifying DutyScheduler of new validators")
 		}
 	}
@@ -1097,8 +1162,9 @@ func (c *controller) ReportValidatorStatuses(ctx context.Context) {
 			start := time.Now()
 			validatorsPerStatus := make(map[validatorStatus]uint32)
 
-			for _, share := range c.validatorStore.OperatorValidators(c.operatorDataStore.GetOperatorID()) {
-				if share.IsParticipating(c.beacon.GetBeaconNetwork().EstimatedCurrentEpoch()) {
+			ownShares := c.sharesStorage.List(nil, registrystorage.ByOperatorIDs(c.operatorDataStore.GetOperatorIDs()...))
+			for _, share := range ownShares {
+				if share.IsParticipating(c.networkConfig.Beacon.EstimatedCurrentEpoch()) {
 					validatorsPerStatus[statusParticipating]++
 				}
 				meta := share.BeaconMetadata
@@ -1153,7 +1219,14 @@ func SetupCommitteeRunners(
 	ctx context.Context,
 	options validator.Options,
 ) validator.CommitteeRunnerFunc {
+	adaptive := newAdaptiveTimeouts(options.NetworkConfig)
+
 	buildController := func(role spectypes.RunnerRole, valueCheckF specqbft.ProposedValueCheckF) *qbftcontroller.Controller {
+		timer := roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil)
+		if adaptive != nil {
+			timer.SetAdaptiveTimeouts(adaptive, len(options.Operator.Committee))
+		}
+
 		config := &qbft.Config{
 			BeaconSigner: options.Signer,
 			Domain:       options.NetworkConfig.DomainType,
@@ -1162,9 +1235,11 @@ func SetupCommitteeRunners(
 				leader := qbft.RoundRobinProposer(state, round)
 				return leader
 			},
-			Network:     options.Network,
-			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
-			CutOffRound: roundtimer.CutOffRound,
+			Network:            options.Network,
+			Timer:              timer,
+			CutOffRound:        roundtimer.CutOffRound,
+			SignatureVerifier:  options.SignatureVerifier,
+			InstanceStateStore: instanceStateStore(options.Storage, role),
 		}
 
 		identifier := spectypes.NewMsgID(options.NetworkConfig.DomainType, options.Operator.CommitteeID[:], role)
@@ -1174,8 +1249,9 @@ func SetupCommitteeRunners(
 
 	return func(slot phase0.Slot, shares map[phase0.ValidatorIndex]*spectypes.Share, attestingValidators []spectypes.ShareValidatorPK, dutyGuard runner.CommitteeDutyGuard) (*runner.CommitteeRunner, error) {
 		// Create a committee runner.
-		epoch := options.NetworkConfig.Beacon.GetBeaconNetwork().EstimatedEpochAtSlot(slot)
+		epoch := options.NetworkConfig.Beacon.EstimatedEpochAtSlot(slot)
 		valCheck := ssv.BeaconVoteValueCheckF(options.Signer, slot, attestingValidators, epoch)
+		valCheck = options.ValueCheckPolicy.WrapBeaconVote(valCheck, slot)
 		crunner, err := runner.NewCommitteeRunner(
 			options.NetworkConfig,
 			shares,
@@ -1194,6 +1270,31 @@ func SetupCommitteeRunners(
 	}
 }
 
+// newAdaptiveTimeouts returns the adaptive round timeouts if the network schedules them, nil otherwise
+func newAdaptiveTimeouts(networkConfig networkconfig.NetworkConfig) *roundtimer.AdaptiveTimeouts {
+	if !networkConfig.AdaptiveRoundTimeoutsScheduled() {
+		return nil
+	}
+	return roundtimer.NewAdaptiveTimeouts(
+		roundtimer.DefaultAdaptiveTimeoutConfig,
+		networkConfig.SlotsPerEpoch(),
+		networkConfig.SlotDurationSec(),
+		networkConfig.AdaptiveRoundTimeoutsEpoch,
+	)
+}
+
+// instanceStateStore returns the store in which the state of the role's running instances is persisted, nil if there isn't one
+func instanceStateStore(stores *storage.QBFTStores, role spectypes.RunnerRole) qbft.InstanceStateStore {
+	if stores == nil {
+		return nil
+	}
+	store := stores.Get(casts.RunnerRoleToConvertRole(role))
+	if store == nil {
+		return nil
+	}
+	return store
+}
+
 // SetupRunners initializes duty runners for the given validator
 func SetupRunners(
 	ctx context.Context,
@@ -1216,6 +1317,11 @@ func SetupRunners(
 	}
 
 	buildController := func(role spectypes.RunnerRole, valueCheckF specqbft.ProposedValueCheckF) *qbftcontroller.Controller {
+		timer := roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil)
+		if adaptive := newAdaptiveTimeouts(options.NetworkConfig); adaptive != nil {
+			timer.SetAdaptiveTimeouts(adaptive, len(options.Operator.Committee))
+		}
+
 		config := &qbft.Config{
 			BeaconSigner: options.Signer,
 			Domain:       options.NetworkConfig.DomainType,
@@ -1225,9 +1331,11 @@ func SetupRunners(
 				//logger.Debug("leader", zap.Int("operator_id", int(leader)))
 				return leader
 			},
-			Network:     options.Network,
-			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
-			CutOffRound: roundtimer.CutOffRound,
+			Network:            options.Network,
+			Timer:              timer,
+			CutOffRound:        roundtimer.CutOffRound,
+			SignatureVerifier:  options.SignatureVerifier,
+			InstanceStateStore: instanceStateStore(options.Storage, role),
 		}
 		config.ValueCheckF = valueCheckF
 
@@ -1246,6 +1354,9 @@ func SetupRunners(
 		switch role {
 		case spectypes.RoleProposer:
 			proposedValueCheck := ssv.ProposerValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
+			proposedValueCheck = options.ValueCheckPolicy.WrapProposer(proposedValueCheck, func() bellatrix.ExecutionAddress {
+				return options.SSVShare.FeeRecipientAddress
+			})
 			qbftCtrl := buildController(spectypes.RoleProposer, proposedValueCheck)
 			runners[role], err = runner.NewProposerRunner(domainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, proposedValueCheck, 0, options.Graffiti)
 		case spectypes.RoleAggregator:
@@ -1002,6 +1002,56 @@ func TestUpdateFeeRecipient(t *testing.T) {
 	})
 }
 
+func TestCommitteeMemberFromShare_KeyRotation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	operatorStore, done := newOperatorStorageForTest(logger)
+	defer done()
+
+	for id := uint64(1); id <= 5; id++ {
+		od := buildOperatorData(id, "67Ce5c69260bd819B4e0AD13f4b873074D479811")
+		od.PublicKey = []byte(base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("publicKey%d", id))))
+		_, err := operatorStore.SaveOperatorData(nil, od)
+		require.NoError(t, err)
+	}
+
+	operatorDataStore := operatordatastore.New(buildOperatorData(1, "67Ce5c69260bd819B4e0AD13f4b873074D479811"))
+	newOperatorData := buildOperatorData(5, "67Ce5c69260bd819B4e0AD13f4b873074D479811")
+	operatorDataStore.SetNewOperatorData(newOperatorData)
+
+	operatorSigner := types.NewSsvOperatorSigner(nil, operatorDataStore.GetOperatorID)
+	newOperatorSigner := types.NewSsvOperatorSigner(nil, func() spectypes.OperatorID { return newOperatorData.ID })
+	ctr := setupController(logger, MockControllerOptions{
+		operatorStorage:   operatorStore,
+		operatorDataStore: operatorDataStore,
+		validatorOptions:  validator.Options{OperatorSigner: operatorSigner},
+	})
+	ctr.newOperatorSigner = newOperatorSigner
+
+	committee := func(ids ...spectypes.OperatorID) *types.SSVShare {
+		share := &types.SSVShare{}
+		for _, id := range ids {
+			share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: id})
+		}
+		return share
+	}
+
+	// the node is a member of committees of either operator, and signs for each with its key
+	for _, tc := range []struct {
+		share      *types.SSVShare
+		operatorID spectypes.OperatorID
+		signer     types.OperatorSigner
+	}{
+		{share: committee(1, 2, 3, 4), operatorID: 1, signer: operatorSigner},
+		{share: committee(2, 3, 4, 5), operatorID: 5, signer: newOperatorSigner},
+	} {
+		member, err := ctr.committeeMemberFromShare(tc.share)
+		require.NoError(t, err)
+		require.Equal(t, tc.operatorID, member.OperatorID)
+		require.Equal(t, []byte(fmt.Sprintf("publicKey%d", tc.operatorID)), member.SSVOperatorPubKey)
+		require.Same(t, tc.signer, ctr.operatorSignerFor(member.OperatorID))
+	}
+}
+
 func setupController(logger *zap.Logger, opts MockControllerOptions) controller {
 	// Default to test network config if not provided.
 	if opts.networkConfig.Name == "" {
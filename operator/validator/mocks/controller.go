@@ -20,6 +20,7 @@ import (
 	records "github.com/ssvlabs/ssv/network/records"
 	duties "github.com/ssvlabs/ssv/operator/duties"
 	beacon "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	queue "github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	validator "github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
 	types0 "github.com/ssvlabs/ssv/protocol/v2/types"
 	storage "github.com/ssvlabs/ssv/registry/storage"
@@ -177,6 +178,20 @@ func (mr *MockControllerMockRecorder) LiquidateCluster(owner, operatorIDs, toLiq
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiquidateCluster", reflect.TypeOf((*MockController)(nil).LiquidateCluster), owner, operatorIDs, toLiquidate)
 }
 
+// QueueSaturation mocks base method.
+func (m *MockController) QueueSaturation() queue.Saturation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSaturation")
+	ret0, _ := ret[0].(queue.Saturation)
+	return ret0
+}
+
+// QueueSaturation indicates an expected call of QueueSaturation.
+func (mr *MockControllerMockRecorder) QueueSaturation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSaturation", reflect.TypeOf((*MockController)(nil).QueueSaturation))
+}
+
 // ReactivateCluster mocks base method.
 func (m *MockController) ReactivateCluster(owner common.Address, operatorIDs []uint64, toReactivate []*types0.SSVShare) error {
 	m.ctrl.T.Helper()
@@ -109,7 +109,7 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 		select {
 		case c.validatorExitCh <- exitDesc:
 			logger.Debug("added voluntary exit task to pipeline")
-		case <-time.After(2 * c.beacon.GetBeaconNetwork().SlotDurationSec()):
+		case <-time.After(2 * c.networkConfig.SlotDurationSec()):
 			logger.Error("failed to schedule ExitValidator duty!")
 		}
 	}()
@@ -57,6 +57,10 @@ type BeaconNode interface {
 	beaconValidator
 	signer // TODO need to handle differently
 	proposer
+
+	// Network returns the beacon network of the node, whose parameters the slot and epoch math depends on,
+	// unlike the spec network returned by GetBeaconNetwork which only names it.
+	Network() Network
 }
 
 // Options for controller struct creation
@@ -67,6 +71,7 @@ type Options struct {
 	GasLimit       uint64
 
 	SyncDistanceTolerance uint64 `yaml:"SyncDistanceTolerance" env:"BEACON_SYNC_DISTANCE_TOLERANCE" env-default:"4" env-description:"The number of out-of-sync slots we can tolerate"`
+	DynamicNetwork        bool   `yaml:"DynamicNetwork" env:"BEACON_DYNAMIC_NETWORK" env-default:"false" env-description:"Derive the beacon network parameters from the spec and genesis of the beacon node, failing on a mismatch with the parameters of the configured network, or those set explicitly in its network config file"`
 
 	CommonTimeout time.Duration // Optional.
 	LongTimeout   time.Duration // Optional.
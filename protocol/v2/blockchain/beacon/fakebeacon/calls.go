@@ -0,0 +1,453 @@
+package fakebeacon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+func (n *Node) GetBeaconNetwork() spectypes.BeaconNetwork {
+	return n.network.GetBeaconNetwork()
+}
+
+func (n *Node) Network() beacon.Network {
+	return n.network.GetNetwork()
+}
+
+func (n *Node) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
+	if _, err := n.call(ctx, EndpointAttesterDuties); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var duties []*eth2apiv1.AttesterDuty
+	for _, duty := range n.attesterDuties[epoch] {
+		if containsIndex(validatorIndices, duty.ValidatorIndex) {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, nil
+}
+
+func (n *Node) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
+	if _, err := n.call(ctx, EndpointProposerDuties); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var duties []*eth2apiv1.ProposerDuty
+	for _, duty := range n.proposerDuties[epoch] {
+		if containsIndex(validatorIndices, duty.ValidatorIndex) {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, nil
+}
+
+func (n *Node) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
+	if _, err := n.call(ctx, EndpointSyncCommitteeDuties); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var duties []*eth2apiv1.SyncCommitteeDuty
+	for _, duty := range n.syncCommitteeDuties[epoch] {
+		if containsIndex(indices, duty.ValidatorIndex) {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, nil
+}
+
+// containsIndex reports whether the index is in indices, an empty list contains all indices.
+func containsIndex(indices []phase0.ValidatorIndex, index phase0.ValidatorIndex) bool {
+	if len(indices) == 0 {
+		return true
+	}
+	for _, i := range indices {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// Events subscribes the handler to the events of the topics, which are emitted on reorgs.
+func (n *Node) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	if _, err := n.call(ctx, EndpointEvents); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, topic := range topics {
+		n.eventHandlers[topic] = append(n.eventHandlers[topic], handler)
+	}
+	return nil
+}
+
+func (n *Node) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, spec.DataVersion, error) {
+	variant, err := n.call(context.Background(), EndpointAttestationData)
+	if err != nil {
+		return nil, spec.DataVersionUnknown, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	data := *spectestingutils.TestingAttestationData
+	data.Slot = slot
+	if committeeIndex != 0 {
+		data.Index = committeeIndex
+	}
+	data.BeaconBlockRoot = n.headRoot(data.BeaconBlockRoot, slot, variant)
+	return &data, spec.DataVersionPhase0, nil
+}
+
+func (n *Node) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	variant, err := n.call(context.Background(), EndpointBeaconBlock)
+	if err != nil {
+		return nil, spec.DataVersionUnknown, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	version := spectestingutils.VersionBySlot(slot)
+	proposal := spectestingutils.TestingBeaconBlockV(version)
+	switch version {
+	case spec.DataVersionCapella:
+		block := &capella.BeaconBlock{}
+		if err := copySSZ(proposal.Capella, block); err != nil {
+			return nil, spec.DataVersionUnknown, err
+		}
+		block.ParentRoot = n.headRoot(block.ParentRoot, slot, variant)
+		return block, version, nil
+	case spec.DataVersionDeneb:
+		contents := &apiv1deneb.BlockContents{}
+		if err := copySSZ(proposal.Deneb, contents); err != nil {
+			return nil, spec.DataVersionUnknown, err
+		}
+		contents.Block.ParentRoot = n.headRoot(contents.Block.ParentRoot, slot, variant)
+		return contents, version, nil
+	default:
+		return nil, spec.DataVersionUnknown, fmt.Errorf("unsupported block version %s", version)
+	}
+}
+
+type sszObject interface {
+	ssz.Marshaler
+	ssz.Unmarshaler
+}
+
+// copySSZ deep copies src into dst, so that the shared test fixtures aren't modified.
+func copySSZ(src ssz.Marshaler, dst sszObject) error {
+	data, err := src.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("marshal block: %w", err)
+	}
+	if err := dst.UnmarshalSSZ(data); err != nil {
+		return fmt.Errorf("unmarshal block: %w", err)
+	}
+	return nil
+}
+
+func (n *Node) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.BLSSignature) error {
+	if _, err := n.call(context.Background(), EndpointSubmitBeaconBlock); err != nil {
+		return err
+	}
+
+	var root phase0.Root
+	var err error
+	switch block.Version {
+	case spec.DataVersionCapella:
+		if block.Capella == nil {
+			return fmt.Errorf("%s block is nil", block.Version)
+		}
+		root, err = (&capella.SignedBeaconBlock{Message: block.Capella, Signature: sig}).HashTreeRoot()
+	case spec.DataVersionDeneb:
+		if block.Deneb == nil || block.Deneb.Block == nil {
+			return fmt.Errorf("%s block is nil", block.Version)
+		}
+		root, err = (&apiv1deneb.SignedBlockContents{
+			SignedBlock: &deneb.SignedBeaconBlock{Message: block.Deneb.Block, Signature: sig},
+			KZGProofs:   block.Deneb.KZGProofs,
+			Blobs:       block.Deneb.Blobs,
+		}).HashTreeRoot()
+	default:
+		return fmt.Errorf("unknown block version %s", block.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	n.record(root)
+	return nil
+}
+
+func (n *Node) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal, sig phase0.BLSSignature) error {
+	if _, err := n.call(context.Background(), EndpointSubmitBlindedBeaconBlock); err != nil {
+		return err
+	}
+
+	var root phase0.Root
+	var err error
+	switch block.Version {
+	case spec.DataVersionCapella:
+		if block.Capella == nil {
+			return fmt.Errorf("%s blinded block is nil", block.Version)
+		}
+		root, err = (&apiv1capella.SignedBlindedBeaconBlock{Message: block.Capella, Signature: sig}).HashTreeRoot()
+	case spec.DataVersionDeneb:
+		if block.Deneb == nil {
+			return fmt.Errorf("%s blinded block is nil", block.Version)
+		}
+		root, err = (&apiv1deneb.SignedBlindedBeaconBlock{Message: block.Deneb, Signature: sig}).HashTreeRoot()
+	default:
+		return fmt.Errorf("unknown blinded block version %s", block.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	n.record(root)
+	return nil
+}
+
+func (n *Node) SubmitAttestations(attestations []*phase0.Attestation) error {
+	if _, err := n.call(context.Background(), EndpointSubmitAttestations); err != nil {
+		return err
+	}
+
+	for _, attestation := range attestations {
+		root, err := attestation.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		n.record(root)
+	}
+	return nil
+}
+
+func (n *Node) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	variant, err := n.call(context.Background(), EndpointAggregateSelectionProof)
+	if err != nil {
+		return nil, spec.DataVersionUnknown, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	aggregate := *spectestingutils.TestingAggregateAndProof
+	attestation := *aggregate.Aggregate
+	data := *attestation.Data
+	data.BeaconBlockRoot = n.headRoot(data.BeaconBlockRoot, data.Slot, variant)
+	attestation.Data = &data
+	aggregate.Aggregate = &attestation
+	return &aggregate, spec.DataVersionPhase0, nil
+}
+
+func (n *Node) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
+	if _, err := n.call(context.Background(), EndpointSubmitAggregate); err != nil {
+		return err
+	}
+
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	n.record(root)
+	return nil
+}
+
+func (n *Node) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
+	variant, err := n.call(context.Background(), EndpointSyncMessageBlockRoot)
+	if err != nil {
+		return phase0.Root{}, spec.DataVersionUnknown, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.headRoot(spectestingutils.TestingSyncCommitteeBlockRoot, slot, variant), spec.DataVersionPhase0, nil
+}
+
+func (n *Node) SubmitSyncMessages(msgs []*altair.SyncCommitteeMessage) error {
+	if _, err := n.call(context.Background(), EndpointSubmitSyncMessages); err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		root, err := msg.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		n.record(root)
+	}
+	return nil
+}
+
+func (n *Node) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
+	if _, err := n.call(context.Background(), EndpointIsSyncCommitteeAggregator); err != nil {
+		return false, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.aggregatorProofs) == 0 {
+		return true, nil
+	}
+	return n.aggregatorProofs[hex.EncodeToString(proof)], nil
+}
+
+func (n *Node) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64, error) {
+	return uint64(index), nil
+}
+
+func (n *Node) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	variant, err := n.call(context.Background(), EndpointSyncCommitteeContribution)
+	if err != nil {
+		return nil, spec.DataVersionUnknown, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	contributions := make(spectypes.Contributions, 0, len(spectestingutils.TestingContributionsData))
+	for _, c := range spectestingutils.TestingContributionsData {
+		contribution := *c
+		contribution.Contribution.BeaconBlockRoot = n.headRoot(contribution.Contribution.BeaconBlockRoot, contribution.Contribution.Slot, variant)
+		contributions = append(contributions, &contribution)
+	}
+	return &contributions, spec.DataVersionBellatrix, nil
+}
+
+func (n *Node) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
+	if _, err := n.call(context.Background(), EndpointSubmitContribution); err != nil {
+		return err
+	}
+
+	root, err := contribution.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	n.record(root)
+	return nil
+}
+
+func (n *Node) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
+	if _, err := n.call(context.Background(), EndpointSubmitRegistration); err != nil {
+		return err
+	}
+
+	registration := &eth2apiv1.ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     spectestingutils.TestingValidatorRegistration.GasLimit,
+		Timestamp:    spectestingutils.TestingValidatorRegistration.Timestamp,
+	}
+	copy(registration.Pubkey[:], pubkey)
+	root, err := registration.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	n.record(root)
+	return nil
+}
+
+func (n *Node) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	if _, err := n.call(context.Background(), EndpointSubmitVoluntaryExit); err != nil {
+		return err
+	}
+
+	root, err := voluntaryExit.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	n.record(root)
+	return nil
+}
+
+func (n *Node) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
+	if _, err := n.call(context.Background(), EndpointDomainData); err != nil {
+		return phase0.Domain{}, err
+	}
+	// the fork version is the same in all epochs
+	return spectypes.ComputeETHDomain(domain, spectypes.GenesisForkVersion, spectypes.GenesisValidatorsRoot)
+}
+
+func (n *Node) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
+	_, err := n.call(ctx, EndpointSubscriptions)
+	return err
+}
+
+func (n *Node) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.SyncCommitteeSubscription) error {
+	_, err := n.call(ctx, EndpointSubscriptions)
+	return err
+}
+
+func (n *Node) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	if _, err := n.call(context.Background(), EndpointValidatorData); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	result := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator)
+	for index, validator := range n.validators {
+		for _, pubKey := range validatorPubKeys {
+			if validator.Validator != nil && validator.Validator.PublicKey == pubKey {
+				result[index] = validator
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (n *Node) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	if _, err := n.call(context.Background(), EndpointProposalPreparation); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for index, feeRecipient := range feeRecipients {
+		n.preparations[index] = feeRecipient
+	}
+	return nil
+}
+
+func (n *Node) ComputeSigningRoot(object interface{}, domain phase0.Domain) ([32]byte, error) {
+	root, ok := object.(ssz.HashRoot)
+	if !ok {
+		return [32]byte{}, fmt.Errorf("cannot compute signing root of %T", object)
+	}
+	return spectypes.ComputeETHSigningRoot(root, domain)
+}
@@ -0,0 +1,322 @@
+// Package fakebeacon provides an in-process beacon node for tests, which serves configured duties,
+// produces attestation data, blocks and sync committee roots, and can inject faults per endpoint.
+//
+// Without faults it produces the same data as the spec testing beacon node,
+// so it can replace it in runner tests that use the spec test fixtures.
+package fakebeacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// Endpoint identifies a beacon node call by the name of its method.
+type Endpoint string
+
+const (
+	EndpointAttesterDuties            Endpoint = "AttesterDuties"
+	EndpointProposerDuties            Endpoint = "ProposerDuties"
+	EndpointSyncCommitteeDuties       Endpoint = "SyncCommitteeDuties"
+	EndpointEvents                    Endpoint = "Events"
+	EndpointAttestationData           Endpoint = "GetAttestationData"
+	EndpointBeaconBlock               Endpoint = "GetBeaconBlock"
+	EndpointSyncMessageBlockRoot      Endpoint = "GetSyncMessageBlockRoot"
+	EndpointAggregateSelectionProof   Endpoint = "SubmitAggregateSelectionProof"
+	EndpointSyncCommitteeContribution Endpoint = "GetSyncCommitteeContribution"
+	EndpointIsSyncCommitteeAggregator Endpoint = "IsSyncCommitteeAggregator"
+	EndpointValidatorData             Endpoint = "GetValidatorData"
+	EndpointDomainData                Endpoint = "DomainData"
+	EndpointSubmitAttestations        Endpoint = "SubmitAttestations"
+	EndpointSubmitBeaconBlock         Endpoint = "SubmitBeaconBlock"
+	EndpointSubmitBlindedBeaconBlock  Endpoint = "SubmitBlindedBeaconBlock"
+	EndpointSubmitAggregate           Endpoint = "SubmitSignedAggregateSelectionProof"
+	EndpointSubmitSyncMessages        Endpoint = "SubmitSyncMessages"
+	EndpointSubmitContribution        Endpoint = "SubmitSignedContributionAndProof"
+	EndpointSubmitRegistration        Endpoint = "SubmitValidatorRegistration"
+	EndpointSubmitVoluntaryExit       Endpoint = "SubmitVoluntaryExit"
+	EndpointSubscriptions             Endpoint = "Subscriptions"
+	EndpointProposalPreparation       Endpoint = "SubmitProposalPreparation"
+)
+
+var _ beacon.BeaconNode = (*Node)(nil)
+
+// ErrInjected is the default error returned by faulty calls.
+var ErrInjected = errors.New("injected beacon node error")
+
+// Fault describes how calls to an endpoint misbehave.
+type Fault struct {
+	// Latency delays the call, calls that take a context return early when it's done
+	Latency time.Duration
+	// Err fails the calls with it, ErrInjected is used if FailRate is set without it
+	Err error
+	// FailRate fails every FailRate-th call instead of every call
+	FailRate int
+	// Inconsistent makes every call of the data endpoints return different roots than the previous one
+	Inconsistent bool
+	// Calls limits the number of calls the fault applies to, zero applies it to all calls
+	Calls int
+}
+
+type Option func(*Node)
+
+// WithNetwork sets the beacon network of the node, the spec test network is used by default.
+func WithNetwork(network beacon.BeaconNetwork) Option {
+	return func(n *Node) {
+		n.network = network
+	}
+}
+
+// Node is a fake beacon node implementing beacon.BeaconNode.
+type Node struct {
+	network beacon.BeaconNetwork
+
+	mu                  sync.Mutex
+	faults              map[Endpoint]*faultState
+	calls               map[Endpoint]int
+	attesterDuties      map[phase0.Epoch][]*eth2apiv1.AttesterDuty
+	proposerDuties      map[phase0.Epoch][]*eth2apiv1.ProposerDuty
+	syncCommitteeDuties map[phase0.Epoch][]*eth2apiv1.SyncCommitteeDuty
+	validators          map[phase0.ValidatorIndex]*eth2apiv1.Validator
+	aggregatorProofs    map[string]bool
+	// reorgs are the slots from which the chain was reorganized, in the order they happened
+	reorgs        []phase0.Slot
+	eventHandlers map[string][]eth2client.EventHandlerFunc
+	submitted     []phase0.Root
+	preparations  map[phase0.ValidatorIndex]bellatrix.ExecutionAddress
+}
+
+type faultState struct {
+	Fault
+	calls int
+}
+
+// New creates a new fake beacon node without duties or faults.
+func New(opts ...Option) *Node {
+	n := &Node{
+		network:             beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		faults:              make(map[Endpoint]*faultState),
+		calls:               make(map[Endpoint]int),
+		attesterDuties:      make(map[phase0.Epoch][]*eth2apiv1.AttesterDuty),
+		proposerDuties:      make(map[phase0.Epoch][]*eth2apiv1.ProposerDuty),
+		syncCommitteeDuties: make(map[phase0.Epoch][]*eth2apiv1.SyncCommitteeDuty),
+		validators:          make(map[phase0.ValidatorIndex]*eth2apiv1.Validator),
+		eventHandlers:       make(map[string][]eth2client.EventHandlerFunc),
+		preparations:        make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// InjectFault makes calls to the endpoint misbehave, replacing its previous fault.
+func (n *Node) InjectFault(endpoint Endpoint, fault Fault) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.faults[endpoint] = &faultState{Fault: fault}
+}
+
+// ClearFaults removes the faults of the given endpoints, or of all endpoints when none are given.
+func (n *Node) ClearFaults(endpoints ...Endpoint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(endpoints) == 0 {
+		n.faults = make(map[Endpoint]*faultState)
+		return
+	}
+	for _, endpoint := range endpoints {
+		delete(n.faults, endpoint)
+	}
+}
+
+// Calls returns the number of calls made to the endpoint.
+func (n *Node) Calls(endpoint Endpoint) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calls[endpoint]
+}
+
+// SetAttesterDuties sets the attester duties of the epoch.
+func (n *Node) SetAttesterDuties(epoch phase0.Epoch, duties ...*eth2apiv1.AttesterDuty) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.attesterDuties[epoch] = duties
+}
+
+// SetProposerDuties sets the proposer duties of the epoch.
+func (n *Node) SetProposerDuties(epoch phase0.Epoch, duties ...*eth2apiv1.ProposerDuty) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.proposerDuties[epoch] = duties
+}
+
+// SetSyncCommitteeDuties sets the sync committee duties of the epoch.
+func (n *Node) SetSyncCommitteeDuties(epoch phase0.Epoch, duties ...*eth2apiv1.SyncCommitteeDuty) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.syncCommitteeDuties[epoch] = duties
+}
+
+// SetValidators sets the validators returned by GetValidatorData.
+func (n *Node) SetValidators(validators ...*eth2apiv1.Validator) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.validators = make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(validators))
+	for _, validator := range validators {
+		n.validators[validator.Index] = validator
+	}
+}
+
+// SetSyncCommitteeAggregatorProofs sets which selection proofs, hex encoded, are of sync committee aggregators.
+// All proofs are of aggregators when it's not set.
+func (n *Node) SetSyncCommitteeAggregatorProofs(proofs map[string]bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.aggregatorProofs = proofs
+}
+
+// Reorg reorganizes the chain from the given slot, changing the head roots of it and the following slots.
+// It notifies the subscribers of head and chain_reorg events.
+func (n *Node) Reorg(slot phase0.Slot) {
+	n.mu.Lock()
+	oldRoot := n.headRoot(spectestingutils.TestingAttestationData.BeaconBlockRoot, slot, 0)
+	n.reorgs = append(n.reorgs, slot)
+	newRoot := n.headRoot(spectestingutils.TestingAttestationData.BeaconBlockRoot, slot, 0)
+	events := []*eth2apiv1.Event{
+		{
+			Topic: "chain_reorg",
+			Data: &eth2apiv1.ChainReorgEvent{
+				Slot:         slot,
+				Depth:        1,
+				OldHeadBlock: oldRoot,
+				NewHeadBlock: newRoot,
+				Epoch:        n.network.EstimatedEpochAtSlot(slot),
+			},
+		},
+		{
+			Topic: "head",
+			Data: &eth2apiv1.HeadEvent{
+				Slot:            slot,
+				Block:           newRoot,
+				EpochTransition: n.network.IsFirstSlotOfEpoch(slot),
+			},
+		},
+	}
+	var handlers [][]eth2client.EventHandlerFunc
+	for _, event := range events {
+		handlers = append(handlers, append([]eth2client.EventHandlerFunc(nil), n.eventHandlers[event.Topic]...))
+	}
+	n.mu.Unlock()
+
+	for i, event := range events {
+		for _, handler := range handlers[i] {
+			handler(event)
+		}
+	}
+}
+
+// Submitted returns the roots of the objects submitted to the node, in the order they were submitted.
+func (n *Node) Submitted() []phase0.Root {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]phase0.Root(nil), n.submitted...)
+}
+
+// ProposalPreparations returns the latest fee recipients submitted for the validators.
+func (n *Node) ProposalPreparations() map[phase0.ValidatorIndex]bellatrix.ExecutionAddress {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	preparations := make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, len(n.preparations))
+	for index, feeRecipient := range n.preparations {
+		preparations[index] = feeRecipient
+	}
+	return preparations
+}
+
+// call records a call to the endpoint and applies its fault,
+// returning the number of the call within the fault and the error to fail it with.
+func (n *Node) call(ctx context.Context, endpoint Endpoint) (variant int, err error) {
+	n.mu.Lock()
+	n.calls[endpoint]++
+	state, ok := n.faults[endpoint]
+	if !ok || (state.Calls > 0 && state.calls >= state.Calls) {
+		n.mu.Unlock()
+		return 0, nil
+	}
+	state.calls++
+	fault := state.Fault
+	callNumber := state.calls
+	n.mu.Unlock()
+
+	if fault.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(fault.Latency):
+		}
+	}
+
+	if fault.Err != nil || fault.FailRate > 0 {
+		if fault.FailRate <= 1 || callNumber%fault.FailRate == 0 {
+			if fault.Err != nil {
+				return 0, fault.Err
+			}
+			return 0, ErrInjected
+		}
+	}
+
+	if fault.Inconsistent {
+		variant = callNumber
+	}
+	return variant, nil
+}
+
+// headRoot returns the root of the head at the slot, derived from the given root by the reorgs affecting the slot
+// and the variant of an inconsistent call.
+func (n *Node) headRoot(root phase0.Root, slot phase0.Slot, variant int) phase0.Root {
+	reorgs := 0
+	for _, reorgSlot := range n.reorgs {
+		if reorgSlot <= slot {
+			reorgs++
+		}
+	}
+	if reorgs == 0 && variant == 0 {
+		return root
+	}
+
+	buf := make([]byte, 0, len(root)+16)
+	buf = append(buf, root[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(reorgs))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(variant))
+	return sha256.Sum256(buf)
+}
+
+func (n *Node) record(root phase0.Root) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.submitted = append(n.submitted, root)
+}
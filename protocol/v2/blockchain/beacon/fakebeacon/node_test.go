@@ -0,0 +1,130 @@
+package fakebeacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_MatchesSpecBeaconNode(t *testing.T) {
+	node := New()
+	specNode := spectestingutils.NewTestingBeaconNode()
+
+	data, _, err := node.GetAttestationData(12, 3)
+	require.NoError(t, err)
+	specData, _, err := specNode.GetAttestationData(12, 3)
+	require.NoError(t, err)
+	require.Equal(t, specData, data)
+
+	root, _, err := node.GetSyncMessageBlockRoot(12)
+	require.NoError(t, err)
+	require.Equal(t, spectestingutils.TestingSyncCommitteeBlockRoot, root)
+
+	block, version, err := node.GetBeaconBlock(spectestingutils.TestingDutySlotCapella, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, spec.DataVersionCapella, version)
+	specBlock, _, err := specNode.GetBeaconBlock(spectestingutils.TestingDutySlotCapella, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, specBlock, block)
+}
+
+func TestNode_Reorg(t *testing.T) {
+	node := New()
+
+	var events []*eth2apiv1.Event
+	require.NoError(t, node.Events(context.Background(), []string{"chain_reorg", "head"}, func(event *eth2apiv1.Event) {
+		events = append(events, event)
+	}))
+
+	before, _, err := node.GetAttestationData(10, 0)
+	require.NoError(t, err)
+	node.Reorg(11)
+	require.Len(t, events, 2)
+	require.Equal(t, "chain_reorg", events[0].Topic)
+	require.EqualValues(t, 11, events[0].Data.(*eth2apiv1.ChainReorgEvent).Slot)
+
+	// slots before the reorg keep their head
+	after, _, err := node.GetAttestationData(10, 0)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	reorged, _, err := node.GetAttestationData(11, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, before.BeaconBlockRoot, reorged.BeaconBlockRoot)
+	require.Equal(t, events[1].Data.(*eth2apiv1.HeadEvent).Block, reorged.BeaconBlockRoot)
+
+	block, _, err := node.GetBeaconBlock(spectestingutils.TestingDutySlotCapella, nil, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, spectestingutils.TestingBeaconBlockCapella.ParentRoot, block.(*capella.BeaconBlock).ParentRoot)
+}
+
+func TestNode_Faults(t *testing.T) {
+	node := New()
+
+	t.Run("error", func(t *testing.T) {
+		expected := errors.New("unavailable")
+		node.InjectFault(EndpointAttestationData, Fault{Err: expected, Calls: 2})
+		for range 2 {
+			_, _, err := node.GetAttestationData(1, 0)
+			require.ErrorIs(t, err, expected)
+		}
+		_, _, err := node.GetAttestationData(1, 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, node.Calls(EndpointAttestationData))
+	})
+
+	t.Run("fail rate", func(t *testing.T) {
+		node.InjectFault(EndpointSubmitAttestations, Fault{FailRate: 2})
+		require.NoError(t, node.SubmitAttestations(nil))
+		require.ErrorIs(t, node.SubmitAttestations(nil), ErrInjected)
+		require.NoError(t, node.SubmitAttestations(nil))
+	})
+
+	t.Run("inconsistent", func(t *testing.T) {
+		node.InjectFault(EndpointSyncMessageBlockRoot, Fault{Inconsistent: true})
+		first, _, err := node.GetSyncMessageBlockRoot(1)
+		require.NoError(t, err)
+		second, _, err := node.GetSyncMessageBlockRoot(1)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+
+		node.ClearFaults(EndpointSyncMessageBlockRoot)
+		root, _, err := node.GetSyncMessageBlockRoot(1)
+		require.NoError(t, err)
+		require.Equal(t, spectestingutils.TestingSyncCommitteeBlockRoot, root)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		node.InjectFault(EndpointAttesterDuties, Fault{Latency: time.Minute})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := node.AttesterDuties(ctx, 1, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		node.ClearFaults()
+	})
+}
+
+func TestNode_Duties(t *testing.T) {
+	node := New()
+	node.SetAttesterDuties(2,
+		&eth2apiv1.AttesterDuty{ValidatorIndex: 1, Slot: 64},
+		&eth2apiv1.AttesterDuty{ValidatorIndex: 2, Slot: 65},
+	)
+
+	duties, err := node.AttesterDuties(context.Background(), 2, []phase0.ValidatorIndex{2, 3})
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.EqualValues(t, 65, duties[0].Slot)
+
+	duties, err = node.AttesterDuties(context.Background(), 3, nil)
+	require.NoError(t, err)
+	require.Empty(t, duties)
+}
@@ -461,6 +461,20 @@ func (mr *MockBeaconNodeMockRecorder) IsSyncCommitteeAggregator(proof any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSyncCommitteeAggregator", reflect.TypeOf((*MockBeaconNode)(nil).IsSyncCommitteeAggregator), proof)
 }
 
+// Network mocks base method.
+func (m *MockBeaconNode) Network() Network {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Network")
+	ret0, _ := ret[0].(Network)
+	return ret0
+}
+
+// Network indicates an expected call of Network.
+func (mr *MockBeaconNodeMockRecorder) Network() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Network", reflect.TypeOf((*MockBeaconNode)(nil).Network))
+}
+
 // ProposerDuties mocks base method.
 func (m *MockBeaconNode) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*v1.ProposerDuty, error) {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -13,6 +14,61 @@ import (
 type Network struct {
 	spectypes.BeaconNetwork
 	LocalTestNet bool
+	// params override the parameters of the spec network, for networks the spec doesn't know
+	params *Params
+	// explicit are the parameters of params that were set by the network config, the zero ones defaulted.
+	// nil if all of them were set.
+	explicit *Params
+}
+
+// Params are the parameters of a beacon chain network that the slot and epoch math depends on.
+type Params struct {
+	GenesisForkVersion           [4]byte
+	GenesisTime                  int64
+	SlotDuration                 time.Duration
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+}
+
+// Fill returns p with its unset (zero) parameters taken from defaults.
+func (p Params) Fill(defaults Params) Params {
+	if p.GenesisForkVersion == [4]byte{} {
+		p.GenesisForkVersion = defaults.GenesisForkVersion
+	}
+	if p.GenesisTime == 0 {
+		p.GenesisTime = defaults.GenesisTime
+	}
+	if p.SlotDuration == 0 {
+		p.SlotDuration = defaults.SlotDuration
+	}
+	if p.SlotsPerEpoch == 0 {
+		p.SlotsPerEpoch = defaults.SlotsPerEpoch
+	}
+	if p.EpochsPerSyncCommitteePeriod == 0 {
+		p.EpochsPerSyncCommitteePeriod = defaults.EpochsPerSyncCommitteePeriod
+	}
+	return p
+}
+
+// Diff returns the descriptions of the parameters that differ between p and other.
+func (p Params) Diff(other Params) []string {
+	var diff []string
+	if p.GenesisForkVersion != other.GenesisForkVersion {
+		diff = append(diff, fmt.Sprintf("genesis fork version %#x != %#x", p.GenesisForkVersion, other.GenesisForkVersion))
+	}
+	if p.GenesisTime != other.GenesisTime {
+		diff = append(diff, fmt.Sprintf("genesis time %d != %d", p.GenesisTime, other.GenesisTime))
+	}
+	if p.SlotDuration != other.SlotDuration {
+		diff = append(diff, fmt.Sprintf("slot duration %s != %s", p.SlotDuration, other.SlotDuration))
+	}
+	if p.SlotsPerEpoch != other.SlotsPerEpoch {
+		diff = append(diff, fmt.Sprintf("slots per epoch %d != %d", p.SlotsPerEpoch, other.SlotsPerEpoch))
+	}
+	if p.EpochsPerSyncCommitteePeriod != other.EpochsPerSyncCommitteePeriod {
+		diff = append(diff, fmt.Sprintf("epochs per sync committee period %d != %d", p.EpochsPerSyncCommitteePeriod, other.EpochsPerSyncCommitteePeriod))
+	}
+	return diff
 }
 
 type BeaconNetwork interface {
@@ -58,8 +114,75 @@ func NewLocalTestNetwork(network spectypes.BeaconNetwork) Network {
 	}
 }
 
+// NewCustomNetwork creates a new beacon chain network with the given parameters.
+// network names it, its parameters in the spec are ignored.
+func NewCustomNetwork(network spectypes.BeaconNetwork, params Params) Network {
+	return Network{
+		BeaconNetwork: network,
+		params:        &params,
+	}
+}
+
+// NewConfiguredNetwork creates a new beacon chain network with the given parameters,
+// of which only the non-zero explicit ones were configured and the others are defaults.
+func NewConfiguredNetwork(network spectypes.BeaconNetwork, params, explicit Params) Network {
+	return Network{
+		BeaconNetwork: network,
+		params:        &params,
+		explicit:      &explicit,
+	}
+}
+
+// ExplicitParams returns the parameters that were configured explicitly, the others are zero.
+// All the parameters of spec and custom networks are explicit, only those set in the network config
+// are explicit for a network configured with unset parameters.
+func (n Network) ExplicitParams() Params {
+	if n.explicit != nil {
+		return *n.explicit
+	}
+	return n.Params()
+}
+
+// Params returns the parameters of the network.
+func (n Network) Params() Params {
+	return Params{
+		GenesisForkVersion:           n.ForkVersion(),
+		GenesisTime:                  n.MinGenesisTime(),
+		SlotDuration:                 n.SlotDurationSec(),
+		SlotsPerEpoch:                n.SlotsPerEpoch(),
+		EpochsPerSyncCommitteePeriod: n.EpochsPerSyncCommitteePeriod(),
+	}
+}
+
+// ForkVersion returns the genesis fork version of the network.
+func (n Network) ForkVersion() [4]byte {
+	if n.params != nil {
+		return n.params.GenesisForkVersion
+	}
+	return n.BeaconNetwork.ForkVersion()
+}
+
+// SlotDurationSec returns the duration of a slot.
+func (n Network) SlotDurationSec() time.Duration {
+	if n.params != nil {
+		return n.params.SlotDuration
+	}
+	return n.BeaconNetwork.SlotDurationSec()
+}
+
+// SlotsPerEpoch returns the number of slots in an epoch.
+func (n Network) SlotsPerEpoch() uint64 {
+	if n.params != nil {
+		return n.params.SlotsPerEpoch
+	}
+	return n.BeaconNetwork.SlotsPerEpoch()
+}
+
 // MinGenesisTime returns min genesis time value
 func (n Network) MinGenesisTime() int64 {
+	if n.params != nil {
+		return n.params.GenesisTime
+	}
 	if n.LocalTestNet {
 		return 1689072978
 	}
@@ -102,6 +225,21 @@ func (n Network) EstimatedSlotAtTime(time int64) phase0.Slot {
 	return phase0.Slot(uint64(time-genesis) / uint64(n.SlotDurationSec().Seconds())) //#nosec G115
 }
 
+// EstimatedTimeAtSlot estimates the unix time of the start of the given slot
+func (n Network) EstimatedTimeAtSlot(slot phase0.Slot) int64 {
+	return n.GetSlotStartTime(slot).Unix()
+}
+
+// FirstSlotAtEpoch returns the first slot of the given epoch
+func (n Network) FirstSlotAtEpoch(epoch phase0.Epoch) phase0.Slot {
+	return n.GetEpochFirstSlot(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch
+func (n Network) EpochStartTime(epoch phase0.Epoch) time.Time {
+	return n.GetSlotStartTime(n.GetEpochFirstSlot(epoch))
+}
+
 // EstimatedCurrentEpoch estimates the current epoch
 // https://github.com/ethereum/eth2.0-specs/blob/dev/specs/phase0/beacon-chain.md#compute_start_slot_at_epoch
 func (n Network) EstimatedCurrentEpoch() phase0.Epoch {
@@ -125,6 +263,9 @@ func (n Network) GetEpochFirstSlot(epoch phase0.Epoch) phase0.Slot {
 
 // EpochsPerSyncCommitteePeriod returns the number of epochs per sync committee period.
 func (n Network) EpochsPerSyncCommitteePeriod() uint64 {
+	if n.params != nil {
+		return n.params.EpochsPerSyncCommitteePeriod
+	}
 	return 256
 }
 
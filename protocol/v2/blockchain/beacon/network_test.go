@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -17,3 +18,40 @@ func TestNetwork_GetSlotEndTime(t *testing.T) {
 
 	require.Equal(t, n.SlotDurationSec(), slotEnd.Sub(slotStart))
 }
+
+func TestNetwork_Custom(t *testing.T) {
+	params := Params{
+		GenesisForkVersion:           [4]byte{0x10, 0x0, 0x0, 0x38},
+		GenesisTime:                  1727000000,
+		SlotDuration:                 6 * time.Second,
+		SlotsPerEpoch:                8,
+		EpochsPerSyncCommitteePeriod: 4,
+	}
+	n := NewCustomNetwork("devnet", params)
+
+	require.Equal(t, params, n.Params())
+	require.Empty(t, params.Diff(n.Params()))
+	require.Len(t, params.Diff(NewNetwork(spectypes.HoleskyNetwork).Params()), 5)
+
+	require.Equal(t, phase0.Slot(16), n.FirstSlotAtEpoch(2))
+	require.Equal(t, time.Unix(1727000000+16*6, 0), n.EpochStartTime(2))
+	require.Equal(t, phase0.Epoch(2), n.EstimatedEpochAtSlot(23))
+	require.Equal(t, phase0.Slot(2), n.EstimatedSlotAtTime(1727000000+13))
+	require.Equal(t, phase0.Slot(4*8-2), n.LastSlotOfSyncPeriod(0))
+}
+
+func TestNetwork_ExplicitParams(t *testing.T) {
+	holesky := NewNetwork(spectypes.HoleskyNetwork)
+	require.Equal(t, holesky.Params(), holesky.ExplicitParams())
+
+	explicit := Params{SlotDuration: 6 * time.Second}
+	params := explicit.Fill(holesky.Params())
+	require.Equal(t, []string{"slot duration 12s != 6s"}, holesky.Params().Diff(params))
+
+	n := NewConfiguredNetwork(spectypes.HoleskyNetwork, params, explicit)
+	require.Equal(t, params, n.Params())
+	require.Equal(t, explicit, n.ExplicitParams())
+
+	custom := NewCustomNetwork("devnet", params)
+	require.Equal(t, params, custom.ExplicitParams())
+}
@@ -0,0 +1,73 @@
+package protocolp2p
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+)
+
+var (
+	// ErrNoDecidedHistoryPeers is returned when there are no connected peers that serve decided history
+	ErrNoDecidedHistoryPeers = errors.New("no peers serve decided history")
+)
+
+// DecidedHistoryRequest is a request for the decided participants of an identifier in a slot range
+type DecidedHistoryRequest struct {
+	Identifier convert.MessageID
+	From       phase0.Slot
+	To         phase0.Slot
+	// Limit is the maximum number of entries the requester wants, the responder might cap it further
+	Limit uint64
+}
+
+// Encode returns the encoded request
+func (r *DecidedHistoryRequest) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode decodes the given data into the request
+func (r *DecidedHistoryRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// DecidedHistoryEntry holds the decided participants of a single slot
+type DecidedHistoryEntry struct {
+	Slot    phase0.Slot
+	Signers []spectypes.OperatorID
+	// Proof holds the partial signatures of the quorum, entries without a proof can't be verified
+	Proof *qbftstorage.ParticipantsProof `json:",omitempty"`
+}
+
+// DecidedHistoryResponse is the response for DecidedHistoryRequest
+type DecidedHistoryResponse struct {
+	Entries []DecidedHistoryEntry
+	// To is the last slot that was scanned by the responder, the next request should start from To+1
+	To phase0.Slot
+	// Error is set when the request couldn't be served
+	Error string `json:",omitempty"`
+}
+
+// Encode returns the encoded response
+func (r *DecidedHistoryResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode decodes the given data into the response
+func (r *DecidedHistoryResponse) Decode(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// DecidedHistorySyncer enables to sync decided history from peers
+type DecidedHistorySyncer interface {
+	// DecidedHistoryPeers returns the number of connected peers that serve decided history
+	DecidedHistoryPeers() int
+	// SyncDecidedByRange requests the decided participants of the given identifier in the given slot range,
+	// the returned entries are not verified
+	SyncDecidedByRange(logger *zap.Logger, identifier convert.MessageID, from, to phase0.Slot) ([]DecidedHistoryEntry, error)
+}
@@ -1,6 +1,8 @@
 package qbft
 
 import (
+	"context"
+
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
@@ -27,6 +29,31 @@ type IConfig interface {
 	GetTimer() roundtimer.Timer
 	// GetRoundCutOff returns the round cut off
 	GetCutOffRound() specqbft.Round
+	// GetSignatureVerifier returns the verifier of the operator signatures of messages
+	GetSignatureVerifier() SignatureVerifier
+	// GetInstanceStateStore returns the store of the state of running instances, or nil if it isn't persisted
+	GetInstanceStateStore() InstanceStateStore
+}
+
+// SignatureVerifier verifies the operator signatures of a signed message
+type SignatureVerifier interface {
+	Verify(ctx context.Context, msg *spectypes.SignedSSVMessage, operators []*spectypes.Operator) error
+}
+
+// SpecSignatureVerifier verifies signatures as the spec does, without caching
+type SpecSignatureVerifier struct{}
+
+func (SpecSignatureVerifier) Verify(_ context.Context, msg *spectypes.SignedSSVMessage, operators []*spectypes.Operator) error {
+	return spectypes.Verify(msg, operators)
+}
+
+// InstanceStateStore persists the state of running instances, so that an operator that restarts mid-duty
+// resumes them instead of starting over and contradicting the messages it sent before the restart.
+type InstanceStateStore interface {
+	// SaveInstanceState replaces the saved state of the instance's identifier
+	SaveInstanceState(state *specqbft.State) error
+	// GetInstanceState returns the saved state of the given identifier, or nil if there isn't one
+	GetInstanceState(identifier []byte) (*specqbft.State, error)
 }
 
 type Config struct {
@@ -37,6 +64,10 @@ type Config struct {
 	Network      specqbft.Network
 	Timer        roundtimer.Timer
 	CutOffRound  specqbft.Round
+	// SignatureVerifier is optional, signatures are verified as in the spec if it's nil
+	SignatureVerifier SignatureVerifier
+	// InstanceStateStore is optional, the state of instances isn't persisted if it's nil
+	InstanceStateStore InstanceStateStore
 }
 
 // GetShareSigner returns a BeaconSigner instance
@@ -72,3 +103,16 @@ func (c *Config) GetTimer() roundtimer.Timer {
 func (c *Config) GetCutOffRound() specqbft.Round {
 	return c.CutOffRound
 }
+
+// GetSignatureVerifier returns the verifier of the operator signatures of messages
+func (c *Config) GetSignatureVerifier() SignatureVerifier {
+	if c.SignatureVerifier == nil {
+		return SpecSignatureVerifier{}
+	}
+	return c.SignatureVerifier
+}
+
+// GetInstanceStateStore returns the store of the state of running instances, or nil if it isn't persisted
+func (c *Config) GetInstanceStateStore() InstanceStateStore {
+	return c.InstanceStateStore
+}
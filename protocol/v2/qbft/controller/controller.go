@@ -14,6 +14,7 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/instance"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
@@ -71,11 +72,33 @@ func (c *Controller) StartNewInstance(ctx context.Context, logger *zap.Logger, h
 	c.Height = height
 
 	newInstance := c.addAndStoreNewInstance()
-	newInstance.Start(ctx, logger, value, height)
+	if saved := c.savedInstanceState(logger, height); saved != nil {
+		newInstance.Resume(logger, value, saved)
+	} else {
+		newInstance.Start(ctx, logger, value, height)
+	}
 	c.forceStopAllInstanceExceptCurrent()
 	return nil
 }
 
+// savedInstanceState returns the state saved for the instance of the given height before a restart, if any
+func (c *Controller) savedInstanceState(logger *zap.Logger, height specqbft.Height) *specqbft.State {
+	store := c.GetConfig().GetInstanceStateStore()
+	if store == nil {
+		return nil
+	}
+
+	state, err := store.GetInstanceState(c.Identifier)
+	if err != nil {
+		logger.Warn("❗ failed to get saved instance state", zap.Error(err))
+		return nil
+	}
+	if state == nil || state.Height != height {
+		return nil
+	}
+	return state
+}
+
 func (c *Controller) forceStopAllInstanceExceptCurrent() {
 	for _, i := range c.StoredInstances {
 		if i.State.Height != c.Height {
@@ -107,7 +130,7 @@ func (c *Controller) ProcessMsg(ctx context.Context, logger *zap.Logger, signedM
 		return nil, err
 	}
 	if isDecided {
-		return c.UponDecided(logger, msg)
+		return c.UponDecided(ctx, logger, msg)
 	}
 
 	isFuture, err := c.isFutureMessage(msg)
@@ -143,6 +166,7 @@ func (c *Controller) UponExistingInstanceMsg(ctx context.Context, logger *zap.Lo
 	if !decided {
 		return nil, nil
 	}
+	c.observeDecision(msg.QBFTMessage.Height, inst.State.Round)
 
 	if err := c.broadcastDecided(decidedMsg); err != nil {
 		// no need to fail processing instance deciding if failed to save/ broadcast
@@ -152,6 +176,13 @@ func (c *Controller) UponExistingInstanceMsg(ctx context.Context, logger *zap.Lo
 	return decidedMsg, nil
 }
 
+// observeDecision lets the timer learn from the round in which an instance was decided, if it supports it
+func (c *Controller) observeDecision(height specqbft.Height, round specqbft.Round) {
+	if observer, ok := c.GetConfig().GetTimer().(roundtimer.DecisionObserver); ok {
+		observer.Decided(height, round)
+	}
+}
+
 // BaseMsgValidation returns error if msg is invalid (base validation)
 func (c *Controller) BaseMsgValidation(msg *specqbft.ProcessingMessage) error {
 	// verify msg belongs to controller
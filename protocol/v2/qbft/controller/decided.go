@@ -2,6 +2,7 @@ package controller
 
 import (
 	"bytes"
+	"context"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -13,8 +14,9 @@ import (
 )
 
 // UponDecided returns decided msg if decided, nil otherwise
-func (c *Controller) UponDecided(logger *zap.Logger, msg *specqbft.ProcessingMessage) (*spectypes.SignedSSVMessage, error) {
+func (c *Controller) UponDecided(ctx context.Context, logger *zap.Logger, msg *specqbft.ProcessingMessage) (*spectypes.SignedSSVMessage, error) {
 	if err := ValidateDecided(
+		ctx,
 		c.config,
 		msg,
 		c.CommitteeMember,
@@ -62,12 +64,15 @@ func (c *Controller) UponDecided(logger *zap.Logger, msg *specqbft.ProcessingMes
 	}
 
 	if !prevDecided {
+		// the round is part of the decided message, so it's observed the same by every operator
+		c.observeDecision(msg.QBFTMessage.Height, msg.QBFTMessage.Round)
 		return msg.SignedMessage, nil
 	}
 	return nil, nil
 }
 
 func ValidateDecided(
+	ctx context.Context,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
 	committeeMember *spectypes.CommitteeMember,
@@ -80,7 +85,7 @@ func ValidateDecided(
 		return errors.New("not a decided msg")
 	}
 
-	if err := instance.BaseCommitValidationVerifySignature(config, msg, msg.QBFTMessage.Height, committeeMember.Committee); err != nil {
+	if err := instance.BaseCommitValidationVerifySignature(ctx, config, msg, msg.QBFTMessage.Height, committeeMember.Committee); err != nil {
 		return errors.Wrap(err, "invalid decided msg")
 	}
 
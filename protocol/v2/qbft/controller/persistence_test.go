@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// testInstanceStateStore keeps the states encoded, as they would be in the database
+type testInstanceStateStore struct {
+	states map[string][]byte
+	saves  int
+}
+
+func (s *testInstanceStateStore) SaveInstanceState(state *specqbft.State) error {
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.states[string(state.ID)] = encoded
+	s.saves++
+	return nil
+}
+
+func (s *testInstanceStateStore) GetInstanceState(identifier []byte) (*specqbft.State, error) {
+	encoded, ok := s.states[string(identifier)]
+	if !ok {
+		return nil, nil
+	}
+	state := &specqbft.State{}
+	return state, json.Unmarshal(encoded, state)
+}
+
+func TestController_ResumeSavedInstance(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx := context.Background()
+
+	keySet := spectestingutils.Testing4SharesSet()
+	store := &testInstanceStateStore{states: make(map[string][]byte)}
+	identifier := make([]byte, 56)
+	identifier[0] = 1
+
+	newController := func() (*Controller, *spectestingutils.TestingNetwork) {
+		network := spectestingutils.NewTestingNetwork(1, keySet.OperatorKeys[1])
+		config := &qbft.Config{
+			BeaconSigner:       spectestingutils.NewTestingKeyManager(),
+			Domain:             spectestingutils.TestingSSVDomainType,
+			ValueCheckF:        func([]byte) error { return nil },
+			ProposerF:          qbft.RoundRobinProposer,
+			Network:            network,
+			Timer:              roundtimer.NewTestingTimer(),
+			CutOffRound:        spectestingutils.TestingCutOffRound,
+			InstanceStateStore: store,
+		}
+		member := spectestingutils.TestingCommitteeMember(keySet)
+		return NewController(identifier, member, config, spectestingutils.TestingOperatorSigner(keySet), false), network
+	}
+
+	// operator 1 leads the first round of height 8, so it proposes when starting the instance
+	const height = specqbft.Height(8)
+	ctrl, network := newController()
+	require.NoError(t, ctrl.StartNewInstance(ctx, logger, height, spectestingutils.TestingQBFTFullData))
+	require.Len(t, network.BroadcastedMsgs, 1)
+	require.Equal(t, 1, store.saves)
+
+	saved, err := store.GetInstanceState(identifier)
+	require.NoError(t, err)
+	require.Equal(t, height, saved.Height)
+	require.Equal(t, specqbft.FirstRound, saved.Round)
+
+	// a timeout moves the instance to the next round, which is saved
+	inst := ctrl.StoredInstances.FindInstance(height)
+	require.NoError(t, inst.UponRoundTimeout(ctx, logger))
+	saved, err = store.GetInstanceState(identifier)
+	require.NoError(t, err)
+	require.Equal(t, specqbft.Round(2), saved.Round)
+	require.Equal(t, 2, store.saves)
+
+	// after a restart the instance resumes in the saved round without proposing a different value
+	restarted, network := newController()
+	require.NoError(t, restarted.StartNewInstance(ctx, logger, height, []byte{1, 2, 3}))
+	require.Empty(t, network.BroadcastedMsgs)
+	resumed := restarted.StoredInstances.FindInstance(height)
+	require.Equal(t, specqbft.Round(2), resumed.State.Round)
+	require.Equal(t, 2, store.saves)
+
+	// instances of other heights start over
+	restarted, network = newController()
+	require.NoError(t, restarted.StartNewInstance(ctx, logger, height+4, spectestingutils.TestingQBFTFullData))
+	require.Len(t, network.BroadcastedMsgs, 1)
+	require.Equal(t, specqbft.FirstRound, restarted.StoredInstances.FindInstance(height+4).State.Round)
+}
+
+func TestController_PersistOnlyBeforeBroadcasting(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx := context.Background()
+
+	keySet := spectestingutils.Testing4SharesSet()
+	store := &testInstanceStateStore{states: make(map[string][]byte)}
+	identifier := make([]byte, 56)
+	identifier[0] = 1
+
+	network := spectestingutils.NewTestingNetwork(1, keySet.OperatorKeys[1])
+	ctrl := newPersistingController(keySet, identifier, network, store)
+
+	const height = specqbft.Height(8)
+	require.NoError(t, ctrl.StartNewInstance(ctx, logger, height, spectestingutils.TestingQBFTFullData))
+	require.Equal(t, 1, store.saves)
+
+	// a single round change doesn't make the operator change round, so nothing is broadcast nor saved
+	roundChange := signedTestMessage(keySet, 2, specqbft.RoundChangeMsgType, identifier, height, 2)
+	_, err := ctrl.ProcessMsg(ctx, logger, roundChange)
+	require.NoError(t, err)
+	require.Len(t, network.BroadcastedMsgs, 1)
+	require.Equal(t, 1, store.saves)
+
+	// accepting the proposal is saved before the prepare is broadcast
+	proposal := signedTestMessage(keySet, 1, specqbft.ProposalMsgType, identifier, height, specqbft.FirstRound)
+	_, err = ctrl.ProcessMsg(ctx, logger, proposal)
+	require.NoError(t, err)
+	require.Len(t, network.BroadcastedMsgs, 2)
+	require.Equal(t, 2, store.saves)
+}
+
+// BenchmarkController_Decide runs full instances, from the proposal to the decision,
+// without persisting their state and with their state persisted to the database.
+func BenchmarkController_Decide(b *testing.B) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	keySet := spectestingutils.Testing4SharesSet()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(b, err)
+	b.Cleanup(func() { _ = db.Close() })
+
+	// every run of a benchmark uses a different identifier, so that it doesn't resume the instances saved by earlier runs
+	runs := 0
+	for _, bm := range []struct {
+		name  string
+		store qbft.InstanceStateStore
+	}{
+		{name: "no store"},
+		{name: "db store", store: ibftstorage.New(db, "benchmark")},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			runs++
+			identifier := make([]byte, 56)
+			identifier[0] = 1
+			identifier[1] = byte(runs)
+			network := spectestingutils.NewTestingNetwork(1, keySet.OperatorKeys[1])
+			ctrl := newPersistingController(keySet, identifier, network, bm.store)
+
+			for i := 0; i < b.N; i++ {
+				// operator 1 leads the first round of heights which are multiples of the committee size
+				height := specqbft.Height(4 * (i + 1))
+
+				b.StopTimer()
+				msgs := []*spectypes.SignedSSVMessage{
+					signedTestMessage(keySet, 1, specqbft.ProposalMsgType, identifier, height, specqbft.FirstRound),
+				}
+				for _, msgType := range []specqbft.MessageType{specqbft.PrepareMsgType, specqbft.CommitMsgType} {
+					for id := spectypes.OperatorID(1); id <= 3; id++ {
+						msgs = append(msgs, signedTestMessage(keySet, id, msgType, identifier, height, specqbft.FirstRound))
+					}
+				}
+				b.StartTimer()
+
+				require.NoError(b, ctrl.StartNewInstance(ctx, logger, height, spectestingutils.TestingQBFTFullData))
+				for _, msg := range msgs {
+					_, err := ctrl.ProcessMsg(ctx, logger, msg)
+					require.NoError(b, err)
+				}
+				require.True(b, ctrl.StoredInstances.FindInstance(height).State.Decided)
+			}
+		})
+	}
+}
+
+func newPersistingController(
+	keySet *spectestingutils.TestKeySet,
+	identifier []byte,
+	network *spectestingutils.TestingNetwork,
+	store qbft.InstanceStateStore,
+) *Controller {
+	config := &qbft.Config{
+		BeaconSigner:       spectestingutils.NewTestingKeyManager(),
+		Domain:             spectestingutils.TestingSSVDomainType,
+		ValueCheckF:        func([]byte) error { return nil },
+		ProposerF:          qbft.RoundRobinProposer,
+		Network:            network,
+		Timer:              roundtimer.NewTestingTimer(),
+		CutOffRound:        spectestingutils.TestingCutOffRound,
+		InstanceStateStore: store,
+	}
+	member := spectestingutils.TestingCommitteeMember(keySet)
+	return NewController(identifier, member, config, spectestingutils.TestingOperatorSigner(keySet), false)
+}
+
+// signedTestMessage returns a message of the given operator for the testing full data
+func signedTestMessage(
+	keySet *spectestingutils.TestKeySet,
+	id spectypes.OperatorID,
+	msgType specqbft.MessageType,
+	identifier []byte,
+	height specqbft.Height,
+	round specqbft.Round,
+) *spectypes.SignedSSVMessage {
+	msg := spectestingutils.SignQBFTMsg(keySet.OperatorKeys[id], id, &specqbft.Message{
+		MsgType:    msgType,
+		Height:     height,
+		Round:      round,
+		Identifier: identifier,
+		Root:       spectestingutils.TestingQBFTRootData,
+	})
+	if msgType == specqbft.ProposalMsgType {
+		msg.FullData = spectestingutils.TestingQBFTFullData
+	}
+	return msg
+}
@@ -159,6 +159,7 @@ func baseCommitValidationIgnoreSignature(
 }
 
 func BaseCommitValidationVerifySignature(
+	ctx context.Context,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
 	height specqbft.Height,
@@ -170,7 +171,7 @@ func BaseCommitValidationVerifySignature(
 	}
 
 	// verify signature
-	if err := spectypes.Verify(msg.SignedMessage, operators); err != nil {
+	if err := config.GetSignatureVerifier().Verify(ctx, msg.SignedMessage, operators); err != nil {
 		return errors.Wrap(err, "msg signature invalid")
 	}
 
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
@@ -29,7 +30,14 @@ type Instance struct {
 	forceStop  bool
 	StartValue []byte
 
+	// persisted is the checkpoint of the last saved state
+	persisted stateCheckpoint
+
 	metrics *metrics
+
+	// traceParent is the span context the instance was started with, parent of the spans of its rounds.
+	traceParent trace.SpanContext
+	roundSpan   trace.Span
 }
 
 func NewInstance(
@@ -67,14 +75,16 @@ func NewInstance(
 
 func (i *Instance) ForceStop() {
 	i.forceStop = true
+	i.endRoundSpan(decidedAttribute(i.State.Decided))
 }
 
 // Start is an interface implementation
 func (i *Instance) Start(ctx context.Context, logger *zap.Logger, value []byte, height specqbft.Height) {
 	i.startOnce.Do(func() {
 		i.StartValue = value
-		i.bumpToRound(ctx, specqbft.FirstRound)
 		i.State.Height = height
+		i.traceParent = trace.SpanContextFromContext(ctx)
+		i.bumpToRound(ctx, specqbft.FirstRound)
 		i.metrics.StartStage()
 		i.config.GetTimer().TimeoutForRound(height, specqbft.FirstRound)
 
@@ -111,10 +121,16 @@ func (i *Instance) Start(ctx context.Context, logger *zap.Logger, value []byte,
 }
 
 func (i *Instance) Broadcast(logger *zap.Logger, msg *spectypes.SignedSSVMessage) error {
+	return i.broadcast(logger, msg, i.State.Round)
+}
+
+// broadcast persists the state as of the given round, which is the round of the message, and broadcasts the message
+func (i *Instance) broadcast(logger *zap.Logger, msg *spectypes.SignedSSVMessage, round specqbft.Round) error {
 	if !i.CanProcessMessages() {
 		return errors.New("instance stopped processing messages")
 	}
 
+	i.persistState(logger, round)
 	return i.GetConfig().GetNetwork().Broadcast(msg.SSVMessage.GetID(), msg)
 }
 
@@ -132,7 +148,7 @@ func (i *Instance) ProcessMsg(ctx context.Context, logger *zap.Logger, msg *spec
 		return false, nil, nil, errors.New("instance stopped processing messages")
 	}
 
-	if err := i.BaseMsgValidation(msg); err != nil {
+	if err := i.BaseMsgValidation(ctx, msg); err != nil {
 		return false, nil, nil, errors.Wrap(err, "invalid signed message")
 	}
 
@@ -148,6 +164,7 @@ func (i *Instance) ProcessMsg(ctx context.Context, logger *zap.Logger, msg *spec
 			if decided {
 				i.State.Decided = decided
 				i.State.DecidedValue = decidedValue
+				i.endRoundSpan(decidedAttribute(true))
 			}
 			return err
 		case specqbft.RoundChangeMsgType:
@@ -162,7 +179,7 @@ func (i *Instance) ProcessMsg(ctx context.Context, logger *zap.Logger, msg *spec
 	return i.State.Decided, i.State.DecidedValue, aggregatedCommit, nil
 }
 
-func (i *Instance) BaseMsgValidation(msg *specqbft.ProcessingMessage) error {
+func (i *Instance) BaseMsgValidation(ctx context.Context, msg *specqbft.ProcessingMessage) error {
 	if err := msg.Validate(); err != nil {
 		return err
 	}
@@ -174,6 +191,7 @@ func (i *Instance) BaseMsgValidation(msg *specqbft.ProcessingMessage) error {
 	switch msg.QBFTMessage.MsgType {
 	case specqbft.ProposalMsgType:
 		return isValidProposal(
+			ctx,
 			i.State,
 			i.config,
 			msg,
@@ -205,7 +223,7 @@ func (i *Instance) BaseMsgValidation(msg *specqbft.ProcessingMessage) error {
 			i.State.CommitteeMember.Committee,
 		)
 	case specqbft.RoundChangeMsgType:
-		return validRoundChangeForDataIgnoreSignature(i.State, i.config, msg, i.State.Height, msg.QBFTMessage.Round, msg.SignedMessage.FullData)
+		return validRoundChangeForDataIgnoreSignature(ctx, i.State, i.config, msg, i.State.Height, msg.QBFTMessage.Round, msg.SignedMessage.FullData)
 	default:
 		return errors.New("signed message type not supported")
 	}
@@ -249,9 +267,10 @@ func (i *Instance) Decode(data []byte) error {
 	return json.Unmarshal(data, &i)
 }
 
-// bumpToRound sets round and sends current round metrics.
+// bumpToRound sets round and starts the span of the round.
 func (i *Instance) bumpToRound(ctx context.Context, round specqbft.Round) {
 	i.State.Round = round
+	i.startRoundSpan(ctx, round)
 }
 
 // CanProcessMessages will return true if instance can process messages
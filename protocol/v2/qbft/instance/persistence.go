@@ -0,0 +1,95 @@
+package instance
+
+import (
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// stateCheckpoint holds the parts of the state that determine which messages the operator may send,
+// the state is persisted when they changed before the operator sends a message.
+type stateCheckpoint struct {
+	round         specqbft.Round
+	preparedRound specqbft.Round
+	proposalRoot  [32]byte
+}
+
+// checkpoint returns the checkpoint of the state once it's in the given round,
+// which is ahead of the state's round when the operator changes round after broadcasting its round change.
+func (i *Instance) checkpoint(round specqbft.Round) stateCheckpoint {
+	cp := stateCheckpoint{
+		round:         i.State.Round,
+		preparedRound: i.State.LastPreparedRound,
+	}
+	if round > i.State.Round {
+		cp.round = round
+		return cp
+	}
+	if proposal := i.State.ProposalAcceptedForCurrentRound; proposal != nil && proposal.QBFTMessage != nil {
+		cp.proposalRoot = proposal.QBFTMessage.Root
+	}
+	return cp
+}
+
+// persistState saves the compacted state, as of the given round, if it changed since it was last saved.
+// It's only called before broadcasting, which is the only transition crash recovery depends on:
+// the messages of the operator are always backed by a saved state, while processing messages
+// that don't lead to a broadcast doesn't write to the database.
+func (i *Instance) persistState(logger *zap.Logger, round specqbft.Round) {
+	store := i.config.GetInstanceStateStore()
+	if store == nil {
+		return
+	}
+
+	cp := i.checkpoint(round)
+	if cp == i.persisted {
+		return
+	}
+
+	state := CompactCopy(i.State, nil)
+	if round > state.Round {
+		state.Round = round
+		state.ProposalAcceptedForCurrentRound = nil
+	}
+	if err := store.SaveInstanceState(state); err != nil {
+		logger.Warn("❗ failed to save instance state", fields.Height(state.Height), fields.Round(state.Round), zap.Error(err))
+		return
+	}
+	i.persisted = cp
+}
+
+// Resume starts the instance from a state saved before a restart, continuing in the saved round
+// with the saved prepared value and accepted proposal.
+// Unlike Start it doesn't propose, as the operator might have proposed a different value in that round before the restart.
+// The decision isn't restored, decided messages of the committee decide the instance again.
+func (i *Instance) Resume(logger *zap.Logger, value []byte, saved *specqbft.State) {
+	i.startOnce.Do(func() {
+		i.StartValue = value
+		i.State.Round = saved.Round
+		i.State.LastPreparedRound = saved.LastPreparedRound
+		i.State.LastPreparedValue = saved.LastPreparedValue
+		i.State.ProposalAcceptedForCurrentRound = saved.ProposalAcceptedForCurrentRound
+		if saved.ProposeContainer != nil {
+			i.State.ProposeContainer = saved.ProposeContainer
+		}
+		if saved.PrepareContainer != nil {
+			i.State.PrepareContainer = saved.PrepareContainer
+		}
+		if saved.CommitContainer != nil {
+			i.State.CommitContainer = saved.CommitContainer
+		}
+		if saved.RoundChangeContainer != nil {
+			i.State.RoundChangeContainer = saved.RoundChangeContainer
+		}
+		i.persisted = i.checkpoint(i.State.Round)
+
+		i.metrics.StartStage()
+		i.config.GetTimer().TimeoutForRound(i.State.Height, i.State.Round)
+
+		logger.Info("ℹ️ resuming QBFT instance from saved state",
+			fields.Height(i.State.Height),
+			fields.Round(i.State.Round),
+			zap.Uint64("prepared_round", uint64(i.State.LastPreparedRound)))
+	})
+}
@@ -139,6 +139,7 @@ func validSignedPrepareForHeightRoundAndRootIgnoreSignature(
 }
 
 func validSignedPrepareForHeightRoundAndRootVerifySignature(
+	ctx context.Context,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
 	height specqbft.Height,
@@ -151,7 +152,7 @@ func validSignedPrepareForHeightRoundAndRootVerifySignature(
 	}
 
 	// Verify signature
-	if err := spectypes.Verify(msg.SignedMessage, operators); err != nil {
+	if err := config.GetSignatureVerifier().Verify(ctx, msg.SignedMessage, operators); err != nil {
 		return errors.Wrap(err, "msg signature invalid")
 	}
 
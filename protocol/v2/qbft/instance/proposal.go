@@ -63,6 +63,7 @@ func (i *Instance) uponProposal(ctx context.Context, logger *zap.Logger, msg *sp
 }
 
 func isValidProposal(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
@@ -121,6 +122,7 @@ func isValidProposal(
 	}
 
 	if err := isProposalJustification(
+		ctx,
 		state,
 		config,
 		roundChangeJustification,
@@ -141,6 +143,7 @@ func isValidProposal(
 }
 
 func IsProposalJustification(
+	ctx context.Context,
 	config qbft.IConfig,
 	committeeMember *spectypes.CommitteeMember,
 	roundChangeMsgs []*specqbft.ProcessingMessage,
@@ -150,6 +153,7 @@ func IsProposalJustification(
 	fullData []byte,
 ) error {
 	return isProposalJustification(
+		ctx,
 		&specqbft.State{
 			CommitteeMember: committeeMember,
 			Height:          height,
@@ -166,6 +170,7 @@ func IsProposalJustification(
 
 // isProposalJustification returns nil if the proposal and round change messages are valid and justify a proposal message for the provided round, value and leader
 func isProposalJustification(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	roundChangeMsgs []*specqbft.ProcessingMessage,
@@ -186,7 +191,7 @@ func isProposalJustification(
 		// no quorum, duplicate signers,  invalid still has quorum, invalid no quorum
 		// prepared
 		for _, rc := range roundChangeMsgs {
-			if err := validRoundChangeForDataVerifySignature(state, config, rc, height, round, fullData); err != nil {
+			if err := validRoundChangeForDataVerifySignature(ctx, state, config, rc, height, round, fullData); err != nil {
 				return errors.Wrap(err, "change round msg not valid")
 			}
 		}
@@ -239,6 +244,7 @@ func isProposalJustification(
 			// validate each prepare message against the highest previously prepared fullData and round
 			for _, pm := range prepareMsgs {
 				if err := validSignedPrepareForHeightRoundAndRootVerifySignature(
+					ctx,
 					config,
 					pm,
 					height,
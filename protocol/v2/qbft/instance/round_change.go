@@ -49,6 +49,7 @@ func (i *Instance) uponRoundChange(
 		zap.Any("round_change_signers", msg.SignedMessage.OperatorIDs))
 
 	justifiedRoundChangeMsg, valueToPropose, err := hasReceivedProposalJustificationForLeadingRound(
+		ctx,
 		i.State,
 		i.config,
 		instanceStartValue,
@@ -152,6 +153,7 @@ func hasReceivedPartialQuorum(state *specqbft.State, roundChangeMsgContainer *sp
 // if received round change msgs with prepare justification - returns the highest prepare justification round change msg and value to propose
 // (all the above considering the operator is a leader for the round
 func hasReceivedProposalJustificationForLeadingRound(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	instanceStartValue []byte,
@@ -188,6 +190,7 @@ func hasReceivedProposalJustificationForLeadingRound(
 		}
 
 		if isProposalJustificationForLeadingRound(
+			ctx,
 			state,
 			config,
 			containerRoundChangeMessage,
@@ -206,6 +209,7 @@ func hasReceivedProposalJustificationForLeadingRound(
 
 // isProposalJustificationForLeadingRound - returns nil if we have a quorum of round change msgs and highest justified value for leading round
 func isProposalJustificationForLeadingRound(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	roundChangeMsg *specqbft.ProcessingMessage,
@@ -216,6 +220,7 @@ func isProposalJustificationForLeadingRound(
 	newRound specqbft.Round,
 ) error {
 	if err := isReceivedProposalJustification(
+		ctx,
 		state,
 		config,
 		roundChanges,
@@ -242,6 +247,7 @@ func isProposalJustificationForLeadingRound(
 
 // isReceivedProposalJustification - returns nil if we have a quorum of round change msgs and highest justified value
 func isReceivedProposalJustification(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	roundChanges, prepares []*specqbft.ProcessingMessage,
@@ -250,6 +256,7 @@ func isReceivedProposalJustification(
 	valCheck specqbft.ProposedValueCheckF,
 ) error {
 	if err := isProposalJustification(
+		ctx,
 		state,
 		config,
 		roundChanges,
@@ -265,6 +272,7 @@ func isReceivedProposalJustification(
 }
 
 func validRoundChangeForDataIgnoreSignature(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
@@ -315,6 +323,7 @@ func validRoundChangeForDataIgnoreSignature(
 
 		for _, pm := range prepareMsgs {
 			if err := validSignedPrepareForHeightRoundAndRootVerifySignature(
+				ctx,
 				config,
 				pm,
 				state.Height,
@@ -344,6 +353,7 @@ func validRoundChangeForDataIgnoreSignature(
 }
 
 func validRoundChangeForDataVerifySignature(
+	ctx context.Context,
 	state *specqbft.State,
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
@@ -351,12 +361,12 @@ func validRoundChangeForDataVerifySignature(
 	round specqbft.Round,
 	fullData []byte,
 ) error {
-	if err := validRoundChangeForDataIgnoreSignature(state, config, msg, height, round, fullData); err != nil {
+	if err := validRoundChangeForDataIgnoreSignature(ctx, state, config, msg, height, round, fullData); err != nil {
 		return err
 	}
 
 	// Verify signature
-	if err := spectypes.Verify(msg.SignedMessage, state.CommitteeMember.Committee); err != nil {
+	if err := config.GetSignatureVerifier().Verify(ctx, msg.SignedMessage, state.CommitteeMember.Committee); err != nil {
 		return errors.Wrap(err, "msg signature invalid")
 	}
 
@@ -43,7 +43,8 @@ func (i *Instance) UponRoundTimeout(ctx context.Context, logger *zap.Logger) err
 		fields.Height(i.State.Height),
 		zap.String("reason", "timeout"))
 
-	if err := i.Broadcast(logger, roundChange); err != nil {
+	// the round is bumped after broadcasting, but the state is saved in the new round as the operator already left the current one
+	if err := i.broadcast(logger, roundChange, newRound); err != nil {
 		return errors.Wrap(err, "failed to broadcast round change message")
 	}
 
@@ -0,0 +1,51 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+var tracer = otel.Tracer(observabilityName)
+
+// startRoundSpan ends the span of the current round and starts the span of the given one,
+// as a child of the span the instance was started with.
+func (i *Instance) startRoundSpan(ctx context.Context, round specqbft.Round) {
+	i.endRoundSpan()
+	_, i.roundSpan = tracer.Start(trace.ContextWithSpanContext(ctx, i.traceParent), spanName("consensus.round"),
+		trace.WithAttributes(
+			roleAttribute(i.metrics.role),
+			observability.DutyRoundAttribute(round),
+			heightAttribute(i.State.Height)))
+}
+
+// endRoundSpan ends the span of the current round, if any, with the given attributes.
+func (i *Instance) endRoundSpan(attrs ...attribute.KeyValue) {
+	if i.roundSpan == nil {
+		return
+	}
+	i.roundSpan.SetAttributes(attrs...)
+	i.roundSpan.End()
+	i.roundSpan = nil
+}
+
+func heightAttribute(height specqbft.Height) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ssv.validator.duty.height",
+		Value: observability.Uint64AttributeValue(uint64(height)),
+	}
+}
+
+func decidedAttribute(decided bool) attribute.KeyValue {
+	return attribute.Bool("ssv.validator.duty.decided", decided)
+}
+
+func spanName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
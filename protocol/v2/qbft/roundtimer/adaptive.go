@@ -0,0 +1,213 @@
+package roundtimer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+
+	"github.com/ssvlabs/ssv/utils/casts"
+)
+
+// DefaultAdaptiveTimeoutConfig is the adaptive policy used when the network schedules adaptive round timeouts
+var DefaultAdaptiveTimeoutConfig = AdaptiveTimeoutConfig{
+	Base:         QuickTimeout,
+	PerFaulty:    250 * time.Millisecond,
+	Growth:       500 * time.Millisecond,
+	Min:          QuickTimeout,
+	Max:          2 * QuickTimeout,
+	RoundsInSlot: 2,
+	ProbeEvery:   3,
+	Window:       2,
+	MinSamples:   12,
+	LearnStep:    500 * time.Millisecond,
+	LearnSteps:   4,
+}
+
+// AdaptiveTimeoutConfig configures how quick-round timeouts are derived from the committee size and the round.
+type AdaptiveTimeoutConfig struct {
+	// Base is the timeout of the first round of a committee of 4 operators
+	Base time.Duration
+	// PerFaulty is added for every faulty operator the committee tolerates beyond one,
+	// as larger committees take longer to gather a quorum
+	PerFaulty time.Duration
+	// Growth is added for every round after the first, so that rounds which keep failing wait longer
+	Growth time.Duration
+	// Min and Max bound the timeout
+	Min time.Duration
+	Max time.Duration
+	// RoundsInSlot is the number of rounds that must fit in the rest of the slot once the instance started,
+	// it bounds the timeout so that the first round changes happen within the duty's slot
+	RoundsInSlot uint64
+
+	// ProbeEvery makes every ProbeEvery-th slot a probe slot, whose instances keep the timeouts derived from the
+	// committee size. the timeouts are learned from the instances of probe slots only, so what is learned doesn't
+	// depend on the learned timeouts. it shouldn't divide committee sizes, so that probe slots rotate the leaders.
+	// zero disables learning.
+	ProbeEvery uint64
+	// Window is the number of epochs of probe instances the timeouts are learned from. the window ends an epoch
+	// before the current one, so that the decisions of its instances reached every operator.
+	Window uint64
+	// MinSamples is the number of decided probe instances in the window below which nothing is learned
+	MinSamples uint64
+	// LearnStep is added LearnSteps times at most, in proportion to the share of probe instances that
+	// didn't decide in their first round beyond those that faulty leaders account for
+	LearnStep  time.Duration
+	LearnSteps uint64
+}
+
+// AdaptiveTimeouts derives the quick-round timeouts of roles whose rounds are aligned to the slot,
+// and learns the decision latency of a committee from its recently decided instances.
+//
+// The timeout only depends on inputs which every operator of the committee shares: the slot, the round,
+// the committee size, the offset into the slot at which the role's instances start and the rounds in which
+// recent instances were decided, which are part of the decided messages. So all the operators of a committee
+// change rounds at the same time, as with fixed timeouts. Decisions are only kept in memory, so a restarted
+// operator uses the timeouts derived from the committee size until it observed a window of decisions again.
+//
+// An instance of a probe slot that didn't decide in its first round shows that the committee needed more than
+// the timeout derived from its size to gather a quorum, or that the leader of the first round was faulty.
+// Up to a faulty leader's share of the instances are attributed to faulty leaders, so that a crashed operator
+// doesn't make the committee wait longer for it.
+type AdaptiveTimeouts struct {
+	cfg           AdaptiveTimeoutConfig
+	slotsPerEpoch uint64
+	slotDuration  time.Duration
+	forkEpoch     phase0.Epoch
+
+	mtx sync.Mutex
+	// decided holds the round in which the instances of recent probe slots were decided
+	decided map[phase0.Slot]specqbft.Round
+}
+
+// NewAdaptiveTimeouts creates a new AdaptiveTimeouts, which are active from forkEpoch onwards
+func NewAdaptiveTimeouts(cfg AdaptiveTimeoutConfig, slotsPerEpoch uint64, slotDuration time.Duration, forkEpoch phase0.Epoch) *AdaptiveTimeouts {
+	return &AdaptiveTimeouts{
+		cfg:           cfg,
+		slotsPerEpoch: slotsPerEpoch,
+		slotDuration:  slotDuration,
+		forkEpoch:     forkEpoch,
+		decided:       make(map[phase0.Slot]specqbft.Round),
+	}
+}
+
+// Decided records the round in which the instance of the given slot was decided,
+// only the first decision of the instances of probe slots from the fork onwards is kept.
+func (at *AdaptiveTimeouts) Decided(slot phase0.Slot, round specqbft.Round) {
+	if !at.probe(slot) || at.epoch(slot) < at.forkEpoch {
+		return
+	}
+
+	at.mtx.Lock()
+	defer at.mtx.Unlock()
+
+	if _, ok := at.decided[slot]; ok {
+		return
+	}
+	at.decided[slot] = round
+
+	// instances that are out of the windows of the slot's epoch and of the previous one are no longer needed
+	if from, _ := at.window(at.epoch(slot)); uint64(from) > at.slotsPerEpoch {
+		for s := range at.decided {
+			if uint64(s) < uint64(from)-at.slotsPerEpoch {
+				delete(at.decided, s)
+			}
+		}
+	}
+}
+
+// QuickTimeout returns the timeout of the given quick round of an instance of the given slot,
+// run by a committee of the given size and started at base into the slot.
+func (at *AdaptiveTimeouts) QuickTimeout(slot phase0.Slot, round specqbft.Round, committeeSize int, base time.Duration) time.Duration {
+	if at.epoch(slot) < at.forkEpoch {
+		return QuickTimeout
+	}
+
+	timeout := at.cfg.Base
+	if faulty := (committeeSize - 1) / 3; faulty > 1 {
+		timeout += time.Duration(faulty-1) * at.cfg.PerFaulty
+	}
+	if round > specqbft.FirstRound {
+		timeout += casts.DurationFromUint64(uint64(round-specqbft.FirstRound)) * at.cfg.Growth
+	}
+	if !at.probe(slot) {
+		timeout += at.learned(at.epoch(slot), committeeSize)
+	}
+	timeout = min(max(timeout, at.cfg.Min), at.cfg.Max)
+
+	if at.cfg.RoundsInSlot > 0 && base < at.slotDuration {
+		timeout = min(timeout, (at.slotDuration-base)/casts.DurationFromUint64(at.cfg.RoundsInSlot))
+	}
+	return timeout
+}
+
+// learned returns the timeout learned for the instances of the given epoch from the decided probe instances in its window
+func (at *AdaptiveTimeouts) learned(epoch phase0.Epoch, committeeSize int) time.Duration {
+	from, to := at.window(epoch)
+	if to <= from || committeeSize <= 0 {
+		return 0
+	}
+
+	at.mtx.Lock()
+	var samples, late uint64
+	for slot, round := range at.decided {
+		if slot < from || slot >= to {
+			continue
+		}
+		samples++
+		if round > specqbft.FirstRound {
+			late++
+		}
+	}
+	at.mtx.Unlock()
+
+	if samples == 0 || samples < at.cfg.MinSamples {
+		return 0
+	}
+	// the share of the instances whose first-round leader might have been faulty, rounded up
+	size := uint64(committeeSize) // #nosec G115
+	faultyLeaders := ((size-1)/3*samples + size - 1) / size
+	if late <= faultyLeaders {
+		return 0
+	}
+	excess, rest := late-faultyLeaders, samples-faultyLeaders
+	steps := (excess*at.cfg.LearnSteps + rest - 1) / rest
+	return casts.DurationFromUint64(steps) * at.cfg.LearnStep
+}
+
+// window returns the range of slots [from, to) whose decided probe instances the timeouts of the given epoch are learned from,
+// it doesn't reach before the fork.
+func (at *AdaptiveTimeouts) window(epoch phase0.Epoch) (phase0.Slot, phase0.Slot) {
+	if uint64(epoch) < uint64(at.forkEpoch)+1 {
+		return 0, 0
+	}
+	end := uint64(epoch) - 1
+	start := uint64(at.forkEpoch)
+	if end > at.cfg.Window && end-at.cfg.Window > start {
+		start = end - at.cfg.Window
+	}
+	return phase0.Slot(start * at.slotsPerEpoch), phase0.Slot(end * at.slotsPerEpoch)
+}
+
+// probe returns true if the instances of the given slot keep the timeouts derived from the committee size
+func (at *AdaptiveTimeouts) probe(slot phase0.Slot) bool {
+	return at.cfg.ProbeEvery > 0 && uint64(slot)%at.cfg.ProbeEvery == 0
+}
+
+func (at *AdaptiveTimeouts) epoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / at.slotsPerEpoch)
+}
+
+// RoundEnd returns the offset from the start of the slot at which the given round of an instance times out,
+// for instances that start at base into the slot and whose quick rounds time out after quick.
+func RoundEnd(base time.Duration, round specqbft.Round, quick func(round specqbft.Round) time.Duration) time.Duration {
+	end := base
+	for r := specqbft.FirstRound; r <= round && r <= QuickTimeoutThreshold; r++ {
+		end += quick(r)
+	}
+	if round > QuickTimeoutThreshold {
+		end += casts.DurationFromUint64(uint64(round-QuickTimeoutThreshold)) * SlowTimeout
+	}
+	return end
+}
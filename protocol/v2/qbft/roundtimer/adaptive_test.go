@@ -0,0 +1,115 @@
+package roundtimer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer/mocks"
+)
+
+const testSlotsPerEpoch = 4
+
+func TestAdaptiveTimeouts_QuickTimeout(t *testing.T) {
+	at := NewAdaptiveTimeouts(DefaultAdaptiveTimeoutConfig, testSlotsPerEpoch, 12*time.Second, 1)
+	committeeBase := 4 * time.Second
+
+	// not active before the fork
+	require.Equal(t, QuickTimeout, at.QuickTimeout(3, 3, 13, committeeBase))
+
+	// grows with the round
+	require.Equal(t, 2*time.Second, at.QuickTimeout(4, specqbft.FirstRound, 4, committeeBase))
+	require.Equal(t, 2500*time.Millisecond, at.QuickTimeout(4, 2, 4, committeeBase))
+
+	// grows with the committee size
+	require.Equal(t, 2250*time.Millisecond, at.QuickTimeout(4, specqbft.FirstRound, 7, committeeBase))
+	require.Equal(t, 2750*time.Millisecond, at.QuickTimeout(4, specqbft.FirstRound, 13, committeeBase))
+
+	// bounded by Max
+	require.Equal(t, 4*time.Second, at.QuickTimeout(4, QuickTimeoutThreshold, 13, committeeBase))
+
+	// bounded so that the first rounds of instances which start late in the slot end within it
+	require.Equal(t, 2*time.Second, at.QuickTimeout(4, 3, 4, 8*time.Second))
+	require.LessOrEqual(t, RoundEnd(8*time.Second, 2, func(r specqbft.Round) time.Duration {
+		return at.QuickTimeout(4, r, 13, 8*time.Second)
+	}), 12*time.Second)
+}
+
+func TestAdaptiveTimeouts_Learned(t *testing.T) {
+	const slotsPerEpoch = 12
+	cfg := DefaultAdaptiveTimeoutConfig
+	cfg.MinSamples = 4
+	committeeBase := 4 * time.Second
+
+	// the timeouts of epoch 4 are learned from the probe instances of epochs 1 and 2: slots 12, 15, ..., 33
+	decide := func(late int) *AdaptiveTimeouts {
+		at := NewAdaptiveTimeouts(cfg, slotsPerEpoch, 12*time.Second, 1)
+		for slot := phase0.Slot(0); slot < 4*slotsPerEpoch; slot++ {
+			round := specqbft.FirstRound
+			if slot%3 != 0 || (slot >= 12 && late > 0) {
+				round = 3
+			}
+			if slot%3 == 0 && slot >= 12 && late > 0 {
+				late--
+			}
+			at.Decided(slot, round)
+		}
+		return at
+	}
+
+	// the instances of other slots and those before the window aren't learned from
+	require.Equal(t, 2*time.Second, decide(0).QuickTimeout(49, specqbft.FirstRound, 4, committeeBase))
+
+	// late instances which a faulty leader could account for aren't learned from
+	require.Equal(t, 2*time.Second, decide(2).QuickTimeout(49, specqbft.FirstRound, 4, committeeBase))
+
+	// 3 of the 6 instances beyond the faulty leader's are late, so 2 of the 4 steps are added
+	at := decide(5)
+	require.Equal(t, 3*time.Second, at.QuickTimeout(49, specqbft.FirstRound, 4, committeeBase))
+	require.Equal(t, 3500*time.Millisecond, at.QuickTimeout(49, 2, 4, committeeBase))
+	// probe slots keep the timeouts derived from the committee size
+	require.Equal(t, 2*time.Second, at.QuickTimeout(48, specqbft.FirstRound, 4, committeeBase))
+	// nothing is learned from windows with too few instances
+	require.Equal(t, 2*time.Second, at.QuickTimeout(25, specqbft.FirstRound, 4, committeeBase))
+
+	// bounded by Max
+	at = decide(8)
+	require.Equal(t, 4*time.Second, at.QuickTimeout(49, specqbft.FirstRound, 4, committeeBase))
+	require.Equal(t, 4*time.Second, at.QuickTimeout(49, 2, 4, committeeBase))
+}
+
+func TestRoundTimer_AdaptiveTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	beaconNetwork := mocks.NewMockBeaconNetwork(ctrl)
+	slotStart := time.Now().Add(-4900 * time.Millisecond)
+	beaconNetwork.EXPECT().SlotDurationSec().Return(12 * time.Second).AnyTimes()
+	beaconNetwork.EXPECT().GetSlotStartTime(gomock.Any()).Return(slotStart).AnyTimes()
+
+	at := NewAdaptiveTimeouts(DefaultAdaptiveTimeoutConfig, testSlotsPerEpoch, 12*time.Second, 1)
+	timer := New(context.Background(), beaconNetwork, spectypes.RoleCommittee, nil)
+	timer.SetAdaptiveTimeouts(at, 4)
+
+	// round 2 times out at 4s + 2s + 2.5s into the slot
+	timeout := timer.RoundTimeout(specqbft.Height(testSlotsPerEpoch), specqbft.Round(2))
+	require.InDelta(t, 3600*time.Millisecond, timeout, float64(50*time.Millisecond))
+
+	// before the fork the quick timeout is fixed, 4s + 2 * 2s into the slot
+	timeout = timer.RoundTimeout(specqbft.Height(1), specqbft.Round(2))
+	require.InDelta(t, 3100*time.Millisecond, timeout, float64(50*time.Millisecond))
+
+	// roles whose rounds aren't aligned to the slot aren't adapted
+	proposer := New(context.Background(), beaconNetwork, spectypes.RoleProposer, nil)
+	proposer.SetAdaptiveTimeouts(at, 4)
+	require.Equal(t, QuickTimeout, proposer.RoundTimeout(specqbft.Height(testSlotsPerEpoch), specqbft.FirstRound))
+	proposer.Decided(specqbft.Height(testSlotsPerEpoch), specqbft.Round(3))
+	require.Empty(t, at.decided)
+
+	timer.Decided(specqbft.Height(3*testSlotsPerEpoch), specqbft.Round(3))
+	require.Equal(t, map[phase0.Slot]specqbft.Round{3 * testSlotsPerEpoch: 3}, at.decided)
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
-	"github.com/ssvlabs/ssv/utils/casts"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/timer.go -source=./timer.go
@@ -30,6 +29,12 @@ type Timer interface {
 	TimeoutForRound(height specqbft.Height, round specqbft.Round)
 }
 
+// DecisionObserver is implemented by timers that learn from the rounds in which instances are decided
+type DecisionObserver interface {
+	// Decided is called once the instance of the given height is decided in the given round
+	Decided(height specqbft.Height, round specqbft.Round)
+}
+
 type BeaconNetwork interface {
 	GetSlotStartTime(slot phase0.Slot) time.Time
 	SlotDurationSec() time.Duration
@@ -59,6 +64,10 @@ type RoundTimer struct {
 	role spectypes.RunnerRole
 	// beaconNetwork is the beacon network
 	beaconNetwork BeaconNetwork
+	// adaptive derives the quick timeouts of slot-aligned roles, nil unless adaptive timeouts are enabled
+	adaptive *AdaptiveTimeouts
+	// committeeSize is the number of operators running the instances, used by adaptive timeouts
+	committeeSize int
 }
 
 // New creates a new instance of RoundTimer.
@@ -80,6 +89,16 @@ func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.Runne
 	}
 }
 
+// SetAdaptiveTimeouts enables adaptive quick timeouts for roles whose rounds are aligned to the slot,
+// for instances run by a committee of the given size.
+func (t *RoundTimer) SetAdaptiveTimeouts(at *AdaptiveTimeouts, committeeSize int) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.adaptive = at
+	t.committeeSize = committeeSize
+}
+
 // RoundTimeout calculates the timeout duration for a specific role, height, and round.
 //
 // Timeout Rules:
@@ -89,6 +108,7 @@ func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.Runne
 //
 // Additional Timeout:
 // - For rounds less than or equal to quickThreshold, the additional timeout is 'quick' seconds.
+// - If adaptive timeouts are set, 'quick' is derived from the slot, the round, the committee size and recent decisions.
 // - For rounds greater than quickThreshold, the additional timeout is 'slow' seconds.
 //
 // SIP Reference:
@@ -102,36 +122,18 @@ func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.Runne
 // which is calculated from the slot height. The base timeout is set based on the role,
 // and the additional timeout is added based on the round number.
 func (t *RoundTimer) RoundTimeout(height specqbft.Height, round specqbft.Round) time.Duration {
-	// Initialize duration to zero
-	var baseDuration time.Duration
-
 	// Set base duration based on role
-	switch t.role {
-	case spectypes.RoleCommittee:
-		// third of the slot time
-		baseDuration = t.beaconNetwork.SlotDurationSec() / 3
-	case spectypes.RoleAggregator, spectypes.RoleSyncCommitteeContribution:
-		// two-third of the slot time
-		baseDuration = t.beaconNetwork.SlotDurationSec() / 3 * 2
-	default:
+	baseDuration, slotAligned := t.baseDuration()
+	if !slotAligned {
 		if round <= t.timeoutOptions.quickThreshold {
 			return t.timeoutOptions.quick
 		}
 		return t.timeoutOptions.slow
 	}
-
-	// Calculate additional timeout based on round
-	var additionalTimeout time.Duration
-	if round <= t.timeoutOptions.quickThreshold {
-		additionalTimeout = casts.DurationFromUint64(uint64(round)) * t.timeoutOptions.quick
-	} else {
-		quickPortion := casts.DurationFromUint64(uint64(t.timeoutOptions.quickThreshold)) * t.timeoutOptions.quick
-		slowPortion := casts.DurationFromUint64(uint64(round-t.timeoutOptions.quickThreshold)) * t.timeoutOptions.slow
-		additionalTimeout = quickPortion + slowPortion
-	}
-
-	// Combine base duration and additional timeout
-	timeoutDuration := baseDuration + additionalTimeout
+	// Combine base duration and the timeouts of the rounds so far
+	timeoutDuration := RoundEnd(baseDuration, round, func(r specqbft.Round) time.Duration {
+		return t.quickTimeout(height, r, baseDuration)
+	})
 
 	// Get the start time of the duty
 	dutyStartTime := t.beaconNetwork.GetSlotStartTime(phase0.Slot(height))
@@ -140,6 +142,46 @@ func (t *RoundTimer) RoundTimeout(height specqbft.Height, round specqbft.Round)
 	return time.Until(dutyStartTime.Add(timeoutDuration))
 }
 
+// Decided lets the adaptive timeouts learn from the round in which the instance of the given height was decided,
+// if adaptive timeouts are set and the rounds of the role are aligned to the slot.
+func (t *RoundTimer) Decided(height specqbft.Height, round specqbft.Round) {
+	t.mtx.RLock()
+	adaptive := t.adaptive
+	t.mtx.RUnlock()
+
+	if _, slotAligned := t.baseDuration(); adaptive == nil || !slotAligned {
+		return
+	}
+	adaptive.Decided(phase0.Slot(height), round)
+}
+
+// baseDuration returns the offset from the start of the slot at which instances of the role start,
+// and false if the rounds of the role aren't aligned to the slot.
+func (t *RoundTimer) baseDuration() (time.Duration, bool) {
+	switch t.role {
+	case spectypes.RoleCommittee:
+		// third of the slot time
+		return t.beaconNetwork.SlotDurationSec() / 3, true
+	case spectypes.RoleAggregator, spectypes.RoleSyncCommitteeContribution:
+		// two-third of the slot time
+		return t.beaconNetwork.SlotDurationSec() / 3 * 2, true
+	default:
+		return 0, false
+	}
+}
+
+// quickTimeout returns the timeout of the given quick round of the instance of the given height
+func (t *RoundTimer) quickTimeout(height specqbft.Height, round specqbft.Round, baseDuration time.Duration) time.Duration {
+	t.mtx.RLock()
+	adaptive, committeeSize := t.adaptive, t.committeeSize
+	t.mtx.RUnlock()
+
+	if adaptive == nil {
+		return t.timeoutOptions.quick
+	}
+	return adaptive.QuickTimeout(phase0.Slot(height), round, committeeSize, baseDuration)
+}
+
 // OnTimeout sets a function called on timeout.
 func (t *RoundTimer) OnTimeout(done OnRoundTimeoutF) {
 	t.mtx.Lock() // write to t.done
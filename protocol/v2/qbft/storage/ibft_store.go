@@ -32,6 +32,28 @@ type ParticipantsRangeEntry struct {
 	Identifier convert.MessageID
 }
 
+// ParticipantsProof holds the partial signatures of the quorum that decided, it allows peers
+// that sync decided participants to verify them against the committee shares.
+type ParticipantsProof struct {
+	ValidatorIndex phase0.ValidatorIndex
+	SigningRoot    phase0.Root
+	Signatures     map[spectypes.OperatorID]spectypes.Signature
+	// BeaconVote and CommitteeIndex are the decided vote and attestation committee the signing root was computed from,
+	// so peers can recompute the signing root of the slot and role instead of trusting SigningRoot.
+	BeaconVote     *spectypes.BeaconVote `json:",omitempty"`
+	CommitteeIndex phase0.CommitteeIndex `json:",omitempty"`
+}
+
+// Encode returns a ParticipantsProof encoded bytes or error.
+func (pp *ParticipantsProof) Encode() ([]byte, error) {
+	return json.Marshal(pp)
+}
+
+// Decode returns error if decoding failed.
+func (pp *ParticipantsProof) Decode(data []byte) error {
+	return json.Unmarshal(data, &pp)
+}
+
 // QBFTStore is the store used by QBFT components
 type QBFTStore interface {
 	// CleanAllInstances removes all historical and highest instances for the given identifier.
@@ -45,4 +67,19 @@ type QBFTStore interface {
 
 	// GetParticipants returns participants in quorum for the given slot.
 	GetParticipants(identifier convert.MessageID, slot phase0.Slot) ([]spectypes.OperatorID, error)
+
+	// SaveParticipantsProof saves the partial signatures that formed the quorum for the given slot.
+	SaveParticipantsProof(identifier convert.MessageID, slot phase0.Slot, proof *ParticipantsProof) error
+
+	// GetParticipantsProof returns the proof of the participants for the given slot, or nil if not found.
+	GetParticipantsProof(identifier convert.MessageID, slot phase0.Slot) (*ParticipantsProof, error)
+
+	// GetHighestSlot returns the highest slot that participants of the given identifier were saved for.
+	GetHighestSlot(identifier convert.MessageID) (phase0.Slot, bool, error)
+
+	// SaveInstanceState replaces the saved state of the running instance of the state's identifier.
+	SaveInstanceState(state *specqbft.State) error
+
+	// GetInstanceState returns the saved state of the running instance of the given identifier, or nil if not found.
+	GetInstanceState(identifier []byte) (*specqbft.State, error)
 }
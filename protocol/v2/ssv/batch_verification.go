@@ -0,0 +1,76 @@
+package ssv
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// PendingSignature is a signature of a validator's signing root that is reconstructed
+// from the partial signatures of a quorum.
+type PendingSignature struct {
+	ValidatorIndex  phase0.ValidatorIndex
+	ValidatorPubKey []byte
+	Root            [32]byte
+
+	// Signature is the reconstructed signature, set only if it's valid
+	Signature []byte
+	// Err is set if the signature couldn't be reconstructed or is invalid
+	Err error
+}
+
+// ReconstructAndVerifyBatch reconstructs the given signatures and verifies all of them in a single batch.
+// if the batch fails, every signature is verified on its own so that only the invalid ones fail.
+// signatures that were already verified aren't verified again, and valid ones are kept for ReconstructSignature.
+func (ps *PartialSigContainer) ReconstructAndVerifyBatch(pending []*PendingSignature) {
+	var (
+		batch   = make([]*PendingSignature, 0, len(pending))
+		sigs    = make([]bls.Sign, 0, len(pending))
+		pubKeys = make([]bls.PublicKey, 0, len(pending))
+		roots   = make([][32]byte, 0, len(pending))
+	)
+	for _, p := range pending {
+		if signature, ok := ps.verifiedSignature(p.ValidatorIndex, p.Root); ok {
+			p.Signature = signature
+			continue
+		}
+		sig, err := ps.reconstructUnverifiedSignature(p.Root, p.ValidatorIndex)
+		if err != nil {
+			p.Err = err
+			continue
+		}
+		pk, err := types.DeserializeBLSPublicKey(p.ValidatorPubKey)
+		if err != nil {
+			p.Err = errors.Wrap(err, "could not deserialize validator pk")
+			continue
+		}
+		batch = append(batch, p)
+		sigs = append(sigs, *sig)
+		pubKeys = append(pubKeys, pk)
+		roots = append(roots, p.Root)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	if types.VerifyBatch(sigs, pubKeys, roots) {
+		for i, p := range batch {
+			p.Signature = sigs[i].Serialize()
+			ps.setVerifiedSignature(p.ValidatorIndex, p.Root, p.Signature)
+		}
+		return
+	}
+
+	for i, p := range batch {
+		// the root is copied, since the pending signature holds Go pointers that cgo must not see
+		root := p.Root
+		if !sigs[i].VerifyByte(&pubKeys[i], root[:]) {
+			p.Err = errors.New("failed to verify reconstruct signature: could not reconstruct a valid signature")
+			continue
+		}
+		p.Signature = sigs[i].Serialize()
+		ps.setVerifiedSignature(p.ValidatorIndex, p.Root, p.Signature)
+	}
+}
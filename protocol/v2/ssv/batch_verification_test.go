@@ -0,0 +1,131 @@
+package ssv
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+const (
+	testCommitteeSize = 4
+	testQuorum        = 3
+)
+
+// newTestContainer returns a container with the partial signatures of a quorum for every validator,
+// each validator signing a different root, and the pending signatures to reconstruct.
+func newTestContainer(tb testing.TB, validators int) (*PartialSigContainer, []*PendingSignature) {
+	threshold.Init()
+
+	container := NewPartialSigContainer(testQuorum)
+	pending := make([]*PendingSignature, 0, validators)
+	for i := 0; i < validators; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		shares, err := threshold.Create(sk.Serialize(), testQuorum, testCommitteeSize)
+		require.NoError(tb, err)
+
+		index := phase0.ValidatorIndex(i)
+		root := sha256.Sum256(binary.LittleEndian.AppendUint64(nil, uint64(i)))
+		for signer := spectypes.OperatorID(1); signer <= testQuorum; signer++ {
+			container.AddSignature(&spectypes.PartialSignatureMessage{
+				PartialSignature: shares[signer].SignByte(root[:]).Serialize(),
+				SigningRoot:      root,
+				Signer:           signer,
+				ValidatorIndex:   index,
+			})
+		}
+		pending = append(pending, &PendingSignature{
+			ValidatorIndex:  index,
+			ValidatorPubKey: sk.GetPublicKey().Serialize(),
+			Root:            root,
+		})
+	}
+	return container, pending
+}
+
+func resetPending(pending []*PendingSignature) {
+	for _, p := range pending {
+		p.Signature, p.Err = nil, nil
+	}
+}
+
+func TestPartialSigContainer_ReconstructAndVerifyBatch(t *testing.T) {
+	container, pending := newTestContainer(t, 10)
+
+	container.ReconstructAndVerifyBatch(pending)
+	for _, p := range pending {
+		require.NoError(t, p.Err)
+		expected, err := container.ReconstructSignature(p.Root, p.ValidatorPubKey, p.ValidatorIndex)
+		require.NoError(t, err)
+		require.Equal(t, expected, p.Signature)
+	}
+
+	// verified signatures are reused until their partial signatures change
+	require.Len(t, container.verified, len(pending))
+
+	// an invalid partial signature only fails its validator
+	resetPending(pending)
+	invalid := pending[3]
+	wrongRoot := sha256.Sum256([]byte("wrong root"))
+	otherSig, err := container.GetSignature(pending[4].ValidatorIndex, 1, pending[4].Root)
+	require.NoError(t, err)
+	container.Remove(invalid.ValidatorIndex, 2, invalid.Root)
+	container.AddSignature(&spectypes.PartialSignatureMessage{
+		PartialSignature: otherSig,
+		SigningRoot:      invalid.Root,
+		Signer:           2,
+		ValidatorIndex:   invalid.ValidatorIndex,
+	})
+	// and a validator without signatures fails to reconstruct
+	missing := &PendingSignature{ValidatorIndex: 100, ValidatorPubKey: invalid.ValidatorPubKey, Root: wrongRoot}
+
+	container.ReconstructAndVerifyBatch(append(pending, missing))
+	for _, p := range pending {
+		if p == invalid {
+			require.ErrorContains(t, p.Err, "failed to verify reconstruct signature")
+			require.Nil(t, p.Signature)
+			continue
+		}
+		require.NoError(t, p.Err)
+		require.NotNil(t, p.Signature)
+	}
+	require.ErrorContains(t, missing.Err, "no signatures for the given validator index")
+	_, ok := container.verifiedSignature(invalid.ValidatorIndex, invalid.Root)
+	require.False(t, ok)
+}
+
+func BenchmarkPartialSigContainer_ReconstructAndVerify(b *testing.B) {
+	for _, validators := range []int{100, 500, 1000} {
+		container, pending := newTestContainer(b, validators)
+
+		b.Run(fmt.Sprintf("validators=%d/sequential", validators), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				container.verified = nil
+				for _, p := range pending {
+					if _, err := container.ReconstructSignature(p.Root, p.ValidatorPubKey, p.ValidatorIndex); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("validators=%d/batch", validators), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				resetPending(pending)
+				container.verified = nil
+				container.ReconstructAndVerifyBatch(pending)
+				if pending[0].Err != nil {
+					b.Fatal(pending[0].Err)
+				}
+			}
+		})
+	}
+}
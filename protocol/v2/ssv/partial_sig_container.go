@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	specssv "github.com/ssvlabs/ssv-spec/ssv"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -19,6 +20,14 @@ type PartialSigContainer struct {
 	Signatures map[phase0.ValidatorIndex]map[specssv.SigningRoot]map[spectypes.OperatorID]spectypes.Signature
 	// Quorum is the number of min signatures needed for quorum
 	Quorum uint64
+
+	// verified holds the reconstructed signatures that were already verified, so they aren't verified again
+	verified map[verifiedKey][]byte
+}
+
+type verifiedKey struct {
+	validatorIndex phase0.ValidatorIndex
+	root           [32]byte
 }
 
 func NewPartialSigContainer(quorum uint64) *PartialSigContainer {
@@ -40,6 +49,7 @@ func (ps *PartialSigContainer) AddSignature(sigMsg *spectypes.PartialSignatureMe
 	if m[sigMsg.Signer] == nil {
 		m[sigMsg.Signer] = make([]byte, 96)
 		copy(m[sigMsg.Signer], sigMsg.PartialSignature)
+		delete(ps.verified, verifiedKey{validatorIndex: sigMsg.ValidatorIndex, root: sigMsg.SigningRoot})
 	}
 }
 
@@ -83,9 +93,46 @@ func (ps *PartialSigContainer) Remove(validatorIndex phase0.ValidatorIndex, sign
 		return
 	}
 	delete(ps.Signatures[validatorIndex][signingRootHex(signingRoot)], signer)
+	delete(ps.verified, verifiedKey{validatorIndex: validatorIndex, root: signingRoot})
 }
 
 func (ps *PartialSigContainer) ReconstructSignature(root [32]byte, validatorPubKey []byte, validatorIndex phase0.ValidatorIndex) ([]byte, error) {
+	if signature, ok := ps.verifiedSignature(validatorIndex, root); ok {
+		return signature, nil
+	}
+
+	signature, err := ps.reconstructUnverifiedSignature(root, validatorIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get validator pub key copy (This avoids cgo Go pointer to Go pointer issue)
+	validatorPubKeyCopy := make([]byte, len(validatorPubKey))
+	copy(validatorPubKeyCopy, validatorPubKey)
+
+	if err := types.VerifyReconstructedSignature(signature, validatorPubKeyCopy, root); err != nil {
+		return nil, errors.Wrap(err, "failed to verify reconstruct signature")
+	}
+	serialized := signature.Serialize()
+	ps.setVerifiedSignature(validatorIndex, root, serialized)
+	return serialized, nil
+}
+
+// verifiedSignature returns the reconstructed signature of the given validator and root if it was already verified
+// and the partial signatures it was reconstructed from didn't change since.
+func (ps *PartialSigContainer) verifiedSignature(validatorIndex phase0.ValidatorIndex, root [32]byte) ([]byte, bool) {
+	signature, ok := ps.verified[verifiedKey{validatorIndex: validatorIndex, root: root}]
+	return signature, ok
+}
+
+func (ps *PartialSigContainer) setVerifiedSignature(validatorIndex phase0.ValidatorIndex, root [32]byte, signature []byte) {
+	if ps.verified == nil {
+		ps.verified = make(map[verifiedKey][]byte)
+	}
+	ps.verified[verifiedKey{validatorIndex: validatorIndex, root: root}] = signature
+}
+
+func (ps *PartialSigContainer) reconstructUnverifiedSignature(root [32]byte, validatorIndex phase0.ValidatorIndex) (*bls.Sign, error) {
 	// Reconstruct signatures
 	if ps.Signatures[validatorIndex] == nil {
 		return nil, errors.New("no signatures for the given validator index")
@@ -102,15 +149,7 @@ func (ps *PartialSigContainer) ReconstructSignature(root [32]byte, validatorPubK
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to reconstruct signatures")
 	}
-
-	// Get validator pub key copy (This avoids cgo Go pointer to Go pointer issue)
-	validatorPubKeyCopy := make([]byte, len(validatorPubKey))
-	copy(validatorPubKeyCopy, validatorPubKey)
-
-	if err := types.VerifyReconstructedSignature(signature, validatorPubKeyCopy, root); err != nil {
-		return nil, errors.Wrap(err, "failed to verify reconstruct signature")
-	}
-	return signature.Serialize(), nil
+	return signature, nil
 }
 
 func (ps *PartialSigContainer) HasQuorum(validatorIndex phase0.ValidatorIndex, root [32]byte) bool {
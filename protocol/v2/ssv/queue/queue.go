@@ -37,6 +37,9 @@ type Queue interface {
 
 	// Len returns the number of messages in the queue.
 	Len() int
+
+	// Cap returns the number of messages the queue can hold before pushes block or fail.
+	Cap() int
 }
 
 type priorityQueue struct {
@@ -196,6 +199,10 @@ func (q *priorityQueue) Len() int {
 	return n
 }
 
+func (q *priorityQueue) Cap() int {
+	return cap(q.inbox)
+}
+
 // item is a node in a linked list of DecodedSSVMessage.
 type item struct {
 	message *SSVMessage
@@ -474,3 +474,24 @@ func decodeAndPush(t require.TestingT, queue Queue, msg mockMessage, state *Stat
 	queue.Push(decoded)
 	return decoded
 }
+
+func TestSaturation(t *testing.T) {
+	full := New(2)
+	decodeAndPush(t, full, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, full, mockConsensusMessage{Height: 101, Type: qbft.PrepareMsgType}, mockState)
+	require.Equal(t, 2, full.Cap())
+
+	half := New(4)
+	decodeAndPush(t, half, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, half, mockConsensusMessage{Height: 101, Type: qbft.PrepareMsgType}, mockState)
+
+	var s Saturation
+	s.Add(half)
+	require.Equal(t, Saturation{Queues: 1, Max: 0.5}, s)
+
+	var other Saturation
+	other.Add(full)
+	other.Add(NewDefault())
+	s.Merge(other)
+	require.Equal(t, Saturation{Queues: 3, Full: 1, Max: 1}, s)
+}
@@ -0,0 +1,34 @@
+package queue
+
+// Saturation describes how full a set of queues is. Messages pushed to a full queue
+// are dropped or block the pusher, so saturated queues indicate a consumer that can't keep up.
+type Saturation struct {
+	// Queues is the number of queues.
+	Queues int `json:"queues"`
+	// Full is the number of queues at or above their capacity.
+	Full int `json:"full"`
+	// Max is the highest ratio of the length of a queue to its capacity, at most 1.
+	Max float64 `json:"max"`
+}
+
+// Add accounts for the given queue.
+func (s *Saturation) Add(q Queue) {
+	s.Queues++
+
+	capacity := q.Cap()
+	if capacity == 0 {
+		return
+	}
+	ratio := min(float64(q.Len())/float64(capacity), 1)
+	if ratio == 1 {
+		s.Full++
+	}
+	s.Max = max(s.Max, ratio)
+}
+
+// Merge accounts for the queues of another Saturation.
+func (s *Saturation) Merge(other Saturation) {
+	s.Queues += other.Queues
+	s.Full += other.Full
+	s.Max = max(s.Max, other.Max)
+}
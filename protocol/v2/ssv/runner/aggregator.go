@@ -14,6 +14,7 @@ import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
@@ -28,6 +29,7 @@ type AggregatorRunner struct {
 	operatorSigner ssvtypes.OperatorSigner
 	valCheck       specqbft.ProposedValueCheckF
 	measurements   measurementsStore
+	spans          *dutySpans
 }
 
 var _ Runner = &AggregatorRunner{}
@@ -64,11 +66,17 @@ func NewAggregatorRunner(
 		operatorSigner: operatorSigner,
 		valCheck:       valCheck,
 		measurements:   NewMeasurementsStore(),
+		spans:          &dutySpans{},
 	}, nil
 }
 
 func (r *AggregatorRunner) StartNewDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty, quorum uint64) error {
-	return r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum)
+	ctx = r.spans.StartValidatorDuty(ctx, spectypes.RoleAggregator, duty)
+	if err := r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum); err != nil {
+		r.spans.EndDuty(err)
+		return err
+	}
+	return nil
 }
 
 // HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
@@ -87,6 +95,7 @@ func (r *AggregatorRunner) ProcessPreConsensus(ctx context.Context, logger *zap.
 	}
 
 	r.measurements.EndPreConsensus()
+	r.spans.EndPhase(nil)
 	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleAggregator)
 
 	// only 1 root, verified by basePreConsensusMsgProcessing
@@ -125,6 +134,7 @@ func (r *AggregatorRunner) ProcessPreConsensus(ctx context.Context, logger *zap.
 		DataSSZ: byts,
 	}
 
+	ctx = r.spans.StartPhase(ctx, consensusPhase)
 	if err := r.BaseRunner.decide(ctx, logger, r, duty.Slot, input); err != nil {
 		return errors.Wrap(err, "can't start new duty runner instance for duty")
 	}
@@ -144,9 +154,11 @@ func (r *AggregatorRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 
 	r.measurements.EndConsensus()
+	r.spans.EndPhase(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleAggregator)
 
 	r.measurements.StartPostConsensus()
+	r.spans.StartPhase(ctx, postConsensusPhase)
 
 	decidedValue := encDecidedValue.(*spectypes.ValidatorConsensusData)
 
@@ -208,6 +220,7 @@ func (r *AggregatorRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 
 	r.measurements.EndPostConsensus()
+	r.spans.EndPhase(nil)
 	recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleAggregator)
 
 	var successfullySubmittedAggregates uint32
@@ -240,8 +253,12 @@ func (r *AggregatorRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 		start := time.Now()
 
-		if err := r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg); err != nil {
+		_, span := r.spans.StartSubmission(ctx, "SubmitSignedAggregateSelectionProof")
+		err = r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg)
+		observability.EndSpan(span, err)
+		if err != nil {
 			recordFailedSubmission(ctx, spectypes.BNRoleAggregator)
+			r.spans.EndDuty(err)
 			logger.Error("❌ could not submit to Beacon chain reconstructed contribution and proof",
 				fields.SubmissionTime(time.Since(start)),
 				zap.Error(err))
@@ -254,13 +271,14 @@ func (r *AggregatorRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 
 	r.GetState().Finished = true
+	r.spans.EndDuty(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 
 	r.measurements.EndDutyFlow()
 
 	recordDutyDuration(ctx, r.measurements.DutyDurationTime(), spectypes.BNRoleAggregator, r.GetState().RunningInstance.State.Round)
 	recordSuccessfulSubmission(ctx,
 		successfullySubmittedAggregates,
-		r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
+		r.GetBeaconNode().Network().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
 		spectypes.BNRoleAggregator)
 
 	return nil
@@ -294,6 +312,7 @@ func (r *AggregatorRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot
 func (r *AggregatorRunner) executeDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty) error {
 	r.measurements.StartDutyFlow()
 	r.measurements.StartPreConsensus()
+	r.spans.StartPhase(ctx, preConsensusPhase)
 
 	// sign selection proof
 	msg, err := r.BaseRunner.signBeaconObject(r, duty.(*spectypes.ValidatorDuty), spectypes.SSZUint64(duty.DutySlot()), duty.DutySlot(), spectypes.DomainSelectionProof)
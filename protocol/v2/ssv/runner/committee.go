@@ -14,6 +14,7 @@ import (
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
@@ -21,8 +22,10 @@ import (
 
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
 
@@ -44,6 +47,7 @@ type CommitteeRunner struct {
 	valCheck       specqbft.ProposedValueCheckF
 	DutyGuard      CommitteeDutyGuard
 	measurements   measurementsStore
+	spans          *dutySpans
 
 	submittedDuties map[spectypes.BeaconRole]map[phase0.ValidatorIndex]struct{}
 }
@@ -78,6 +82,7 @@ func NewCommitteeRunner(
 		submittedDuties: make(map[spectypes.BeaconRole]map[phase0.ValidatorIndex]struct{}),
 		DutyGuard:       dutyGuard,
 		measurements:    NewMeasurementsStore(),
+		spans:           &dutySpans{},
 	}, nil
 }
 
@@ -86,15 +91,24 @@ func (cr *CommitteeRunner) StartNewDuty(ctx context.Context, logger *zap.Logger,
 	if !ok {
 		return errors.New("duty is not a CommitteeDuty")
 	}
+	validatorIndices := make([]phase0.ValidatorIndex, 0, len(d.ValidatorDuties))
+	for _, validatorDuty := range d.ValidatorDuties {
+		validatorIndices = append(validatorIndices, validatorDuty.ValidatorIndex)
+	}
+	ctx = cr.spans.StartDuty(ctx, spectypes.RoleCommittee, d.DutySlot(), observability.ValidatorIndicesAttribute(validatorIndices))
+
 	for _, validatorDuty := range d.ValidatorDuties {
 		err := cr.DutyGuard.StartDuty(validatorDuty.Type, spectypes.ValidatorPK(validatorDuty.PubKey), d.DutySlot())
 		if err != nil {
-			return fmt.Errorf("could not start %s duty at slot %d for validator %x: %w",
+			err = fmt.Errorf("could not start %s duty at slot %d for validator %x: %w",
 				validatorDuty.Type, d.DutySlot(), validatorDuty.PubKey, err)
+			cr.spans.EndDuty(err)
+			return err
 		}
 	}
 	err := cr.BaseRunner.baseStartNewDuty(ctx, logger, cr, duty, quorum)
 	if err != nil {
+		cr.spans.EndDuty(err)
 		return err
 	}
 	cr.submittedDuties[spectypes.BNRoleAttester] = make(map[phase0.ValidatorIndex]struct{})
@@ -210,9 +224,11 @@ func (cr *CommitteeRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 
 	cr.measurements.EndConsensus()
+	cr.spans.EndPhase(nil, observability.DutyRoundAttribute(cr.BaseRunner.State.RunningInstance.State.Round))
 	recordConsensusDuration(ctx, cr.measurements.ConsensusTime(), spectypes.RoleCommittee)
 
 	cr.measurements.StartPostConsensus()
+	cr.spans.StartPhase(ctx, postConsensusPhase)
 	// decided means consensus is done
 
 	duty := cr.BaseRunner.State.StartingDuty
@@ -268,6 +284,7 @@ func (cr *CommitteeRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 	if validDuties == 0 {
 		cr.BaseRunner.State.Finished = true
+		cr.spans.EndDuty(ErrNoValidDuties)
 		return ErrNoValidDuties
 	}
 
@@ -337,6 +354,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 	if len(beaconObjects) == 0 {
 		cr.BaseRunner.State.Finished = true
+		cr.spans.EndDuty(ErrNoValidDuties)
 		return ErrNoValidDuties
 	}
 
@@ -349,7 +367,12 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	for _, root := range roots {
 		rootSet[root] = struct{}{}
 	}
-	// For each root that got at least one quorum, find the duties associated to it and try to submit
+	// For each root that got at least one quorum, find the duties associated to it
+	type pendingDuty struct {
+		role spectypes.BeaconRole
+		sig  *ssv.PendingSignature
+	}
+	var pending []pendingDuty
 	for root := range rootSet {
 		// Get validators related to the given root
 		role, validators, found := findValidators(root, attestationMap, committeeMap)
@@ -378,64 +401,82 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 				continue
 			}
 
-			// Reconstruct signature
-			share := cr.BaseRunner.Share[validator]
-			pubKey := share.ValidatorPubKey
-			vlogger := logger.With(zap.Uint64("validator_index", uint64(validator)), zap.String("pubkey", hex.EncodeToString(pubKey[:])))
+			pubKey := cr.BaseRunner.Share[validator].ValidatorPubKey
+			pending = append(pending, pendingDuty{
+				role: role,
+				sig: &ssv.PendingSignature{
+					ValidatorIndex:  validator,
+					ValidatorPubKey: pubKey[:],
+					Root:            root,
+				},
+			})
+		}
+	}
 
-			sig, err := cr.BaseRunner.State.ReconstructBeaconSig(cr.BaseRunner.State.PostConsensusContainer, root,
-				pubKey[:], validator)
-			// If the reconstructed signature verification failed, fall back to verifying each partial signature
-			// TODO should we return an error here? maybe other sigs are fine?
-			if err != nil {
-				for root := range rootSet {
-					cr.BaseRunner.FallBackAndVerifyEachSignature(cr.BaseRunner.State.PostConsensusContainer, root,
-						share.Committee, validator)
-				}
-				vlogger.Error("got post-consensus quorum but it has invalid signatures",
-					fields.Slot(cr.BaseRunner.State.StartingDuty.DutySlot()),
-					zap.Error(err),
-				)
-
-				anyErr = errors.Wrap(err, "got post-consensus quorum but it has invalid signatures")
-				continue
-			}
-			specSig := phase0.BLSSignature{}
-			copy(specSig[:], sig)
-
-			vlogger.Debug("🧩 reconstructed partial signatures committee",
-				zap.Uint64s("signers", getPostConsensusCommitteeSigners(cr.BaseRunner.State, root)))
-			// Get the beacon object related to root
-			validatorObjs, exists := beaconObjects[validator]
-			if !exists {
-				anyErr = errors.Wrap(err, "could not find beacon object for validator")
-				continue
-			}
-			sszObject, exists := validatorObjs[root]
-			if !exists {
-				anyErr = errors.Wrap(err, "could not find beacon object for validator")
-				continue
+	// Reconstruct the signatures and verify all of them at once
+	pendingSigs := make([]*ssv.PendingSignature, len(pending))
+	for i, p := range pending {
+		pendingSigs[i] = p.sig
+	}
+	cr.BaseRunner.State.PostConsensusContainer.ReconstructAndVerifyBatch(pendingSigs)
+
+	for _, p := range pending {
+		role, validator, root := p.role, p.sig.ValidatorIndex, p.sig.Root
+		share := cr.BaseRunner.Share[validator]
+		vlogger := logger.With(zap.Uint64("validator_index", uint64(validator)), zap.String("pubkey", hex.EncodeToString(p.sig.ValidatorPubKey)))
+
+		// If the reconstructed signature verification failed, fall back to verifying each partial signature
+		// TODO should we return an error here? maybe other sigs are fine?
+		if p.sig.Err != nil {
+			err := errors.Wrap(p.sig.Err, "could not reconstruct beacon sig")
+			for root := range rootSet {
+				cr.BaseRunner.FallBackAndVerifyEachSignature(cr.BaseRunner.State.PostConsensusContainer, root,
+					share.Committee, validator)
 			}
+			vlogger.Error("got post-consensus quorum but it has invalid signatures",
+				fields.Slot(cr.BaseRunner.State.StartingDuty.DutySlot()),
+				zap.Error(err),
+			)
+
+			anyErr = errors.Wrap(err, "got post-consensus quorum but it has invalid signatures")
+			continue
+		}
+		specSig := phase0.BLSSignature{}
+		copy(specSig[:], p.sig.Signature)
+
+		vlogger.Debug("🧩 reconstructed partial signatures committee",
+			zap.Uint64s("signers", getPostConsensusCommitteeSigners(cr.BaseRunner.State, root)))
+		// Get the beacon object related to root
+		validatorObjs, exists := beaconObjects[validator]
+		if !exists {
+			anyErr = errors.Wrap(err, "could not find beacon object for validator")
+			continue
+		}
+		sszObject, exists := validatorObjs[root]
+		if !exists {
+			anyErr = errors.Wrap(err, "could not find beacon object for validator")
+			continue
+		}
 
-			// Store objects for multiple submission
-			if role == spectypes.BNRoleSyncCommittee {
-				syncMsg := sszObject.(*altair.SyncCommitteeMessage)
-				// Insert signature
-				syncMsg.Signature = specSig
+		// Store objects for multiple submission
+		if role == spectypes.BNRoleSyncCommittee {
+			syncMsg := sszObject.(*altair.SyncCommitteeMessage)
+			// Insert signature
+			syncMsg.Signature = specSig
 
-				syncCommitteeMessagesToSubmit[validator] = syncMsg
+			syncCommitteeMessagesToSubmit[validator] = syncMsg
 
-			} else if role == spectypes.BNRoleAttester {
-				att := sszObject.(*phase0.Attestation)
-				// Insert signature
-				att.Signature = specSig
+		} else if role == spectypes.BNRoleAttester {
+			att := sszObject.(*phase0.Attestation)
+			// Insert signature
+			att.Signature = specSig
 
-				attestationsToSubmit[validator] = att
-			}
+			attestationsToSubmit[validator] = att
 		}
 	}
 
 	cr.measurements.EndPostConsensus()
+	cr.spans.EndPhase(nil)
 	recordPostConsensusDuration(ctx, cr.measurements.PostConsensusTime(), spectypes.RoleCommittee)
 
 	logger = logger.With(fields.PostConsensusTime(cr.measurements.PostConsensusTime()))
@@ -450,7 +491,10 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 	if len(attestations) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitAttestations(attestations); err != nil {
+		_, span := cr.spans.StartSubmission(ctx, "SubmitAttestations", attribute.Int(submissionsAttrKey, len(attestations)))
+		err := cr.beacon.SubmitAttestations(attestations)
+		observability.EndSpan(span, err)
+		if err != nil {
 			logger.Error("❌ failed to submit attestation", zap.Error(err))
 			recordFailedSubmission(ctx, spectypes.BNRoleAttester)
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed attestation")
@@ -462,12 +506,12 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 		if attestationsCount <= math.MaxUint32 {
 			recordSuccessfulSubmission(ctx,
 				uint32(attestationsCount),
-				cr.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot()),
+				cr.GetBeaconNode().Network().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot()),
 				spectypes.BNRoleAttester)
 		}
 
 		logger.Info("✅ successfully submitted attestations",
-			fields.Epoch(cr.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot())),
+			fields.Epoch(cr.GetBeaconNode().Network().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot())),
 			fields.Height(cr.BaseRunner.QBFTController.Height),
 			fields.Round(cr.BaseRunner.State.RunningInstance.State.Round),
 			fields.BlockRoot(attestations[0].Data.BeaconBlockRoot),
@@ -488,7 +532,10 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 	if len(syncCommitteeMessages) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitSyncMessages(syncCommitteeMessages); err != nil {
+		_, span := cr.spans.StartSubmission(ctx, "SubmitSyncMessages", attribute.Int(submissionsAttrKey, len(syncCommitteeMessages)))
+		err := cr.beacon.SubmitSyncMessages(syncCommitteeMessages)
+		observability.EndSpan(span, err)
+		if err != nil {
 			logger.Error("❌ failed to submit sync committee", zap.Error(err))
 			recordFailedSubmission(ctx, spectypes.BNRoleSyncCommittee)
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed signed sync committee")
@@ -500,7 +547,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 		if syncMsgsCount <= math.MaxUint32 {
 			recordSuccessfulSubmission(ctx,
 				uint32(syncMsgsCount),
-				cr.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot()),
+				cr.GetBeaconNode().Network().EstimatedEpochAtSlot(cr.GetBaseRunner().State.StartingDuty.DutySlot()),
 				spectypes.BNRoleSyncCommittee)
 		}
 
@@ -524,6 +571,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	// Check if duty has terminated (runner has submitted for all duties)
 	if cr.HasSubmittedAllValidatorDuties(attestationMap, committeeMap) {
 		cr.BaseRunner.State.Finished = true
+		cr.spans.EndDuty(nil, observability.DutyRoundAttribute(cr.BaseRunner.State.RunningInstance.State.Round))
 	}
 	return nil
 }
@@ -714,6 +762,7 @@ func (cr *CommitteeRunner) executeDuty(ctx context.Context, logger *zap.Logger,
 	)
 
 	cr.measurements.StartConsensus()
+	ctx = cr.spans.StartPhase(ctx, consensusPhase)
 
 	vote := &spectypes.BeaconVote{
 		BlockRoot: attData.BeaconBlockRoot,
@@ -727,6 +776,11 @@ func (cr *CommitteeRunner) executeDuty(ctx context.Context, logger *zap.Logger,
 	return nil
 }
 
+// EndDutySpans ends the spans of the duty of the runner if it didn't finish, such as when the runner expires.
+func (cr *CommitteeRunner) EndDutySpans() {
+	cr.spans.EndDuty(errDutyNotFinished)
+}
+
 func (cr *CommitteeRunner) GetSigner() spectypes.BeaconSigner {
 	return cr.signer
 }
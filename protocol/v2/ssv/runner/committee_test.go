@@ -0,0 +1,52 @@
+package runner_test
+
+import (
+	"context"
+	"testing"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
+	ssvtesting "github.com/ssvlabs/ssv/protocol/v2/ssv/testing"
+)
+
+func TestCommitteeRunner_BeaconNodeFaults(t *testing.T) {
+	logger := logging.TestLogger(t)
+	keySet := spectestingutils.Testing4SharesSet()
+
+	t.Run("attestation data unavailable", func(t *testing.T) {
+		node := fakebeacon.New()
+		node.InjectFault(fakebeacon.EndpointAttestationData, fakebeacon.Fault{Err: fakebeacon.ErrInjected})
+		r, err := ssvtesting.ConstructBaseRunnerWithBeacon(logger, spectypes.RoleCommittee, keySet, node)
+		require.NoError(t, err)
+
+		duty := spectestingutils.TestingCommitteeDuty(spectestingutils.TestingDutySlot, []int{spectestingutils.TestingValidatorIndex}, nil)
+		err = r.StartNewDuty(context.Background(), logger, duty, keySet.Threshold)
+		require.ErrorIs(t, err, fakebeacon.ErrInjected)
+		require.Nil(t, r.(*runner.CommitteeRunner).BaseRunner.State.RunningInstance)
+	})
+
+	t.Run("reorged head", func(t *testing.T) {
+		node := fakebeacon.New()
+		node.Reorg(spectestingutils.TestingDutySlot)
+		r, err := ssvtesting.ConstructBaseRunnerWithBeacon(logger, spectypes.RoleCommittee, keySet, node)
+		require.NoError(t, err)
+
+		duty := spectestingutils.TestingCommitteeDuty(spectestingutils.TestingDutySlot, []int{spectestingutils.TestingValidatorIndex}, nil)
+		require.NoError(t, r.StartNewDuty(context.Background(), logger, duty, keySet.Threshold))
+
+		// the runner proposes the head of the reorganized chain
+		attData, _, err := node.GetAttestationData(spectestingutils.TestingDutySlot, 0)
+		require.NoError(t, err)
+		instance := r.(*runner.CommitteeRunner).BaseRunner.State.RunningInstance
+		require.NotNil(t, instance)
+		vote := &spectypes.BeaconVote{}
+		require.NoError(t, vote.Decode(instance.StartValue))
+		require.Equal(t, attData.BeaconBlockRoot, vote.BlockRoot)
+		require.NotEqual(t, spectestingutils.TestingAttestationData.BeaconBlockRoot, vote.BlockRoot)
+	})
+}
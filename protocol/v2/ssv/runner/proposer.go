@@ -21,6 +21,7 @@ import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
@@ -35,6 +36,7 @@ type ProposerRunner struct {
 	operatorSigner ssvtypes.OperatorSigner
 	valCheck       specqbft.ProposedValueCheckF
 	measurements   measurementsStore
+	spans          *dutySpans
 	graffiti       []byte
 }
 
@@ -72,11 +74,17 @@ func NewProposerRunner(
 		operatorSigner: operatorSigner,
 		graffiti:       graffiti,
 		measurements:   NewMeasurementsStore(),
+		spans:          &dutySpans{},
 	}, nil
 }
 
 func (r *ProposerRunner) StartNewDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty, quorum uint64) error {
-	return r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum)
+	ctx = r.spans.StartValidatorDuty(ctx, spectypes.RoleProposer, duty)
+	if err := r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum); err != nil {
+		r.spans.EndDuty(err)
+		return err
+	}
+	return nil
 }
 
 // HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
@@ -102,6 +110,7 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	}
 
 	r.measurements.EndPreConsensus()
+	r.spans.EndPhase(nil)
 	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleProposer)
 
 	// only 1 root, verified in basePreConsensusMsgProcessing
@@ -147,6 +156,7 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	}
 
 	r.measurements.StartConsensus()
+	ctx = r.spans.StartPhase(ctx, consensusPhase)
 
 	if err := r.BaseRunner.decide(ctx, logger, r, duty.Slot, input); err != nil {
 		return errors.Wrap(err, "can't start new duty runner instance for duty")
@@ -166,9 +176,11 @@ func (r *ProposerRunner) ProcessConsensus(ctx context.Context, logger *zap.Logge
 	}
 
 	r.measurements.EndConsensus()
+	r.spans.EndPhase(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleProposer)
 
 	r.measurements.StartPostConsensus()
+	r.spans.StartPhase(ctx, postConsensusPhase)
 
 	// specific duty sig
 	var blkToSign ssz.HashRoot
@@ -255,6 +267,7 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 		copy(specSig[:], sig)
 
 		r.measurements.EndPostConsensus()
+		r.spans.EndPhase(nil)
 		recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleProposer)
 
 		logger.Debug("🧩 reconstructed partial post consensus signatures proposer",
@@ -293,8 +306,12 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig); err != nil {
+			_, span := r.spans.StartSubmission(ctx, "SubmitBlindedBeaconBlock")
+			err = r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig)
+			observability.EndSpan(span, err)
+			if err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleProposer)
+				r.spans.EndDuty(err)
 				logger.Error("❌ could not submit blinded Beacon block",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -311,8 +328,12 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig); err != nil {
+			_, span := r.spans.StartSubmission(ctx, "SubmitBeaconBlock")
+			err = r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig)
+			observability.EndSpan(span, err)
+			if err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleProposer)
+				r.spans.EndDuty(err)
 				logger.Error("❌ could not submit Beacon block",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -332,13 +353,14 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 	}
 
 	r.GetState().Finished = true
+	r.spans.EndDuty(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 
 	r.measurements.EndDutyFlow()
 
 	recordDutyDuration(ctx, r.measurements.DutyDurationTime(), spectypes.BNRoleProposer, r.GetState().RunningInstance.State.Round)
 	recordSuccessfulSubmission(ctx,
 		uint32(successfullySubmittedProposals),
-		r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
+		r.GetBeaconNode().Network().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
 		spectypes.BNRoleProposer)
 
 	return nil
@@ -393,9 +415,10 @@ func (r *ProposerRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot,
 func (r *ProposerRunner) executeDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty) error {
 	r.measurements.StartDutyFlow()
 	r.measurements.StartPreConsensus()
+	r.spans.StartPhase(ctx, preConsensusPhase)
 
 	// sign partial randao
-	epoch := r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(duty.DutySlot())
+	epoch := r.GetBeaconNode().Network().EstimatedEpochAtSlot(duty.DutySlot())
 	msg, err := r.BaseRunner.signBeaconObject(r, duty.(*spectypes.ValidatorDuty), spectypes.SSZUint64(epoch), duty.DutySlot(), spectypes.DomainRandao)
 	if err != nil {
 		return errors.Wrap(err, "could not sign randao")
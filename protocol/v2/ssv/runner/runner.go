@@ -233,6 +233,7 @@ func (b *BaseRunner) basePartialSigMsgProcessing(
 ) (bool, [][32]byte) {
 	roots := make([][32]byte, 0)
 	anyQuorum := false
+	var quorums []*ssv.PendingSignature
 
 	for _, msg := range signedMsg.Messages {
 		prevQuorum := container.HasQuorum(msg.ValidatorIndex, msg.SigningRoot)
@@ -250,9 +251,23 @@ func (b *BaseRunner) basePartialSigMsgProcessing(
 			// Notify about first quorum only
 			roots = append(roots, msg.SigningRoot)
 			anyQuorum = true
+
+			if share, ok := b.Share[msg.ValidatorIndex]; ok {
+				quorums = append(quorums, &ssv.PendingSignature{
+					ValidatorIndex:  msg.ValidatorIndex,
+					ValidatorPubKey: share.ValidatorPubKey[:],
+					Root:            msg.SigningRoot,
+				})
+			}
 		}
 	}
 
+	// Verify the reconstructed signatures of the new quorums in a single batch, so the runner reconstructing them
+	// gets them verified. Invalid ones are left to the runner, which falls back to verifying each partial signature.
+	if len(quorums) > 0 {
+		container.ReconstructAndVerifyBatch(quorums)
+	}
+
 	return anyQuorum, roots
 }
 
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"fmt"
+	"slices"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
@@ -9,10 +10,16 @@ import (
 	"github.com/pkg/errors"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	"github.com/ssvlabs/ssv/ekm"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 )
 
+// dutySigner is implemented by beacon signers that attribute the signatures to the duties they're requested for.
+type dutySigner interface {
+	SignBeaconObjectForDuty(obj ssz.HashRoot, domain spec.Domain, pk []byte, domainType spec.DomainType, duty *ekm.SigningDuty) (spectypes.Signature, [32]byte, error)
+}
+
 func (b *BaseRunner) signBeaconObject(
 	runner Runner,
 	duty *spectypes.ValidatorDuty,
@@ -28,7 +35,17 @@ func (b *BaseRunner) signBeaconObject(
 	if _, ok := runner.GetBaseRunner().Share[duty.ValidatorIndex]; !ok {
 		return nil, fmt.Errorf("unknown validator index %d", duty.ValidatorIndex)
 	}
-	sig, r, err := runner.GetSigner().SignBeaconObject(obj, domain, runner.GetBaseRunner().Share[duty.ValidatorIndex].SharePubKey, domainType)
+	sharePubKey := runner.GetBaseRunner().Share[duty.ValidatorIndex].SharePubKey
+	var sig spectypes.Signature
+	var r [32]byte
+	if signer, ok := runner.GetSigner().(dutySigner); ok {
+		sig, r, err = signer.SignBeaconObjectForDuty(obj, domain, sharePubKey, domainType, &ekm.SigningDuty{
+			Runner: b.RunnerRoleType,
+			Duty:   duty,
+		})
+	} else {
+		sig, r, err = runner.GetSigner().SignBeaconObject(obj, domain, sharePubKey, domainType)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not sign beacon object")
 	}
@@ -134,6 +151,34 @@ func (b *BaseRunner) verifyBeaconPartialSignature(signer spectypes.OperatorID, s
 	return errors.New("unknown signer")
 }
 
+// verifyBeaconPartialSignaturesBatch verifies the given partial signatures of a root in a single batch,
+// returns false if any of them is invalid or if any of the signers is unknown.
+func (b *BaseRunner) verifyBeaconPartialSignaturesBatch(signatures map[spectypes.OperatorID]spectypes.Signature, root [32]byte,
+	committee []*spectypes.ShareMember) bool {
+
+	sigs := make([]bls.Sign, 0, len(signatures))
+	pubKeys := make([]bls.PublicKey, 0, len(signatures))
+	roots := make([][32]byte, 0, len(signatures))
+	for signer, signature := range signatures {
+		idx := slices.IndexFunc(committee, func(n *spectypes.ShareMember) bool { return n.Signer == signer })
+		if idx == -1 {
+			return false
+		}
+		pk, err := types.DeserializeBLSPublicKey(committee[idx].SharePubKey)
+		if err != nil {
+			return false
+		}
+		sig := bls.Sign{}
+		if err := sig.Deserialize(signature); err != nil {
+			return false
+		}
+		sigs = append(sigs, sig)
+		pubKeys = append(pubKeys, pk)
+		roots = append(roots, root)
+	}
+	return types.VerifyBatch(sigs, pubKeys, roots)
+}
+
 // Stores the container's existing signature or the new one, depending on their validity. If both are invalid, remove the existing one
 func (b *BaseRunner) resolveDuplicateSignature(container *ssv.PartialSigContainer, msg *spectypes.PartialSignatureMessage) {
 
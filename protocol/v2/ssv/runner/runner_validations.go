@@ -33,6 +33,11 @@ func (b *BaseRunner) FallBackAndVerifyEachSignature(container *ssv.PartialSigCon
 	committee []*spectypes.ShareMember, validatorIndex spec.ValidatorIndex) {
 	signatures := container.GetSignatures(validatorIndex, root)
 
+	// Verifying each signature is only needed to find the invalid ones
+	if b.verifyBeaconPartialSignaturesBatch(signatures, root, committee) {
+		return
+	}
+
 	for operatorID, signature := range signatures {
 		if err := b.verifyBeaconPartialSignature(operatorID, signature, root, committee); err != nil {
 			container.Remove(validatorIndex, operatorID, root)
@@ -16,6 +16,7 @@ import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
@@ -30,6 +31,7 @@ type SyncCommitteeAggregatorRunner struct {
 	operatorSigner ssvtypes.OperatorSigner
 	valCheck       specqbft.ProposedValueCheckF
 	measurements   measurementsStore
+	spans          *dutySpans
 }
 
 func NewSyncCommitteeAggregatorRunner(
@@ -64,11 +66,17 @@ func NewSyncCommitteeAggregatorRunner(
 		valCheck:       valCheck,
 		operatorSigner: operatorSigner,
 		measurements:   NewMeasurementsStore(),
+		spans:          &dutySpans{},
 	}, nil
 }
 
 func (r *SyncCommitteeAggregatorRunner) StartNewDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty, quorum uint64) error {
-	return r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum)
+	ctx = r.spans.StartValidatorDuty(ctx, spectypes.RoleSyncCommitteeContribution, duty)
+	if err := r.BaseRunner.baseStartNewDuty(ctx, logger, r, duty, quorum); err != nil {
+		r.spans.EndDuty(err)
+		return err
+	}
+	return nil
 }
 
 // HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
@@ -88,6 +96,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPreConsensus(ctx context.Context,
 	}
 
 	r.measurements.EndPreConsensus()
+	r.spans.EndPhase(nil)
 	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleSyncCommitteeContribution)
 
 	// collect selection proofs and subnets
@@ -129,6 +138,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPreConsensus(ctx context.Context,
 
 	if len(selectionProofs) == 0 {
 		r.GetState().Finished = true
+		r.spans.EndDuty(nil)
 		return nil
 	}
 
@@ -155,6 +165,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPreConsensus(ctx context.Context,
 	}
 
 	r.measurements.StartConsensus()
+	ctx = r.spans.StartPhase(ctx, consensusPhase)
 	if err := r.BaseRunner.decide(ctx, logger, r, input.Duty.Slot, input); err != nil {
 		return errors.Wrap(err, "can't start new duty runner instance for duty")
 	}
@@ -173,9 +184,11 @@ func (r *SyncCommitteeAggregatorRunner) ProcessConsensus(ctx context.Context, lo
 	}
 
 	r.measurements.EndConsensus()
+	r.spans.EndPhase(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleSyncCommitteeContribution)
 
 	r.measurements.StartPostConsensus()
+	r.spans.StartPhase(ctx, postConsensusPhase)
 
 	cd := decidedValue.(*spectypes.ValidatorConsensusData)
 	contributions, err := cd.GetSyncCommitteeContributions()
@@ -245,6 +258,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 	}
 
 	r.measurements.EndPostConsensus()
+	r.spans.EndPhase(nil)
 	recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleSyncCommitteeContribution)
 
 	// get contributions
@@ -293,8 +307,12 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 				Signature: blsSignedContribAndProof,
 			}
 
-			if err := r.GetBeaconNode().SubmitSignedContributionAndProof(signedContribAndProof); err != nil {
+			_, span := r.spans.StartSubmission(ctx, "SubmitSignedContributionAndProof")
+			err = r.GetBeaconNode().SubmitSignedContributionAndProof(signedContribAndProof)
+			observability.EndSpan(span, err)
+			if err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleSyncCommitteeContribution)
+				r.spans.EndDuty(err)
 				logger.Error("❌ could not submit to Beacon chain reconstructed contribution and proof",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -310,13 +328,14 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 	}
 
 	r.GetState().Finished = true
+	r.spans.EndDuty(nil, observability.DutyRoundAttribute(r.GetState().RunningInstance.State.Round))
 
 	r.measurements.EndDutyFlow()
 
 	recordDutyDuration(ctx, r.measurements.DutyDurationTime(), spectypes.BNRoleSyncCommitteeContribution, r.GetState().RunningInstance.State.Round)
 	recordSuccessfulSubmission(ctx,
 		successfullySubmittedContributions,
-		r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
+		r.GetBeaconNode().Network().EstimatedEpochAtSlot(r.GetState().StartingDuty.DutySlot()),
 		spectypes.BNRoleSyncCommitteeContribution)
 
 	return nil
@@ -389,6 +408,7 @@ func (r *SyncCommitteeAggregatorRunner) expectedPostConsensusRootsAndDomain() ([
 func (r *SyncCommitteeAggregatorRunner) executeDuty(ctx context.Context, logger *zap.Logger, duty spectypes.Duty) error {
 	r.measurements.StartDutyFlow()
 	r.measurements.StartPreConsensus()
+	r.spans.StartPhase(ctx, preConsensusPhase)
 
 	// sign selection proofs
 	msgs := &spectypes.PartialSignatureMessages{
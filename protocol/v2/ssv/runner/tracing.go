@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	preConsensusPhase  = "pre_consensus"
+	consensusPhase     = "consensus"
+	postConsensusPhase = "post_consensus"
+
+	submissionsAttrKey = "ssv.beacon.submissions"
+)
+
+var (
+	tracer = otel.Tracer(observabilityName)
+
+	errDutyNotFinished = errors.New("duty did not finish")
+)
+
+// dutySpans holds the spans of the running duty of a runner. The phases of a duty are processed
+// after the call starting it returns (by the queue consumer), so its spans are kept by the runner
+// rather than passed down a context. A nil dutySpans doesn't trace.
+type dutySpans struct {
+	mtx   sync.Mutex
+	duty  trace.Span
+	phase trace.Span
+}
+
+// StartDuty starts the span of a duty as a child of the span in ctx, if any,
+// ending the spans of the previous duty if it didn't finish.
+func (s *dutySpans) StartDuty(ctx context.Context, role spectypes.RunnerRole, slot phase0.Slot, attrs ...attribute.KeyValue) context.Context {
+	if s == nil {
+		return ctx
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.unsafeEnd(errDutyNotFinished)
+
+	ctx, s.duty = tracer.Start(ctx, spanName("duty"),
+		trace.WithAttributes(
+			observability.RunnerRoleAttribute(role),
+			observability.BeaconSlotAttribute(slot)),
+		trace.WithAttributes(attrs...))
+	return ctx
+}
+
+// StartValidatorDuty starts the span of a duty of a single validator, see StartDuty.
+func (s *dutySpans) StartValidatorDuty(ctx context.Context, role spectypes.RunnerRole, duty spectypes.Duty) context.Context {
+	var attrs []attribute.KeyValue
+	if d, ok := duty.(*spectypes.ValidatorDuty); ok {
+		attrs = append(attrs,
+			observability.BeaconRoleAttribute(d.Type),
+			observability.ValidatorPublicKeyAttribute(d.PubKey),
+			observability.ValidatorIndexAttribute(d.ValidatorIndex))
+	}
+	return s.StartDuty(ctx, role, duty.DutySlot(), attrs...)
+}
+
+// StartPhase ends the current phase of the duty and starts the span of the given one.
+// The returned context holds the span of the phase, with the values of ctx.
+func (s *dutySpans) StartPhase(ctx context.Context, phase string) context.Context {
+	if s == nil {
+		return ctx
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	observability.EndSpan(s.phase, nil)
+	ctx, s.phase = tracer.Start(s.unsafeDutyContext(ctx), spanName(phase))
+	return ctx
+}
+
+// EndPhase ends the current phase of the duty with the given attributes, such as its round.
+func (s *dutySpans) EndPhase(err error, attrs ...attribute.KeyValue) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.phase != nil {
+		s.phase.SetAttributes(attrs...)
+	}
+	observability.EndSpan(s.phase, err)
+	s.phase = nil
+}
+
+// StartSubmission starts the span of a submission of the duty to the beacon node by the given API.
+func (s *dutySpans) StartSubmission(ctx context.Context, api string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if s == nil {
+		return ctx, nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return tracer.Start(s.unsafeDutyContext(ctx), spanName("submission"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("ssv.beacon.api", api)),
+		trace.WithAttributes(attrs...))
+}
+
+// EndDuty ends the spans of the duty.
+func (s *dutySpans) EndDuty(err error, attrs ...attribute.KeyValue) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.duty != nil {
+		s.duty.SetAttributes(attrs...)
+	}
+	s.unsafeEnd(err)
+}
+
+// unsafeDutyContext returns ctx with the span of the duty, if any.
+func (s *dutySpans) unsafeDutyContext(ctx context.Context) context.Context {
+	if s.duty == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, s.duty)
+}
+
+func (s *dutySpans) unsafeEnd(err error) {
+	observability.EndSpan(s.phase, err)
+	observability.EndSpan(s.duty, err)
+	s.phase, s.duty = nil, nil
+}
+
+func spanName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
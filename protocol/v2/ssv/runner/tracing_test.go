@@ -0,0 +1,92 @@
+package runner_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/observability"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/fakebeacon"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
+	ssvtesting "github.com/ssvlabs/ssv/protocol/v2/ssv/testing"
+)
+
+func TestCommitteeRunner_Tracing(t *testing.T) {
+	logger := logging.TestLogger(t)
+	keySet := spectestingutils.Testing4SharesSet()
+	slot := phase0.Slot(spectestingutils.TestingDutySlot)
+	duty := spectestingutils.TestingCommitteeDuty(slot, []int{spectestingutils.TestingValidatorIndex}, nil)
+
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown, err := observability.Initialize("ssv-node", "v0.0.0", observability.WithTraceExporter(exporter))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	spansByName := func() map[string]tracetest.SpanStub {
+		spans := make(map[string]tracetest.SpanStub)
+		for _, span := range exporter.GetSpans() {
+			spans[span.Name] = span
+		}
+		exporter.Reset()
+		return spans
+	}
+
+	t.Run("duty", func(t *testing.T) {
+		r, err := ssvtesting.ConstructBaseRunnerWithBeacon(logger, spectypes.RoleCommittee, keySet, fakebeacon.New())
+		require.NoError(t, err)
+
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "ssv.test.parent")
+		require.NoError(t, r.StartNewDuty(ctx, logger, duty, keySet.Threshold))
+
+		// The spans of the duty end when it finishes or its runner expires.
+		r.(*runner.CommitteeRunner).BaseRunner.State.RunningInstance.ForceStop()
+		r.(*runner.CommitteeRunner).EndDutySpans()
+		parent.End()
+
+		spans := spansByName()
+		require.Len(t, spans, 4)
+
+		dutySpan := spans["ssv.validator.duty"]
+		require.Equal(t, parent.SpanContext().SpanID(), dutySpan.Parent.SpanID())
+		require.Contains(t, dutySpan.Attributes, observability.RunnerRoleAttribute(spectypes.RoleCommittee))
+		require.Contains(t, dutySpan.Attributes, observability.BeaconSlotAttribute(slot))
+		require.Contains(t, dutySpan.Attributes, observability.ValidatorIndicesAttribute(
+			[]phase0.ValidatorIndex{spectestingutils.TestingValidatorIndex}))
+		require.Equal(t, codes.Error, dutySpan.Status.Code)
+
+		consensusSpan := spans["ssv.validator.consensus"]
+		require.Equal(t, dutySpan.SpanContext.SpanID(), consensusSpan.Parent.SpanID())
+
+		roundSpan := spans["ssv.validator.consensus.round"]
+		require.Equal(t, consensusSpan.SpanContext.SpanID(), roundSpan.Parent.SpanID())
+		require.Contains(t, roundSpan.Attributes, observability.DutyRoundAttribute(specqbft.FirstRound))
+		require.Equal(t, dutySpan.SpanContext.TraceID(), roundSpan.SpanContext.TraceID())
+	})
+
+	t.Run("failed duty", func(t *testing.T) {
+		node := fakebeacon.New()
+		node.InjectFault(fakebeacon.EndpointAttestationData, fakebeacon.Fault{Err: fakebeacon.ErrInjected})
+		r, err := ssvtesting.ConstructBaseRunnerWithBeacon(logger, spectypes.RoleCommittee, keySet, node)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, r.StartNewDuty(context.Background(), logger, duty, keySet.Threshold), fakebeacon.ErrInjected)
+
+		spans := spansByName()
+		require.Len(t, spans, 1)
+		dutySpan := spans["ssv.validator.duty"]
+		require.False(t, dutySpan.Parent.IsValid())
+		require.Equal(t, codes.Error, dutySpan.Status.Code)
+		require.Contains(t, dutySpan.Status.Description, fakebeacon.ErrInjected.Error())
+	})
+}
@@ -293,6 +293,7 @@ var baseCommitteeWithRunnerSample = func(
 		createRunnerF,
 		shareMap,
 		committeeDutyGuard,
+		nil,
 	)
 
 	return c
@@ -564,6 +564,7 @@ func fixCommitteeForRun(t *testing.T, ctx context.Context, logger *zap.Logger, c
 		},
 		specCommittee.Share,
 		validator.NewCommitteeDutyGuard(),
+		nil,
 	)
 	tmpSsvCommittee := &validator.Committee{}
 	require.NoError(t, json.Unmarshal(byts, tmpSsvCommittee))
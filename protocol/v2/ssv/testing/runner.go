@@ -12,6 +12,7 @@ import (
 	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
 	"github.com/ssvlabs/ssv/integration/qbft/tests"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/testing"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv"
@@ -71,6 +72,17 @@ var ConstructBaseRunner = func(
 	logger *zap.Logger,
 	role spectypes.RunnerRole,
 	keySet *spectestingutils.TestKeySet,
+) (runner.Runner, error) {
+	return ConstructBaseRunnerWithBeacon(logger, role, keySet, tests.NewTestingBeaconNodeWrapped())
+}
+
+// ConstructBaseRunnerWithBeacon constructs a runner like ConstructBaseRunner with the given beacon node,
+// such as a fakebeacon.Node injecting faults.
+var ConstructBaseRunnerWithBeacon = func(
+	logger *zap.Logger,
+	role spectypes.RunnerRole,
+	keySet *spectestingutils.TestKeySet,
+	beaconNode beacon.BeaconNode,
 ) (runner.Runner, error) {
 	share := spectestingutils.TestingShare(keySet, spectestingutils.TestingValidatorIndex)
 	identifier := spectypes.NewMsgID(spectypes.JatoTestnet, spectestingutils.TestingValidatorPubKey[:], role)
@@ -126,7 +138,7 @@ var ConstructBaseRunner = func(
 			networkconfig.TestNetwork,
 			shareMap,
 			contr,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -139,7 +151,7 @@ var ConstructBaseRunner = func(
 			spectypes.BeaconTestNetwork,
 			shareMap,
 			contr,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -152,7 +164,7 @@ var ConstructBaseRunner = func(
 			spectypes.BeaconTestNetwork,
 			shareMap,
 			contr,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -166,7 +178,7 @@ var ConstructBaseRunner = func(
 			spectypes.BeaconTestNetwork,
 			shareMap,
 			contr,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -178,7 +190,7 @@ var ConstructBaseRunner = func(
 			networkconfig.TestNetwork.DomainType,
 			spectypes.BeaconTestNetwork,
 			shareMap,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -188,7 +200,7 @@ var ConstructBaseRunner = func(
 			networkconfig.TestNetwork.DomainType,
 			spectypes.BeaconTestNetwork,
 			shareMap,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
@@ -198,7 +210,7 @@ var ConstructBaseRunner = func(
 			networkconfig.TestNetwork,
 			shareMap,
 			contr,
-			tests.NewTestingBeaconNodeWrapped(),
+			beaconNode,
 			net,
 			km,
 			opSigner,
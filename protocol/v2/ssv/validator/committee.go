@@ -10,12 +10,15 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
 	"github.com/ssvlabs/ssv/protocol/v2/message"
+	ssvqbft "github.com/ssvlabs/ssv/protocol/v2/qbft"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
@@ -42,8 +45,9 @@ type Committee struct {
 
 	CommitteeMember *spectypes.CommitteeMember
 
-	dutyGuard      *CommitteeDutyGuard
-	CreateRunnerFn CommitteeRunnerFunc
+	dutyGuard         *CommitteeDutyGuard
+	CreateRunnerFn    CommitteeRunnerFunc
+	signatureVerifier ssvqbft.SignatureVerifier
 }
 
 // NewCommittee creates a new cluster
@@ -56,21 +60,26 @@ func NewCommittee(
 	createRunnerFn CommitteeRunnerFunc,
 	shares map[phase0.ValidatorIndex]*spectypes.Share,
 	dutyGuard *CommitteeDutyGuard,
+	signatureVerifier ssvqbft.SignatureVerifier,
 ) *Committee {
 	if shares == nil {
 		shares = make(map[phase0.ValidatorIndex]*spectypes.Share)
 	}
+	if signatureVerifier == nil {
+		signatureVerifier = ssvqbft.SpecSignatureVerifier{}
+	}
 	return &Committee{
-		logger:          logger,
-		BeaconNetwork:   beaconNetwork,
-		ctx:             ctx,
-		cancel:          cancel,
-		Queues:          make(map[phase0.Slot]queueContainer),
-		Runners:         make(map[phase0.Slot]*runner.CommitteeRunner),
-		Shares:          shares,
-		CommitteeMember: committeeMember,
-		CreateRunnerFn:  createRunnerFn,
-		dutyGuard:       dutyGuard,
+		logger:            logger,
+		BeaconNetwork:     beaconNetwork,
+		ctx:               ctx,
+		cancel:            cancel,
+		Queues:            make(map[phase0.Slot]queueContainer),
+		Runners:           make(map[phase0.Slot]*runner.CommitteeRunner),
+		Shares:            shares,
+		CommitteeMember:   committeeMember,
+		CreateRunnerFn:    createRunnerFn,
+		dutyGuard:         dutyGuard,
+		signatureVerifier: signatureVerifier,
 	}
 }
 
@@ -117,7 +126,16 @@ func (c *Committee) StartConsumeQueue(logger *zap.Logger, duty *spectypes.Commit
 }
 
 // StartDuty starts a new duty for the given slot
-func (c *Committee) StartDuty(ctx context.Context, logger *zap.Logger, duty *spectypes.CommitteeDuty) error {
+func (c *Committee) StartDuty(ctx context.Context, logger *zap.Logger, duty *spectypes.CommitteeDuty) (err error) {
+	ctx, span := tracer.Start(ctx, spanName("committee.start_duty"),
+		trace.WithAttributes(
+			observability.CommitteeIDAttribute(c.CommitteeMember.CommitteeID),
+			observability.RunnerRoleAttribute(spectypes.RoleCommittee),
+			observability.BeaconSlotAttribute(duty.Slot)))
+	defer func() {
+		observability.EndSpan(span, err)
+	}()
+
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
@@ -213,7 +231,7 @@ func (c *Committee) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 		}
 
 		// Verify SignedSSVMessage's signature
-		if err := spectypes.Verify(msg.SignedSSVMessage, c.CommitteeMember.Committee); err != nil {
+		if err := c.signatureVerifier.Verify(ctx, msg.SignedSSVMessage, c.CommitteeMember.Committee); err != nil {
 			return errors.Wrap(err, "SignedSSVMessage has an invalid signature")
 		}
 
@@ -284,6 +302,9 @@ func (c *Committee) unsafePruneExpiredRunners(logger *zap.Logger, currentSlot ph
 			committeeDutyID := fields.FormatCommitteeDutyID(opIds, epoch, slot)
 			logger = logger.With(fields.DutyID(committeeDutyID))
 			logger.Debug("pruning expired committee runner", zap.Uint64("slot", uint64(slot)))
+			if r := c.Runners[slot]; r != nil {
+				r.EndDutySpans()
+			}
 			delete(c.Runners, slot)
 			delete(c.Queues, slot)
 		}
@@ -72,6 +72,18 @@ func (c *Committee) HandleMessage(_ context.Context, logger *zap.Logger, msg *qu
 //	}
 //}
 
+// QueueSaturation returns how full the queues of the committee are.
+func (c *Committee) QueueSaturation() queue.Saturation {
+	c.mtx.RLock() // read c.Queues
+	defer c.mtx.RUnlock()
+
+	var s queue.Saturation
+	for _, q := range c.Queues {
+		s.Add(q.Q)
+	}
+	return s
+}
+
 // ConsumeQueue consumes messages from the queue.Queue of the controller
 // it checks for current state
 func (c *Committee) ConsumeQueue(
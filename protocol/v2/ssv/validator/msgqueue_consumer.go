@@ -49,6 +49,18 @@ func (v *Validator) HandleMessage(_ context.Context, logger *zap.Logger, msg *qu
 	}
 }
 
+// QueueSaturation returns how full the queues of the validator are.
+func (v *Validator) QueueSaturation() queue.Saturation {
+	v.mtx.RLock() // read v.Queues
+	defer v.mtx.RUnlock()
+
+	var s queue.Saturation
+	for _, q := range v.Queues {
+		s.Add(q.Q)
+	}
+	return s
+}
+
 // StartQueueConsumer start ConsumeQueue with handler
 func (v *Validator) StartQueueConsumer(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) {
 	ctx, cancel := context.WithCancel(v.ctx)
@@ -31,6 +31,12 @@ import (
 	"github.com/ssvlabs/ssv/utils/casts"
 )
 
+// RootOrigin is the decided beacon vote and attestation committee a committee signing root was computed from.
+type RootOrigin struct {
+	BeaconVote     *spectypes.BeaconVote
+	CommitteeIndex phase0.CommitteeIndex
+}
+
 type CommitteeObserver struct {
 	logger                 *zap.Logger
 	Storage                *storage.QBFTStores
@@ -38,8 +44,8 @@ type CommitteeObserver struct {
 	qbftController         *qbftcontroller.Controller
 	ValidatorStore         registrystorage.ValidatorStore
 	newDecidedHandler      qbftcontroller.NewDecidedHandler
-	attesterRoots          *ttlcache.Cache[phase0.Root, struct{}]
-	syncCommRoots          *ttlcache.Cache[phase0.Root, struct{}]
+	attesterRoots          *ttlcache.Cache[phase0.Root, RootOrigin]
+	syncCommRoots          *ttlcache.Cache[phase0.Root, RootOrigin]
 	domainCache            *DomainCache
 	postConsensusContainer map[phase0.ValidatorIndex]*ssv.PartialSigContainer
 }
@@ -54,8 +60,8 @@ type CommitteeObserverOptions struct {
 	OperatorSigner    ssvtypes.OperatorSigner
 	NewDecidedHandler qbftctrl.NewDecidedHandler
 	ValidatorStore    registrystorage.ValidatorStore
-	AttesterRoots     *ttlcache.Cache[phase0.Root, struct{}]
-	SyncCommRoots     *ttlcache.Cache[phase0.Root, struct{}]
+	AttesterRoots     *ttlcache.Cache[phase0.Root, RootOrigin]
+	SyncCommRoots     *ttlcache.Cache[phase0.Root, RootOrigin]
 	DomainCache       *DomainCache
 }
 
@@ -160,6 +166,19 @@ func (ncv *CommitteeObserver) ProcessMessage(msg *queue.SSVMessage) error {
 				continue
 			}
 
+			// the saved participants are merged with the ones saved before, such as by an earlier quorum
+			// for the same slot, so the proof has to cover them as well.
+			proof := ncv.participantsProof(key, beaconRole, quorum)
+			storedProof, err := roleStorage.GetParticipantsProof(msgID, slot)
+			if err != nil {
+				return fmt.Errorf("get participants proof: %w", err)
+			}
+			mergeParticipantsProof(proof, storedProof)
+
+			if err := roleStorage.SaveParticipantsProof(msgID, slot, proof); err != nil {
+				return fmt.Errorf("save participants proof: %w", err)
+			}
+
 			logger.Info("✅ saved participants",
 				zap.String("converted_role", beaconRole.ToBeaconRole()),
 				zap.Uint64("validator_index", uint64(key.ValidatorIndex)),
@@ -201,6 +220,55 @@ func (ncv *CommitteeObserver) getBeaconRoles(msg *queue.SSVMessage, root phase0.
 	return []convert.RunnerRole{casts.RunnerRoleToConvertRole(msg.MsgID.GetRoleType())}
 }
 
+// participantsProof collects the partial signatures of the given quorum,
+// so peers syncing decided history from us are able to verify the participants.
+func (ncv *CommitteeObserver) participantsProof(key validatorIndexAndRoot, role convert.RunnerRole, quorum []spectypes.OperatorID) *qbftstorage.ParticipantsProof {
+	proof := &qbftstorage.ParticipantsProof{
+		ValidatorIndex: key.ValidatorIndex,
+		SigningRoot:    key.Root,
+		Signatures:     make(map[spectypes.OperatorID]spectypes.Signature, len(quorum)),
+	}
+	var origin *ttlcache.Item[phase0.Root, RootOrigin]
+	switch role {
+	case convert.RoleAttester:
+		origin = ncv.attesterRoots.Get(key.Root)
+	case convert.RoleSyncCommittee:
+		origin = ncv.syncCommRoots.Get(key.Root)
+	}
+	if origin != nil {
+		proof.BeaconVote = origin.Value().BeaconVote
+		proof.CommitteeIndex = origin.Value().CommitteeIndex
+	}
+	container, ok := ncv.postConsensusContainer[key.ValidatorIndex]
+	if !ok {
+		return proof
+	}
+	signatures := container.GetSignatures(key.ValidatorIndex, key.Root)
+	for _, signer := range quorum {
+		if sig, ok := signatures[signer]; ok {
+			proof.Signatures[signer] = sig
+		}
+	}
+	return proof
+}
+
+// mergeParticipantsProof adds the signatures of the stored proof which are missing from the given proof,
+// as long as both prove the same signing root.
+func mergeParticipantsProof(proof, stored *qbftstorage.ParticipantsProof) {
+	if stored == nil || stored.ValidatorIndex != proof.ValidatorIndex || stored.SigningRoot != proof.SigningRoot {
+		return
+	}
+	for signer, sig := range stored.Signatures {
+		if _, ok := proof.Signatures[signer]; !ok {
+			proof.Signatures[signer] = sig
+		}
+	}
+	if proof.BeaconVote == nil {
+		proof.BeaconVote = stored.BeaconVote
+		proof.CommitteeIndex = stored.CommitteeIndex
+	}
+}
+
 // nonCommitteeInstanceContainerCapacity returns the capacity of InstanceContainer for non-committee validators
 func nonCommitteeInstanceContainerCapacity(fullNode bool) int {
 	if fullNode {
@@ -337,7 +405,7 @@ func (ncv *CommitteeObserver) saveAttesterRoots(epoch phase0.Epoch, beaconVote *
 			return err
 		}
 
-		ncv.attesterRoots.Set(attesterRoot, struct{}{}, ttlcache.DefaultTTL)
+		ncv.attesterRoots.Set(attesterRoot, RootOrigin{BeaconVote: beaconVote, CommitteeIndex: committeeIndex}, ttlcache.DefaultTTL)
 	}
 
 	return nil
@@ -355,7 +423,7 @@ func (ncv *CommitteeObserver) saveSyncCommRoots(epoch phase0.Epoch, beaconVote *
 		return err
 	}
 
-	ncv.syncCommRoots.Set(syncCommitteeRoot, struct{}{}, ttlcache.DefaultTTL)
+	ncv.syncCommRoots.Set(syncCommitteeRoot, RootOrigin{BeaconVote: beaconVote}, ttlcache.DefaultTTL)
 
 	return nil
 }
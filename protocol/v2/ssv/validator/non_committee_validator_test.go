@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jellydator/ttlcache/v3"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystoragemocks "github.com/ssvlabs/ssv/registry/storage/mocks"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestCommitteeObserver_SecondQuorumProof(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	const (
+		validatorIndex = phase0.ValidatorIndex(1)
+		slot           = phase0.Slot(100)
+	)
+	keySet := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{Share: *spectestingutils.TestingShare(keySet, validatorIndex)}
+
+	ctrl := gomock.NewController(t)
+	validatorStore := registrystoragemocks.NewMockValidatorStore(ctrl)
+	validatorStore.EXPECT().ValidatorByIndex(validatorIndex).Return(share, true).AnyTimes()
+
+	stores := storage.NewStoresFromRoles(db, convert.RoleAttester)
+	root := phase0.Root{1, 2, 3}
+	attesterRoots := ttlcache.New(ttlcache.WithTTL[phase0.Root, RootOrigin](time.Minute))
+	attesterRoots.Set(root, RootOrigin{CommitteeIndex: 7}, ttlcache.DefaultTTL)
+
+	committeeID := share.CommitteeID()
+	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType, committeeID[:], spectypes.RoleCommittee)
+	newObserver := func() *CommitteeObserver {
+		return NewCommitteeObserver(convert.MessageID(msgID), CommitteeObserverOptions{
+			Logger:         zap.NewNop(),
+			NetworkConfig:  networkconfig.TestNetwork,
+			Storage:        stores,
+			Operator:       &spectypes.CommitteeMember{},
+			ValidatorStore: validatorStore,
+			AttesterRoots:  attesterRoots,
+			SyncCommRoots:  ttlcache.New[phase0.Root, RootOrigin](),
+		})
+	}
+	process := func(observer *CommitteeObserver, signers ...spectypes.OperatorID) {
+		for _, signer := range signers {
+			msgs := &spectypes.PartialSignatureMessages{
+				Type: spectypes.PostConsensusPartialSig,
+				Slot: slot,
+				Messages: []*spectypes.PartialSignatureMessage{{
+					PartialSignature: keySet.Shares[signer].SignByte(root[:]).Serialize(),
+					SigningRoot:      root,
+					Signer:           signer,
+					ValidatorIndex:   validatorIndex,
+				}},
+			}
+			data, err := msgs.Encode()
+			require.NoError(t, err)
+			msg, err := queue.DecodeSSVMessage(&spectypes.SSVMessage{
+				MsgType: spectypes.SSVPartialSignatureMsgType,
+				MsgID:   msgID,
+				Data:    data,
+			})
+			require.NoError(t, err)
+			require.NoError(t, observer.ProcessMessage(msg))
+		}
+	}
+
+	attesterID := convert.NewMsgID(networkconfig.TestNetwork.DomainType, share.ValidatorPubKey[:], convert.RoleAttester)
+	roleStorage := stores.Get(convert.RoleAttester)
+
+	process(newObserver(), 1, 2, 3)
+	participants, err := roleStorage.GetParticipants(attesterID, slot)
+	require.NoError(t, err)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, participants)
+
+	// a second quorum for the same slot, such as after a restart, only holds part of the signatures
+	process(newObserver(), 2, 3, 4)
+	participants, err = roleStorage.GetParticipants(attesterID, slot)
+	require.NoError(t, err)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, participants)
+
+	proof, err := roleStorage.GetParticipantsProof(attesterID, slot)
+	require.NoError(t, err)
+	require.NotNil(t, proof)
+	require.Equal(t, root, proof.SigningRoot)
+	require.EqualValues(t, 7, proof.CommitteeIndex)
+	require.Len(t, proof.Signatures, len(participants))
+	for _, signer := range participants {
+		require.NoError(t, newObserver().verifyBeaconPartialSignature(signer, proof.Signatures[signer], root, share))
+	}
+}
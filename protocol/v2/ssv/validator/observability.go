@@ -0,0 +1,18 @@
+package validator
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
+	observabilityNamespace = "ssv.validator"
+)
+
+var tracer = otel.Tracer(observabilityName)
+
+func spanName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	qbftctrl "github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/valuecheck"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
 
@@ -35,6 +37,11 @@ type Options struct {
 	GasLimit          uint64
 	MessageValidator  validation.MessageValidator
 	Graffiti          []byte
+	// SignatureVerifier verifies the operator signatures of messages, it's shared with message validation
+	// so that messages aren't verified twice
+	SignatureVerifier qbft.SignatureVerifier
+	// ValueCheckPolicy is checked by proposed values in addition to the spec value checks
+	ValueCheckPolicy *valuecheck.Policy
 }
 
 func (o *Options) defaults() {
@@ -44,6 +51,9 @@ func (o *Options) defaults() {
 	if o.GasLimit == 0 {
 		o.GasLimit = spectypes.DefaultGasLimit
 	}
+	if o.SignatureVerifier == nil {
+		o.SignatureVerifier = qbft.SpecSignatureVerifier{}
+	}
 }
 
 // State of the validator
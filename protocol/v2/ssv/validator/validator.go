@@ -15,6 +15,7 @@ import (
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
@@ -45,7 +46,8 @@ type Validator struct {
 
 	state uint32
 
-	messageValidator validation.MessageValidator
+	messageValidator  validation.MessageValidator
+	signatureVerifier qbft.SignatureVerifier
 }
 
 // NewValidator creates a new instance of Validator.
@@ -53,20 +55,21 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 	options.defaults()
 
 	v := &Validator{
-		mtx:              &sync.RWMutex{},
-		ctx:              pctx,
-		cancel:           cancel,
-		NetworkConfig:    options.NetworkConfig,
-		DutyRunners:      options.DutyRunners,
-		Network:          options.Network,
-		Operator:         options.Operator,
-		Share:            options.SSVShare,
-		Signer:           options.Signer,
-		OperatorSigner:   options.OperatorSigner,
-		Queues:           make(map[spectypes.RunnerRole]queueContainer),
-		state:            uint32(NotStarted),
-		dutyIDs:          hashmap.New[spectypes.RunnerRole, string](), // TODO: use beaconrole here?
-		messageValidator: options.MessageValidator,
+		mtx:               &sync.RWMutex{},
+		ctx:               pctx,
+		cancel:            cancel,
+		NetworkConfig:     options.NetworkConfig,
+		DutyRunners:       options.DutyRunners,
+		Network:           options.Network,
+		Operator:          options.Operator,
+		Share:             options.SSVShare,
+		Signer:            options.Signer,
+		OperatorSigner:    options.OperatorSigner,
+		Queues:            make(map[spectypes.RunnerRole]queueContainer),
+		state:             uint32(NotStarted),
+		dutyIDs:           hashmap.New[spectypes.RunnerRole, string](), // TODO: use beaconrole here?
+		messageValidator:  options.MessageValidator,
+		signatureVerifier: options.SignatureVerifier,
 	}
 
 	for _, dutyRunner := range options.DutyRunners {
@@ -126,7 +129,7 @@ func (v *Validator) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 		}
 
 		// Verify SignedSSVMessage's signature
-		if err := spectypes.Verify(msg.SignedSSVMessage, v.Operator.Committee); err != nil {
+		if err := v.signatureVerifier.Verify(ctx, msg.SignedSSVMessage, v.Operator.Committee); err != nil {
 			return errors.Wrap(err, "SignedSSVMessage has an invalid signature")
 		}
 	}
@@ -0,0 +1,165 @@
+package valuecheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// FeeRecipientCheck rejects blocks whose fee recipient differs from the one registered for the validator.
+// Blinded blocks aren't checked, as builders pay the proposer within the payload they build for themselves.
+type FeeRecipientCheck struct{}
+
+func (FeeRecipientCheck) Name() string { return "fee_recipient" }
+
+func (FeeRecipientCheck) CheckProposal(proposal *Proposal) error {
+	if proposal.Block == nil {
+		return nil
+	}
+	feeRecipient, err := proposal.Block.FeeRecipient()
+	if err != nil {
+		return fmt.Errorf("failed to get fee recipient: %w", err)
+	}
+	if feeRecipient != proposal.FeeRecipient {
+		return fmt.Errorf("fee recipient %s differs from registered %s", feeRecipient, proposal.FeeRecipient)
+	}
+	return nil
+}
+
+// GasLimitCheck rejects blocks whose gas limit is above Max.
+type GasLimitCheck struct {
+	Max uint64
+}
+
+func (GasLimitCheck) Name() string { return "gas_limit" }
+
+func (c GasLimitCheck) CheckProposal(proposal *Proposal) error {
+	gasLimit, err := proposalGasLimit(proposal)
+	if err != nil {
+		return err
+	}
+	if gasLimit > c.Max {
+		return fmt.Errorf("gas limit %d is above %d", gasLimit, c.Max)
+	}
+	return nil
+}
+
+func proposalGasLimit(proposal *Proposal) (uint64, error) {
+	if block := proposal.Block; block != nil {
+		switch {
+		case block.Version == spec.DataVersionCapella && block.Capella != nil && block.Capella.Body != nil && block.Capella.Body.ExecutionPayload != nil:
+			return block.Capella.Body.ExecutionPayload.GasLimit, nil
+		case block.Version == spec.DataVersionDeneb && block.Deneb != nil && block.Deneb.Block != nil && block.Deneb.Block.Body != nil && block.Deneb.Block.Body.ExecutionPayload != nil:
+			return block.Deneb.Block.Body.ExecutionPayload.GasLimit, nil
+		default:
+			return 0, fmt.Errorf("no execution payload in %s block", block.Version)
+		}
+	}
+	if block := proposal.BlindedBlock; block != nil {
+		switch {
+		case block.Version == spec.DataVersionCapella && block.Capella != nil && block.Capella.Body != nil && block.Capella.Body.ExecutionPayloadHeader != nil:
+			return block.Capella.Body.ExecutionPayloadHeader.GasLimit, nil
+		case block.Version == spec.DataVersionDeneb && block.Deneb != nil && block.Deneb.Body != nil && block.Deneb.Body.ExecutionPayloadHeader != nil:
+			return block.Deneb.Body.ExecutionPayloadHeader.GasLimit, nil
+		default:
+			return 0, fmt.Errorf("no execution payload header in %s blinded block", block.Version)
+		}
+	}
+	return 0, fmt.Errorf("no block")
+}
+
+// HeadProvider streams the events of our beacon node.
+type HeadProvider interface {
+	Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error
+}
+
+// HeadDivergenceCheck rejects beacon votes whose head block is more than MaxSlots slots away
+// from the head of our beacon node, or is unknown to our beacon node.
+//
+// The head of our beacon node and the slots of the blocks it imported are cached from its event stream,
+// so that checking a vote doesn't wait for the beacon node.
+type HeadDivergenceCheck struct {
+	maxSlots uint64
+
+	mu       sync.RWMutex
+	head     phase0.Root
+	headSlot phase0.Slot
+	// firstSlot is the slot of the first head event, blocks of earlier slots may be missing from blockSlots
+	firstSlot  phase0.Slot
+	hasHead    bool
+	blockSlots map[phase0.Root]phase0.Slot
+}
+
+func NewHeadDivergenceCheck(maxSlots uint64) *HeadDivergenceCheck {
+	return &HeadDivergenceCheck{
+		maxSlots:   maxSlots,
+		blockSlots: make(map[phase0.Root]phase0.Slot),
+	}
+}
+
+// Subscribe caches the head and the blocks of the beacon node's event stream until ctx is done.
+func (c *HeadDivergenceCheck) Subscribe(ctx context.Context, beaconNode HeadProvider) error {
+	return beaconNode.Events(ctx, []string{"head", "block"}, c.HandleEvent)
+}
+
+// HandleEvent caches the head and block events of the beacon node.
+func (c *HeadDivergenceCheck) HandleEvent(event *eth2apiv1.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch data := event.Data.(type) {
+	case *eth2apiv1.HeadEvent:
+		if !c.hasHead {
+			c.firstSlot = data.Slot
+			c.hasHead = true
+		}
+		c.head = data.Block
+		c.headSlot = data.Slot
+		c.blockSlots[data.Block] = data.Slot
+
+		// blocks further than twice the divergence from the head are forgotten,
+		// they're rejected for being unknown just as they would be for diverging
+		for root, slot := range c.blockSlots {
+			if uint64(slot)+2*c.maxSlots < uint64(c.headSlot) {
+				delete(c.blockSlots, root)
+			}
+		}
+	case *eth2apiv1.BlockEvent:
+		c.blockSlots[data.Block] = data.Slot
+	}
+}
+
+func (*HeadDivergenceCheck) Name() string { return "head_divergence" }
+
+func (c *HeadDivergenceCheck) CheckBeaconVote(_ phase0.Slot, vote *spectypes.BeaconVote) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.hasHead {
+		return fmt.Errorf("%w: no head event received from the beacon node yet", ErrUnavailable)
+	}
+	if c.head == vote.BlockRoot {
+		return nil
+	}
+
+	proposedSlot, found := c.blockSlots[vote.BlockRoot]
+	if !found {
+		if uint64(c.firstSlot)+c.maxSlots > uint64(c.headSlot) {
+			// the block may have been imported before the events were subscribed to
+			return fmt.Errorf("%w: head %s wasn't seen since the events were subscribed to", ErrUnavailable, vote.BlockRoot)
+		}
+		return fmt.Errorf("head %s is unknown to the beacon node", vote.BlockRoot)
+	}
+
+	divergence := uint64(max(c.headSlot, proposedSlot) - min(c.headSlot, proposedSlot))
+	if divergence > c.maxSlots {
+		return fmt.Errorf("head of slot %d diverges from our head of slot %d by more than %d slots", proposedSlot, c.headSlot, c.maxSlots)
+	}
+	return nil
+}
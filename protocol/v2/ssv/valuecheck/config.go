@@ -0,0 +1,8 @@
+package valuecheck
+
+// Config configures the policy checks, zero values disable them.
+type Config struct {
+	FeeRecipient      bool   `yaml:"FeeRecipient" env:"VALUE_CHECK_FEE_RECIPIENT" env-description:"Reject proposed blocks whose fee recipient differs from the one registered for the validator"`
+	MaxHeadDivergence uint64 `yaml:"MaxHeadDivergence" env:"VALUE_CHECK_MAX_HEAD_DIVERGENCE" env-description:"Reject proposed attestations whose head is more than this number of slots away from the head of our beacon node"`
+	MaxGasLimit       uint64 `yaml:"MaxGasLimit" env:"VALUE_CHECK_MAX_GAS_LIMIT" env-description:"Reject proposed blocks whose gas limit is above this value"`
+}
@@ -0,0 +1,56 @@
+package valuecheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const (
+	observabilityName      = "github.com/ssvlabs/ssv/protocol/v2/ssv/valuecheck"
+	observabilityNamespace = "ssv.validator.value_check"
+)
+
+const (
+	outcomePassed      = "passed"
+	outcomeRejected    = "rejected"
+	outcomeUnavailable = "unavailable"
+)
+
+var (
+	meter = otel.Meter(observabilityName)
+
+	checksCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("checks"),
+			metric.WithUnit("{check}"),
+			metric.WithDescription("total number of value checks by outcome")))
+
+	checkDurationHistogram = observability.NewMetric(
+		meter.Float64Histogram(
+			metricName("duration"),
+			metric.WithUnit("s"),
+			metric.WithDescription("value check duration"),
+			metric.WithExplicitBucketBoundaries(observability.SecondsHistogramBuckets...)))
+)
+
+func metricName(name string) string {
+	return fmt.Sprintf("%s.%s", observabilityNamespace, name)
+}
+
+func recordCheck(check, outcome string, duration time.Duration) {
+	checkAttribute := attribute.String("ssv.validator.value_check.name", check)
+	checksCounter.Add(context.Background(), 1,
+		metric.WithAttributes(
+			checkAttribute,
+			attribute.String("ssv.validator.value_check.outcome", outcome),
+		))
+	checkDurationHistogram.Record(context.Background(), duration.Seconds(),
+		metric.WithAttributes(checkAttribute))
+}
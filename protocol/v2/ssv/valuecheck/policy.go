@@ -0,0 +1,192 @@
+// Package valuecheck implements operator policies for values proposed in consensus, which are checked
+// in addition to the spec value checks. A value failing any policy check is rejected, so the operator
+// doesn't vote for it, and doesn't propose it when it's the leader.
+package valuecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// ErrUnavailable is wrapped by checks that couldn't evaluate a value, such as when the beacon node is unreachable.
+// Such values aren't rejected, so that the policy doesn't halt duties when its dependencies are down.
+var ErrUnavailable = errors.New("value check unavailable")
+
+// Check is a named policy check.
+type Check interface {
+	Name() string
+}
+
+// BeaconVoteCheck checks the beacon votes proposed in committee consensus.
+type BeaconVoteCheck interface {
+	Check
+	CheckBeaconVote(slot phase0.Slot, vote *spectypes.BeaconVote) error
+}
+
+// ProposalCheck checks the blocks proposed in proposer consensus.
+type ProposalCheck interface {
+	Check
+	CheckProposal(proposal *Proposal) error
+}
+
+// Proposal is a block proposed for a validator, either full or blinded.
+type Proposal struct {
+	Block        *eth2api.VersionedProposal
+	BlindedBlock *eth2api.VersionedBlindedProposal
+	// FeeRecipient is the fee recipient the validator's owner registered
+	FeeRecipient bellatrix.ExecutionAddress
+}
+
+type Option func(*Policy)
+
+// WithChecks adds custom checks to the policy, after the configured ones.
+// Checks implementing neither BeaconVoteCheck nor ProposalCheck are ignored.
+func WithChecks(checks ...Check) Option {
+	return func(p *Policy) {
+		for _, check := range checks {
+			p.add(check)
+		}
+	}
+}
+
+// Policy is a chain of checks that proposed values must pass.
+// A nil Policy has no checks.
+type Policy struct {
+	logger           *zap.Logger
+	beaconVoteChecks []BeaconVoteCheck
+	proposalChecks   []ProposalCheck
+}
+
+// New creates the policy of the configured checks. beaconNode is required by the head divergence check only,
+// whose subscription to the events of the beacon node lasts until ctx is done.
+func New(ctx context.Context, logger *zap.Logger, cfg Config, beaconNode HeadProvider, opts ...Option) (*Policy, error) {
+	p := &Policy{
+		logger: logger.Named("value_check"),
+	}
+	if cfg.FeeRecipient {
+		p.add(FeeRecipientCheck{})
+	}
+	if cfg.MaxGasLimit != 0 {
+		p.add(GasLimitCheck{Max: cfg.MaxGasLimit})
+	}
+	if cfg.MaxHeadDivergence != 0 {
+		if beaconNode == nil {
+			return nil, fmt.Errorf("head divergence check requires a beacon node")
+		}
+		check := NewHeadDivergenceCheck(cfg.MaxHeadDivergence)
+		if err := check.Subscribe(ctx, beaconNode); err != nil {
+			return nil, fmt.Errorf("failed to subscribe to head events: %w", err)
+		}
+		p.add(check)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	for _, check := range p.beaconVoteChecks {
+		p.logger.Info("enabled beacon vote check", zap.String("check", check.Name()))
+	}
+	for _, check := range p.proposalChecks {
+		p.logger.Info("enabled proposal check", zap.String("check", check.Name()))
+	}
+	return p, nil
+}
+
+func (p *Policy) add(check Check) {
+	if c, ok := check.(BeaconVoteCheck); ok {
+		p.beaconVoteChecks = append(p.beaconVoteChecks, c)
+	}
+	if c, ok := check.(ProposalCheck); ok {
+		p.proposalChecks = append(p.proposalChecks, c)
+	}
+}
+
+// WrapBeaconVote returns a value check running the beacon vote checks of the policy after the given check.
+func (p *Policy) WrapBeaconVote(valueCheck specqbft.ProposedValueCheckF, slot phase0.Slot) specqbft.ProposedValueCheckF {
+	if p == nil || len(p.beaconVoteChecks) == 0 {
+		return valueCheck
+	}
+	return func(data []byte) error {
+		if err := valueCheck(data); err != nil {
+			return err
+		}
+
+		vote := &spectypes.BeaconVote{}
+		if err := vote.Decode(data); err != nil {
+			return fmt.Errorf("failed decoding beacon vote: %w", err)
+		}
+		for _, check := range p.beaconVoteChecks {
+			if err := p.run(check, slot, func() error { return check.CheckBeaconVote(slot, vote) }); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// WrapProposer returns a value check running the proposal checks of the policy after the given check.
+// feeRecipient returns the validator's current fee recipient.
+func (p *Policy) WrapProposer(valueCheck specqbft.ProposedValueCheckF, feeRecipient func() bellatrix.ExecutionAddress) specqbft.ProposedValueCheckF {
+	if p == nil || len(p.proposalChecks) == 0 {
+		return valueCheck
+	}
+	return func(data []byte) error {
+		if err := valueCheck(data); err != nil {
+			return err
+		}
+
+		cd := &spectypes.ValidatorConsensusData{}
+		if err := cd.Decode(data); err != nil {
+			return fmt.Errorf("failed decoding consensus data: %w", err)
+		}
+		proposal := &Proposal{FeeRecipient: feeRecipient()}
+		if block, _, err := cd.GetBlindedBlockData(); err == nil {
+			proposal.BlindedBlock = block
+		} else if block, _, err := cd.GetBlockData(); err == nil {
+			proposal.Block = block
+		} else {
+			return fmt.Errorf("no block data")
+		}
+
+		for _, check := range p.proposalChecks {
+			if err := p.run(check, cd.Duty.Slot, func() error { return check.CheckProposal(proposal) }); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// run runs the check and records its outcome, returning the error to reject the value with.
+func (p *Policy) run(check Check, slot phase0.Slot, f func() error) error {
+	start := time.Now()
+	err := f()
+	duration := time.Since(start)
+
+	switch {
+	case err == nil:
+		recordCheck(check.Name(), outcomePassed, duration)
+		return nil
+	case errors.Is(err, ErrUnavailable):
+		recordCheck(check.Name(), outcomeUnavailable, duration)
+		p.logger.Warn("value check unavailable, accepting value",
+			zap.String("check", check.Name()),
+			fields.Slot(slot),
+			zap.Error(err))
+		return nil
+	default:
+		recordCheck(check.Name(), outcomeRejected, duration)
+		return fmt.Errorf("value rejected by %s check: %w", check.Name(), err)
+	}
+}
@@ -0,0 +1,189 @@
+package valuecheck
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func passCheck([]byte) error { return nil }
+
+// testHeadProvider streams the given events when subscribed to
+type testHeadProvider struct {
+	events []*eth2apiv1.Event
+	err    error
+}
+
+func (p *testHeadProvider) Events(_ context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	if p.err != nil {
+		return p.err
+	}
+	for _, event := range p.events {
+		if slices.Contains(topics, event.Topic) {
+			handler(event)
+		}
+	}
+	return nil
+}
+
+func headEvent(slot phase0.Slot, root phase0.Root) *eth2apiv1.Event {
+	return &eth2apiv1.Event{Topic: "head", Data: &eth2apiv1.HeadEvent{Slot: slot, Block: root}}
+}
+
+func blockEvent(slot phase0.Slot, root phase0.Root) *eth2apiv1.Event {
+	return &eth2apiv1.Event{Topic: "block", Data: &eth2apiv1.BlockEvent{Slot: slot, Block: root}}
+}
+
+func TestPolicy_Nil(t *testing.T) {
+	var policy *Policy
+	failing := func([]byte) error { return errors.New("spec check failed") }
+
+	require.EqualError(t, policy.WrapBeaconVote(failing, 1)(nil), "spec check failed")
+	require.EqualError(t, policy.WrapProposer(failing, nil)(nil), "spec check failed")
+}
+
+func TestPolicy_FeeRecipient(t *testing.T) {
+	policy, err := New(context.Background(), logging.TestLogger(t), Config{FeeRecipient: true}, nil)
+	require.NoError(t, err)
+
+	block := spectestingutils.TestingBeaconBlockV(spec.DataVersionDeneb)
+	feeRecipient, err := block.FeeRecipient()
+	require.NoError(t, err)
+	data := spectestingutils.TestProposerConsensusDataBytsV(spec.DataVersionDeneb)
+
+	valueCheck := policy.WrapProposer(passCheck, func() bellatrix.ExecutionAddress { return feeRecipient })
+	require.NoError(t, valueCheck(data))
+
+	valueCheck = policy.WrapProposer(passCheck, func() bellatrix.ExecutionAddress { return bellatrix.ExecutionAddress{0x1} })
+	require.ErrorContains(t, valueCheck(data), "value rejected by fee_recipient check")
+
+	// blinded blocks pay the proposer within the payload
+	require.NoError(t, valueCheck(spectestingutils.TestProposerBlindedBlockConsensusDataBytsV(spec.DataVersionDeneb)))
+}
+
+func TestPolicy_GasLimit(t *testing.T) {
+	block := spectestingutils.TestingBeaconBlockV(spec.DataVersionDeneb)
+	gasLimit := block.Deneb.Block.Body.ExecutionPayload.GasLimit
+	noFeeRecipient := func() bellatrix.ExecutionAddress { return bellatrix.ExecutionAddress{} }
+
+	for _, version := range []spec.DataVersion{spec.DataVersionCapella, spec.DataVersionDeneb} {
+		t.Run(version.String(), func(t *testing.T) {
+			for _, data := range [][]byte{
+				spectestingutils.TestProposerConsensusDataBytsV(version),
+				spectestingutils.TestProposerBlindedBlockConsensusDataBytsV(version),
+			} {
+				policy, err := New(context.Background(), logging.TestLogger(t), Config{MaxGasLimit: gasLimit}, nil)
+				require.NoError(t, err)
+				require.NoError(t, policy.WrapProposer(passCheck, noFeeRecipient)(data))
+
+				policy, err = New(context.Background(), logging.TestLogger(t), Config{MaxGasLimit: gasLimit - 1}, nil)
+				require.NoError(t, err)
+				require.ErrorContains(t, policy.WrapProposer(passCheck, noFeeRecipient)(data), "value rejected by gas_limit check")
+			}
+		})
+	}
+}
+
+func TestPolicy_HeadDivergence(t *testing.T) {
+	vote := spectestingutils.TestBeaconVote
+	data := spectestingutils.TestBeaconVoteByts
+	ourHead := phase0.Root{0x1}
+
+	t.Run("requires beacon node", func(t *testing.T) {
+		_, err := New(context.Background(), logging.TestLogger(t), Config{MaxHeadDivergence: 2}, nil)
+		require.Error(t, err)
+	})
+
+	tests := []struct {
+		name    string
+		events  []*eth2apiv1.Event
+		err     error
+		wantErr string
+	}{
+		{
+			name:   "same head",
+			events: []*eth2apiv1.Event{headEvent(12, vote.BlockRoot)},
+		},
+		{
+			name:   "within divergence",
+			events: []*eth2apiv1.Event{headEvent(10, vote.BlockRoot), headEvent(12, ourHead)},
+		},
+		{
+			name:   "block that wasn't head within divergence",
+			events: []*eth2apiv1.Event{headEvent(9, phase0.Root{0x2}), blockEvent(10, vote.BlockRoot), headEvent(11, ourHead)},
+		},
+		{
+			name:    "beyond divergence",
+			events:  []*eth2apiv1.Event{headEvent(10, vote.BlockRoot), headEvent(13, ourHead)},
+			wantErr: "value rejected by head_divergence check",
+		},
+		{
+			name:    "unknown head",
+			events:  []*eth2apiv1.Event{headEvent(10, phase0.Root{0x2}), headEvent(13, ourHead)},
+			wantErr: "is unknown to the beacon node",
+		},
+		{
+			name:   "head not seen since subscribing",
+			events: []*eth2apiv1.Event{headEvent(13, ourHead)},
+		},
+		{
+			name: "no head event yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beaconNode := &testHeadProvider{events: tt.events}
+			policy, err := New(context.Background(), logging.TestLogger(t), Config{MaxHeadDivergence: 2}, beaconNode)
+			require.NoError(t, err)
+
+			err = policy.WrapBeaconVote(passCheck, 13)(data)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("subscription failure", func(t *testing.T) {
+		beaconNode := &testHeadProvider{err: errors.New("connection refused")}
+		_, err := New(context.Background(), logging.TestLogger(t), Config{MaxHeadDivergence: 2}, beaconNode)
+		require.ErrorContains(t, err, "failed to subscribe to head events")
+	})
+
+	t.Run("old blocks are forgotten", func(t *testing.T) {
+		check := NewHeadDivergenceCheck(2)
+		check.HandleEvent(headEvent(10, vote.BlockRoot))
+		check.HandleEvent(headEvent(15, ourHead))
+		require.Len(t, check.blockSlots, 1)
+	})
+}
+
+type rejectAllCheck struct{}
+
+func (rejectAllCheck) Name() string { return "reject_all" }
+
+func (rejectAllCheck) CheckBeaconVote(phase0.Slot, *spectypes.BeaconVote) error {
+	return errors.New("rejected")
+}
+
+func TestPolicy_WithChecks(t *testing.T) {
+	policy, err := New(context.Background(), logging.TestLogger(t), Config{}, nil, WithChecks(rejectAllCheck{}))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, policy.WrapBeaconVote(passCheck, 1)(spectestingutils.TestBeaconVoteByts), "value rejected by reject_all check")
+	// the check doesn't apply to proposals
+	require.NoError(t, policy.WrapProposer(passCheck, nil)(spectestingutils.TestProposerConsensusDataBytsV(spec.DataVersionDeneb)))
+}
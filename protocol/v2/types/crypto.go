@@ -16,3 +16,21 @@ func VerifyReconstructedSignature(sig *bls.Sign, validatorPubKey []byte, root [3
 	}
 	return nil
 }
+
+// VerifyBatch verifies signatures of 32-byte roots in a single batch, by checking a random linear combination
+// of them against the combination of their public keys. It returns false if any of the signatures is invalid,
+// without telling which, so callers should fall back to verifying each signature when it fails.
+func VerifyBatch(sigs []bls.Sign, pubKeys []bls.PublicKey, roots [][32]byte) bool {
+	if len(sigs) == 0 || len(sigs) != len(pubKeys) || len(sigs) != len(roots) {
+		return false
+	}
+	if len(sigs) == 1 {
+		return sigs[0].VerifyByte(&pubKeys[0], roots[0][:])
+	}
+
+	msgs := make([]byte, 0, len(roots)*len(roots[0]))
+	for _, root := range roots {
+		msgs = append(msgs, root[:]...)
+	}
+	return bls.MultiVerify(sigs, pubKeys, msgs)
+}
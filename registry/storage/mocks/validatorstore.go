@@ -323,18 +323,18 @@ func (mr *MockValidatorStoreMockRecorder) Validators() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validators", reflect.TypeOf((*MockValidatorStore)(nil).Validators))
 }
 
-// WithOperatorID mocks base method.
-func (m *MockValidatorStore) WithOperatorID(operatorID func() types.OperatorID) storage.SelfValidatorStore {
+// WithOperatorIDs mocks base method.
+func (m *MockValidatorStore) WithOperatorIDs(operatorIDs func() []types.OperatorID) storage.SelfValidatorStore {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WithOperatorID", operatorID)
+	ret := m.ctrl.Call(m, "WithOperatorIDs", operatorIDs)
 	ret0, _ := ret[0].(storage.SelfValidatorStore)
 	return ret0
 }
 
-// WithOperatorID indicates an expected call of WithOperatorID.
-func (mr *MockValidatorStoreMockRecorder) WithOperatorID(operatorID any) *gomock.Call {
+// WithOperatorIDs indicates an expected call of WithOperatorIDs.
+func (mr *MockValidatorStoreMockRecorder) WithOperatorIDs(operatorIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithOperatorID", reflect.TypeOf((*MockValidatorStore)(nil).WithOperatorID), operatorID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithOperatorIDs", reflect.TypeOf((*MockValidatorStore)(nil).WithOperatorIDs), operatorIDs)
 }
 
 // MockSelfValidatorStore is a mock of SelfValidatorStore interface.
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -420,6 +421,13 @@ func ByOperatorID(operatorID spectypes.OperatorID) SharesFilter {
 	}
 }
 
+// ByOperatorIDs filters by any of the given operator IDs.
+func ByOperatorIDs(operatorIDs ...spectypes.OperatorID) SharesFilter {
+	return func(share *types.SSVShare) bool {
+		return slices.ContainsFunc(operatorIDs, share.BelongsToOperator)
+	}
+}
+
 // ByNotLiquidated filters for not liquidated.
 func ByNotLiquidated() SharesFilter {
 	return func(share *types.SSVShare) bool {
@@ -32,7 +32,7 @@ type BaseValidatorStore interface {
 type ValidatorStore interface {
 	BaseValidatorStore
 
-	WithOperatorID(operatorID func() spectypes.OperatorID) SelfValidatorStore
+	WithOperatorIDs(operatorIDs func() []spectypes.OperatorID) SelfValidatorStore
 }
 
 type SelfValidatorStore interface {
@@ -68,9 +68,9 @@ type sharesAndCommittees struct {
 }
 
 type validatorStore struct {
-	operatorID func() spectypes.OperatorID
-	shares     func() []*types.SSVShare
-	byPubKey   func([]byte) (*types.SSVShare, bool)
+	operatorIDs func() []spectypes.OperatorID
+	shares      func() []*types.SSVShare
+	byPubKey    func([]byte) (*types.SSVShare, bool)
 
 	byValidatorIndex map[phase0.ValidatorIndex]*types.SSVShare
 	byCommitteeID    map[spectypes.CommitteeID]*Committee
@@ -174,23 +174,25 @@ func (c *validatorStore) OperatorCommittees(id spectypes.OperatorID) []*Committe
 	return nil
 }
 
-func (c *validatorStore) WithOperatorID(operatorID func() spectypes.OperatorID) SelfValidatorStore {
-	c.operatorID = operatorID
+// WithOperatorIDs returns the store of the validators of the given operators,
+// which are those the node runs as.
+func (c *validatorStore) WithOperatorIDs(operatorIDs func() []spectypes.OperatorID) SelfValidatorStore {
+	c.operatorIDs = operatorIDs
 	return c
 }
 
 func (c *validatorStore) SelfValidators() []*types.SSVShare {
-	if c.operatorID == nil {
+	if c.operatorIDs == nil {
 		return nil
 	}
-	return c.OperatorValidators(c.operatorID())
+	return c.selfValidators()
 }
 
 func (c *validatorStore) SelfParticipatingValidators(epoch phase0.Epoch) []*types.SSVShare {
-	if c.operatorID == nil {
+	if c.operatorIDs == nil {
 		return nil
 	}
-	validators := c.OperatorValidators(c.operatorID())
+	validators := c.selfValidators()
 	var participating []*types.SSVShare
 	for _, validator := range validators {
 		if validator.IsParticipating(epoch) {
@@ -201,17 +203,17 @@ func (c *validatorStore) SelfParticipatingValidators(epoch phase0.Epoch) []*type
 }
 
 func (c *validatorStore) SelfCommittees() []*Committee {
-	if c.operatorID == nil {
+	if c.operatorIDs == nil {
 		return nil
 	}
-	return c.OperatorCommittees(c.operatorID())
+	return c.selfCommittees()
 }
 
 func (c *validatorStore) SelfParticipatingCommittees(epoch phase0.Epoch) []*Committee {
-	if c.operatorID == nil {
+	if c.operatorIDs == nil {
 		return nil
 	}
-	committees := c.OperatorCommittees(c.operatorID())
+	committees := c.selfCommittees()
 	var participating []*Committee
 	for _, committee := range committees {
 		if committee.IsParticipating(epoch) {
@@ -221,6 +223,46 @@ func (c *validatorStore) SelfParticipatingCommittees(epoch phase0.Epoch) []*Comm
 	return participating
 }
 
+// selfValidators returns the validators of the operators the node runs as,
+// the ones of committees with several of them only once.
+func (c *validatorStore) selfValidators() []*types.SSVShare {
+	operatorIDs := c.operatorIDs()
+	if len(operatorIDs) == 1 {
+		return c.OperatorValidators(operatorIDs[0])
+	}
+	var validators []*types.SSVShare
+	seen := make(map[spectypes.ValidatorPK]struct{})
+	for _, id := range operatorIDs {
+		for _, share := range c.OperatorValidators(id) {
+			if _, ok := seen[share.ValidatorPubKey]; !ok {
+				seen[share.ValidatorPubKey] = struct{}{}
+				validators = append(validators, share)
+			}
+		}
+	}
+	return validators
+}
+
+// selfCommittees returns the committees of the operators the node runs as,
+// the ones with several of them only once.
+func (c *validatorStore) selfCommittees() []*Committee {
+	operatorIDs := c.operatorIDs()
+	if len(operatorIDs) == 1 {
+		return c.OperatorCommittees(operatorIDs[0])
+	}
+	var committees []*Committee
+	seen := make(map[spectypes.CommitteeID]struct{})
+	for _, id := range operatorIDs {
+		for _, committee := range c.OperatorCommittees(id) {
+			if _, ok := seen[committee.ID]; !ok {
+				seen[committee.ID] = struct{}{}
+				committees = append(committees, committee)
+			}
+		}
+	}
+	return committees
+}
+
 func (c *validatorStore) handleSharesAdded(shares ...*types.SSVShare) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
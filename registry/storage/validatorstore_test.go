@@ -95,8 +95,8 @@ func TestValidatorStore(t *testing.T) {
 		},
 	)
 
-	selfStore := store.WithOperatorID(func() spectypes.OperatorID {
-		return share2.Committee[0].Signer
+	selfStore := store.WithOperatorIDs(func() []spectypes.OperatorID {
+		return []spectypes.OperatorID{share2.Committee[0].Signer}
 	})
 
 	t.Run("check initial store state", func(t *testing.T) {
@@ -422,7 +422,7 @@ func TestSelfValidatorStore_NilOperatorID(t *testing.T) {
 	shareMap[share2.ValidatorPubKey] = share2
 	require.NoError(t, store.handleSharesAdded(share1, share2))
 
-	selfStore := store.WithOperatorID(nil)
+	selfStore := store.WithOperatorIDs(nil)
 	require.Nil(t, selfStore.SelfValidators())
 	require.Nil(t, selfStore.SelfCommittees())
 	require.Nil(t, selfStore.SelfParticipatingValidators(99))
@@ -431,6 +431,35 @@ func TestSelfValidatorStore_NilOperatorID(t *testing.T) {
 	require.Nil(t, selfStore.SelfParticipatingCommittees(201))
 }
 
+func TestSelfValidatorStore_SeveralOperatorIDs(t *testing.T) {
+	shareMap := map[spectypes.ValidatorPK]*ssvtypes.SSVShare{}
+
+	store := newValidatorStore(
+		func() []*ssvtypes.SSVShare { return maps.Values(shareMap) },
+		func(pubKey []byte) (*ssvtypes.SSVShare, bool) {
+			share := shareMap[spectypes.ValidatorPK(pubKey)]
+			if share == nil {
+				return nil, false
+			}
+			return share, true
+		},
+	)
+
+	shareMap[share1.ValidatorPubKey] = share1
+	shareMap[share2.ValidatorPubKey] = share2
+	require.NoError(t, store.handleSharesAdded(share1, share2))
+
+	// a node rotating its key runs as both its old and new operator
+	operatorIDs := []spectypes.OperatorID{1, 2}
+	selfStore := store.WithOperatorIDs(func() []spectypes.OperatorID { return operatorIDs })
+	require.ElementsMatch(t, []*ssvtypes.SSVShare{share1, share2}, selfStore.SelfValidators())
+	require.Len(t, selfStore.SelfCommittees(), 2)
+
+	operatorIDs = []spectypes.OperatorID{2}
+	require.Equal(t, []*ssvtypes.SSVShare{share2}, selfStore.SelfValidators())
+	require.Len(t, selfStore.SelfCommittees(), 1)
+}
+
 func BenchmarkValidatorStore_Add(b *testing.B) {
 	shares := map[spectypes.ValidatorPK]*ssvtypes.SSVShare{}
 
@@ -58,6 +58,11 @@ func SetupMockBeaconNetwork(t *testing.T, currentSlot *SlotValue) *mocknetwork.M
 			return beaconNetwork.EstimatedEpochAtSlot(slot)
 		},
 	).AnyTimes()
+	mockBeaconNetwork.EXPECT().EstimatedSlotAtTime(gomock.Any()).DoAndReturn(
+		func(time int64) phase0.Slot {
+			return beaconNetwork.EstimatedSlotAtTime(time)
+		},
+	).AnyTimes()
 	mockBeaconNetwork.EXPECT().FirstSlotAtEpoch(gomock.Any()).DoAndReturn(
 		func(epoch phase0.Epoch) phase0.Slot {
 			return beaconNetwork.FirstSlotAtEpoch(epoch)
@@ -35,3 +35,25 @@ func ReconstructSignatures(signatures map[spectypes.OperatorID][]byte) (*bls.Sig
 	err := reconstructedSig.Recover(sigVec, idVec)
 	return &reconstructedSig, err
 }
+
+// ReconstructSecretKey receives a map of user indexes and bls.SecretKey shares.
+// It then reconstructs the original secret key using lagrange interpolation
+func ReconstructSecretKey(shares map[uint64]*bls.SecretKey) (*bls.SecretKey, error) {
+	reconstructedKey := bls.SecretKey{}
+
+	idVec := make([]bls.ID, 0, len(shares))
+	skVec := make([]bls.SecretKey, 0, len(shares))
+
+	for index, share := range shares {
+		blsID := bls.ID{}
+		err := blsID.SetDecString(fmt.Sprintf("%d", index))
+		if err != nil {
+			return nil, err
+		}
+
+		idVec = append(idVec, blsID)
+		skVec = append(skVec, *share)
+	}
+	err := reconstructedKey.Recover(skVec, idVec)
+	return &reconstructedKey, err
+}
@@ -151,3 +151,25 @@ func TestIncorrectShare(t *testing.T) {
 //	log.Println(fmt.Sprintf("recoverd sig: %s", recoverdSig.SerializeToHexStr()))
 //	log.Println(fmt.Sprintf("is sig equal: %t", recoverdSig.SerializeToHexStr() == sig.SerializeToHexStr()))
 //}
+
+func TestReconstructSecretKey(t *testing.T) {
+	Init()
+	shareSet, err := generateShares(4, 3, "bloxRocks!")
+	require.NoError(t, err)
+
+	// any threshold of shares reconstructs the secret key
+	shares := map[uint64]*bls.SecretKey{
+		1: shareSet.shares[1],
+		3: shareSet.shares[3],
+		4: shareSet.shares[4],
+	}
+	sk, err := ReconstructSecretKey(shares)
+	require.NoError(t, err)
+	require.True(t, shareSet.sk.IsEqual(sk))
+
+	// less than threshold doesn't
+	delete(shares, 4)
+	sk, err = ReconstructSecretKey(shares)
+	require.NoError(t, err)
+	require.False(t, shareSet.sk.IsEqual(sk))
+}